@@ -32,6 +32,7 @@ import (
 )
 
 func Convert_v1beta2_VSphereClusterSpec_To_v1beta1_VSphereClusterSpec(in *infrav1.VSphereClusterSpec, out *VSphereClusterSpec, s apimachineryconversion.Scope) error {
+	// NOTE: caBundleRef, insecure and placement do not exist in v1beta1.
 	if err := autoConvert_v1beta2_VSphereClusterSpec_To_v1beta1_VSphereClusterSpec(in, out, s); err != nil {
 		return err
 	}
@@ -149,6 +150,11 @@ func Convert_v1beta2_VSphereClusterTemplateResource_To_v1beta1_VSphereClusterTem
 	return nil
 }
 
+func Convert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(in *infrav1.VSphereClusterIdentitySpec, out *VSphereClusterIdentitySpec, s apimachineryconversion.Scope) error {
+	// NOTE: credentialsProvider and caBundleRef do not exist in v1beta1.
+	return autoConvert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(in, out, s)
+}
+
 func Convert_v1beta2_VSphereClusterIdentityStatus_To_v1beta1_VSphereClusterIdentityStatus(in *infrav1.VSphereClusterIdentityStatus, out *VSphereClusterIdentityStatus, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta2_VSphereClusterIdentityStatus_To_v1beta1_VSphereClusterIdentityStatus(in, out, s); err != nil {
 		return err
@@ -205,7 +211,13 @@ func Convert_v1beta1_VSphereClusterIdentityStatus_To_v1beta2_VSphereClusterIdent
 	return nil
 }
 
+func Convert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(in *infrav1.VSphereDeploymentZoneSpec, out *VSphereDeploymentZoneSpec, s apimachineryconversion.Scope) error {
+	// NOTE: caBundleRef, insecure and templateReplicas do not exist in v1beta1.
+	return autoConvert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(in, out, s)
+}
+
 func Convert_v1beta2_VSphereDeploymentZoneStatus_To_v1beta1_VSphereDeploymentZoneStatus(in *infrav1.VSphereDeploymentZoneStatus, out *VSphereDeploymentZoneStatus, s apimachineryconversion.Scope) error {
+	// NOTE: templateReplicas does not exist in v1beta1.
 	if err := autoConvert_v1beta2_VSphereDeploymentZoneStatus_To_v1beta1_VSphereDeploymentZoneStatus(in, out, s); err != nil {
 		return err
 	}
@@ -261,19 +273,34 @@ func Convert_v1beta1_VSphereDeploymentZoneStatus_To_v1beta2_VSphereDeploymentZon
 	return nil
 }
 
+func Convert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(in *infrav1.VSphereFailureDomain, out *VSphereFailureDomain, s apimachineryconversion.Scope) error {
+	// NOTE: status does not exist in v1beta1.
+	return autoConvert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(in, out, s)
+}
+
+func Convert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(in *infrav1.VSphereFailureDomainSpec, out *VSphereFailureDomainSpec, s apimachineryconversion.Scope) error {
+	// NOTE: server does not exist in v1beta1.
+	return autoConvert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(in, out, s)
+}
+
 func Convert_v1beta2_Topology_To_v1beta1_Topology(in *infrav1.Topology, out *Topology, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta2_Topology_To_v1beta1_Topology(in, out, s); err != nil {
 		return err
 	}
 	if !reflect.DeepEqual(in.Hosts, infrav1.FailureDomainHosts{}) {
 		out.Hosts = &FailureDomainHosts{}
-		if err := autoConvert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(&in.Hosts, out.Hosts, s); err != nil {
+		if err := Convert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(&in.Hosts, out.Hosts, s); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+func Convert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(in *infrav1.FailureDomainHosts, out *FailureDomainHosts, s apimachineryconversion.Scope) error {
+	// NOTE: managed does not exist in v1beta1.
+	return autoConvert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(in, out, s)
+}
+
 func Convert_v1beta1_Topology_To_v1beta2_Topology(in *Topology, out *infrav1.Topology, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta1_Topology_To_v1beta2_Topology(in, out, s); err != nil {
 		return err
@@ -319,6 +346,16 @@ func Convert_v1beta1_VSphereMachineSpec_To_v1beta2_VSphereMachineSpec(in *VSpher
 	return nil
 }
 
+func Convert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(in *infrav1.VSphereMachineTemplateSpec, out *VSphereMachineTemplateSpec, s apimachineryconversion.Scope) error {
+	// NOTE: warmPool does not exist in v1beta1.
+	return autoConvert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(in, out, s)
+}
+
+func Convert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate(in *infrav1.VSphereMachineTemplate, out *VSphereMachineTemplate, s apimachineryconversion.Scope) error {
+	// NOTE: status does not exist in v1beta1.
+	return autoConvert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate(in, out, s)
+}
+
 func Convert_v1beta2_VSphereMachineStatus_To_v1beta1_VSphereMachineStatus(in *infrav1.VSphereMachineStatus, out *VSphereMachineStatus, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta2_VSphereMachineStatus_To_v1beta1_VSphereMachineStatus(in, out, s); err != nil {
 		return err
@@ -402,6 +439,11 @@ func Convert_v1beta1_VSphereVMSpec_To_v1beta2_VSphereVMSpec(in *VSphereVMSpec, o
 	return nil
 }
 
+func Convert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(in *infrav1.VirtualMachineCloneSpec, out *VirtualMachineCloneSpec, s apimachineryconversion.Scope) error {
+	// NOTE: templateSelector, contentLibraryItem, sysprep, resizePolicy, diskGrowHint, driftRemediation, datastoreCluster, instantCloneParent, snapshotPolicy, templateRef, bootstrapDataDelivery and bootstrapDataScrub do not exist in v1beta1.
+	return autoConvert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(in, out, s)
+}
+
 func Convert_v1beta1_NetworkSpec_To_v1beta2_NetworkSpec(in *NetworkSpec, out *infrav1.NetworkSpec, s apimachineryconversion.Scope) error {
 	if err := autoConvert_v1beta1_NetworkSpec_To_v1beta2_NetworkSpec(in, out, s); err != nil {
 		return err
@@ -423,6 +465,8 @@ func Convert_v1beta2_VSphereVMStatus_To_v1beta1_VSphereVMStatus(in *infrav1.VSph
 		return err
 	}
 
+	// NOTE: templateUUID, drift, datastore and bootstrapISO do not exist in v1beta1.
+
 	// Reset conditions from autogenerated conversions
 	// NOTE: v1beta2 conditions should not automatically be converted into legacy conditions (v1beta1).
 	out.Conditions = nil
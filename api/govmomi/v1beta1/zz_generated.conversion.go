@@ -90,11 +90,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.FailureDomainHosts)(nil), (*FailureDomainHosts)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(a.(*v1beta2.FailureDomainHosts), b.(*FailureDomainHosts), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*NetworkConfiguration)(nil), (*v1beta2.NetworkConfiguration)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration(a.(*NetworkConfiguration), b.(*v1beta2.NetworkConfiguration), scope)
 	}); err != nil {
@@ -205,11 +200,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VSphereClusterIdentitySpec)(nil), (*VSphereClusterIdentitySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(a.(*v1beta2.VSphereClusterIdentitySpec), b.(*VSphereClusterIdentitySpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VSphereClusterList)(nil), (*v1beta2.VSphereClusterList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VSphereClusterList_To_v1beta2_VSphereClusterList(a.(*VSphereClusterList), b.(*v1beta2.VSphereClusterList), scope)
 	}); err != nil {
@@ -290,11 +280,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VSphereDeploymentZoneSpec)(nil), (*VSphereDeploymentZoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(a.(*v1beta2.VSphereDeploymentZoneSpec), b.(*VSphereDeploymentZoneSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VSphereDisk)(nil), (*v1beta2.VSphereDisk)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VSphereDisk_To_v1beta2_VSphereDisk(a.(*VSphereDisk), b.(*v1beta2.VSphereDisk), scope)
 	}); err != nil {
@@ -310,11 +295,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VSphereFailureDomain)(nil), (*VSphereFailureDomain)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(a.(*v1beta2.VSphereFailureDomain), b.(*VSphereFailureDomain), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VSphereFailureDomainList)(nil), (*v1beta2.VSphereFailureDomainList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VSphereFailureDomainList_To_v1beta2_VSphereFailureDomainList(a.(*VSphereFailureDomainList), b.(*v1beta2.VSphereFailureDomainList), scope)
 	}); err != nil {
@@ -330,11 +310,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VSphereFailureDomainSpec)(nil), (*VSphereFailureDomainSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(a.(*v1beta2.VSphereFailureDomainSpec), b.(*VSphereFailureDomainSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VSphereIdentityReference)(nil), (*v1beta2.VSphereIdentityReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VSphereIdentityReference_To_v1beta2_VSphereIdentityReference(a.(*VSphereIdentityReference), b.(*v1beta2.VSphereIdentityReference), scope)
 	}); err != nil {
@@ -370,11 +345,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VSphereMachineTemplate)(nil), (*VSphereMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate(a.(*v1beta2.VSphereMachineTemplate), b.(*VSphereMachineTemplate), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VSphereMachineTemplateList)(nil), (*v1beta2.VSphereMachineTemplateList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VSphereMachineTemplateList_To_v1beta2_VSphereMachineTemplateList(a.(*VSphereMachineTemplateList), b.(*v1beta2.VSphereMachineTemplateList), scope)
 	}); err != nil {
@@ -400,11 +370,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VSphereMachineTemplateSpec)(nil), (*VSphereMachineTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(a.(*v1beta2.VSphereMachineTemplateSpec), b.(*VSphereMachineTemplateSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VSphereVM)(nil), (*v1beta2.VSphereVM)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VSphereVM_To_v1beta2_VSphereVM(a.(*VSphereVM), b.(*v1beta2.VSphereVM), scope)
 	}); err != nil {
@@ -430,11 +395,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta2.VirtualMachineCloneSpec)(nil), (*VirtualMachineCloneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(a.(*v1beta2.VirtualMachineCloneSpec), b.(*VirtualMachineCloneSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineResourceShares)(nil), (*v1beta2.VirtualMachineResourceShares)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_VirtualMachineResourceShares_To_v1beta2_VirtualMachineResourceShares(a.(*VirtualMachineResourceShares), b.(*v1beta2.VirtualMachineResourceShares), scope)
 	}); err != nil {
@@ -545,6 +505,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.FailureDomainHosts)(nil), (*FailureDomainHosts)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(a.(*v1beta2.FailureDomainHosts), b.(*FailureDomainHosts), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.IPPoolReference)(nil), (*corev1.TypedLocalObjectReference)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_IPPoolReference_To_v1_TypedLocalObjectReference(a.(*v1beta2.IPPoolReference), b.(*corev1.TypedLocalObjectReference), scope)
 	}); err != nil {
@@ -565,6 +530,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.VSphereClusterIdentitySpec)(nil), (*VSphereClusterIdentitySpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(a.(*v1beta2.VSphereClusterIdentitySpec), b.(*VSphereClusterIdentitySpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.VSphereClusterIdentityStatus)(nil), (*VSphereClusterIdentityStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_VSphereClusterIdentityStatus_To_v1beta1_VSphereClusterIdentityStatus(a.(*v1beta2.VSphereClusterIdentityStatus), b.(*VSphereClusterIdentityStatus), scope)
 	}); err != nil {
@@ -585,11 +555,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.VSphereDeploymentZoneSpec)(nil), (*VSphereDeploymentZoneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(a.(*v1beta2.VSphereDeploymentZoneSpec), b.(*VSphereDeploymentZoneSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.VSphereDeploymentZoneStatus)(nil), (*VSphereDeploymentZoneStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_VSphereDeploymentZoneStatus_To_v1beta1_VSphereDeploymentZoneStatus(a.(*v1beta2.VSphereDeploymentZoneStatus), b.(*VSphereDeploymentZoneStatus), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.VSphereFailureDomainSpec)(nil), (*VSphereFailureDomainSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(a.(*v1beta2.VSphereFailureDomainSpec), b.(*VSphereFailureDomainSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta2.VSphereFailureDomain)(nil), (*VSphereFailureDomain)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(a.(*v1beta2.VSphereFailureDomain), b.(*VSphereFailureDomain), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.VSphereMachineSpec)(nil), (*VSphereMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_VSphereMachineSpec_To_v1beta1_VSphereMachineSpec(a.(*v1beta2.VSphereMachineSpec), b.(*VSphereMachineSpec), scope)
 	}); err != nil {
@@ -600,6 +585,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.VSphereMachineTemplateSpec)(nil), (*VSphereMachineTemplateSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(a.(*v1beta2.VSphereMachineTemplateSpec), b.(*VSphereMachineTemplateSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta2.VSphereMachineTemplate)(nil), (*VSphereMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate(a.(*v1beta2.VSphereMachineTemplate), b.(*VSphereMachineTemplate), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta2.VSphereVMSpec)(nil), (*VSphereVMSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta2_VSphereVMSpec_To_v1beta1_VSphereVMSpec(a.(*v1beta2.VSphereVMSpec), b.(*VSphereVMSpec), scope)
 	}); err != nil {
@@ -610,6 +605,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta2.VirtualMachineCloneSpec)(nil), (*VirtualMachineCloneSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(a.(*v1beta2.VirtualMachineCloneSpec), b.(*VirtualMachineCloneSpec), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -765,14 +765,10 @@ func Convert_v1beta1_FailureDomainHosts_To_v1beta2_FailureDomainHosts(in *Failur
 func autoConvert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(in *v1beta2.FailureDomainHosts, out *FailureDomainHosts, s conversion.Scope) error {
 	out.VMGroupName = in.VMGroupName
 	out.HostGroupName = in.HostGroupName
+	// WARNING: in.Managed requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts is an autogenerated conversion function.
-func Convert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(in *v1beta2.FailureDomainHosts, out *FailureDomainHosts, s conversion.Scope) error {
-	return autoConvert_v1beta2_FailureDomainHosts_To_v1beta1_FailureDomainHosts(in, out, s)
-}
-
 func autoConvert_v1beta1_NetworkConfiguration_To_v1beta2_NetworkConfiguration(in *NetworkConfiguration, out *v1beta2.NetworkConfiguration, s conversion.Scope) error {
 	out.NetworkName = in.NetworkName
 	out.DHCP4 = (*bool)(unsafe.Pointer(in.DHCP4))
@@ -1342,15 +1338,12 @@ func Convert_v1beta1_VSphereClusterIdentitySpec_To_v1beta2_VSphereClusterIdentit
 
 func autoConvert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(in *v1beta2.VSphereClusterIdentitySpec, out *VSphereClusterIdentitySpec, s conversion.Scope) error {
 	out.SecretName = in.SecretName
+	// WARNING: in.CredentialsProvider requires manual conversion: does not exist in peer-type
+	// WARNING: in.CABundleRef requires manual conversion: does not exist in peer-type
 	out.AllowedNamespaces = (*AllowedNamespaces)(unsafe.Pointer(in.AllowedNamespaces))
 	return nil
 }
 
-// Convert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec is an autogenerated conversion function.
-func Convert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(in *v1beta2.VSphereClusterIdentitySpec, out *VSphereClusterIdentitySpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_VSphereClusterIdentitySpec_To_v1beta1_VSphereClusterIdentitySpec(in, out, s)
-}
-
 func autoConvert_v1beta1_VSphereClusterIdentityStatus_To_v1beta2_VSphereClusterIdentityStatus(in *VSphereClusterIdentityStatus, out *v1beta2.VSphereClusterIdentityStatus, s conversion.Scope) error {
 	if err := v1.Convert_bool_To_Pointer_bool(&in.Ready, &out.Ready, s); err != nil {
 		return err
@@ -1385,6 +1378,7 @@ func autoConvert_v1beta2_VSphereClusterIdentityStatus_To_v1beta1_VSphereClusterI
 	if err := v1.Convert_Pointer_bool_To_bool(&in.Ready, &out.Ready, s); err != nil {
 		return err
 	}
+	// WARNING: in.LastCredentialsRotationTime requires manual conversion: does not exist in peer-type
 	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1459,6 +1453,8 @@ func autoConvert_v1beta1_VSphereClusterSpec_To_v1beta2_VSphereClusterSpec(in *VS
 func autoConvert_v1beta2_VSphereClusterSpec_To_v1beta1_VSphereClusterSpec(in *v1beta2.VSphereClusterSpec, out *VSphereClusterSpec, s conversion.Scope) error {
 	out.Server = in.Server
 	out.Thumbprint = in.Thumbprint
+	// WARNING: in.CABundleRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.Insecure requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta2_APIEndpoint_To_v1beta1_APIEndpoint(&in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint, s); err != nil {
 		return err
 	}
@@ -1477,6 +1473,7 @@ func autoConvert_v1beta2_VSphereClusterSpec_To_v1beta1_VSphereClusterSpec(in *v1
 	if err := v1.Convert_Pointer_bool_To_bool(&in.DisableClusterModule, &out.DisableClusterModule, s); err != nil {
 		return err
 	}
+	// WARNING: in.Placement requires manual conversion: does not exist in peer-type
 	out.FailureDomainSelector = (*v1.LabelSelector)(unsafe.Pointer(in.FailureDomainSelector))
 	return nil
 }
@@ -1742,19 +1739,17 @@ func Convert_v1beta1_VSphereDeploymentZoneSpec_To_v1beta2_VSphereDeploymentZoneS
 
 func autoConvert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(in *v1beta2.VSphereDeploymentZoneSpec, out *VSphereDeploymentZoneSpec, s conversion.Scope) error {
 	out.Server = in.Server
+	// WARNING: in.CABundleRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.Insecure requires manual conversion: does not exist in peer-type
 	out.FailureDomain = in.FailureDomain
 	out.ControlPlane = (*bool)(unsafe.Pointer(in.ControlPlane))
 	if err := Convert_v1beta2_PlacementConstraint_To_v1beta1_PlacementConstraint(&in.PlacementConstraint, &out.PlacementConstraint, s); err != nil {
 		return err
 	}
+	// WARNING: in.TemplateReplicas requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec is an autogenerated conversion function.
-func Convert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(in *v1beta2.VSphereDeploymentZoneSpec, out *VSphereDeploymentZoneSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_VSphereDeploymentZoneSpec_To_v1beta1_VSphereDeploymentZoneSpec(in, out, s)
-}
-
 func autoConvert_v1beta1_VSphereDeploymentZoneStatus_To_v1beta2_VSphereDeploymentZoneStatus(in *VSphereDeploymentZoneStatus, out *v1beta2.VSphereDeploymentZoneStatus, s conversion.Scope) error {
 	out.Ready = (*bool)(unsafe.Pointer(in.Ready))
 	if in.Conditions != nil {
@@ -1785,6 +1780,8 @@ func autoConvert_v1beta2_VSphereDeploymentZoneStatus_To_v1beta1_VSphereDeploymen
 		out.Conditions = nil
 	}
 	out.Ready = (*bool)(unsafe.Pointer(in.Ready))
+	// WARNING: in.TemplateReplicas requires manual conversion: does not exist in peer-type
+	// WARNING: in.ManagedHosts requires manual conversion: does not exist in peer-type
 	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -1831,14 +1828,10 @@ func autoConvert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(in
 	if err := Convert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	// WARNING: in.Status requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain is an autogenerated conversion function.
-func Convert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(in *v1beta2.VSphereFailureDomain, out *VSphereFailureDomain, s conversion.Scope) error {
-	return autoConvert_v1beta2_VSphereFailureDomain_To_v1beta1_VSphereFailureDomain(in, out, s)
-}
-
 func autoConvert_v1beta1_VSphereFailureDomainList_To_v1beta2_VSphereFailureDomainList(in *VSphereFailureDomainList, out *v1beta2.VSphereFailureDomainList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -1900,6 +1893,7 @@ func Convert_v1beta1_VSphereFailureDomainSpec_To_v1beta2_VSphereFailureDomainSpe
 }
 
 func autoConvert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(in *v1beta2.VSphereFailureDomainSpec, out *VSphereFailureDomainSpec, s conversion.Scope) error {
+	// WARNING: in.Server requires manual conversion: does not exist in peer-type
 	if err := Convert_v1beta2_FailureDomain_To_v1beta1_FailureDomain(&in.Region, &out.Region, s); err != nil {
 		return err
 	}
@@ -1912,11 +1906,6 @@ func autoConvert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomai
 	return nil
 }
 
-// Convert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec is an autogenerated conversion function.
-func Convert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(in *v1beta2.VSphereFailureDomainSpec, out *VSphereFailureDomainSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_VSphereFailureDomainSpec_To_v1beta1_VSphereFailureDomainSpec(in, out, s)
-}
-
 func autoConvert_v1beta1_VSphereIdentityReference_To_v1beta2_VSphereIdentityReference(in *VSphereIdentityReference, out *v1beta2.VSphereIdentityReference, s conversion.Scope) error {
 	out.Kind = v1beta2.VSphereIdentityKind(in.Kind)
 	out.Name = in.Name
@@ -2116,6 +2105,7 @@ func autoConvert_v1beta2_VSphereMachineStatus_To_v1beta1_VSphereMachineStatus(in
 	} else {
 		out.Network = nil
 	}
+	// WARNING: in.FailureDomain requires manual conversion: does not exist in peer-type
 	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -2138,14 +2128,10 @@ func autoConvert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplat
 	if err := Convert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	// WARNING: in.Status requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate is an autogenerated conversion function.
-func Convert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate(in *v1beta2.VSphereMachineTemplate, out *VSphereMachineTemplate, s conversion.Scope) error {
-	return autoConvert_v1beta2_VSphereMachineTemplate_To_v1beta1_VSphereMachineTemplate(in, out, s)
-}
-
 func autoConvert_v1beta1_VSphereMachineTemplateList_To_v1beta2_VSphereMachineTemplateList(in *VSphereMachineTemplateList, out *v1beta2.VSphereMachineTemplateList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -2234,14 +2220,10 @@ func autoConvert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTem
 	if err := Convert_v1beta2_VSphereMachineTemplateResource_To_v1beta1_VSphereMachineTemplateResource(&in.Template, &out.Template, s); err != nil {
 		return err
 	}
+	// WARNING: in.WarmPool requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec is an autogenerated conversion function.
-func Convert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(in *v1beta2.VSphereMachineTemplateSpec, out *VSphereMachineTemplateSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_VSphereMachineTemplateSpec_To_v1beta1_VSphereMachineTemplateSpec(in, out, s)
-}
-
 func autoConvert_v1beta1_VSphereVM_To_v1beta2_VSphereVM(in *VSphereVM, out *v1beta2.VSphereVM, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_VSphereVMSpec_To_v1beta2_VSphereVMSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2396,6 +2378,11 @@ func autoConvert_v1beta2_VSphereVMStatus_To_v1beta1_VSphereVMStatus(in *v1beta2.
 	}
 	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
 	out.CloneMode = CloneMode(in.CloneMode)
+	// WARNING: in.TemplateUUID requires manual conversion: does not exist in peer-type
+	// WARNING: in.TemplateLibraryItemID requires manual conversion: does not exist in peer-type
+	// WARNING: in.Datastore requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapISO requires manual conversion: does not exist in peer-type
+	// WARNING: in.ContentLibraryItemID requires manual conversion: does not exist in peer-type
 	out.Snapshot = in.Snapshot
 	out.RetryAfter = in.RetryAfter
 	out.TaskRef = in.TaskRef
@@ -2412,6 +2399,7 @@ func autoConvert_v1beta2_VSphereVMStatus_To_v1beta1_VSphereVMStatus(in *v1beta2.
 	}
 	out.ModuleUUID = (*string)(unsafe.Pointer(in.ModuleUUID))
 	out.VMRef = in.VMRef
+	// WARNING: in.Drift requires manual conversion: does not exist in peer-type
 	// WARNING: in.Deprecated requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -2461,13 +2449,19 @@ func Convert_v1beta1_VirtualMachineCloneSpec_To_v1beta2_VirtualMachineCloneSpec(
 
 func autoConvert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(in *v1beta2.VirtualMachineCloneSpec, out *VirtualMachineCloneSpec, s conversion.Scope) error {
 	out.Template = in.Template
+	// WARNING: in.TemplateSelector requires manual conversion: does not exist in peer-type
+	// WARNING: in.ContentLibraryItem requires manual conversion: does not exist in peer-type
+	// WARNING: in.TemplateRef requires manual conversion: does not exist in peer-type
 	out.CloneMode = CloneMode(in.CloneMode)
+	// WARNING: in.InstantCloneParent requires manual conversion: does not exist in peer-type
 	out.Snapshot = in.Snapshot
+	// WARNING: in.SnapshotPolicy requires manual conversion: does not exist in peer-type
 	out.Server = in.Server
 	out.Thumbprint = in.Thumbprint
 	out.Datacenter = in.Datacenter
 	out.Folder = in.Folder
 	out.Datastore = in.Datastore
+	// WARNING: in.DatastoreCluster requires manual conversion: does not exist in peer-type
 	out.StoragePolicyName = in.StoragePolicyName
 	out.ResourcePool = in.ResourcePool
 	if err := Convert_v1beta2_NetworkSpec_To_v1beta1_NetworkSpec(&in.Network, &out.Network, s); err != nil {
@@ -2481,14 +2475,20 @@ func autoConvert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneS
 		return err
 	}
 	out.MemoryMiB = in.MemoryMiB
+	// WARNING: in.ResizePolicy requires manual conversion: does not exist in peer-type
 	out.DiskGiB = in.DiskGiB
 	out.AdditionalDisksGiB = *(*[]int32)(unsafe.Pointer(&in.AdditionalDisksGiB))
 	out.CustomVMXKeys = *(*map[string]string)(unsafe.Pointer(&in.CustomVMXKeys))
 	out.TagIDs = *(*[]string)(unsafe.Pointer(&in.TagIDs))
 	out.PciDevices = *(*[]PCIDeviceSpec)(unsafe.Pointer(&in.PciDevices))
 	out.OS = OS(in.OS)
+	// WARNING: in.Sysprep requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapDataDelivery requires manual conversion: does not exist in peer-type
+	// WARNING: in.BootstrapDataScrub requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	out.DataDisks = *(*[]VSphereDisk)(unsafe.Pointer(&in.DataDisks))
+	// WARNING: in.DiskGrowHint requires manual conversion: does not exist in peer-type
+	// WARNING: in.DriftRemediation requires manual conversion: does not exist in peer-type
 	out.NestedHV = (*bool)(unsafe.Pointer(in.NestedHV))
 	out.FtEncryptionMode = FtEncryptionMode(in.FtEncryptionMode)
 	out.MigrateEncryption = MigrateEncryption(in.MigrateEncryption)
@@ -2497,11 +2497,6 @@ func autoConvert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneS
 	return nil
 }
 
-// Convert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec is an autogenerated conversion function.
-func Convert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(in *v1beta2.VirtualMachineCloneSpec, out *VirtualMachineCloneSpec, s conversion.Scope) error {
-	return autoConvert_v1beta2_VirtualMachineCloneSpec_To_v1beta1_VirtualMachineCloneSpec(in, out, s)
-}
-
 func autoConvert_v1beta1_VirtualMachineResourceShares_To_v1beta2_VirtualMachineResourceShares(in *VirtualMachineResourceShares, out *v1beta2.VirtualMachineResourceShares, s conversion.Scope) error {
 	out.CPU = in.CPU
 	out.Memory = in.Memory
@@ -59,6 +59,12 @@ const (
 	// NOTE: This reason does not apply to VSphereVM (this state happens before the VSphereVM is actually created).
 	WaitingForBootstrapDataV1Beta1Reason = "WaitingForBootstrapData"
 
+	// WaitingForFailureDomainCapacityV1Beta1Reason (Severity=Info) documents a VSphereMachine waiting for a failure
+	// domain with enough free capacity to be placed in.
+	//
+	// NOTE: This reason does not apply to VSphereVM (this state happens before the VSphereVM is actually created).
+	WaitingForFailureDomainCapacityV1Beta1Reason = "WaitingForFailureDomainCapacity"
+
 	// WaitingForStaticIPAllocationV1Beta1Reason (Severity=Info) documents a VSphereVM waiting for the allocation of
 	// a static IP address.
 	WaitingForStaticIPAllocationV1Beta1Reason = "WaitingForStaticIPAllocation"
@@ -158,6 +164,12 @@ const (
 
 	// SecretAlreadyInUseV1Beta1Reason is used when another VSphereClusterIdentity is using the secret.
 	SecretAlreadyInUseV1Beta1Reason = "SecretInUse"
+
+	// CredentialsProviderNotAvailableV1Beta1Reason is used when the credentials cannot be retrieved from the credentials provider of the VSphereClusterIdentity.
+	CredentialsProviderNotAvailableV1Beta1Reason = "CredentialsProviderNotAvailable"
+
+	// CredentialsValidationFailedV1Beta1Reason is used when the credentials of the VSphereClusterIdentity cannot be used to login to vCenter.
+	CredentialsValidationFailedV1Beta1Reason = "CredentialsValidationFailed"
 )
 
 const (
@@ -240,3 +252,83 @@ const (
 	// shutdown request fails.
 	GuestSoftPowerOffFailedV1Beta1Reason = "GuestSoftPowerOffFailed"
 )
+
+const (
+	// VMResizedV1Beta1Condition documents the status of applying changes to numCPUs, numCoresPerSocket,
+	// memoryMiB and resources to an existing VSphereVM.
+	VMResizedV1Beta1Condition clusterv1.ConditionType = "VirtualMachineResized"
+
+	// ResizingV1Beta1Reason (Severity=Info) documents a VSphereVM being reconfigured or power cycled
+	// to apply the desired CPU, memory and resource allocation settings.
+	ResizingV1Beta1Reason = "Resizing"
+
+	// PowerCycleRequiredV1Beta1Reason (Severity=Warning) documents a VSphereVM whose desired CPU and memory
+	// settings can't be applied without a power cycle which is not allowed by its resizePolicy.
+	PowerCycleRequiredV1Beta1Reason = "PowerCycleRequired"
+
+	// ResizeFailedV1Beta1Reason (Severity=Warning) documents a VSphereVM controller detecting
+	// an error while reconfiguring the VM; those kind of errors are usually transient and failed
+	// operations are automatically re-tried by the controller.
+	ResizeFailedV1Beta1Reason = "ResizeFailed"
+)
+
+const (
+	// DisksResizedV1Beta1Condition documents the status of extending the disks of an existing VSphereVM
+	// after diskGiB, additionalDisksGiB or the sizeGiB of a data disk has been increased.
+	DisksResizedV1Beta1Condition clusterv1.ConditionType = "VirtualMachineDisksResized"
+
+	// DisksResizingV1Beta1Reason (Severity=Info) documents the disks of a VSphereVM being extended.
+	DisksResizingV1Beta1Reason = "DisksResizing"
+
+	// DisksResizeFailedV1Beta1Reason (Severity=Warning) documents a VSphereVM controller detecting
+	// an error while extending the disks of the VM; those kind of errors are usually transient and failed
+	// operations are automatically re-tried by the controller.
+	DisksResizeFailedV1Beta1Reason = "DisksResizeFailed"
+
+	// DiskShrinkNotSupportedV1Beta1Reason (Severity=Warning) documents a VSphereVM with a desired disk size
+	// smaller than the size of the disk of the VM; disks are never shrunk.
+	DiskShrinkNotSupportedV1Beta1Reason = "DiskShrinkNotSupported"
+
+	// DeltaDiskNotResizableV1Beta1Reason (Severity=Warning) documents a VSphereVM with a desired disk size
+	// larger than the size of a disk of the VM which is backed by a delta disk, e.g. the disk of a linked
+	// clone or of a VM with snapshots; delta disks can't be extended.
+	DeltaDiskNotResizableV1Beta1Reason = "DeltaDiskNotResizable"
+)
+
+const (
+	// VMInSyncV1Beta1Condition documents whether the configuration of the VM of a VSphereVM
+	// matches the VSphereVM's spec.
+	VMInSyncV1Beta1Condition clusterv1.ConditionType = "VirtualMachineInSync"
+
+	// DriftedV1Beta1Reason (Severity=Warning) documents a VSphereVM whose VM has been changed out of band
+	// and whose drift is not remediated.
+	DriftedV1Beta1Reason = "Drifted"
+
+	// RemediatingDriftV1Beta1Reason (Severity=Info) documents the VM of a VSphereVM being changed back
+	// to match the VSphereVM's spec.
+	RemediatingDriftV1Beta1Reason = "RemediatingDrift"
+
+	// DriftRemediationFailedV1Beta1Reason (Severity=Warning) documents a VSphereVM controller detecting
+	// an error while changing the VM back to match the VSphereVM's spec; those kind of errors are usually
+	// transient and failed operations are automatically re-tried by the controller.
+	DriftRemediationFailedV1Beta1Reason = "DriftRemediationFailed"
+)
+
+const (
+	// BootstrapDataScrubbedV1Beta1Condition documents whether the bootstrap data has been
+	// removed from the guestinfo of the VM of a VSphereVM.
+	BootstrapDataScrubbedV1Beta1Condition clusterv1.ConditionType = "BootstrapDataScrubbed"
+
+	// WaitingForNodeV1Beta1Reason (Severity=Info) documents a VSphereVM whose bootstrap data is
+	// kept in the guestinfo of the VM until its Machine has a node, or until the delay has passed.
+	WaitingForNodeV1Beta1Reason = "WaitingForNode"
+
+	// BootstrapDataRetainedV1Beta1Reason (Severity=Info) documents a VSphereVM whose bootstrap data
+	// is kept in the guestinfo of the VM because of the Retain policy.
+	BootstrapDataRetainedV1Beta1Reason = "BootstrapDataRetained"
+
+	// BootstrapDataScrubFailedV1Beta1Reason (Severity=Warning) documents a VSphereVM controller detecting
+	// an error while removing the bootstrap data from the guestinfo of the VM; those kind
+	// of errors are usually transient and failed operations are automatically re-tried by the controller.
+	BootstrapDataScrubFailedV1Beta1Reason = "BootstrapDataScrubFailed"
+)
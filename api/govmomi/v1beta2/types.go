@@ -37,7 +37,7 @@ const (
 )
 
 // CloneMode is the type of clone operation used to clone a VM from a template.
-// +kubebuilder:validation:Enum=fullClone;linkedClone
+// +kubebuilder:validation:Enum=fullClone;linkedClone;instantClone
 type CloneMode string
 
 const (
@@ -51,6 +51,12 @@ const (
 	// clone mode, but it also prevents expanding a VMs disk beyond the size of
 	// the source VM/template.
 	LinkedClone CloneMode = "linkedClone"
+
+	// InstantClone means resulting VMs are forked from the running, frozen
+	// parent VM with the instant clone API, and share its memory and disks.
+	// This is the fastest clone mode, but the VMs inherit the virtual hardware
+	// of the parent VM and the guest must be prepared to be forked.
+	InstantClone CloneMode = "instantClone"
 )
 
 // FtEncryptionMode represents the encrypted fault tolerance mode.
@@ -129,15 +135,255 @@ const (
 	VirtualMachinePowerOpModeTrySoft VirtualMachinePowerOpMode = "trySoft"
 )
 
+// VirtualMachineResizePolicy describes how changes to the CPU and memory settings
+// of an existing virtual machine are applied.
+// +kubebuilder:validation:Enum=HotAdd;PowerCycle
+type VirtualMachineResizePolicy string
+
+const (
+	// VirtualMachineResizePolicyHotAdd applies CPU and memory changes only if they
+	// are supported on a running virtual machine, e.g. when CPU or memory hot add is
+	// enabled and the value is increased. Changes which require the virtual machine to
+	// be powered off are not applied until the virtual machine is powered off out of band.
+	VirtualMachineResizePolicyHotAdd VirtualMachineResizePolicy = "HotAdd"
+
+	// VirtualMachineResizePolicyPowerCycle applies CPU and memory changes without a power
+	// cycle if possible, and otherwise powers off the virtual machine, reconfigures it and
+	// powers it on again.
+	VirtualMachineResizePolicyPowerCycle VirtualMachineResizePolicy = "PowerCycle"
+)
+
+// DiskGrowHint describes whether the guest of a virtual machine is notified
+// after its disks have been extended.
+// +kubebuilder:validation:Enum=None;GuestInfo
+type DiskGrowHint string
+
+const (
+	// DiskGrowHintNone does not notify the guest after its disks have been extended.
+	DiskGrowHintNone DiskGrowHint = "None"
+
+	// DiskGrowHintGuestInfo notifies the guest after its disks have been extended by setting
+	// the guestinfo.capv.disks.grow.disks and guestinfo.capv.disks.grow.timestamp keys.
+	DiskGrowHintGuestInfo DiskGrowHint = "GuestInfo"
+)
+
+// LinkedCloneSnapshotPolicy describes what happens if the template of a linked clone
+// has no snapshot from which the virtual machine can be cloned.
+// +kubebuilder:validation:Enum=UseExisting;CreateIfMissing
+type LinkedCloneSnapshotPolicy string
+
+const (
+	// LinkedCloneSnapshotPolicyUseExisting only uses existing snapshots of the template,
+	// and falls back to a full clone if the template has no snapshot.
+	LinkedCloneSnapshotPolicyUseExisting LinkedCloneSnapshotPolicy = "UseExisting"
+
+	// LinkedCloneSnapshotPolicyCreateIfMissing creates the snapshot on the template if it
+	// is missing, so the virtual machine is always a linked clone. VM templates are never
+	// changed, the clone fails if a VM template has no snapshot.
+	LinkedCloneSnapshotPolicyCreateIfMissing LinkedCloneSnapshotPolicy = "CreateIfMissing"
+)
+
+// LinkedCloneSnapshotName is the name of the snapshot which is created on templates
+// for linked clones if no snapshot name is set.
+const LinkedCloneSnapshotName = "capv-linked-clone"
+
+// BootstrapDataDelivery describes how the bootstrap data and the metadata are delivered
+// to the guest of a virtual machine.
+// +kubebuilder:validation:Enum=GuestInfo;NoCloudISO
+type BootstrapDataDelivery string
+
+const (
+	// BootstrapDataDeliveryGuestInfo sets the bootstrap data and the metadata in the guestinfo
+	// extra config keys of the virtual machine.
+	BootstrapDataDeliveryGuestInfo BootstrapDataDelivery = "GuestInfo"
+
+	// BootstrapDataDeliveryNoCloudISO renders a NoCloud seed ISO with the bootstrap data and the
+	// metadata, uploads it to the datastore of the virtual machine and attaches it as a CD-ROM.
+	BootstrapDataDeliveryNoCloudISO BootstrapDataDelivery = "NoCloudISO"
+)
+
+// BootstrapDataScrubPolicy describes whether the bootstrap data is removed from the guestinfo
+// of a virtual machine once it is no longer needed.
+// +kubebuilder:validation:Enum=Scrub;Retain
+type BootstrapDataScrubPolicy string
+
+const (
+	// BootstrapDataScrubPolicyScrub removes the bootstrap data from the guestinfo of the virtual
+	// machine once its Machine has a node, or once the delay has passed.
+	BootstrapDataScrubPolicyScrub BootstrapDataScrubPolicy = "Scrub"
+
+	// BootstrapDataScrubPolicyRetain keeps the bootstrap data in the guestinfo of the virtual
+	// machine for its whole lifetime, e.g. to debug the bootstrap of the node.
+	BootstrapDataScrubPolicyRetain BootstrapDataScrubPolicy = "Retain"
+)
+
+// VirtualMachineDriftRemediationField is a field of a virtual machine which is
+// changed back to the desired value if it drifted.
+// +kubebuilder:validation:Enum=Folder;ResourcePool;Network
+type VirtualMachineDriftRemediationField string
+
+const (
+	// VirtualMachineDriftRemediationFieldFolder moves the virtual machine back into the desired folder.
+	VirtualMachineDriftRemediationFieldFolder VirtualMachineDriftRemediationField = "Folder"
+
+	// VirtualMachineDriftRemediationFieldResourcePool relocates the virtual machine back into the
+	// desired resource pool.
+	VirtualMachineDriftRemediationFieldResourcePool VirtualMachineDriftRemediationField = "ResourcePool"
+
+	// VirtualMachineDriftRemediationFieldNetwork connects the network devices of the virtual machine
+	// back to the desired networks.
+	VirtualMachineDriftRemediationFieldNetwork VirtualMachineDriftRemediationField = "Network"
+)
+
+// VirtualMachineTemplateSelector selects the template used to clone a virtual machine
+// by the vSphere tags attached to it, or the content library item the virtual machine
+// is deployed from by the vSphere tags attached to the item.
+type VirtualMachineTemplateSelector struct {
+	// matchTags is the list of vSphere tags which must all be attached to the template.
+	// Only templates in the datacenter of the virtual machine are considered,
+	// virtual machines which are not marked as templates are ignored.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	MatchTags []VirtualMachineTemplateTag `json:"matchTags,omitempty"`
+
+	// contentLibrary is the name of the content library whose items are selected by matchTags
+	// instead of the templates in the inventory. The virtual machine is deployed from the
+	// selected item like from a contentLibraryItem, and the ID of the item is recorded in the
+	// status of the VSphereVM instead of the instance UUID of a template.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	ContentLibrary string `json:"contentLibrary,omitempty"`
+}
+
+// VirtualMachineTemplateTag is a vSphere tag identified by its category and name.
+type VirtualMachineTemplateTag struct {
+	// category is the name of the tag category, e.g. k8s-version.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Category string `json:"category,omitempty"`
+
+	// name is the name of the tag, e.g. v1.34.1.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
+}
+
+// VSphereTemplateReference is a reference to a VSphereTemplate.
+type VSphereTemplateReference struct {
+	// name is the name of the VSphereTemplate.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+}
+
+// ContentLibraryItemReference references an item of a vSphere content library.
+type ContentLibraryItemReference struct {
+	// library is the name of the content library which contains the item.
+	// Required if item is the name of the item.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Library string `json:"library,omitempty"`
+
+	// item is the name or the ID of the content library item.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Item string `json:"item,omitempty"`
+
+	// provisioningMode is the provisioning type of the disks of a virtual machine
+	// deployed from an OVF template. If omitted, the provisioning type of the OVF
+	// template is used. It must not be set for VM templates, whose disks are
+	// provisioned like the disks of the template; their deployment fails if it is.
+	// +optional
+	ProvisioningMode ProvisioningMode `json:"provisioningMode,omitempty"`
+}
+
+// SysprepSpec configures the customization of a Windows virtual machine with sysprep.
+type SysprepSpec struct {
+	// fullName is the full name of the registered user of the Windows installation.
+	// Defaults to Administrator.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	FullName string `json:"fullName,omitempty"`
+
+	// organizationName is the name of the organization of the Windows installation.
+	// Defaults to Kubernetes.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	OrganizationName string `json:"organizationName,omitempty"`
+
+	// timeZone is the index of the time zone of the Windows installation, as listed in the
+	// Microsoft Time Zone Index Values. Defaults to 85 (GMT Standard Time).
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	TimeZone *int32 `json:"timeZone,omitempty"`
+
+	// workgroup is the workgroup the virtual machine joins. Defaults to WORKGROUP.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=15
+	Workgroup string `json:"workgroup,omitempty"`
+
+	// adminPasswordSecretName is the name of a Secret in the namespace of the virtual machine
+	// with the password of the local Administrator account in its password key.
+	// If omitted, the password of the Administrator account is left blank.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	AdminPasswordSecretName string `json:"adminPasswordSecretName,omitempty"`
+}
+
 // VirtualMachineCloneSpec is information used to clone a virtual machine.
+// +kubebuilder:validation:XValidation:rule="[has(self.template), has(self.templateSelector), has(self.contentLibraryItem), has(self.templateRef)].filter(x, x).size() == 1",message="exactly one of template, templateSelector, contentLibraryItem or templateRef must be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.sysprep) || (has(self.os) && self.os == 'Windows')",message="sysprep can only be set if os is Windows"
+// +kubebuilder:validation:XValidation:rule="!(has(self.datastore) && has(self.datastoreCluster))",message="datastore and datastoreCluster are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="!has(self.cloneMode) || self.cloneMode != 'instantClone' || has(self.instantCloneParent)",message="instantCloneParent must be set if cloneMode is instantClone"
+// +kubebuilder:validation:XValidation:rule="!has(self.bootstrapDataDelivery) || self.bootstrapDataDelivery != 'NoCloudISO' || (!has(self.contentLibraryItem) && !(has(self.templateSelector) && has(self.templateSelector.contentLibrary)) && (!has(self.cloneMode) || self.cloneMode != 'instantClone'))",message="bootstrapDataDelivery NoCloudISO can not be used with contentLibraryItem, the contentLibrary of templateSelector or cloneMode instantClone"
 type VirtualMachineCloneSpec struct {
 	// template is the name, inventory path, managed object reference or the managed
 	// object ID of the template used to clone the virtual machine.
-	// +required
+	// Exactly one of template, templateSelector, contentLibraryItem or templateRef must be set.
+	// +optional
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=2048
 	Template string `json:"template,omitempty"`
 
+	// templateSelector selects the template used to clone the virtual machine by the
+	// vSphere tags attached to it, e.g. tags for the Kubernetes version, the operating
+	// system and the architecture of the image. If several templates match, the most
+	// recently created one is used. With contentLibrary, the item of the content library
+	// the virtual machine is deployed from is selected instead.
+	// The instance UUID of the selected template, or the ID of the selected item, is recorded
+	// in the status of the VSphereVM and used if the clone operation is retried.
+	// Exactly one of template, templateSelector, contentLibraryItem or templateRef must be set.
+	// +optional
+	TemplateSelector *VirtualMachineTemplateSelector `json:"templateSelector,omitempty"`
+
+	// contentLibraryItem is the content library item, either an OVF template or a VM template,
+	// from which the virtual machine is deployed instead of cloning a template from the inventory.
+	// The virtual machine is deployed with the vCenter OVF or VM template deploy API and
+	// reconfigured afterwards; cloneMode and snapshot are ignored.
+	// Exactly one of template, templateSelector, contentLibraryItem or templateRef must be set.
+	// +optional
+	ContentLibraryItem *ContentLibraryItemReference `json:"contentLibraryItem,omitempty"`
+
+	// templateRef references the VSphereTemplate in the namespace of the virtual machine whose
+	// imported template is used to clone the virtual machine. The virtual machine is cloned
+	// once the template is imported.
+	// The instance UUID of the template is recorded in the status of the VSphereVM.
+	// Exactly one of template, templateSelector, contentLibraryItem or templateRef must be set.
+	// +optional
+	TemplateRef *VSphereTemplateReference `json:"templateRef,omitempty"`
+
 	// cloneMode specifies the type of clone operation.
 	// The linkedClone mode is only support for templates that have at least
 	// one snapshot. If the template has no snapshots, then CloneMode defaults
@@ -146,9 +392,21 @@ type VirtualMachineCloneSpec struct {
 	// not possible to expand disks of linked clones.
 	// Defaults to linkedClone, but fails gracefully to fullClone if the source
 	// of the clone operation has no snapshots.
+	// The instantClone mode forks the running, frozen instantCloneParent VM
+	// instead. It fails gracefully to linkedClone from the template if the
+	// parent VM is not available, or if the VM requires devices or settings
+	// which can't be applied to an instant clone.
 	// +optional
 	CloneMode CloneMode `json:"cloneMode,omitempty"`
 
+	// instantCloneParent is the name, inventory path, managed object reference or the
+	// managed object ID of the running, frozen VM from which the virtual machine is
+	// forked if cloneMode is instantClone.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	InstantCloneParent string `json:"instantCloneParent,omitempty"`
+
 	// snapshot is the name of the snapshot from which to create a linked clone.
 	// This field is ignored if linkedClone is not enabled.
 	// Defaults to the source's current snapshot.
@@ -157,6 +415,17 @@ type VirtualMachineCloneSpec struct {
 	// +kubebuilder:validation:MaxLength=1024
 	Snapshot string `json:"snapshot,omitempty"`
 
+	// snapshotPolicy determines what happens if the template of a linked clone has
+	// no snapshot, or no snapshot with the name of snapshot. With UseExisting the
+	// virtual machine falls back to a full clone, with CreateIfMissing the snapshot
+	// is created on the template, named after snapshot or capv-linked-clone.
+	// Snapshots are not created on VM templates, which are shared by other users,
+	// the clone fails instead.
+	// This field is ignored if linkedClone is not enabled.
+	// Defaults to UseExisting.
+	// +optional
+	SnapshotPolicy LinkedCloneSnapshotPolicy `json:"snapshotPolicy,omitempty"`
+
 	// server is the IP address or FQDN of the vSphere server on which
 	// the virtual machine is created/located.
 	// +optional
@@ -195,6 +464,15 @@ type VirtualMachineCloneSpec struct {
 	// +kubebuilder:validation:MaxLength=2048
 	Datastore string `json:"datastore,omitempty"`
 
+	// datastoreCluster is the name, inventory path, managed object reference or the managed
+	// object ID of the datastore cluster in which the virtual machine is created.
+	// The datastore of the virtual machine and its disks is recommended by Storage DRS.
+	// datastore and datastoreCluster are mutually exclusive.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	DatastoreCluster string `json:"datastoreCluster,omitempty"`
+
 	// storagePolicyName of the storage policy to use with this
 	// Virtual Machine
 	// +optional
@@ -241,9 +519,26 @@ type VirtualMachineCloneSpec struct {
 	// +kubebuilder:validation:Minimum=1
 	MemoryMiB int64 `json:"memoryMiB,omitempty"`
 
+	// resizePolicy describes how changes to numCPUs, numCoresPerSocket, memoryMiB and
+	// resources are applied to an existing virtual machine.
+	//
+	// Resource allocation changes (reservations, limits and shares) are always applied
+	// to the running virtual machine. CPU and memory increases are applied to the running
+	// virtual machine if CPU respectively memory hot add is enabled for it. All other changes
+	// require the virtual machine to be powered off; with HotAdd they are deferred until the
+	// virtual machine is powered off, with PowerCycle the virtual machine is powered off
+	// (using a hard power off), reconfigured and powered on again.
+	//
+	// If omitted, the policy defaults to HotAdd.
+	//
+	// +optional
+	ResizePolicy VirtualMachineResizePolicy `json:"resizePolicy,omitempty"`
+
 	// diskGiB is the size of a virtual machine's disk, in GiB.
 	// Defaults to the eponymous property value in the template from which the
 	// virtual machine is cloned.
+	// Increasing the value extends the disk of the existing virtual machine;
+	// disks are never shrunk.
 	// +optional
 	// +kubebuilder:validation:Minimum=1
 	DiskGiB int32 `json:"diskGiB,omitempty"`
@@ -283,6 +578,41 @@ type VirtualMachineCloneSpec struct {
 	// +kubebuilder:validation:MaxLength=128
 	OS OS `json:"os,omitempty"`
 
+	// sysprep enables the customization of the guest with sysprep, which sets the computer name
+	// and the network configuration, including the static IP addresses of the network devices
+	// and the addresses claimed from IP pools, on the first boot of the virtual machine.
+	// If omitted, the guest is not customized and Cloudbase-Init configures the guest from the
+	// metadata of the virtual machine.
+	// Can only be set if os is Windows.
+	// +optional
+	Sysprep *SysprepSpec `json:"sysprep,omitempty"`
+
+	// bootstrapDataDelivery determines how the bootstrap data and the metadata are delivered
+	// to the guest.
+	//
+	// With GuestInfo, they are set in the guestinfo.userdata and guestinfo.metadata extra
+	// config keys of the virtual machine.
+	//
+	// With NoCloudISO, a NoCloud seed ISO labeled cidata with the user-data, meta-data and
+	// network-config files is uploaded to the datastore of the virtual machine, in the
+	// capv-cidata/<namespace> directory, and attached as a CD-ROM when the virtual machine is
+	// cloned. The ISO is ejected and deleted once the Machine has a node, or once the delaySeconds
+	// of bootstrapDataScrub have passed, unless its policy is Retain. The bootstrap data is thus
+	// neither limited in size by the extra config nor readable by users with read access to
+	// the virtual machine. Ignition bootstrap data can not be delivered with NoCloudISO, and it
+	// can not be used with contentLibraryItem, the contentLibrary of templateSelector or the
+	// instantClone cloneMode.
+	//
+	// If omitted, the bootstrap data is delivered with GuestInfo.
+	//
+	// +optional
+	BootstrapDataDelivery BootstrapDataDelivery `json:"bootstrapDataDelivery,omitempty"`
+
+	// bootstrapDataScrub configures the removal of the bootstrap data, which contains secrets like
+	// the join token of the node, from the guestinfo of the virtual machine once it is no longer needed.
+	// +optional
+	BootstrapDataScrub BootstrapDataScrubSpec `json:"bootstrapDataScrub,omitempty,omitzero"`
+
 	// hardwareVersion is the hardware version of the virtual machine.
 	// Defaults to the eponymous property value in the template from which the
 	// virtual machine is cloned.
@@ -299,6 +629,33 @@ type VirtualMachineCloneSpec struct {
 	// +kubebuilder:validation:MaxItems=29
 	DataDisks []VSphereDisk `json:"dataDisks,omitempty"`
 
+	// diskGrowHint controls whether the guest is notified after disks of an existing
+	// virtual machine have been extended because diskGiB, additionalDisksGiB or the
+	// sizeGiB of a data disk has been increased.
+	//
+	// With GuestInfo, guestinfo.capv.disks.grow.disks is set to a comma-separated list
+	// of the extended disks (primary, additional-<index> or the name of the data disk) and
+	// guestinfo.capv.disks.grow.timestamp to the time of the extension, so an agent in the
+	// guest can grow the partitions and filesystems on them.
+	//
+	// If omitted, the hint defaults to None.
+	//
+	// +optional
+	DiskGrowHint DiskGrowHint `json:"diskGrowHint,omitempty"`
+
+	// driftRemediation is the list of fields which are changed back to the desired value
+	// if they have been changed on the existing virtual machine, e.g. in the vSphere UI.
+	//
+	// Drift of numCPUs, numCoresPerSocket and memoryMiB is always remediated according to
+	// the resizePolicy. Drift of all other fields is only reported in status.drift and in the
+	// VirtualMachineInSync condition unless the field is listed here.
+	//
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=3
+	DriftRemediation []VirtualMachineDriftRemediationField `json:"driftRemediation,omitempty"`
+
 	// nestedHV controls nested hardware-assisted virtualization.
 	// Defaults to the eponymous property value in the template from which the
 	// virtual machine is cloned.
@@ -334,6 +691,38 @@ type VirtualMachineCloneSpec struct {
 	CryptoProfile string `json:"cryptoProfile,omitempty"`
 }
 
+// BootstrapDataScrubSpec configures the removal of the bootstrap data from the guestinfo of a
+// virtual machine.
+// +kubebuilder:validation:MinProperties=1
+type BootstrapDataScrubSpec struct {
+	// policy determines whether the bootstrap data is removed from the guestinfo of the virtual machine.
+	//
+	// With Scrub, the guestinfo.userdata and guestinfo.ignition.config.data extra config keys are
+	// blanked once the Machine of the virtual machine has a node, or once delaySeconds have passed
+	// since the virtual machine has been provisioned. The guestinfo.metadata extra config key with
+	// the instance-id and the network configuration is kept and still updated. With the NoCloudISO
+	// bootstrapDataDelivery, the seed ISO is ejected and deleted instead.
+	//
+	// With Retain, they are kept for the whole lifetime of the virtual machine, e.g. to debug
+	// the bootstrap of the node.
+	//
+	// If omitted, the policy defaults to Scrub.
+	//
+	// +optional
+	Policy BootstrapDataScrubPolicy `json:"policy,omitempty"`
+
+	// delaySeconds is the time after which the bootstrap data is removed once the virtual machine
+	// has been provisioned, even if its Machine has no node yet. It must leave the guest enough
+	// time to read it.
+	//
+	// If omitted, it is only removed once the Machine has a node, or 600 seconds after the virtual
+	// machines of a VSphereMachinePool, which have no Machine, have been provisioned.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DelaySeconds int32 `json:"delaySeconds,omitempty"`
+}
+
 // VirtualMachineResources is the definition of the VM's cpu and memory
 // reservations, limits and shares.
 // +kubebuilder:validation:MinProperties=1
@@ -453,6 +842,44 @@ func (v APIEndpoint) String() string {
 	return fmt.Sprintf("%s:%d", v.Host, v.Port)
 }
 
+// CABundleKind is the kind of object containing a CA bundle.
+// +kubebuilder:validation:Enum=ConfigMap;Secret
+type CABundleKind string
+
+const (
+	// ConfigMapCABundleKind is used when a CA bundle is stored in a ConfigMap.
+	ConfigMapCABundleKind CABundleKind = "ConfigMap"
+
+	// SecretCABundleKind is used when a CA bundle is stored in a Secret.
+	SecretCABundleKind CABundleKind = "Secret"
+)
+
+// CABundleReference is a reference to a key of a ConfigMap or Secret containing
+// PEM encoded CA certificates which are trusted to verify the vCenter server certificate.
+type CABundleReference struct {
+	// kind of the object containing the CA bundle. Can either be ConfigMap or Secret.
+	// +required
+	Kind CABundleKind `json:"kind,omitempty"`
+
+	// name of the object containing the CA bundle.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Name string `json:"name,omitempty"`
+
+	// key of the CA bundle inside the object.
+	// If not set, ca.crt is used.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	Key string `json:"key,omitempty"`
+}
+
+// IsDefined returns true if the ref is defined.
+func (r *CABundleReference) IsDefined() bool {
+	return r.Kind != "" || r.Name != ""
+}
+
 // PCIDeviceSpec defines virtual machine's PCI configuration.
 type PCIDeviceSpec struct {
 	// deviceId is the device ID of a virtual machine's PCI, in integer.
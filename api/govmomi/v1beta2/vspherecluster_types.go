@@ -106,6 +106,11 @@ func NewVCenterVersion(version string) VCenterVersion {
 }
 
 // VSphereClusterSpec defines the desired state of VSphereCluster.
+// +kubebuilder:validation:XValidation:rule="!(has(self.thumbprint) && has(self.caBundleRef))",message="only one of thumbprint or caBundleRef can be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.insecure) || !self.insecure || (!has(self.thumbprint) && !has(self.caBundleRef))",message="insecure cannot be set to true if thumbprint or caBundleRef is set"
+// +kubebuilder:validation:XValidation:rule="(has(self.placement) && has(self.placement.strategy)) == (has(oldSelf.placement) && has(oldSelf.placement.strategy)) && (!has(self.placement) || !has(self.placement.strategy) || self.placement.strategy == oldSelf.placement.strategy)",message="placement.strategy is immutable"
+// +kubebuilder:validation:XValidation:rule="(has(self.placement) && has(self.placement.controlPlaneRule)) == (has(oldSelf.placement) && has(oldSelf.placement.controlPlaneRule)) && (!has(self.placement) || !has(self.placement.controlPlaneRule) || self.placement.controlPlaneRule == oldSelf.placement.controlPlaneRule)",message="placement.controlPlaneRule is immutable"
+// +kubebuilder:validation:XValidation:rule="(has(self.placement) && has(self.placement.workerRule)) == (has(oldSelf.placement) && has(oldSelf.placement.workerRule)) && (!has(self.placement) || !has(self.placement.workerRule) || self.placement.workerRule == oldSelf.placement.workerRule)",message="placement.workerRule is immutable"
 type VSphereClusterSpec struct {
 	// server is the address of the vSphere endpoint.
 	// +required
@@ -119,6 +124,19 @@ type VSphereClusterSpec struct {
 	// +kubebuilder:validation:MaxLength=1024
 	Thumbprint string `json:"thumbprint,omitempty"`
 
+	// caBundleRef is a reference to a ConfigMap or Secret in the namespace of the VSphereCluster containing
+	// PEM encoded CA certificates which are trusted to verify the vCenter server certificate.
+	// If neither thumbprint nor caBundleRef is set, the CA bundle of the VSphereClusterIdentity referenced
+	// by identityRef is used; if none is set either, the system trust store of the controller is used.
+	// +optional
+	CABundleRef CABundleReference `json:"caBundleRef,omitempty,omitzero"`
+
+	// insecure allows connecting to vCenter without verifying the vCenter server certificate
+	// if neither thumbprint nor caBundleRef is set.
+	// This is not recommended for production environments.
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+
 	// controlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint APIEndpoint `json:"controlPlaneEndpoint,omitempty,omitzero"`
@@ -141,6 +159,12 @@ type VSphereClusterSpec struct {
 	// +optional
 	DisableClusterModule *bool `json:"disableClusterModule,omitempty"`
 
+	// placement configures how the VMs of each KubeadmControlPlane, MachineDeployment and MachinePool
+	// of the cluster are placed relative to each other.
+	// It is only used if the NodeAntiAffinity feature flag is enabled.
+	// +optional
+	Placement VSphereClusterPlacement `json:"placement,omitempty,omitzero"`
+
 	// failureDomainSelector is the label selector to use for failure domain selection
 	// for the control plane nodes of the cluster.
 	// If not set (`nil`), selecting failure domains will be disabled.
@@ -150,6 +174,109 @@ type VSphereClusterSpec struct {
 	FailureDomainSelector *metav1.LabelSelector `json:"failureDomainSelector,omitempty"`
 }
 
+// VSpherePlacementStrategy is the vSphere construct used to place the VMs of an object relative to each other.
+// +kubebuilder:validation:Enum=ClusterModule;DRSRule
+type VSpherePlacementStrategy string
+
+const (
+	// ClusterModulePlacementStrategy anti-affines the VMs of an object with a vCenter cluster module.
+	// Cluster modules require vCenter 7 or later and DRS in fully automated mode.
+	ClusterModulePlacementStrategy VSpherePlacementStrategy = "ClusterModule"
+
+	// DRSRulePlacementStrategy places the VMs of an object with a DRS VM-VM affinity or anti-affinity rule.
+	DRSRulePlacementStrategy VSpherePlacementStrategy = "DRSRule"
+)
+
+// VSpherePlacementRuleType is the type of the DRS VM-VM rule of the VMs of an object.
+// +kubebuilder:validation:Enum=AntiAffinity;Affinity
+type VSpherePlacementRuleType string
+
+const (
+	// AntiAffinityPlacementRuleType keeps the VMs of an object on different hosts.
+	AntiAffinityPlacementRuleType VSpherePlacementRuleType = "AntiAffinity"
+
+	// AffinityPlacementRuleType keeps the VMs of an object on the same host.
+	AffinityPlacementRuleType VSpherePlacementRuleType = "Affinity"
+)
+
+// VSphereClusterPlacement configures how the VMs of each KubeadmControlPlane, MachineDeployment
+// and MachinePool of the cluster are placed relative to each other.
+// +kubebuilder:validation:MinProperties=1
+type VSphereClusterPlacement struct {
+	// strategy is the vSphere construct used to place the VMs.
+	// ClusterModule anti-affines the VMs of each object with a vCenter cluster module.
+	// DRSRule creates a DRS VM-VM rule for the VMs of each object in each compute cluster,
+	// once the object has at least two VMs in the compute cluster.
+	// Defaults to ClusterModule.
+	// +optional
+	Strategy VSpherePlacementStrategy `json:"strategy,omitempty"`
+
+	// controlPlaneRule is the type of the DRS rule of the VMs of the KubeadmControlPlane.
+	// It is only used with the DRSRule strategy.
+	// Defaults to AntiAffinity.
+	// +optional
+	ControlPlaneRule VSpherePlacementRuleType `json:"controlPlaneRule,omitempty"`
+
+	// workerRule is the type of the DRS rule of the VMs of each MachineDeployment and MachinePool.
+	// It is only used with the DRSRule strategy.
+	// Defaults to AntiAffinity.
+	// +optional
+	WorkerRule VSpherePlacementRuleType `json:"workerRule,omitempty"`
+
+	// failureDomains configures how worker machines without a failure domain are placed in
+	// the failure domains of the cluster.
+	// +optional
+	FailureDomains VSphereFailureDomainPlacement `json:"failureDomains,omitempty,omitzero"`
+}
+
+// VSphereFailureDomainPlacementStrategy is how worker machines without a failure domain are placed
+// in the failure domains of the cluster.
+// +kubebuilder:validation:Enum=None;MostFreeCapacity
+type VSphereFailureDomainPlacementStrategy string
+
+const (
+	// NoneFailureDomainPlacementStrategy does not place worker machines without a failure domain
+	// in a failure domain.
+	NoneFailureDomainPlacementStrategy VSphereFailureDomainPlacementStrategy = "None"
+
+	// MostFreeCapacityFailureDomainPlacementStrategy places worker machines without a failure domain
+	// in the failure domain with the most free memory, and then the most free CPU.
+	MostFreeCapacityFailureDomainPlacementStrategy VSphereFailureDomainPlacementStrategy = "MostFreeCapacity"
+)
+
+// VSphereFailureDomainPlacement configures how worker machines without a failure domain are placed
+// in the failure domains of the cluster.
+// +kubebuilder:validation:MinProperties=1
+type VSphereFailureDomainPlacement struct {
+	// strategy is how worker machines without a failure domain are placed in the failure domains.
+	// None leaves them outside of the failure domains.
+	// MostFreeCapacity places each machine in the failure domain with the most free memory, and
+	// then the most free CPU, as published in the attributes of the failure domains of the cluster.
+	// Defaults to None.
+	// +optional
+	Strategy VSphereFailureDomainPlacementStrategy `json:"strategy,omitempty"`
+
+	// minDatastoreFreeGiB is the free space in GiB of the datastore of a failure domain below which
+	// no machine is placed in it by the MostFreeCapacity strategy.
+	// Machines are not created until a failure domain has enough free space.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinDatastoreFreeGiB *int64 `json:"minDatastoreFreeGiB,omitempty"`
+}
+
+// Attributes of the failure domains in the status of a VSphereCluster, which are published from
+// the capacity of the corresponding VSphereFailureDomains.
+const (
+	// FailureDomainCPUFreeMHzAttribute is the free CPU of the hosts of a failure domain in MHz.
+	FailureDomainCPUFreeMHzAttribute = "cpuFreeMHz"
+
+	// FailureDomainMemoryFreeMiBAttribute is the free memory of the hosts of a failure domain in MiB.
+	FailureDomainMemoryFreeMiBAttribute = "memoryFreeMiB"
+
+	// FailureDomainDatastoreFreeGiBAttribute is the free space of the datastore of a failure domain in GiB.
+	FailureDomainDatastoreFreeGiBAttribute = "datastoreFreeGiB"
+)
+
 // ClusterModule holds the anti affinity construct `ClusterModule` identifier
 // in use by the VMs owned by the object referred by the TargetObjectName field.
 type ClusterModule struct {
@@ -26,6 +26,10 @@ const (
 	SecretIdentitySetFinalizer = "vspherecluster/infrastructure.cluster.x-k8s.io"
 	// VSphereClusterIdentityFinalizer is the finalizer for VSphereClusterIdentity credentials secrets.
 	VSphereClusterIdentityFinalizer = "vsphereclusteridentity/infrastructure.cluster.x-k8s.io"
+
+	// VSphereClusterIdentityCredentialsHashAnnotation is the annotation containing the SHA-256 hash of the
+	// credentials last observed by the controller, which is used to detect the rotation of the credentials.
+	VSphereClusterIdentityCredentialsHashAnnotation = "vsphereclusteridentity.infrastructure.cluster.x-k8s.io/credentials-hash"
 )
 
 // VSphereClusterIdentity's Available condition and corresponding reasons that will be used in v1Beta2 API version.
@@ -45,18 +49,51 @@ const (
 	// VSphereClusterIdentitySettingSecretOwnerReferenceFailedReason surfaces when setting the owner reference on the VSphereClusterIdentity secret failed.
 	VSphereClusterIdentitySettingSecretOwnerReferenceFailedReason = "SettingSecretOwnerReferenceFailed"
 
+	// VSphereClusterIdentityCredentialsProviderNotAvailableReason surfaces when the credentials cannot be retrieved from the VSphereClusterIdentity credentials provider.
+	VSphereClusterIdentityCredentialsProviderNotAvailableReason = "CredentialsProviderNotAvailable"
+
+	// VSphereClusterIdentityCredentialsValidationFailedReason surfaces when the VSphereClusterIdentity credentials cannot be used to login to vCenter.
+	VSphereClusterIdentityCredentialsValidationFailedReason = "CredentialsValidationFailed"
+
 	// VSphereClusterIdentityDeletingReason surfaces when the VSphereClusterIdentity is being deleted.
 	VSphereClusterIdentityDeletingReason = clusterv1.DeletingReason
 )
 
-// VSphereClusterIdentitySpec contains a secret reference and a group of allowed namespaces.
+// VSphereClusterIdentity's CredentialsRotated condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereClusterIdentityCredentialsRotatedCondition documents the rotation of the VSphereClusterIdentity credentials.
+	VSphereClusterIdentityCredentialsRotatedCondition = "CredentialsRotated"
+
+	// VSphereClusterIdentityCredentialsRotatedReason surfaces when the VSphereClusterIdentity credentials have been rotated
+	// and the vCenter sessions using the previous credentials have been logged out.
+	VSphereClusterIdentityCredentialsRotatedReason = "CredentialsRotated"
+
+	// VSphereClusterIdentityCredentialsNotRotatedReason surfaces when no rotation of the VSphereClusterIdentity credentials has been observed.
+	VSphereClusterIdentityCredentialsNotRotatedReason = "CredentialsNotRotated"
+)
+
+// VSphereClusterIdentitySpec contains a credentials source and a group of allowed namespaces.
+// +kubebuilder:validation:XValidation:rule="has(self.secretName) != has(self.credentialsProvider)",message="exactly one of secretName or credentialsProvider must be set"
 type VSphereClusterIdentitySpec struct {
-	// secretName references a Secret inside the controller namespace with the credentials to use
-	// +required
+	// secretName references a Secret inside the controller namespace with the credentials to use.
+	// Mutually exclusive with credentialsProvider.
+	// +optional
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=253
 	SecretName string `json:"secretName,omitempty"`
 
+	// credentialsProvider configures a source for the credentials other than a Secret,
+	// e.g. files mounted into the controller, a Vault server or an exec plugin.
+	// Mutually exclusive with secretName.
+	// +optional
+	CredentialsProvider CredentialsProvider `json:"credentialsProvider,omitempty,omitzero"`
+
+	// caBundleRef is a reference to a ConfigMap or Secret inside the controller namespace containing
+	// PEM encoded CA certificates which are trusted to verify the vCenter server certificates.
+	// It is used by VSphereClusters using this identity which set neither thumbprint nor caBundleRef.
+	// +optional
+	CABundleRef CABundleReference `json:"caBundleRef,omitempty,omitzero"`
+
 	// allowedNamespaces is used to identify which namespaces are allowed to use this account.
 	// Namespaces can be selected with a label selector.
 	// If this object is nil, no namespaces will be allowed
@@ -68,7 +105,7 @@ type VSphereClusterIdentitySpec struct {
 // +kubebuilder:validation:MinProperties=1
 type VSphereClusterIdentityStatus struct {
 	// conditions represents the observations of a VSphereClusterIdentity's current state.
-	// Known condition types are Available and Paused.
+	// Known condition types are Available, CredentialsRotated and Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -79,6 +116,10 @@ type VSphereClusterIdentityStatus struct {
 	// +optional
 	Ready *bool `json:"ready,omitempty"`
 
+	// lastCredentialsRotationTime is the time the controller last observed a rotation of the credentials.
+	// +optional
+	LastCredentialsRotationTime metav1.Time `json:"lastCredentialsRotationTime,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *VSphereClusterIdentityDeprecatedStatus `json:"deprecated,omitempty"`
@@ -103,6 +144,144 @@ type VSphereClusterIdentityV1Beta1DeprecatedStatus struct {
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
 
+// CredentialsProvider configures where the credentials of a VSphereClusterIdentity are retrieved from.
+// Exactly one of file, vault or exec must be set.
+// +kubebuilder:validation:MinProperties=1
+// +kubebuilder:validation:MaxProperties=1
+type CredentialsProvider struct {
+	// file reads the credentials from a directory mounted into the controller,
+	// e.g. a projected volume or a volume managed by the Secrets Store CSI driver.
+	// +optional
+	File FileCredentialsProvider `json:"file,omitempty,omitzero"`
+
+	// vault reads the credentials from a HashiCorp Vault compatible KV secrets engine.
+	// +optional
+	Vault VaultCredentialsProvider `json:"vault,omitempty,omitzero"`
+
+	// exec retrieves the credentials by running a plugin binary.
+	// +optional
+	Exec ExecCredentialsProvider `json:"exec,omitempty,omitzero"`
+}
+
+// FileCredentialsProvider reads the credentials from a directory.
+// The directory must contain a file named username and a file named password.
+// The files are read every time credentials are required, so they can be rotated in place.
+// Files are only read if the controller has been started with a credentials directory.
+type FileCredentialsProvider struct {
+	// path is the absolute path of the directory containing the credentials inside the controller container.
+	// The directory must be inside the credentials directory of the controller.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path,omitempty"`
+}
+
+// VaultCredentialsProvider reads the credentials from a HashiCorp Vault compatible KV secrets engine.
+// The secret must contain the username and password keys; both version 1 and version 2 of the
+// KV secrets engine are supported.
+// +kubebuilder:validation:XValidation:rule="has(self.kubernetesAuth) != has(self.tokenSecretName)",message="exactly one of kubernetesAuth or tokenSecretName must be set"
+type VaultCredentialsProvider struct {
+	// address is the URL of the Vault server, e.g. https://vault.example.com:8200.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Address string `json:"address,omitempty"`
+
+	// path is the API path of the secret below /v1/, e.g. secret/data/vsphere for the
+	// vsphere secret in a KV version 2 secrets engine mounted at secret.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Path string `json:"path,omitempty"`
+
+	// namespace is the Vault namespace to use.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Namespace string `json:"namespace,omitempty"`
+
+	// kubernetesAuth configures login to Vault with a service account token of the controller.
+	// Mutually exclusive with tokenSecretName.
+	// +optional
+	KubernetesAuth VaultKubernetesAuth `json:"kubernetesAuth,omitempty,omitzero"`
+
+	// tokenSecretName references a Secret inside the controller namespace containing a Vault token in the token key.
+	// Mutually exclusive with kubernetesAuth.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	TokenSecretName string `json:"tokenSecretName,omitempty"`
+}
+
+// VaultKubernetesAuth configures login to Vault using the Kubernetes auth method.
+type VaultKubernetesAuth struct {
+	// role is the Vault role to login with.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Role string `json:"role,omitempty"`
+
+	// mountPath is the path the Kubernetes auth method is mounted at.
+	// Defaults to kubernetes.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	MountPath string `json:"mountPath,omitempty"`
+
+	// tokenPath is the absolute path of the service account token used to login inside the controller container,
+	// e.g. a projected service account token with a Vault specific audience.
+	// The token must be inside the credentials directory of the controller.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	// +kubebuilder:validation:Pattern=`^/`
+	TokenPath string `json:"tokenPath,omitempty"`
+}
+
+// ExecCredentialsProvider retrieves the credentials by running a plugin binary.
+// The plugin must write a JSON object with the username and password keys to stdout.
+// Plugins are only run if the controller has been started with a credentials plugin directory.
+type ExecCredentialsProvider struct {
+	// command is the name of the plugin binary inside the credentials plugin directory of the controller.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	Command string `json:"command,omitempty"`
+
+	// args are the arguments passed to the plugin.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=1024
+	Args []string `json:"args,omitempty"`
+
+	// env are additional environment variables passed to the plugin.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=32
+	Env []ExecEnvVar `json:"env,omitempty"`
+}
+
+// ExecEnvVar is an environment variable passed to a credentials plugin.
+type ExecEnvVar struct {
+	// name of the environment variable.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
+
+	// value of the environment variable.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Value string `json:"value,omitempty"`
+}
+
 // AllowedNamespaces restricts the namespaces this VSphereClusterIdentity can be used from.
 type AllowedNamespaces struct {
 	// selector is a standard Kubernetes LabelSelector. A label query over a set of resources.
@@ -114,7 +114,29 @@ const (
 	VSphereDeploymentZoneFailureDomainDeletingReason = clusterv1.DeletingReason
 )
 
+// VSphereDeploymentZone's TemplateReplicasReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereDeploymentZoneTemplateReplicasReadyCondition documents the status of the replicas of the templates
+	// on the datastore of the failure domain of a VSphereDeploymentZone.
+	// It is only set if templateReplicas is set.
+	VSphereDeploymentZoneTemplateReplicasReadyCondition = "TemplateReplicasReady"
+
+	// VSphereDeploymentZoneTemplateReplicasReadyReason surfaces when the replicas of all the templates are ready.
+	VSphereDeploymentZoneTemplateReplicasReadyReason = clusterv1.ReadyReason
+
+	// VSphereDeploymentZoneTemplateReplicasCloningReason surfaces when the replica of at least one template is being cloned.
+	VSphereDeploymentZoneTemplateReplicasCloningReason = "Cloning"
+
+	// VSphereDeploymentZoneTemplateReplicasFailedReason surfaces when the replica of at least one template could not be created.
+	VSphereDeploymentZoneTemplateReplicasFailedReason = "Failed"
+
+	// VSphereDeploymentZoneTemplateReplicasNoDatastoreReason surfaces when the failure domain of the
+	// VSphereDeploymentZone has no datastore to replicate the templates to.
+	VSphereDeploymentZoneTemplateReplicasNoDatastoreReason = "NoDatastore"
+)
+
 // VSphereDeploymentZoneSpec defines the desired state of VSphereDeploymentZone.
+// +kubebuilder:validation:XValidation:rule="!has(self.insecure) || !self.insecure || !has(self.caBundleRef)",message="insecure cannot be set to true if caBundleRef is set"
 type VSphereDeploymentZoneSpec struct {
 	// server is the address of the vSphere endpoint.
 	// +optional
@@ -122,6 +144,18 @@ type VSphereDeploymentZoneSpec struct {
 	// +kubebuilder:validation:MaxLength=1024
 	Server string `json:"server,omitempty"`
 
+	// caBundleRef is a reference to a ConfigMap or Secret in the controller namespace containing
+	// PEM encoded CA certificates which are trusted to verify the vCenter server certificate.
+	// If not set, the certificate is verified with the settings of a VSphereCluster using the same server.
+	// +optional
+	CABundleRef CABundleReference `json:"caBundleRef,omitempty,omitzero"`
+
+	// insecure allows connecting to vCenter without verifying the vCenter server certificate
+	// if no thumbprint or CA bundle is configured.
+	// This is not recommended for production environments.
+	// +optional
+	Insecure *bool `json:"insecure,omitempty"`
+
 	// failureDomain is the name of the VSphereFailureDomain used for this VSphereDeploymentZone
 	// +required
 	// +kubebuilder:validation:MinLength=1
@@ -136,6 +170,19 @@ type VSphereDeploymentZoneSpec struct {
 	// used within this deployment zone.
 	// +optional
 	PlacementConstraint PlacementConstraint `json:"placementConstraint,omitempty,omitzero"`
+
+	// templateReplicas are the names, inventory paths, managed object references or managed
+	// object IDs of the templates which are replicated to the datastore of the failure domain.
+	// Virtual machines which are cloned from one of the templates in the failure domain are
+	// cloned from its replica instead, so linked clones stay on the datastore of the failure domain.
+	// The replicas are full clones of the templates with a snapshot for linked clones, and are
+	// placed next to the templates.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=2048
+	TemplateReplicas []string `json:"templateReplicas,omitempty"`
 }
 
 // PlacementConstraint is the context information for VM placements within a failure domain.
@@ -160,7 +207,7 @@ type PlacementConstraint struct {
 // +kubebuilder:validation:MinProperties=1
 type VSphereDeploymentZoneStatus struct {
 	// conditions represents the observations of a VSphereDeploymentZone's current state.
-	// Known condition types are Ready, VCenterAvailable, PlacementConstraintReady, FailureDomainValidated and Paused.
+	// Known condition types are Ready, VCenterAvailable, PlacementConstraintReady, FailureDomainValidated, TemplateReplicasReady and Paused.
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -172,11 +219,74 @@ type VSphereDeploymentZoneStatus struct {
 	// +optional
 	Ready *bool `json:"ready,omitempty"`
 
+	// templateReplicas are the replicas of the templates on the datastore of the failure domain.
+	// +optional
+	// +listType=map
+	// +listMapKey=template
+	// +kubebuilder:validation:MaxItems=32
+	TemplateReplicas []VSphereDeploymentZoneTemplateReplica `json:"templateReplicas,omitempty"`
+
+	// managedHosts are the VM group, the host group and the VM-Host rule of a managed failure domain
+	// which have been created by CAPV. Only these are updated and deleted by CAPV.
+	// +optional
+	ManagedHosts VSphereDeploymentZoneManagedHosts `json:"managedHosts,omitempty,omitzero"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *VSphereDeploymentZoneDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// VSphereDeploymentZoneTemplateReplica is the replica of a template on the datastore of a failure domain.
+type VSphereDeploymentZoneTemplateReplica struct {
+	// template is the template from the spec of the VSphereDeploymentZone.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Template string `json:"template,omitempty"`
+
+	// replica is the inventory path of the replica of the template, once it is ready.
+	// It is the template itself if the template is on the datastore of the failure domain.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Replica string `json:"replica,omitempty"`
+
+	// taskRef is the managed object reference of the task which clones the replica.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	TaskRef string `json:"taskRef,omitempty"`
+
+	// message describes why the replica could not be created.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=10240
+	Message string `json:"message,omitempty"`
+}
+
+// VSphereDeploymentZoneManagedHosts are the names of the VM group, the host group and the VM-Host rule
+// which have been created by CAPV in the compute cluster of a failure domain.
+// +kubebuilder:validation:MinProperties=1
+type VSphereDeploymentZoneManagedHosts struct {
+	// vmGroupName is the name of the VM group created by CAPV.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	VMGroupName string `json:"vmGroupName,omitempty"`
+
+	// hostGroupName is the name of the host group created by CAPV.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	HostGroupName string `json:"hostGroupName,omitempty"`
+
+	// ruleName is the name of the VM-Host rule created by CAPV.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	RuleName string `json:"ruleName,omitempty"`
+}
+
 // VSphereDeploymentZoneDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type VSphereDeploymentZoneDeprecatedStatus struct {
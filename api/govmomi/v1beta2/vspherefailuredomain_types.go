@@ -18,6 +18,7 @@ package v1beta2
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 )
 
 // FailureDomainType defines the VCenter object the failure domain represents.
@@ -32,8 +33,120 @@ const (
 	DatacenterFailureDomain FailureDomainType = "Datacenter"
 )
 
+// VSphereFailureDomain's Ready condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainReadyCondition is true if the VSphereFailureDomain's VCenterAvailable, DatacenterReady,
+	// ComputeClusterReady, DatastoreReady, NetworksReady and HostGroupReady conditions are true.
+	VSphereFailureDomainReadyCondition = clusterv1.ReadyCondition
+
+	// VSphereFailureDomainReadyReason surfaces when the VSphereFailureDomain readiness criteria is met.
+	VSphereFailureDomainReadyReason = clusterv1.ReadyReason
+
+	// VSphereFailureDomainNotReadyReason surfaces when the VSphereFailureDomain readiness criteria is not met.
+	VSphereFailureDomainNotReadyReason = clusterv1.NotReadyReason
+
+	// VSphereFailureDomainReadyUnknownReason surfaces when at least one VSphereFailureDomain readiness criteria is unknown
+	// and no VSphereFailureDomain readiness criteria is not met.
+	VSphereFailureDomainReadyUnknownReason = clusterv1.ReadyUnknownReason
+)
+
+// VSphereFailureDomain's VCenterAvailable condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainVCenterAvailableCondition documents the status of vCenter for a VSphereFailureDomain.
+	VSphereFailureDomainVCenterAvailableCondition = "VCenterAvailable"
+
+	// VSphereFailureDomainVCenterAvailableReason surfaces when the vCenter for a VSphereFailureDomain is available.
+	VSphereFailureDomainVCenterAvailableReason = clusterv1.AvailableReason
+
+	// VSphereFailureDomainVCenterUnreachableReason surfaces when the vCenter for a VSphereFailureDomain is unreachable.
+	VSphereFailureDomainVCenterUnreachableReason = "VCenterUnreachable"
+
+	// VSphereFailureDomainVCenterServerUnknownReason surfaces when the VSphereFailureDomain has no server
+	// and no VSphereDeploymentZone uses it.
+	VSphereFailureDomainVCenterServerUnknownReason = "ServerUnknown"
+)
+
+// VSphereFailureDomain's DatacenterReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainDatacenterReadyCondition documents the status of the datacenter of a VSphereFailureDomain.
+	VSphereFailureDomainDatacenterReadyCondition = "DatacenterReady"
+
+	// VSphereFailureDomainDatacenterReadyReason surfaces when the datacenter of a VSphereFailureDomain is found.
+	VSphereFailureDomainDatacenterReadyReason = clusterv1.ReadyReason
+
+	// VSphereFailureDomainDatacenterNotFoundReason surfaces when the datacenter of a VSphereFailureDomain is not found.
+	VSphereFailureDomainDatacenterNotFoundReason = "DatacenterNotFound"
+)
+
+// VSphereFailureDomain's ComputeClusterReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainComputeClusterReadyCondition documents the status of the compute cluster of a VSphereFailureDomain.
+	// The condition is only set if the VSphereFailureDomain has a compute cluster.
+	VSphereFailureDomainComputeClusterReadyCondition = "ComputeClusterReady"
+
+	// VSphereFailureDomainComputeClusterReadyReason surfaces when the compute cluster of a VSphereFailureDomain is found.
+	VSphereFailureDomainComputeClusterReadyReason = clusterv1.ReadyReason
+
+	// VSphereFailureDomainComputeClusterNotFoundReason surfaces when the compute cluster of a VSphereFailureDomain is not found.
+	VSphereFailureDomainComputeClusterNotFoundReason = "ComputeClusterNotFound"
+)
+
+// VSphereFailureDomain's DatastoreReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainDatastoreReadyCondition documents the status of the datastore of a VSphereFailureDomain.
+	// The condition is only set if the VSphereFailureDomain has a datastore.
+	VSphereFailureDomainDatastoreReadyCondition = "DatastoreReady"
+
+	// VSphereFailureDomainDatastoreReadyReason surfaces when the datastore of a VSphereFailureDomain is found.
+	VSphereFailureDomainDatastoreReadyReason = clusterv1.ReadyReason
+
+	// VSphereFailureDomainDatastoreNotFoundReason surfaces when the datastore of a VSphereFailureDomain is not found.
+	VSphereFailureDomainDatastoreNotFoundReason = "DatastoreNotFound"
+)
+
+// VSphereFailureDomain's NetworksReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainNetworksReadyCondition documents the status of the networks of a VSphereFailureDomain.
+	// The condition is only set if the VSphereFailureDomain has networks.
+	VSphereFailureDomainNetworksReadyCondition = "NetworksReady"
+
+	// VSphereFailureDomainNetworksReadyReason surfaces when all the networks of a VSphereFailureDomain are found.
+	VSphereFailureDomainNetworksReadyReason = clusterv1.ReadyReason
+
+	// VSphereFailureDomainNetworkNotFoundReason surfaces when a network of a VSphereFailureDomain is not found.
+	VSphereFailureDomainNetworkNotFoundReason = "NetworkNotFound"
+)
+
+// VSphereFailureDomain's HostGroupReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereFailureDomainHostGroupReadyCondition documents the status of the host group, the VM group and the
+	// VM-Host rule of a VSphereFailureDomain. The condition is only set if the VSphereFailureDomain has hosts.
+	VSphereFailureDomainHostGroupReadyCondition = "HostGroupReady"
+
+	// VSphereFailureDomainHostGroupReadyReason surfaces when the host group of a VSphereFailureDomain has hosts
+	// and the VM-Host rule exists.
+	VSphereFailureDomainHostGroupReadyReason = clusterv1.ReadyReason
+
+	// VSphereFailureDomainHostGroupNotFoundReason surfaces when the host group of a VSphereFailureDomain is not found.
+	VSphereFailureDomainHostGroupNotFoundReason = "HostGroupNotFound"
+
+	// VSphereFailureDomainHostGroupEmptyReason surfaces when the host group of a VSphereFailureDomain has no hosts.
+	VSphereFailureDomainHostGroupEmptyReason = "HostGroupEmpty"
+
+	// VSphereFailureDomainVMHostRuleNotFoundReason surfaces when the VM-Host rule of a VSphereFailureDomain is not found.
+	VSphereFailureDomainVMHostRuleNotFoundReason = "VMHostRuleNotFound"
+)
+
 // VSphereFailureDomainSpec defines the desired state of VSphereFailureDomain.
 type VSphereFailureDomainSpec struct {
+	// server is the address of the vSphere endpoint of the failure domain.
+	// It is used to report the status of the failure domain before a VSphereDeploymentZone uses it.
+	// If not set, the server of a VSphereDeploymentZone which uses the failure domain is used.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Server string `json:"server,omitempty"`
+
 	// region defines the name and type of a region
 	// +required
 	Region FailureDomain `json:"region,omitzero"`
@@ -184,6 +297,61 @@ type FailureDomainHosts struct {
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=2048
 	HostGroupName string `json:"hostGroupName,omitempty"`
+
+	// managed makes CAPV create the VM group, the host group and the VM-Host rule of the failure
+	// domain in the compute cluster, instead of referencing existing ones.
+	// The host group is kept up to date with the hosts of the compute cluster selected by hostSelector,
+	// and the groups and the rule are deleted when the VSphereDeploymentZone is deleted.
+	// Existing groups and rules with the same names which have not been created by CAPV are
+	// neither taken over nor deleted.
+	// +optional
+	Managed *ManagedFailureDomainHosts `json:"managed,omitempty"`
+}
+
+// ManagedFailureDomainHosts configures the VM group, the host group and the VM-Host rule
+// CAPV manages for a failure domain.
+type ManagedFailureDomainHosts struct {
+	// hostSelector selects the hosts of the compute cluster which are members of the host group.
+	// +required
+	HostSelector HostSelector `json:"hostSelector,omitempty,omitzero"`
+
+	// ruleName is the name of the VM-Host rule.
+	// Defaults to the name of the VM group suffixed with -rule.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	RuleName string `json:"ruleName,omitempty"`
+
+	// mandatory makes the VM-Host rule a "must run on hosts in group" rule.
+	// Defaults to false, which makes it a "should run on hosts in group" rule.
+	// +optional
+	Mandatory *bool `json:"mandatory,omitempty"`
+}
+
+// HostSelector selects ESXi hosts by their vSphere tags.
+type HostSelector struct {
+	// matchTags are the vSphere tags a host must have to be selected.
+	// A host is selected if it has all the tags.
+	// +required
+	// +listType=atomic
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	MatchTags []HostTag `json:"matchTags,omitempty"`
+}
+
+// HostTag is a vSphere tag identified by its category and name.
+type HostTag struct {
+	// category is the name of the tag category.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Category string `json:"category,omitempty"`
+
+	// name is the name of the tag.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name,omitempty"`
 }
 
 // IsDefined returns true if the ref is defined.
@@ -191,9 +359,72 @@ func (m *FailureDomainHosts) IsDefined() bool {
 	return m.VMGroupName != "" || m.HostGroupName != ""
 }
 
+// IsManaged returns true if CAPV manages the VM group, the host group and the VM-Host rule.
+func (m *FailureDomainHosts) IsManaged() bool {
+	return m.Managed != nil
+}
+
+// GetRuleName returns the name of the VM-Host rule managed by CAPV.
+func (m *ManagedFailureDomainHosts) GetRuleName(vmGroupName string) string {
+	if m.RuleName != "" {
+		return m.RuleName
+	}
+	return vmGroupName + "-rule"
+}
+
+// VSphereFailureDomainStatus defines the observed state of VSphereFailureDomain.
+// +kubebuilder:validation:MinProperties=1
+type VSphereFailureDomainStatus struct {
+	// conditions represents the observations of a VSphereFailureDomain's current state.
+	// Known condition types are Ready, VCenterAvailable, DatacenterReady, ComputeClusterReady, DatastoreReady,
+	// NetworksReady and HostGroupReady.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// capacity is the free capacity of the failure domain, which is gathered periodically.
+	// +optional
+	Capacity VSphereFailureDomainCapacity `json:"capacity,omitempty,omitzero"`
+}
+
+// VSphereFailureDomainCapacity is the free capacity of a failure domain.
+// The CPU and memory are the ones of the connected hosts of the failure domain which are not
+// in maintenance mode: the hosts of the host group, of the compute cluster or of the datacenter.
+// +kubebuilder:validation:MinProperties=1
+type VSphereFailureDomainCapacity struct {
+	// availableHosts is the number of connected hosts of the failure domain which are not in maintenance mode.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	AvailableHosts *int32 `json:"availableHosts,omitempty"`
+
+	// cpuFreeMHz is the free CPU of the available hosts in MHz.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	CPUFreeMHz *int64 `json:"cpuFreeMHz,omitempty"`
+
+	// memoryFreeMiB is the free memory of the available hosts in MiB.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MemoryFreeMiB *int64 `json:"memoryFreeMiB,omitempty"`
+
+	// datastoreFreeGiB is the free space of the datastore of the failure domain in GiB.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	DatastoreFreeGiB *int64 `json:"datastoreFreeGiB,omitempty"`
+
+	// lastUpdateTime is the time the capacity was gathered.
+	// +optional
+	LastUpdateTime metav1.Time `json:"lastUpdateTime,omitempty,omitzero"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:storageversion
 // +kubebuilder:resource:path=vspherefailuredomains,scope=Cluster,categories=cluster-api
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="VSphereFailureDomain is ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VSphereFailureDomain"
 
 // VSphereFailureDomain is the Schema for the vspherefailuredomains API.
 type VSphereFailureDomain struct {
@@ -206,6 +437,20 @@ type VSphereFailureDomain struct {
 	// spec is the desired state of VSphereFailureDomain.
 	// +required
 	Spec VSphereFailureDomainSpec `json:"spec,omitempty,omitzero"`
+
+	// status is the observed state of VSphereFailureDomain.
+	// +optional
+	Status VSphereFailureDomainStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (c *VSphereFailureDomain) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (c *VSphereFailureDomain) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
 }
 
 // +kubebuilder:object:root=true
@@ -89,6 +89,10 @@ const (
 	// by the VSphereMachine waiting for the machine network settings to be reported after machine being powered on.
 	VSphereMachineVirtualMachineWaitingForNetworkAddressReason = "WaitingForNetworkAddress"
 
+	// VSphereMachineVirtualMachineWaitingForFailureDomainCapacityReason surfaces when the VirtualMachine that is controlled
+	// by the VSphereMachine is waiting for a failure domain with enough free capacity to be placed in.
+	VSphereMachineVirtualMachineWaitingForFailureDomainCapacityReason = "WaitingForFailureDomainCapacity"
+
 	// VSphereMachineVirtualMachineWaitingForBIOSUUIDReason surfaces when the VirtualMachine that is controlled
 	// by the VSphereMachine waiting for the machine to have a BIOS UUID.
 	// Note: This reason is used only in supervisor mode.
@@ -202,6 +206,13 @@ type VSphereMachineStatus struct {
 	// +kubebuilder:validation:MaxItems=128
 	Network []NetworkStatus `json:"network,omitempty"`
 
+	// failureDomain is the failure domain in which CAPV placed the machine, when the Machine has no
+	// failure domain and the VSphereCluster places worker machines by capacity.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	FailureDomain string `json:"failureDomain,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *VSphereMachineDeprecatedStatus `json:"deprecated,omitempty"`
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// MachinePoolFinalizer allows ReconcileVSphereMachinePool to clean up the VSphereVMs
+	// of the VSphereMachinePool before removing it from the API Server.
+	MachinePoolFinalizer = "vspheremachinepool.infrastructure.cluster.x-k8s.io"
+
+	// MachinePoolNameLabel is the label set on the VSphereVMs of a VSphereMachinePool
+	// with the name of the VSphereMachinePool.
+	MachinePoolNameLabel = "vspheremachinepool.infrastructure.cluster.x-k8s.io/name"
+
+	// MachinePoolTemplateHashLabel is the label set on the VSphereVMs of a VSphereMachinePool
+	// with the hash of the machine template and the bootstrap data they were created from.
+	MachinePoolTemplateHashLabel = "vspheremachinepool.infrastructure.cluster.x-k8s.io/template-hash"
+
+	// MachinePoolDeleteAnnotation is the annotation set on the VSphereVMs of a VSphereMachinePool
+	// which were selected for deletion by a scale down or a rolling update. Their nodes are cordoned
+	// and drained before the VSphereVMs are deleted.
+	MachinePoolDeleteAnnotation = "vspheremachinepool.infrastructure.cluster.x-k8s.io/delete"
+)
+
+// VSphereMachinePool's Ready condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereMachinePoolReadyCondition is true if the VSphereMachinePool's deletionTimestamp is not set and
+	// VSphereMachinePool's VirtualMachinesReady condition is true.
+	VSphereMachinePoolReadyCondition = clusterv1.ReadyCondition
+
+	// VSphereMachinePoolReadyReason surfaces when the VSphereMachinePool readiness criteria is met.
+	VSphereMachinePoolReadyReason = clusterv1.ReadyReason
+
+	// VSphereMachinePoolNotReadyReason surfaces when the VSphereMachinePool readiness criteria is not met.
+	VSphereMachinePoolNotReadyReason = clusterv1.NotReadyReason
+
+	// VSphereMachinePoolReadyUnknownReason surfaces when at least one VSphereMachinePool readiness criteria is unknown
+	// and no VSphereMachinePool readiness criteria is not met.
+	VSphereMachinePoolReadyUnknownReason = clusterv1.ReadyUnknownReason
+)
+
+// VSphereMachinePool's VirtualMachinesReady condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereMachinePoolVirtualMachinesReadyCondition documents the status of the VSphereVMs that are controlled
+	// by the VSphereMachinePool.
+	VSphereMachinePoolVirtualMachinesReadyCondition = "VirtualMachinesReady"
+
+	// VSphereMachinePoolVirtualMachinesWaitingForClusterInfrastructureReadyReason documents the VSphereMachinePool
+	// waiting for the cluster infrastructure to be ready.
+	VSphereMachinePoolVirtualMachinesWaitingForClusterInfrastructureReadyReason = clusterv1.WaitingForClusterInfrastructureReadyReason
+
+	// VSphereMachinePoolVirtualMachinesWaitingForBootstrapDataReason documents the VSphereMachinePool
+	// waiting for the bootstrap data to be ready.
+	VSphereMachinePoolVirtualMachinesWaitingForBootstrapDataReason = clusterv1.WaitingForBootstrapDataReason
+
+	// VSphereMachinePoolVirtualMachinesReadyReason surfaces when all the VSphereVMs of the VSphereMachinePool
+	// are ready and up-to-date.
+	VSphereMachinePoolVirtualMachinesReadyReason = clusterv1.ReadyReason
+
+	// VSphereMachinePoolVirtualMachinesScalingUpReason surfaces when the VSphereMachinePool is creating VSphereVMs.
+	VSphereMachinePoolVirtualMachinesScalingUpReason = "ScalingUp"
+
+	// VSphereMachinePoolVirtualMachinesScalingDownReason surfaces when the VSphereMachinePool is deleting VSphereVMs.
+	VSphereMachinePoolVirtualMachinesScalingDownReason = "ScalingDown"
+
+	// VSphereMachinePoolVirtualMachinesRollingUpdateReason surfaces when the VSphereMachinePool is replacing
+	// VSphereVMs which are not up-to-date.
+	VSphereMachinePoolVirtualMachinesRollingUpdateReason = "RollingUpdate"
+
+	// VSphereMachinePoolVirtualMachinesDrainingNodesReason surfaces when the VSphereMachinePool is draining
+	// the nodes of VSphereVMs selected for deletion.
+	VSphereMachinePoolVirtualMachinesDrainingNodesReason = "DrainingNodes"
+
+	// VSphereMachinePoolVirtualMachinesNotReadyReason surfaces when some of the VSphereVMs of the
+	// VSphereMachinePool are not ready.
+	VSphereMachinePoolVirtualMachinesNotReadyReason = clusterv1.NotReadyReason
+
+	// VSphereMachinePoolVirtualMachinesDeletingReason surfaces when the VSphereVMs of the VSphereMachinePool
+	// are being deleted.
+	VSphereMachinePoolVirtualMachinesDeletingReason = clusterv1.DeletingReason
+)
+
+// VSphereMachinePoolSpec defines the desired state of VSphereMachinePool.
+type VSphereMachinePoolSpec struct {
+	// providerIDList is the list of the provider IDs of the virtual machines of the pool.
+	// NOTE: this field is part of the Cluster API contract, and it is used to match the nodes of the MachinePool.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=10000
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=512
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+
+	// template is the spec of the virtual machines of the pool.
+	// A change of the template, or of the bootstrap data of the MachinePool, replaces all the virtual
+	// machines of the pool according to the strategy.
+	// +required
+	Template VSphereMachinePoolMachineSpec `json:"template,omitzero"`
+
+	// strategy defines how the virtual machines of the pool are replaced.
+	// +optional
+	Strategy VSphereMachinePoolStrategy `json:"strategy,omitempty,omitzero"`
+}
+
+// VSphereMachinePoolMachineSpec defines the spec of the virtual machines of a VSphereMachinePool.
+type VSphereMachinePoolMachineSpec struct {
+	VirtualMachineCloneSpec `json:",inline"`
+
+	// powerOffMode describes the desired behavior when powering off a VM.
+	// See VSphereMachineSpec.PowerOffMode for the supported modes.
+	// If omitted, the mode defaults to hard.
+	// +optional
+	PowerOffMode VirtualMachinePowerOpMode `json:"powerOffMode,omitempty"`
+
+	// guestSoftPowerOffTimeoutSeconds sets the wait timeout for shutdown in the VM guest.
+	// This parameter only applies when the PowerOffMode is set to trySoft.
+	// If omitted, the timeout defaults to 5 minutes.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	GuestSoftPowerOffTimeoutSeconds int32 `json:"guestSoftPowerOffTimeoutSeconds,omitempty"`
+}
+
+// VSphereMachinePoolStrategy defines how the virtual machines of a VSphereMachinePool are replaced.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachinePoolStrategy struct {
+	// rollingUpdate configures the replacement of the virtual machines when the template changes.
+	// +optional
+	RollingUpdate VSphereMachinePoolRollingUpdate `json:"rollingUpdate,omitempty,omitzero"`
+}
+
+// VSphereMachinePoolRollingUpdate configures the rolling update of the virtual machines of a VSphereMachinePool.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachinePoolRollingUpdate struct {
+	// maxSurge is the maximum number of virtual machines that can be created above the
+	// desired number of replicas during a rolling update.
+	// Value can be an absolute number (ex: 5) or a percentage of the desired replicas (ex: 10%),
+	// which is rounded up. Defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// maxUnavailable is the maximum number of virtual machines that can be unavailable
+	// during a rolling update.
+	// Value can be an absolute number (ex: 5) or a percentage of the desired replicas (ex: 10%),
+	// which is rounded down. Defaults to 0.
+	// maxSurge and maxUnavailable cannot both be 0.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// VSphereMachinePoolStatus defines the observed state of VSphereMachinePool.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachinePoolStatus struct {
+	// conditions represents the observations of a VSphereMachinePool's current state.
+	// Known condition types are Ready, VirtualMachinesReady and Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// initialization provides observations of the VSphereMachinePool initialization process.
+	// NOTE: Fields in this struct are part of the Cluster API contract and are used to orchestrate initial MachinePool provisioning.
+	// +optional
+	Initialization VSphereMachinePoolInitializationStatus `json:"initialization,omitempty,omitzero"`
+
+	// replicas is the most recently observed number of virtual machines of the pool.
+	// NOTE: this field is part of the Cluster API contract.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// readyReplicas is the number of virtual machines of the pool which are ready.
+	// +optional
+	ReadyReplicas *int32 `json:"readyReplicas,omitempty"`
+
+	// upToDateReplicas is the number of virtual machines of the pool which are created
+	// from the current template and bootstrap data.
+	// +optional
+	UpToDateReplicas *int32 `json:"upToDateReplicas,omitempty"`
+}
+
+// VSphereMachinePoolInitializationStatus provides observations of the VSphereMachinePool initialization process.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachinePoolInitializationStatus struct {
+	// provisioned is true when the infrastructure provider reports that the MachinePool's infrastructure is fully provisioned.
+	// NOTE: this field is part of the Cluster API contract, and it is used to orchestrate initial MachinePool provisioning.
+	// +optional
+	Provisioned *bool `json:"provisioned,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=vspheremachinepools,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".metadata.labels['cluster\\.x-k8s\\.io/cluster-name']",description="Cluster"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Number of virtual machines of the pool"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas",description="Number of ready virtual machines of the pool"
+// +kubebuilder:printcolumn:name="Up-to-date",type="integer",JSONPath=".status.upToDateReplicas",description="Number of up-to-date virtual machines of the pool"
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused",priority=10
+// +kubebuilder:printcolumn:name="Provisioned",type="string",JSONPath=".status.initialization.provisioned",description="VSphereMachinePool is provisioned"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VSphereMachinePool"
+
+// VSphereMachinePool is the Schema for the vspheremachinepools API.
+type VSphereMachinePool struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired state of VSphereMachinePool.
+	// +required
+	Spec VSphereMachinePoolSpec `json:"spec,omitempty,omitzero"`
+
+	// status is the observed state of VSphereMachinePool.
+	// +optional
+	Status VSphereMachinePoolStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (c *VSphereMachinePool) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (c *VSphereMachinePool) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VSphereMachinePoolList contains a list of VSphereMachinePool.
+type VSphereMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereMachinePool `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VSphereMachinePool{}, &VSphereMachinePoolList{})
+}
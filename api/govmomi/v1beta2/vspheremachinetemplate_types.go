@@ -17,19 +17,153 @@ limitations under the License.
 package v1beta2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	// MachineTemplateWarmPoolFinalizer allows the reconciler to remove the virtual machines
+	// of the warm pool of a VSphereMachineTemplate before removing it from the API Server.
+	MachineTemplateWarmPoolFinalizer = "vspheremachinetemplate.infrastructure.cluster.x-k8s.io/warm-pool"
+)
+
+const (
+	// VSphereResourceCPU defines Resource type CPU for VSphereMachines.
+	VSphereResourceCPU corev1.ResourceName = "cpu"
+
+	// VSphereResourceMemory defines Resource type memory for VSphereMachines.
+	VSphereResourceMemory corev1.ResourceName = "memory"
+
+	// VSphereResourceEphemeralStorage defines Resource type ephemeral storage for VSphereMachines.
+	VSphereResourceEphemeralStorage corev1.ResourceName = "ephemeral-storage"
+
+	// VSphereResourceNvidiaGPU defines Resource type NVIDIA GPU for VSphereMachines.
+	VSphereResourceNvidiaGPU corev1.ResourceName = "nvidia.com/gpu"
+)
+
+// Architecture represents the CPU architecture of the node.
+// Its underlying type is a string and its value can be any of amd64, arm64, s390x, ppc64le.
+// +kubebuilder:validation:Enum=amd64;arm64;s390x;ppc64le
+// +enum
+type Architecture string
+
+const (
+	// ArchitectureAmd64 is the AMD64 architecture.
+	ArchitectureAmd64 Architecture = "amd64"
+	// ArchitectureArm64 is the ARM64 architecture.
+	ArchitectureArm64 Architecture = "arm64"
+	// ArchitectureS390x is the S390X architecture.
+	ArchitectureS390x Architecture = "s390x"
+	// ArchitecturePpc64le is the PPC64LE architecture.
+	ArchitecturePpc64le Architecture = "ppc64le"
+)
+
+// OperatingSystem represents the operating system of the node.
+// Its underlying type is a string and its value can be any of linux, windows.
+// +kubebuilder:validation:Enum=linux;windows
+// +enum
+type OperatingSystem string
+
+const (
+	// OperatingSystemLinux is the Linux operating system.
+	OperatingSystemLinux OperatingSystem = "linux"
+	// OperatingSystemWindows is the Windows operating system.
+	OperatingSystemWindows OperatingSystem = "windows"
+)
+
 // VSphereMachineTemplateSpec defines the desired state of VSphereMachineTemplate.
 type VSphereMachineTemplateSpec struct {
 	// template defines the desired state of VSphereMachineTemplate.
 	// +required
 	Template VSphereMachineTemplateResource `json:"template,omitzero"`
+
+	// warmPool configures a pool of powered-off virtual machines which are cloned from
+	// the template in advance. New machines adopt a virtual machine of the pool instead
+	// of cloning one, which shortens the provisioning time during scale-ups.
+	// +optional
+	WarmPool VSphereMachineWarmPool `json:"warmPool,omitempty,omitzero"`
+}
+
+// VSphereMachineWarmPool configures the warm pool of a VSphereMachineTemplate.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachineWarmPool struct {
+	// size is the number of powered-off virtual machines kept in the pool.
+	// The pool is disabled if size is 0 or not set.
+	// The pool is kept for the placement of the template only, machines of failure
+	// domains overriding the placement don't adopt its virtual machines.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Size *int32 `json:"size,omitempty"`
+
+	// folder is the name or inventory path of the folder in which the virtual machines
+	// of the pool are kept. Defaults to the folder of the template.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Folder string `json:"folder,omitempty"`
+}
+
+// VSphereMachineTemplateStatus defines the observed state of VSphereMachineTemplate.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachineTemplateStatus struct {
+	// capacity defines the resource capacity for this VSphereMachineTemplate.
+	// This value is used for autoscaling from zero operations as defined in:
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty,omitzero"`
+
+	// nodeInfo defines the node's architecture and operating system.
+	// This value is used for autoscaling from zero operations as defined in:
+	// https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20210310-opt-in-autoscaling-from-zero.md#implementation-detailsnotesconstraints
+	// +optional
+	NodeInfo NodeInfo `json:"nodeInfo,omitempty,omitzero"`
+
+	// warmPool is the observed state of the warm pool.
+	// +optional
+	WarmPool VSphereMachineWarmPoolStatus `json:"warmPool,omitempty,omitzero"`
+}
+
+// VSphereMachineWarmPoolStatus is the observed state of the warm pool of a VSphereMachineTemplate.
+// +kubebuilder:validation:MinProperties=1
+type VSphereMachineWarmPoolStatus struct {
+	// replicas is the number of virtual machines in the pool which can be adopted by new machines.
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// pendingReplicas is the number of virtual machines of the pool which are being cloned.
+	// +optional
+	PendingReplicas *int32 `json:"pendingReplicas,omitempty"`
+
+	// pendingTaskRefs are the managed object references of the tasks cloning the virtual machines
+	// of the pool. They are tracked until the tasks complete, because the virtual machines can't be
+	// found before the clone is completed.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=100
+	// +kubebuilder:validation:items:MinLength=1
+	// +kubebuilder:validation:items:MaxLength=2048
+	PendingTaskRefs []string `json:"pendingTaskRefs,omitempty"`
+}
+
+// NodeInfo contains information about the node's architecture and operating system.
+// +kubebuilder:validation:MinProperties=1
+type NodeInfo struct {
+	// architecture is the CPU architecture of the node.
+	// Its underlying type is a string and its value can be any of amd64, arm64, s390x, ppc64le.
+	// +optional
+	Architecture Architecture `json:"architecture,omitempty"`
+
+	// operatingSystem is a string representing the operating system of the node.
+	// This may be a string like 'linux' or 'windows'.
+	// +optional
+	OperatingSystem OperatingSystem `json:"operatingSystem,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=vspheremachinetemplates,scope=Namespaced,categories=cluster-api
 // +kubebuilder:storageversion
+// +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="ClusterClass",type="string",JSONPath=`.metadata.ownerReferences[?(@.kind=="ClusterClass")].name`,description="Name of the ClusterClass owning this template"
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=`.metadata.ownerReferences[?(@.kind=="Cluster")].name`,description="Name of the Cluster owning this template"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VSphereMachineTemplate"
@@ -45,6 +179,10 @@ type VSphereMachineTemplate struct {
 	// spec is the desired state of VSphereMachineTemplate.
 	// +optional
 	Spec VSphereMachineTemplateSpec `json:"spec,omitempty,omitzero"`
+
+	// status is the observed state of VSphereMachineTemplate.
+	// +optional
+	Status VSphereMachineTemplateStatus `json:"status,omitempty,omitzero"`
 }
 
 // +kubebuilder:object:root=true
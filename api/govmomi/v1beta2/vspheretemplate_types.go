@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// TemplateFinalizer allows ReconcileVSphereTemplate to remove the template from vCenter
+	// before removing the VSphereTemplate from the API Server.
+	TemplateFinalizer = "vspheretemplate.infrastructure.cluster.x-k8s.io"
+)
+
+// VSphereTemplate's Ready condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereTemplateReadyCondition is true if the VSphereTemplate's deletionTimestamp is not set and
+	// VSphereTemplate's TemplateImported condition is true.
+	VSphereTemplateReadyCondition = clusterv1.ReadyCondition
+
+	// VSphereTemplateReadyReason surfaces when the VSphereTemplate readiness criteria is met.
+	VSphereTemplateReadyReason = clusterv1.ReadyReason
+
+	// VSphereTemplateNotReadyReason surfaces when the VSphereTemplate readiness criteria is not met.
+	VSphereTemplateNotReadyReason = clusterv1.NotReadyReason
+
+	// VSphereTemplateReadyUnknownReason surfaces when at least one VSphereTemplate readiness criteria is unknown
+	// and no VSphereTemplate readiness criteria is not met.
+	VSphereTemplateReadyUnknownReason = clusterv1.ReadyUnknownReason
+)
+
+// VSphereTemplate's TemplateImported condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereTemplateTemplateImportedCondition documents the status of the import of the template into vCenter.
+	VSphereTemplateTemplateImportedCondition = "TemplateImported"
+
+	// VSphereTemplateTemplateImportedReason surfaces when the template is imported into vCenter.
+	VSphereTemplateTemplateImportedReason = "Imported"
+
+	// VSphereTemplateTemplateImportingReason surfaces when the template is being imported into vCenter.
+	VSphereTemplateTemplateImportingReason = "Importing"
+
+	// VSphereTemplateWaitingForVSphereClusterReason surfaces when the VSphereCluster used to connect
+	// to vCenter does not exist.
+	VSphereTemplateWaitingForVSphereClusterReason = "WaitingForVSphereCluster"
+
+	// VSphereTemplateChecksumMismatchReason surfaces when the checksum of the source does not match
+	// the checksum of the spec.
+	VSphereTemplateChecksumMismatchReason = "ChecksumMismatch"
+
+	// VSphereTemplateTemplateImportFailedReason surfaces when the import of the template failed.
+	VSphereTemplateTemplateImportFailedReason = "ImportFailed"
+
+	// VSphereTemplateTemplateNotFoundReason surfaces when the template does not exist anymore
+	// in vCenter, e.g. because it was removed in vCenter.
+	VSphereTemplateTemplateNotFoundReason = "NotFound"
+
+	// VSphereTemplateTemplateDeletingReason surfaces when the template is being removed from vCenter.
+	VSphereTemplateTemplateDeletingReason = clusterv1.DeletingReason
+)
+
+// VSphereTemplateChecksumAlgorithm is the algorithm of the checksum of the source of a VSphereTemplate.
+// +kubebuilder:validation:Enum=SHA256;SHA512
+type VSphereTemplateChecksumAlgorithm string
+
+const (
+	// VSphereTemplateChecksumAlgorithmSHA256 is the SHA-256 algorithm.
+	VSphereTemplateChecksumAlgorithmSHA256 VSphereTemplateChecksumAlgorithm = "SHA256"
+
+	// VSphereTemplateChecksumAlgorithmSHA512 is the SHA-512 algorithm.
+	VSphereTemplateChecksumAlgorithmSHA512 VSphereTemplateChecksumAlgorithm = "SHA512"
+)
+
+// VSphereTemplateDeletionPolicy determines what happens to the template in vCenter when its
+// VSphereTemplate is deleted.
+// +kubebuilder:validation:Enum=Delete;Retain
+type VSphereTemplateDeletionPolicy string
+
+const (
+	// VSphereTemplateDeletionPolicyDelete removes the template from vCenter.
+	VSphereTemplateDeletionPolicyDelete VSphereTemplateDeletionPolicy = "Delete"
+
+	// VSphereTemplateDeletionPolicyRetain keeps the template in vCenter.
+	VSphereTemplateDeletionPolicyRetain VSphereTemplateDeletionPolicy = "Retain"
+)
+
+// VSphereTemplateSpec defines the desired state of VSphereTemplate.
+type VSphereTemplateSpec struct {
+	// clusterName is the name of the VSphereCluster in the namespace of the VSphereTemplate whose
+	// server, identity and CA bundle are used to connect to vCenter.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="clusterName is immutable"
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// source is the OVA or OVF which is imported as the template.
+	// +required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="source is immutable"
+	Source VSphereTemplateSource `json:"source,omitempty,omitzero"`
+
+	// templateName is the name of the template in vCenter.
+	// Defaults to the name of the VSphereTemplate.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=80
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="templateName is immutable"
+	TemplateName string `json:"templateName,omitempty"`
+
+	// datacenter is the name or inventory path of the datacenter in which the template is imported.
+	// Defaults to the default datacenter of vCenter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="datacenter is immutable"
+	Datacenter string `json:"datacenter,omitempty"`
+
+	// datastore is the name or inventory path of the datastore on which the template is imported.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="datastore is immutable"
+	Datastore string `json:"datastore,omitempty"`
+
+	// folder is the name or inventory path of the folder in which the template is imported.
+	// Defaults to the default folder of the datacenter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="folder is immutable"
+	Folder string `json:"folder,omitempty"`
+
+	// resourcePool is the name or inventory path of the resource pool in which the template is imported.
+	// Defaults to the default resource pool of the datacenter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="resourcePool is immutable"
+	ResourcePool string `json:"resourcePool,omitempty"`
+
+	// network is the name or inventory path of the network to which the networks of the OVF are mapped.
+	// Defaults to the default network of vCenter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="network is immutable"
+	Network string `json:"network,omitempty"`
+
+	// deletionPolicy determines what happens to the template in vCenter when the VSphereTemplate
+	// is deleted. With Delete the template is removed from vCenter, with Retain it is kept, e.g.
+	// because virtual machines are still linked clones of it.
+	// Defaults to Retain.
+	// +optional
+	DeletionPolicy VSphereTemplateDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// VSphereTemplateSource is the OVA or OVF which is imported as a template.
+type VSphereTemplateSource struct {
+	// url is the HTTP or HTTPS URL of the OVA or OVF. The files referenced by an OVF are downloaded
+	// relative to its URL.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url,omitempty"`
+
+	// checksum is the checksum of the file at the url. The template is only imported if the
+	// checksum of the downloaded file matches.
+	// +required
+	Checksum VSphereTemplateChecksum `json:"checksum,omitempty,omitzero"`
+}
+
+// VSphereTemplateChecksum is the checksum of the source of a VSphereTemplate.
+type VSphereTemplateChecksum struct {
+	// algorithm is the algorithm of the checksum.
+	// +required
+	Algorithm VSphereTemplateChecksumAlgorithm `json:"algorithm,omitempty"`
+
+	// value is the hex encoded checksum.
+	// +required
+	// +kubebuilder:validation:MinLength=64
+	// +kubebuilder:validation:MaxLength=128
+	// +kubebuilder:validation:Pattern=`^[0-9a-fA-F]+$`
+	Value string `json:"value,omitempty"`
+}
+
+// VSphereTemplateStatus defines the observed state of VSphereTemplate.
+// +kubebuilder:validation:MinProperties=1
+type VSphereTemplateStatus struct {
+	// conditions represents the observations of a VSphereTemplate's current state.
+	// Known condition types are Ready, TemplateImported and Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// instanceUUID is the instance UUID of the template in vCenter, once it is imported.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	InstanceUUID string `json:"instanceUUID,omitempty"`
+
+	// inventoryPath is the inventory path of the template in vCenter, once it is imported.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	InventoryPath string `json:"inventoryPath,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=vspheretemplates,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Template is ready"
+// +kubebuilder:printcolumn:name="Instance UUID",type="string",JSONPath=".status.instanceUUID",description="Instance UUID of the template"
+// +kubebuilder:printcolumn:name="URL",type="string",JSONPath=".spec.source.url",description="URL of the OVA or OVF",priority=10
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused",priority=10
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VSphereTemplate"
+
+// VSphereTemplate is the Schema for the vspheretemplates API.
+type VSphereTemplate struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired state of VSphereTemplate.
+	// +required
+	Spec VSphereTemplateSpec `json:"spec,omitempty,omitzero"`
+
+	// status is the observed state of VSphereTemplate.
+	// +optional
+	Status VSphereTemplateStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (c *VSphereTemplate) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (c *VSphereTemplate) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VSphereTemplateList contains a list of VSphereTemplate.
+type VSphereTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereTemplate `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VSphereTemplate{}, &VSphereTemplateList{})
+}
@@ -174,6 +174,109 @@ const (
 	VSphereVMPCIDevicesDetachedNotFoundReason = "NotFound"
 )
 
+// VSphereVM's VirtualMachineResized condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMVirtualMachineResizedCondition documents the status of applying changes to numCPUs, numCoresPerSocket,
+	// memoryMiB and resources to an existing VirtualMachine.
+	// The condition is only set after such a change has been detected.
+	VSphereVMVirtualMachineResizedCondition string = "VirtualMachineResized"
+
+	// VSphereVMVirtualMachineResizedReason surfaces when the VirtualMachine that is controlled
+	// by the VSphereVM matches the desired CPU, memory and resource allocation settings.
+	VSphereVMVirtualMachineResizedReason = "Resized"
+
+	// VSphereVMVirtualMachineResizingReason surfaces when the VirtualMachine that is controlled
+	// by the VSphereVM is being reconfigured or power cycled to apply the desired CPU, memory
+	// and resource allocation settings.
+	VSphereVMVirtualMachineResizingReason = "Resizing"
+
+	// VSphereVMVirtualMachineResizePowerCycleRequiredReason surfaces when the desired CPU and memory settings
+	// can't be applied while the VirtualMachine is powered on and resizePolicy does not allow to power cycle it.
+	VSphereVMVirtualMachineResizePowerCycleRequiredReason = "PowerCycleRequired"
+
+	// VSphereVMVirtualMachineResizeFailedReason surfaces when the reconfigure operation
+	// for the VirtualMachine that is controlled by the VSphereVM failed.
+	VSphereVMVirtualMachineResizeFailedReason = "ResizeFailed"
+)
+
+// VSphereVM's VirtualMachineInSync condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMVirtualMachineInSyncCondition documents whether the configuration of the VirtualMachine
+	// that is controlled by the VSphereVM matches the VSphereVM's spec.
+	// Details about the drifted fields are reported in status.drift.
+	VSphereVMVirtualMachineInSyncCondition string = "VirtualMachineInSync"
+
+	// VSphereVMVirtualMachineInSyncReason surfaces when the configuration of the VirtualMachine
+	// that is controlled by the VSphereVM matches the VSphereVM's spec.
+	VSphereVMVirtualMachineInSyncReason = "InSync"
+
+	// VSphereVMVirtualMachineDriftedReason surfaces when the configuration of the VirtualMachine
+	// that is controlled by the VSphereVM has been changed out of band and the drift is not remediated.
+	VSphereVMVirtualMachineDriftedReason = "Drifted"
+
+	// VSphereVMVirtualMachineRemediatingDriftReason surfaces when the configuration of the VirtualMachine
+	// that is controlled by the VSphereVM is being changed back to match the VSphereVM's spec.
+	VSphereVMVirtualMachineRemediatingDriftReason = "RemediatingDrift"
+
+	// VSphereVMVirtualMachineDriftRemediationFailedReason surfaces when the operation changing the
+	// VirtualMachine that is controlled by the VSphereVM back to match the VSphereVM's spec failed.
+	VSphereVMVirtualMachineDriftRemediationFailedReason = "DriftRemediationFailed"
+)
+
+// VSphereVM's VirtualMachineDisksResized condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMVirtualMachineDisksResizedCondition documents the status of extending the disks of an existing
+	// VirtualMachine after diskGiB, additionalDisksGiB or the sizeGiB of a data disk has been increased.
+	// The condition is only set after such a change has been detected.
+	VSphereVMVirtualMachineDisksResizedCondition string = "VirtualMachineDisksResized"
+
+	// VSphereVMVirtualMachineDisksResizedReason surfaces when the disks of the VirtualMachine that is controlled
+	// by the VSphereVM match the desired sizes.
+	VSphereVMVirtualMachineDisksResizedReason = "Resized"
+
+	// VSphereVMVirtualMachineDisksResizingReason surfaces when the disks of the VirtualMachine that is controlled
+	// by the VSphereVM are being extended.
+	VSphereVMVirtualMachineDisksResizingReason = "Resizing"
+
+	// VSphereVMVirtualMachineDisksResizeFailedReason surfaces when extending the disks of the VirtualMachine
+	// that is controlled by the VSphereVM failed.
+	VSphereVMVirtualMachineDisksResizeFailedReason = "ResizeFailed"
+
+	// VSphereVMVirtualMachineDisksShrinkNotSupportedReason surfaces when the desired size of a disk is smaller
+	// than the disk of the VirtualMachine that is controlled by the VSphereVM; disks are never shrunk.
+	VSphereVMVirtualMachineDisksShrinkNotSupportedReason = "ShrinkNotSupported"
+
+	// VSphereVMVirtualMachineDisksDeltaDiskNotResizableReason surfaces when the desired size of a disk is larger
+	// than the disk of the VirtualMachine that is controlled by the VSphereVM, but the disk is backed by a delta disk,
+	// e.g. the disk of a linked clone or of a VirtualMachine with snapshots; delta disks can't be extended.
+	VSphereVMVirtualMachineDisksDeltaDiskNotResizableReason = "DeltaDiskNotResizable"
+)
+
+// VSphereVM's BootstrapDataScrubbed condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMBootstrapDataScrubbedCondition documents whether the bootstrap data has been
+	// removed from the guestinfo of the VirtualMachine that is controlled by the VSphereVM.
+	// The condition is only set if the bootstrap data is delivered with GuestInfo.
+	VSphereVMBootstrapDataScrubbedCondition string = "BootstrapDataScrubbed"
+
+	// VSphereVMBootstrapDataScrubbedReason surfaces when the bootstrap data has been removed
+	// from the guestinfo of the VirtualMachine that is controlled by the VSphereVM.
+	VSphereVMBootstrapDataScrubbedReason = "Scrubbed"
+
+	// VSphereVMBootstrapDataWaitingForNodeReason surfaces when the bootstrap data is kept in
+	// the guestinfo of the VirtualMachine that is controlled by the VSphereVM until its Machine has a node,
+	// or until the delay has passed.
+	VSphereVMBootstrapDataWaitingForNodeReason = "WaitingForNode"
+
+	// VSphereVMBootstrapDataRetainedReason surfaces when the bootstrap data is kept in the
+	// guestinfo of the VirtualMachine that is controlled by the VSphereVM because of the Retain policy.
+	VSphereVMBootstrapDataRetainedReason = "Retained"
+
+	// VSphereVMBootstrapDataScrubFailedReason surfaces when removing the bootstrap data from
+	// the guestinfo of the VirtualMachine that is controlled by the VSphereVM failed.
+	VSphereVMBootstrapDataScrubFailedReason = "ScrubFailed"
+)
+
 // VSphereVMSpec defines the desired state of VSphereVM.
 type VSphereVMSpec struct {
 	VirtualMachineCloneSpec `json:",inline"`
@@ -268,11 +371,49 @@ type VSphereVMStatus struct {
 
 	// cloneMode is the type of clone operation used to clone this VM. Since
 	// linkedClone is the default but fails gracefully if the source of the
-	// clone has no snapshots, this field may be used to determine the actual
+	// clone has no snapshots, and instantClone falls back to linkedClone if the
+	// parent VM is not available, this field may be used to determine the actual
 	// type of clone operation used to create this VM.
 	// +optional
 	CloneMode CloneMode `json:"cloneMode,omitempty"`
 
+	// templateUUID is the instance UUID of the template from which the VM was cloned.
+	// It is recorded when the template is resolved using the templateSelector or the templateRef.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	TemplateUUID string `json:"templateUUID,omitempty"`
+
+	// templateLibraryItemID is the ID of the content library item from which the VM was deployed.
+	// It is recorded when the item is resolved using the contentLibrary of the templateSelector.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	TemplateLibraryItemID string `json:"templateLibraryItemID,omitempty"`
+
+	// datastore is the name of the datastore recommended by Storage DRS in the datastore
+	// cluster on which the VM was created.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Datastore string `json:"datastore,omitempty"`
+
+	// bootstrapISO is the datastore path of the NoCloud seed ISO attached to the VM if the
+	// bootstrap data is delivered with NoCloudISO. It is cleared once the ISO is deleted.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	BootstrapISO string `json:"bootstrapISO,omitempty"`
+
+	// contentLibraryItemID is the ID of the content library item from which the VM is being
+	// deployed. It is set before the deployment starts and cleared once the deployed VM has
+	// been configured with the spec of the VSphereVM, so a VM which was deployed but not
+	// configured, e.g. because the controller restarted, is configured on the next reconcile.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	ContentLibraryItemID string `json:"contentLibraryItemID,omitempty"`
+
 	// snapshot is the name of the snapshot from which the VM was cloned if
 	// linkedClone is enabled.
 	// +optional
@@ -315,11 +456,42 @@ type VSphereVMStatus struct {
 	// +kubebuilder:validation:MaxLength=2048
 	VMRef string `json:"vmRef,omitempty"`
 
+	// drift lists the fields of the VM whose configuration on vSphere doesn't match
+	// the spec, e.g. because they have been changed in the vSphere UI.
+	// +optional
+	// +listType=map
+	// +listMapKey=path
+	// +kubebuilder:validation:MaxItems=32
+	Drift []VirtualMachineDrift `json:"drift,omitempty"`
+
 	// deprecated groups all the status fields that are deprecated and will be removed when all the nested field are removed.
 	// +optional
 	Deprecated *VSphereVMDeprecatedStatus `json:"deprecated,omitempty"`
 }
 
+// VirtualMachineDrift describes a field of a VM whose configuration on vSphere
+// doesn't match the spec.
+type VirtualMachineDrift struct {
+	// path is the path of the drifted field in the spec, e.g. numCPUs, memoryMiB,
+	// folder, resourcePool or network.devices[0].networkName.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	Path string `json:"path,omitempty"`
+
+	// desired is the value of the field in the spec.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Desired string `json:"desired,omitempty"`
+
+	// actual is the value of the field on vSphere.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	Actual string `json:"actual,omitempty"`
+}
+
 // VSphereVMDeprecatedStatus groups all the status fields that are deprecated and will be removed in a future version.
 // See https://github.com/kubernetes-sigs/cluster-api/blob/main/docs/proposals/20240916-improve-status-in-CAPI-resources.md for more context.
 type VSphereVMDeprecatedStatus struct {
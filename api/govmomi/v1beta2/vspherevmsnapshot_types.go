@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+)
+
+const (
+	// VMSnapshotFinalizer allows ReconcileVSphereVMSnapshot to remove the snapshot from the
+	// virtual machine before removing the VSphereVMSnapshot from the API Server.
+	VMSnapshotFinalizer = "vspherevmsnapshot.infrastructure.cluster.x-k8s.io"
+)
+
+// VSphereVMSnapshot's Ready condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMSnapshotReadyCondition is true if the VSphereVMSnapshot's deletionTimestamp is not set and
+	// VSphereVMSnapshot's SnapshotCreated condition is true.
+	VSphereVMSnapshotReadyCondition = clusterv1.ReadyCondition
+
+	// VSphereVMSnapshotReadyReason surfaces when the VSphereVMSnapshot readiness criteria is met.
+	VSphereVMSnapshotReadyReason = clusterv1.ReadyReason
+
+	// VSphereVMSnapshotNotReadyReason surfaces when the VSphereVMSnapshot readiness criteria is not met.
+	VSphereVMSnapshotNotReadyReason = clusterv1.NotReadyReason
+
+	// VSphereVMSnapshotReadyUnknownReason surfaces when at least one VSphereVMSnapshot readiness criteria is unknown
+	// and no VSphereVMSnapshot readiness criteria is not met.
+	VSphereVMSnapshotReadyUnknownReason = clusterv1.ReadyUnknownReason
+)
+
+// VSphereVMSnapshot's SnapshotCreated condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMSnapshotSnapshotCreatedCondition documents the status of the snapshot of the virtual machine.
+	VSphereVMSnapshotSnapshotCreatedCondition = "SnapshotCreated"
+
+	// VSphereVMSnapshotSnapshotCreatedReason surfaces when the snapshot of the virtual machine is created.
+	VSphereVMSnapshotSnapshotCreatedReason = "Created"
+
+	// VSphereVMSnapshotWaitingForVirtualMachineReason surfaces when the VSphereVM does not exist
+	// or its virtual machine is not provisioned yet.
+	VSphereVMSnapshotWaitingForVirtualMachineReason = "WaitingForVirtualMachine"
+
+	// VSphereVMSnapshotPCIPassthroughDevicesReason surfaces when the snapshot is not created because
+	// the virtual machine has PCI passthrough devices, which vSphere does not support for snapshots.
+	VSphereVMSnapshotPCIPassthroughDevicesReason = "PCIPassthroughDevices"
+
+	// VSphereVMSnapshotSnapshotCreationFailedReason surfaces when the creation of the snapshot failed.
+	VSphereVMSnapshotSnapshotCreationFailedReason = "CreationFailed"
+
+	// VSphereVMSnapshotSnapshotNotFoundReason surfaces when the snapshot does not exist anymore
+	// on the virtual machine, e.g. because it was removed in vCenter.
+	VSphereVMSnapshotSnapshotNotFoundReason = "NotFound"
+
+	// VSphereVMSnapshotSnapshotDeletingReason surfaces when the snapshot is being removed from the virtual machine.
+	VSphereVMSnapshotSnapshotDeletingReason = clusterv1.DeletingReason
+)
+
+// VSphereVMSnapshot's Reverted condition and corresponding reasons that will be used in v1Beta2 API version.
+const (
+	// VSphereVMSnapshotRevertedCondition documents the status of the last revert of the virtual machine
+	// to the snapshot. The condition is only set once a revert is requested.
+	VSphereVMSnapshotRevertedCondition = "Reverted"
+
+	// VSphereVMSnapshotRevertedReason surfaces when the virtual machine was reverted to the snapshot.
+	VSphereVMSnapshotRevertedReason = "Reverted"
+
+	// VSphereVMSnapshotRevertFailedReason surfaces when the revert of the virtual machine to the snapshot failed.
+	VSphereVMSnapshotRevertFailedReason = "RevertFailed"
+)
+
+// VSphereVMSnapshotSpec defines the desired state of VSphereVMSnapshot.
+type VSphereVMSnapshotSpec struct {
+	// vmName is the name of the VSphereVM in the namespace of the VSphereVMSnapshot whose
+	// virtual machine is snapshotted.
+	// +required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="vmName is immutable"
+	VMName string `json:"vmName,omitempty"`
+
+	// description is the description of the snapshot in vCenter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=512
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="description is immutable"
+	Description string `json:"description,omitempty"`
+
+	// memory includes the memory of the virtual machine in the snapshot, so that reverting
+	// to the snapshot restores the running state of the virtual machine.
+	// If omitted, the memory is not included and the virtual machine is powered off after a revert,
+	// until the VSphereVM controller powers it on again.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="memory is immutable"
+	Memory *bool `json:"memory,omitempty"`
+
+	// quiesce quiesces the file system of the virtual machine with VMware Tools before taking
+	// the snapshot. It is ignored if memory is true.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="quiesce is immutable"
+	Quiesce *bool `json:"quiesce,omitempty"`
+
+	// retention limits the snapshots kept for the virtual machine.
+	// +optional
+	Retention VSphereVMSnapshotRetention `json:"retention,omitempty,omitzero"`
+
+	// revertRequestID requests a revert of the virtual machine to the snapshot. The virtual machine
+	// is reverted once the snapshot is created and every time the value changes afterwards.
+	// The value set when the VSphereVMSnapshot is created does not trigger a revert.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	RevertRequestID string `json:"revertRequestID,omitempty"`
+}
+
+// VSphereVMSnapshotRetention limits the snapshots kept for a virtual machine.
+// +kubebuilder:validation:MinProperties=1
+type VSphereVMSnapshotRetention struct {
+	// maxSnapshots is the maximum number of VSphereVMSnapshots kept for the VSphereVM, including
+	// this one. When the snapshot is created, the oldest VSphereVMSnapshots of the VSphereVM
+	// are deleted until the limit is met.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=32
+	MaxSnapshots int32 `json:"maxSnapshots,omitempty"`
+
+	// maxAgeSeconds is the maximum age of the snapshot. The VSphereVMSnapshot is deleted once
+	// its snapshot is older.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAgeSeconds int32 `json:"maxAgeSeconds,omitempty"`
+}
+
+// VSphereVMSnapshotStatus defines the observed state of VSphereVMSnapshot.
+// +kubebuilder:validation:MinProperties=1
+type VSphereVMSnapshotStatus struct {
+	// conditions represents the observations of a VSphereVMSnapshot's current state.
+	// Known condition types are Ready, SnapshotCreated, Reverted and Paused.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	// +kubebuilder:validation:MaxItems=32
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// snapshotRef is the managed object reference of the snapshot in vCenter.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=2048
+	SnapshotRef string `json:"snapshotRef,omitempty"`
+
+	// creationTime is the time at which the snapshot was created.
+	// +optional
+	CreationTime metav1.Time `json:"creationTime,omitempty,omitzero"`
+
+	// lastRevertRequestID is the revertRequestID of the last revert of the virtual machine to the snapshot.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=256
+	LastRevertRequestID string `json:"lastRevertRequestID,omitempty"`
+
+	// lastRevertTime is the time of the last revert of the virtual machine to the snapshot.
+	// +optional
+	LastRevertTime metav1.Time `json:"lastRevertTime,omitempty,omitzero"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=vspherevmsnapshots,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="VSphereVM",type="string",JSONPath=".spec.vmName",description="VSphereVM of the snapshot"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Snapshot is ready"
+// +kubebuilder:printcolumn:name="Snapshot",type="string",JSONPath=".status.snapshotRef",description="Managed object reference of the snapshot",priority=10
+// +kubebuilder:printcolumn:name="Paused",type="string",JSONPath=`.status.conditions[?(@.type=="Paused")].status`,description="Reconciliation paused",priority=10
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of VSphereVMSnapshot"
+
+// VSphereVMSnapshot is the Schema for the vspherevmsnapshots API.
+type VSphereVMSnapshot struct {
+	metav1.TypeMeta `json:",inline"`
+	// metadata is the standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// spec is the desired state of VSphereVMSnapshot.
+	// +required
+	Spec VSphereVMSnapshotSpec `json:"spec,omitempty,omitzero"`
+
+	// status is the observed state of VSphereVMSnapshot.
+	// +optional
+	Status VSphereVMSnapshotStatus `json:"status,omitempty,omitzero"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (c *VSphereVMSnapshot) GetConditions() []metav1.Condition {
+	return c.Status.Conditions
+}
+
+// SetConditions sets conditions for an API object.
+func (c *VSphereVMSnapshot) SetConditions(conditions []metav1.Condition) {
+	c.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VSphereVMSnapshotList contains a list of VSphereVMSnapshot.
+type VSphereVMSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VSphereVMSnapshot `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &VSphereVMSnapshot{}, &VSphereVMSnapshotList{})
+}
@@ -21,8 +21,10 @@ limitations under the License.
 package v1beta2
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	corev1beta2 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	"sigs.k8s.io/cluster-api/api/deprecated/errors"
 )
@@ -58,6 +60,36 @@ func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapDataScrubSpec) DeepCopyInto(out *BootstrapDataScrubSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapDataScrubSpec.
+func (in *BootstrapDataScrubSpec) DeepCopy() *BootstrapDataScrubSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapDataScrubSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CABundleReference) DeepCopyInto(out *CABundleReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CABundleReference.
+func (in *CABundleReference) DeepCopy() *CABundleReference {
+	if in == nil {
+		return nil
+	}
+	out := new(CABundleReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterModule) DeepCopyInto(out *ClusterModule) {
 	*out = *in
@@ -78,6 +110,39 @@ func (in *ClusterModule) DeepCopy() *ClusterModule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentLibraryItemReference) DeepCopyInto(out *ContentLibraryItemReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentLibraryItemReference.
+func (in *ContentLibraryItemReference) DeepCopy() *ContentLibraryItemReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentLibraryItemReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialsProvider) DeepCopyInto(out *CredentialsProvider) {
+	*out = *in
+	out.File = in.File
+	out.Vault = in.Vault
+	in.Exec.DeepCopyInto(&out.Exec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialsProvider.
+func (in *CredentialsProvider) DeepCopy() *CredentialsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DHCPOverrides) DeepCopyInto(out *DHCPOverrides) {
 	*out = *in
@@ -138,6 +203,46 @@ func (in *DHCPOverrides) DeepCopy() *DHCPOverrides {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecCredentialsProvider) DeepCopyInto(out *ExecCredentialsProvider) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]ExecEnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecCredentialsProvider.
+func (in *ExecCredentialsProvider) DeepCopy() *ExecCredentialsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecCredentialsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecEnvVar) DeepCopyInto(out *ExecEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecEnvVar.
+func (in *ExecEnvVar) DeepCopy() *ExecEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailureDomain) DeepCopyInto(out *FailureDomain) {
 	*out = *in
@@ -156,6 +261,11 @@ func (in *FailureDomain) DeepCopy() *FailureDomain {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailureDomainHosts) DeepCopyInto(out *FailureDomainHosts) {
 	*out = *in
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(ManagedFailureDomainHosts)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainHosts.
@@ -168,6 +278,56 @@ func (in *FailureDomainHosts) DeepCopy() *FailureDomainHosts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FileCredentialsProvider) DeepCopyInto(out *FileCredentialsProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FileCredentialsProvider.
+func (in *FileCredentialsProvider) DeepCopy() *FileCredentialsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(FileCredentialsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostSelector) DeepCopyInto(out *HostSelector) {
+	*out = *in
+	if in.MatchTags != nil {
+		in, out := &in.MatchTags, &out.MatchTags
+		*out = make([]HostTag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostSelector.
+func (in *HostSelector) DeepCopy() *HostSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(HostSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostTag) DeepCopyInto(out *HostTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostTag.
+func (in *HostTag) DeepCopy() *HostTag {
+	if in == nil {
+		return nil
+	}
+	out := new(HostTag)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPPoolReference) DeepCopyInto(out *IPPoolReference) {
 	*out = *in
@@ -183,6 +343,27 @@ func (in *IPPoolReference) DeepCopy() *IPPoolReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedFailureDomainHosts) DeepCopyInto(out *ManagedFailureDomainHosts) {
+	*out = *in
+	in.HostSelector.DeepCopyInto(&out.HostSelector)
+	if in.Mandatory != nil {
+		in, out := &in.Mandatory, &out.Mandatory
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedFailureDomainHosts.
+func (in *ManagedFailureDomainHosts) DeepCopy() *ManagedFailureDomainHosts {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedFailureDomainHosts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 	*out = *in
@@ -379,6 +560,21 @@ func (in *NetworkStatus) DeepCopy() *NetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeInfo) DeepCopyInto(out *NodeInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeInfo.
+func (in *NodeInfo) DeepCopy() *NodeInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PCIDeviceSpec) DeepCopyInto(out *PCIDeviceSpec) {
 	*out = *in
@@ -439,10 +635,30 @@ func (in *SSHUser) DeepCopy() *SSHUser {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SysprepSpec) DeepCopyInto(out *SysprepSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SysprepSpec.
+func (in *SysprepSpec) DeepCopy() *SysprepSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SysprepSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Topology) DeepCopyInto(out *Topology) {
 	*out = *in
-	out.Hosts = in.Hosts
+	in.Hosts.DeepCopyInto(&out.Hosts)
 	if in.Networks != nil {
 		in, out := &in.Networks, &out.Networks
 		*out = make([]string, len(*in))
@@ -596,6 +812,8 @@ func (in *VSphereClusterIdentityList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterIdentitySpec) DeepCopyInto(out *VSphereClusterIdentitySpec) {
 	*out = *in
+	in.CredentialsProvider.DeepCopyInto(&out.CredentialsProvider)
+	out.CABundleRef = in.CABundleRef
 	if in.AllowedNamespaces != nil {
 		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
 		*out = new(AllowedNamespaces)
@@ -628,6 +846,7 @@ func (in *VSphereClusterIdentityStatus) DeepCopyInto(out *VSphereClusterIdentity
 		*out = new(bool)
 		**out = **in
 	}
+	in.LastCredentialsRotationTime.DeepCopyInto(&out.LastCredentialsRotationTime)
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(VSphereClusterIdentityDeprecatedStatus)
@@ -719,9 +938,31 @@ func (in *VSphereClusterList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereClusterPlacement) DeepCopyInto(out *VSphereClusterPlacement) {
+	*out = *in
+	in.FailureDomains.DeepCopyInto(&out.FailureDomains)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereClusterPlacement.
+func (in *VSphereClusterPlacement) DeepCopy() *VSphereClusterPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereClusterPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereClusterSpec) DeepCopyInto(out *VSphereClusterSpec) {
 	*out = *in
+	out.CABundleRef = in.CABundleRef
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
 	out.IdentityRef = in.IdentityRef
 	if in.ClusterModules != nil {
@@ -736,6 +977,7 @@ func (in *VSphereClusterSpec) DeepCopyInto(out *VSphereClusterSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	in.Placement.DeepCopyInto(&out.Placement)
 	if in.FailureDomainSelector != nil {
 		in, out := &in.FailureDomainSelector, &out.FailureDomainSelector
 		*out = new(v1.LabelSelector)
@@ -1002,15 +1244,41 @@ func (in *VSphereDeploymentZoneList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereDeploymentZoneManagedHosts) DeepCopyInto(out *VSphereDeploymentZoneManagedHosts) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDeploymentZoneManagedHosts.
+func (in *VSphereDeploymentZoneManagedHosts) DeepCopy() *VSphereDeploymentZoneManagedHosts {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereDeploymentZoneManagedHosts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDeploymentZoneSpec) DeepCopyInto(out *VSphereDeploymentZoneSpec) {
 	*out = *in
+	out.CABundleRef = in.CABundleRef
+	if in.Insecure != nil {
+		in, out := &in.Insecure, &out.Insecure
+		*out = new(bool)
+		**out = **in
+	}
 	if in.ControlPlane != nil {
 		in, out := &in.ControlPlane, &out.ControlPlane
 		*out = new(bool)
 		**out = **in
 	}
 	out.PlacementConstraint = in.PlacementConstraint
+	if in.TemplateReplicas != nil {
+		in, out := &in.TemplateReplicas, &out.TemplateReplicas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDeploymentZoneSpec.
@@ -1038,6 +1306,12 @@ func (in *VSphereDeploymentZoneStatus) DeepCopyInto(out *VSphereDeploymentZoneSt
 		*out = new(bool)
 		**out = **in
 	}
+	if in.TemplateReplicas != nil {
+		in, out := &in.TemplateReplicas, &out.TemplateReplicas
+		*out = make([]VSphereDeploymentZoneTemplateReplica, len(*in))
+		copy(*out, *in)
+	}
+	out.ManagedHosts = in.ManagedHosts
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(VSphereDeploymentZoneDeprecatedStatus)
@@ -1055,6 +1329,21 @@ func (in *VSphereDeploymentZoneStatus) DeepCopy() *VSphereDeploymentZoneStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereDeploymentZoneTemplateReplica) DeepCopyInto(out *VSphereDeploymentZoneTemplateReplica) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereDeploymentZoneTemplateReplica.
+func (in *VSphereDeploymentZoneTemplateReplica) DeepCopy() *VSphereDeploymentZoneTemplateReplica {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereDeploymentZoneTemplateReplica)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereDeploymentZoneV1Beta1DeprecatedStatus) DeepCopyInto(out *VSphereDeploymentZoneV1Beta1DeprecatedStatus) {
 	*out = *in
@@ -1098,6 +1387,7 @@ func (in *VSphereFailureDomain) DeepCopyInto(out *VSphereFailureDomain) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereFailureDomain.
@@ -1118,6 +1408,42 @@ func (in *VSphereFailureDomain) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereFailureDomainCapacity) DeepCopyInto(out *VSphereFailureDomainCapacity) {
+	*out = *in
+	if in.AvailableHosts != nil {
+		in, out := &in.AvailableHosts, &out.AvailableHosts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CPUFreeMHz != nil {
+		in, out := &in.CPUFreeMHz, &out.CPUFreeMHz
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemoryFreeMiB != nil {
+		in, out := &in.MemoryFreeMiB, &out.MemoryFreeMiB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.DatastoreFreeGiB != nil {
+		in, out := &in.DatastoreFreeGiB, &out.DatastoreFreeGiB
+		*out = new(int64)
+		**out = **in
+	}
+	in.LastUpdateTime.DeepCopyInto(&out.LastUpdateTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereFailureDomainCapacity.
+func (in *VSphereFailureDomainCapacity) DeepCopy() *VSphereFailureDomainCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereFailureDomainCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereFailureDomainList) DeepCopyInto(out *VSphereFailureDomainList) {
 	*out = *in
@@ -1150,6 +1476,26 @@ func (in *VSphereFailureDomainList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereFailureDomainPlacement) DeepCopyInto(out *VSphereFailureDomainPlacement) {
+	*out = *in
+	if in.MinDatastoreFreeGiB != nil {
+		in, out := &in.MinDatastoreFreeGiB, &out.MinDatastoreFreeGiB
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereFailureDomainPlacement.
+func (in *VSphereFailureDomainPlacement) DeepCopy() *VSphereFailureDomainPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereFailureDomainPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereFailureDomainSpec) DeepCopyInto(out *VSphereFailureDomainSpec) {
 	*out = *in
@@ -1168,6 +1514,29 @@ func (in *VSphereFailureDomainSpec) DeepCopy() *VSphereFailureDomainSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereFailureDomainStatus) DeepCopyInto(out *VSphereFailureDomainStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Capacity.DeepCopyInto(&out.Capacity)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereFailureDomainStatus.
+func (in *VSphereFailureDomainStatus) DeepCopy() *VSphereFailureDomainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereFailureDomainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereIdentityReference) DeepCopyInto(out *VSphereIdentityReference) {
 	*out = *in
@@ -1283,14 +1652,210 @@ func (in *VSphereMachineList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VSphereMachineSpec) DeepCopyInto(out *VSphereMachineSpec) {
+func (in *VSphereMachinePool) DeepCopyInto(out *VSphereMachinePool) {
 	*out = *in
-	in.VirtualMachineCloneSpec.DeepCopyInto(&out.VirtualMachineCloneSpec)
-	out.Naming = in.Naming
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineSpec.
-func (in *VSphereMachineSpec) DeepCopy() *VSphereMachineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePool.
+func (in *VSphereMachinePool) DeepCopy() *VSphereMachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereMachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolInitializationStatus) DeepCopyInto(out *VSphereMachinePoolInitializationStatus) {
+	*out = *in
+	if in.Provisioned != nil {
+		in, out := &in.Provisioned, &out.Provisioned
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolInitializationStatus.
+func (in *VSphereMachinePoolInitializationStatus) DeepCopy() *VSphereMachinePoolInitializationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolInitializationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolList) DeepCopyInto(out *VSphereMachinePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereMachinePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolList.
+func (in *VSphereMachinePoolList) DeepCopy() *VSphereMachinePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereMachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolMachineSpec) DeepCopyInto(out *VSphereMachinePoolMachineSpec) {
+	*out = *in
+	in.VirtualMachineCloneSpec.DeepCopyInto(&out.VirtualMachineCloneSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolMachineSpec.
+func (in *VSphereMachinePoolMachineSpec) DeepCopy() *VSphereMachinePoolMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolRollingUpdate) DeepCopyInto(out *VSphereMachinePoolRollingUpdate) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolRollingUpdate.
+func (in *VSphereMachinePoolRollingUpdate) DeepCopy() *VSphereMachinePoolRollingUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolRollingUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolSpec) DeepCopyInto(out *VSphereMachinePoolSpec) {
+	*out = *in
+	if in.ProviderIDList != nil {
+		in, out := &in.ProviderIDList, &out.ProviderIDList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	in.Strategy.DeepCopyInto(&out.Strategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolSpec.
+func (in *VSphereMachinePoolSpec) DeepCopy() *VSphereMachinePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolStatus) DeepCopyInto(out *VSphereMachinePoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Initialization.DeepCopyInto(&out.Initialization)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ReadyReplicas != nil {
+		in, out := &in.ReadyReplicas, &out.ReadyReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpToDateReplicas != nil {
+		in, out := &in.UpToDateReplicas, &out.UpToDateReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolStatus.
+func (in *VSphereMachinePoolStatus) DeepCopy() *VSphereMachinePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachinePoolStrategy) DeepCopyInto(out *VSphereMachinePoolStrategy) {
+	*out = *in
+	in.RollingUpdate.DeepCopyInto(&out.RollingUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachinePoolStrategy.
+func (in *VSphereMachinePoolStrategy) DeepCopy() *VSphereMachinePoolStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachinePoolStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachineSpec) DeepCopyInto(out *VSphereMachineSpec) {
+	*out = *in
+	in.VirtualMachineCloneSpec.DeepCopyInto(&out.VirtualMachineCloneSpec)
+	out.Naming = in.Naming
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineSpec.
+func (in *VSphereMachineSpec) DeepCopy() *VSphereMachineSpec {
 	if in == nil {
 		return nil
 	}
@@ -1345,6 +1910,7 @@ func (in *VSphereMachineTemplate) DeepCopyInto(out *VSphereMachineTemplate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineTemplate.
@@ -1418,6 +1984,7 @@ func (in *VSphereMachineTemplateResource) DeepCopy() *VSphereMachineTemplateReso
 func (in *VSphereMachineTemplateSpec) DeepCopyInto(out *VSphereMachineTemplateSpec) {
 	*out = *in
 	in.Template.DeepCopyInto(&out.Template)
+	in.WarmPool.DeepCopyInto(&out.WarmPool)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineTemplateSpec.
@@ -1430,6 +1997,30 @@ func (in *VSphereMachineTemplateSpec) DeepCopy() *VSphereMachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachineTemplateStatus) DeepCopyInto(out *VSphereMachineTemplateStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	out.NodeInfo = in.NodeInfo
+	in.WarmPool.DeepCopyInto(&out.WarmPool)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineTemplateStatus.
+func (in *VSphereMachineTemplateStatus) DeepCopy() *VSphereMachineTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachineTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereMachineV1Beta1DeprecatedStatus) DeepCopyInto(out *VSphereMachineV1Beta1DeprecatedStatus) {
 	*out = *in
@@ -1462,6 +2053,199 @@ func (in *VSphereMachineV1Beta1DeprecatedStatus) DeepCopy() *VSphereMachineV1Bet
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachineWarmPool) DeepCopyInto(out *VSphereMachineWarmPool) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineWarmPool.
+func (in *VSphereMachineWarmPool) DeepCopy() *VSphereMachineWarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachineWarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereMachineWarmPoolStatus) DeepCopyInto(out *VSphereMachineWarmPoolStatus) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PendingReplicas != nil {
+		in, out := &in.PendingReplicas, &out.PendingReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PendingTaskRefs != nil {
+		in, out := &in.PendingTaskRefs, &out.PendingTaskRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereMachineWarmPoolStatus.
+func (in *VSphereMachineWarmPoolStatus) DeepCopy() *VSphereMachineWarmPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereMachineWarmPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplate) DeepCopyInto(out *VSphereTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplate.
+func (in *VSphereTemplate) DeepCopy() *VSphereTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplateChecksum) DeepCopyInto(out *VSphereTemplateChecksum) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplateChecksum.
+func (in *VSphereTemplateChecksum) DeepCopy() *VSphereTemplateChecksum {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplateChecksum)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplateList) DeepCopyInto(out *VSphereTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplateList.
+func (in *VSphereTemplateList) DeepCopy() *VSphereTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplateReference) DeepCopyInto(out *VSphereTemplateReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplateReference.
+func (in *VSphereTemplateReference) DeepCopy() *VSphereTemplateReference {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplateReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplateSource) DeepCopyInto(out *VSphereTemplateSource) {
+	*out = *in
+	out.Checksum = in.Checksum
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplateSource.
+func (in *VSphereTemplateSource) DeepCopy() *VSphereTemplateSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplateSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplateSpec) DeepCopyInto(out *VSphereTemplateSpec) {
+	*out = *in
+	out.Source = in.Source
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplateSpec.
+func (in *VSphereTemplateSpec) DeepCopy() *VSphereTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereTemplateStatus) DeepCopyInto(out *VSphereTemplateStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereTemplateStatus.
+func (in *VSphereTemplateStatus) DeepCopy() *VSphereTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereVM) DeepCopyInto(out *VSphereVM) {
 	*out = *in
@@ -1571,6 +2355,130 @@ func (in *VSphereVMNamingSpec) DeepCopy() *VSphereVMNamingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereVMSnapshot) DeepCopyInto(out *VSphereVMSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereVMSnapshot.
+func (in *VSphereVMSnapshot) DeepCopy() *VSphereVMSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereVMSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereVMSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereVMSnapshotList) DeepCopyInto(out *VSphereVMSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VSphereVMSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereVMSnapshotList.
+func (in *VSphereVMSnapshotList) DeepCopy() *VSphereVMSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereVMSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VSphereVMSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereVMSnapshotRetention) DeepCopyInto(out *VSphereVMSnapshotRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereVMSnapshotRetention.
+func (in *VSphereVMSnapshotRetention) DeepCopy() *VSphereVMSnapshotRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereVMSnapshotRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereVMSnapshotSpec) DeepCopyInto(out *VSphereVMSnapshotSpec) {
+	*out = *in
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Quiesce != nil {
+		in, out := &in.Quiesce, &out.Quiesce
+		*out = new(bool)
+		**out = **in
+	}
+	out.Retention = in.Retention
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereVMSnapshotSpec.
+func (in *VSphereVMSnapshotSpec) DeepCopy() *VSphereVMSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereVMSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VSphereVMSnapshotStatus) DeepCopyInto(out *VSphereVMSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.CreationTime.DeepCopyInto(&out.CreationTime)
+	in.LastRevertTime.DeepCopyInto(&out.LastRevertTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VSphereVMSnapshotStatus.
+func (in *VSphereVMSnapshotStatus) DeepCopy() *VSphereVMSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VSphereVMSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VSphereVMSpec) DeepCopyInto(out *VSphereVMSpec) {
 	*out = *in
@@ -1621,6 +2529,11 @@ func (in *VSphereVMStatus) DeepCopyInto(out *VSphereVMStatus) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]VirtualMachineDrift, len(*in))
+		copy(*out, *in)
+	}
 	if in.Deprecated != nil {
 		in, out := &in.Deprecated, &out.Deprecated
 		*out = new(VSphereVMDeprecatedStatus)
@@ -1670,9 +2583,55 @@ func (in *VSphereVMV1Beta1DeprecatedStatus) DeepCopy() *VSphereVMV1Beta1Deprecat
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultCredentialsProvider) DeepCopyInto(out *VaultCredentialsProvider) {
+	*out = *in
+	out.KubernetesAuth = in.KubernetesAuth
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultCredentialsProvider.
+func (in *VaultCredentialsProvider) DeepCopy() *VaultCredentialsProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultCredentialsProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VaultKubernetesAuth) DeepCopyInto(out *VaultKubernetesAuth) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VaultKubernetesAuth.
+func (in *VaultKubernetesAuth) DeepCopy() *VaultKubernetesAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(VaultKubernetesAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
 	*out = *in
+	if in.TemplateSelector != nil {
+		in, out := &in.TemplateSelector, &out.TemplateSelector
+		*out = new(VirtualMachineTemplateSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContentLibraryItem != nil {
+		in, out := &in.ContentLibraryItem, &out.ContentLibraryItem
+		*out = new(ContentLibraryItemReference)
+		**out = **in
+	}
+	if in.TemplateRef != nil {
+		in, out := &in.TemplateRef, &out.TemplateRef
+		*out = new(VSphereTemplateReference)
+		**out = **in
+	}
 	in.Network.DeepCopyInto(&out.Network)
 	if in.NumCoresPerSocket != nil {
 		in, out := &in.NumCoresPerSocket, &out.NumCoresPerSocket
@@ -1704,11 +2663,22 @@ func (in *VirtualMachineCloneSpec) DeepCopyInto(out *VirtualMachineCloneSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Sysprep != nil {
+		in, out := &in.Sysprep, &out.Sysprep
+		*out = new(SysprepSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	out.BootstrapDataScrub = in.BootstrapDataScrub
 	if in.DataDisks != nil {
 		in, out := &in.DataDisks, &out.DataDisks
 		*out = make([]VSphereDisk, len(*in))
 		copy(*out, *in)
 	}
+	if in.DriftRemediation != nil {
+		in, out := &in.DriftRemediation, &out.DriftRemediation
+		*out = make([]VirtualMachineDriftRemediationField, len(*in))
+		copy(*out, *in)
+	}
 	if in.NestedHV != nil {
 		in, out := &in.NestedHV, &out.NestedHV
 		*out = new(bool)
@@ -1726,6 +2696,21 @@ func (in *VirtualMachineCloneSpec) DeepCopy() *VirtualMachineCloneSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineDrift) DeepCopyInto(out *VirtualMachineDrift) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineDrift.
+func (in *VirtualMachineDrift) DeepCopy() *VirtualMachineDrift {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineDrift)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineResourceShares) DeepCopyInto(out *VirtualMachineResourceShares) {
 	*out = *in
@@ -1775,3 +2760,38 @@ func (in *VirtualMachineResources) DeepCopy() *VirtualMachineResources {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTemplateSelector) DeepCopyInto(out *VirtualMachineTemplateSelector) {
+	*out = *in
+	if in.MatchTags != nil {
+		in, out := &in.MatchTags, &out.MatchTags
+		*out = make([]VirtualMachineTemplateTag, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTemplateSelector.
+func (in *VirtualMachineTemplateSelector) DeepCopy() *VirtualMachineTemplateSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTemplateSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineTemplateTag) DeepCopyInto(out *VirtualMachineTemplateTag) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineTemplateTag.
+func (in *VirtualMachineTemplateTag) DeepCopy() *VirtualMachineTemplateTag {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineTemplateTag)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -45,7 +45,7 @@ import (
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 )
 
-// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments;machinepools,verbs=get;list;watch
 // +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vsphereclusters,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheremachinetemplates,verbs=get;list;watch
@@ -92,19 +92,20 @@ func (r Reconciler) Reconcile(ctx context.Context, clusterCtx *capvcontext.Clust
 	for _, mod := range clusterCtx.VSphereCluster.Spec.ClusterModules {
 		// Note: We have to use := here to not overwrite log & ctx outside the for loop.
 		log := log
-		// It is safe to infer KubeadmControlPlane or MachineDeployment from .ControlPlane as modules
-		// are only implemented for these types.
-		if ptr.Deref(mod.ControlPlane, false) {
+		// It is safe to infer KubeadmControlPlane from .ControlPlane as modules are only implemented
+		// for KubeadmControlPlanes, MachineDeployments and MachinePools.
+		curr := mod.TargetObjectName
+		switch {
+		case ptr.Deref(mod.ControlPlane, false):
 			log = log.WithValues("KubeadmControlPlane", klog.KRef(clusterCtx.VSphereCluster.Namespace, mod.TargetObjectName), "moduleUUID", mod.ModuleUUID)
-		} else {
+			curr = appendKCPKey(curr)
+		case objectMap[curr] == nil && objectMap[appendMPKey(curr)] != nil:
+			log = log.WithValues("MachinePool", klog.KRef(clusterCtx.VSphereCluster.Namespace, mod.TargetObjectName), "moduleUUID", mod.ModuleUUID)
+			curr = appendMPKey(curr)
+		default:
 			log = log.WithValues("MachineDeployment", klog.KRef(clusterCtx.VSphereCluster.Namespace, mod.TargetObjectName), "moduleUUID", mod.ModuleUUID)
 		}
 		ctx := ctrl.LoggerInto(ctx, log)
-
-		curr := mod.TargetObjectName
-		if ptr.Deref(mod.ControlPlane, false) {
-			curr = appendKCPKey(curr)
-		}
 		if obj, ok := objectMap[curr]; !ok {
 			// Delete the cluster module as the object is marked for deletion or already deleted.
 			if err := r.ClusterModuleService.Remove(ctx, clusterCtx, mod.ModuleUUID); err != nil {
@@ -257,7 +258,7 @@ func (r Reconciler) PopulateWatchesOnController(mgr manager.Manager, controller
 		return err
 	}
 
-	return controller.Watch(
+	if err := controller.Watch(
 		source.Kind(
 			mgr.GetCache(),
 			&clusterv1.MachineDeployment{},
@@ -271,6 +272,24 @@ func (r Reconciler) PopulateWatchesOnController(mgr manager.Manager, controller
 				},
 			},
 		),
+	); err != nil {
+		return err
+	}
+
+	return controller.Watch(
+		source.Kind(
+			mgr.GetCache(),
+			&clusterv1.MachinePool{},
+			handler.TypedEnqueueRequestsFromMapFunc(toAffinityInput[*clusterv1.MachinePool](r.Client)),
+			predicate.TypedFuncs[*clusterv1.MachinePool]{
+				GenericFunc: func(event.TypedGenericEvent[*clusterv1.MachinePool]) bool {
+					return false
+				},
+				UpdateFunc: func(event.TypedUpdateEvent[*clusterv1.MachinePool]) bool {
+					return false
+				},
+			},
+		),
 	)
 }
 
@@ -312,6 +331,19 @@ func (r Reconciler) fetchMachineOwnerObjects(ctx context.Context, clusterCtx *ca
 			objects[md.GetName()] = clustermodule.NewWrapper(md.DeepCopy())
 		}
 	}
+
+	mpList := &clusterv1.MachinePoolList{}
+	if err := r.Client.List(
+		ctx, mpList,
+		client.InNamespace(clusterCtx.VSphereCluster.GetNamespace()),
+		client.MatchingLabels(labels)); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list MachinePool objects")
+	}
+	for _, mp := range mpList.Items {
+		if mp.DeletionTimestamp.IsZero() {
+			objects[appendMPKey(mp.GetName())] = clustermodule.NewWrapper(mp.DeepCopy())
+		}
+	}
 	return objects, nil
 }
 
@@ -322,6 +354,13 @@ func appendKCPKey(name string) string {
 	return "kcp" + name
 }
 
+// appendMPKey adds the prefix "mp" to the name of the object
+// This is used to separate the Machine Pool objects from the Machine Deployment objects
+// having the same name.
+func appendMPKey(name string) string {
+	return "mp" + name
+}
+
 func incompatibleOwnerErrors(errList []clusterModError) []clusterModError {
 	toReport := []clusterModError{}
 	for _, e := range errList {
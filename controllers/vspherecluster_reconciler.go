@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"time"
 
 	pkgerrors "github.com/pkg/errors"
@@ -380,9 +381,16 @@ func (r *clusterReconciler) reconcileIdentitySecret(ctx context.Context, cluster
 }
 
 func (r *clusterReconciler) reconcileVCenterConnectivity(ctx context.Context, clusterCtx *capvcontext.ClusterContext) (*session.Session, error) {
+	caBundle, err := identity.GetCABundle(ctx, r.Client, clusterCtx.VSphereCluster, r.ControllerManagerContext.Namespace)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to get CA bundle")
+	}
+
 	params := session.NewParams().
 		WithServer(clusterCtx.VSphereCluster.Spec.Server).
-		WithThumbprint(clusterCtx.VSphereCluster.Spec.Thumbprint)
+		WithThumbprint(clusterCtx.VSphereCluster.Spec.Thumbprint).
+		WithCABundle(caBundle).
+		WithInsecure(ptr.Deref(clusterCtx.VSphereCluster.Spec.Insecure, false))
 
 	if clusterCtx.VSphereCluster.Spec.IdentityRef.IsDefined() {
 		creds, err := identity.GetCredentials(ctx, r.Client, clusterCtx.VSphereCluster, r.ControllerManagerContext.Namespace)
@@ -434,20 +442,20 @@ func (r *clusterReconciler) reconcileDeploymentZones(ctx context.Context, cluste
 			continue
 		}
 
+		failureDomain := clusterv1.FailureDomain{
+			Name:         zone.Name,
+			ControlPlane: zone.Spec.ControlPlane,
+			Attributes:   r.failureDomainAttributes(ctx, zone),
+		}
+
 		if zone.Status.Ready == nil {
 			readyNotReported++
-			failureDomains = append(failureDomains, clusterv1.FailureDomain{
-				Name:         zone.Name,
-				ControlPlane: zone.Spec.ControlPlane,
-			})
+			failureDomains = append(failureDomains, failureDomain)
 			continue
 		}
 
 		if *zone.Status.Ready {
-			failureDomains = append(failureDomains, clusterv1.FailureDomain{
-				Name:         zone.Name,
-				ControlPlane: zone.Spec.ControlPlane,
-			})
+			failureDomains = append(failureDomains, failureDomain)
 			continue
 		}
 		notReady++
@@ -497,7 +505,39 @@ func (r *clusterReconciler) reconcileDeploymentZones(ctx context.Context, cluste
 	return true, nil
 }
 
+// failureDomainAttributes returns the capacity of the VSphereFailureDomain of the zone as the
+// attributes of the corresponding failure domain.
+func (r *clusterReconciler) failureDomainAttributes(ctx context.Context, zone infrav1.VSphereDeploymentZone) map[string]string {
+	log := ctrl.LoggerFrom(ctx)
+
+	vsphereFailureDomain := &infrav1.VSphereFailureDomain{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: zone.Spec.FailureDomain}, vsphereFailureDomain); err != nil {
+		log.V(4).Error(err, "Failed to get VSphereFailureDomain", "VSphereFailureDomain", klog.KRef("", zone.Spec.FailureDomain))
+		return nil
+	}
+
+	capacity := vsphereFailureDomain.Status.Capacity
+	attributes := map[string]string{}
+	for attribute, value := range map[string]*int64{
+		infrav1.FailureDomainCPUFreeMHzAttribute:       capacity.CPUFreeMHz,
+		infrav1.FailureDomainMemoryFreeMiBAttribute:    capacity.MemoryFreeMiB,
+		infrav1.FailureDomainDatastoreFreeGiBAttribute: capacity.DatastoreFreeGiB,
+	} {
+		if value != nil {
+			attributes[attribute] = strconv.FormatInt(*value, 10)
+		}
+	}
+	if len(attributes) == 0 {
+		return nil
+	}
+	return attributes
+}
+
 func (r *clusterReconciler) reconcileClusterModules(ctx context.Context, clusterCtx *capvcontext.ClusterContext) (reconcile.Result, error) {
+	// DRS rules are maintained by the VSphereVMs, the VSphereCluster has no cluster modules then.
+	if clusterCtx.VSphereCluster.Spec.Placement.Strategy == infrav1.DRSRulePlacementStrategy {
+		return reconcile.Result{}, nil
+	}
 	if feature.Gates.Enabled(feature.NodeAntiAffinity) && !ptr.Deref(clusterCtx.VSphereCluster.Spec.DisableClusterModule, false) {
 		return r.clusterModuleReconciler.Reconcile(ctx, clusterCtx)
 	}
@@ -586,6 +626,38 @@ func (r *clusterReconciler) controlPlaneMachineToCluster(ctx context.Context, o
 	}}
 }
 
+// failureDomainToCluster enqueues the VSphereClusters which use the VSphereFailureDomain through a
+// VSphereDeploymentZone, so that the capacity of the failure domain is published to them.
+func (r *clusterReconciler) failureDomainToCluster(ctx context.Context, o client.Object) []ctrl.Request {
+	log := ctrl.LoggerFrom(ctx)
+
+	obj, ok := o.(*infrav1.VSphereFailureDomain)
+	if !ok {
+		log.Error(nil, fmt.Sprintf("Expected a VSphereFailureDomain but got a %T", o))
+		return nil
+	}
+
+	var deploymentZoneList infrav1.VSphereDeploymentZoneList
+	if err := r.Client.List(ctx, &deploymentZoneList); err != nil {
+		log.V(4).Error(err, "Failed to list VSphereDeploymentZones")
+		return nil
+	}
+
+	var requests []ctrl.Request
+	for i := range deploymentZoneList.Items {
+		zone := &deploymentZoneList.Items[i]
+		if zone.Spec.FailureDomain != obj.Name {
+			continue
+		}
+		for _, request := range r.deploymentZoneToCluster(ctx, zone) {
+			if !slices.Contains(requests, request) {
+				requests = append(requests, request)
+			}
+		}
+	}
+	return requests
+}
+
 func (r *clusterReconciler) deploymentZoneToCluster(ctx context.Context, o client.Object) []ctrl.Request {
 	log := ctrl.LoggerFrom(ctx)
 
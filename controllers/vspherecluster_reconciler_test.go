@@ -88,7 +88,8 @@ var _ = Describe("VIM based VSphere ClusterReconciler", func() {
 						Kind: infrav1.SecretKind,
 						Name: secret.Name,
 					},
-					Server: fmt.Sprintf("%s://%s", vcURL.Scheme, vcURL.Host),
+					Server:   fmt.Sprintf("%s://%s", vcURL.Scheme, vcURL.Host),
+					Insecure: ptr.To(true),
 				},
 			}
 			Expect(testEnv.Create(ctx, instance)).To(Succeed())
@@ -385,6 +386,7 @@ var _ = Describe("VIM based VSphere ClusterReconciler", func() {
 				ObjectMeta: metav1.ObjectMeta{Name: "zone-one"},
 				Spec: infrav1.VSphereDeploymentZoneSpec{
 					Server:        testEnv.Simulator.ServerURL().Host,
+					Insecure:      ptr.To(true),
 					FailureDomain: "fd-one",
 					ControlPlane:  ptr.To(true),
 				},
@@ -407,6 +409,7 @@ var _ = Describe("VIM based VSphere ClusterReconciler", func() {
 				Spec: infrav1.VSphereClusterSpec{
 					FailureDomainSelector: &metav1.LabelSelector{MatchLabels: map[string]string{}},
 					Server:                testEnv.Simulator.ServerURL().Host,
+					Insecure:              ptr.To(true),
 				},
 			}
 			Expect(testEnv.Create(ctx, instance)).To(Succeed())
@@ -693,6 +696,43 @@ func TestClusterReconciler_ReconcileDeploymentZones(t *testing.T) {
 			}, 3)
 		})
 	})
+
+	t.Run("with failure domain capacity", func(t *testing.T) {
+		g := NewWithT(t)
+
+		failureDomain := &infrav1.VSphereFailureDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "fd-1"},
+			Status: infrav1.VSphereFailureDomainStatus{
+				Capacity: infrav1.VSphereFailureDomainCapacity{
+					CPUFreeMHz:       ptr.To[int64](4000),
+					MemoryFreeMiB:    ptr.To[int64](8192),
+					DatastoreFreeGiB: ptr.To[int64](100),
+				},
+			},
+		}
+		controllerManagerContext := fake.NewControllerManagerContext(
+			deploymentZone(server, "fd-1", ptr.To(false), ptr.To(true)),
+			deploymentZone(server, "fd-2", ptr.To(false), ptr.To(true)),
+			failureDomain,
+		)
+		clusterCtx := fake.NewClusterContext(ctx, controllerManagerContext)
+		clusterCtx.VSphereCluster.Spec.Server = server
+		clusterCtx.VSphereCluster.Spec.FailureDomainSelector = &metav1.LabelSelector{MatchLabels: map[string]string{}}
+
+		r := clusterReconciler{
+			ControllerManagerContext: controllerManagerContext,
+			Client:                   controllerManagerContext.Client,
+		}
+		_, err := r.reconcileDeploymentZones(ctx, clusterCtx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(clusterCtx.VSphereCluster.Status.FailureDomains).To(HaveLen(2))
+		g.Expect(clusterCtx.VSphereCluster.Status.FailureDomains[0].Attributes).To(Equal(map[string]string{
+			infrav1.FailureDomainCPUFreeMHzAttribute:       "4000",
+			infrav1.FailureDomainMemoryFreeMiBAttribute:    "8192",
+			infrav1.FailureDomainDatastoreFreeGiBAttribute: "100",
+		}))
+		g.Expect(clusterCtx.VSphereCluster.Status.FailureDomains[1].Attributes).To(BeNil())
+	})
 }
 
 func deploymentZone(server, fdName string, cp, ready *bool) *infrav1.VSphereDeploymentZone {
@@ -19,17 +19,21 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	pkgerrors "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	clusterutilv1 "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	deprecatedv1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
 	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
@@ -47,8 +51,12 @@ import (
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 	pkgidentity "sigs.k8s.io/cluster-api-provider-vsphere/pkg/identity"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
 )
 
+// credentialsRotationCheckInterval is the interval at which the credentials of a VSphereClusterIdentity are checked for rotation.
+const credentialsRotationCheckInterval = 5 * time.Minute
+
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vsphereclusteridentities,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vsphereclusteridentities/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;patch;update;delete
@@ -59,6 +67,7 @@ func AddVsphereClusterIdentityControllerToManager(ctx context.Context, controlle
 		ControllerManagerCtx: controllerManagerCtx,
 		Client:               controllerManagerCtx.Client,
 		Recorder:             mgr.GetEventRecorderFor("vsphereclusteridentity-controller"),
+		usernames:            &sync.Map{},
 	}
 	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "vsphereclusteridentity")
 
@@ -73,6 +82,11 @@ type clusterIdentityReconciler struct {
 	ControllerManagerCtx *capvcontext.ControllerManagerContext
 	Client               client.Client
 	Recorder             record.EventRecorder
+
+	// usernames are the last observed usernames per VSphereClusterIdentity, used to evict the sessions of the
+	// previous username if it is changed when the credentials are rotated. The rotation itself is detected by
+	// the hash of the credentials stored in an annotation of the VSphereClusterIdentity.
+	usernames *sync.Map
 }
 
 func (r clusterIdentityReconciler) Reconcile(ctx context.Context, req reconcile.Request) (_ reconcile.Result, reterr error) {
@@ -111,6 +125,7 @@ func (r clusterIdentityReconciler) Reconcile(ctx context.Context, req reconcile.
 			patch.WithOwnedConditions{Conditions: []string{
 				clusterv1.PausedCondition,
 				infrav1.VSphereClusterIdentityAvailableCondition,
+				infrav1.VSphereClusterIdentityCredentialsRotatedCondition,
 			}}); err != nil {
 			reterr = kerrors.NewAggregate([]error{reterr, err})
 		}
@@ -120,6 +135,10 @@ func (r clusterIdentityReconciler) Reconcile(ctx context.Context, req reconcile.
 		return ctrl.Result{}, r.reconcileDelete(ctx, identity)
 	}
 
+	if identity.Spec.SecretName == "" {
+		return r.reconcileCredentials(ctx, identity)
+	}
+
 	// fetch secret
 	secret := &corev1.Secret{}
 	secretKey := client.ObjectKey{
@@ -175,6 +194,71 @@ func (r clusterIdentityReconciler) Reconcile(ctx context.Context, req reconcile.
 		return reconcile.Result{}, err
 	}
 
+	return r.reconcileCredentials(ctx, identity)
+}
+
+// reconcileCredentials retrieves the credentials of the VSphereClusterIdentity and handles their rotation.
+// New credentials are validated against the vCenters of the VSphereClusters using the identity before
+// the identity is marked as ready, and cached sessions using the previous credentials are logged out.
+func (r clusterIdentityReconciler) reconcileCredentials(ctx context.Context, identity *infrav1.VSphereClusterIdentity) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	provider, err := pkgidentity.NewProvider(r.Client, identity, r.ControllerManagerCtx.Namespace)
+	if err != nil {
+		return reconcile.Result{}, r.markCredentialsProviderNotAvailable(identity, err)
+	}
+	credentials, err := provider.GetCredentials(ctx)
+	if err != nil {
+		return reconcile.Result{}, r.markCredentialsProviderNotAvailable(identity, err)
+	}
+
+	previousHash, known := identity.Annotations[infrav1.VSphereClusterIdentityCredentialsHashAnnotation]
+	changed := known && previousHash != credentials.Hash()
+	// NOTE: credentials of identities which are already ready are not validated again after an upgrade,
+	// to not make all clusters using the identity fail if vCenter is temporarily not reachable.
+	if changed || (!known && !ptr.Deref(identity.Status.Ready, false)) {
+		if err := r.validateCredentials(ctx, identity, credentials); err != nil {
+			deprecatedv1beta1conditions.MarkFalse(identity, infrav1.CredentialsAvailableV1Beta1Condition, infrav1.CredentialsValidationFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%v", err)
+			conditions.Set(identity, metav1.Condition{
+				Type:    infrav1.VSphereClusterIdentityAvailableCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereClusterIdentityCredentialsValidationFailedReason,
+				Message: err.Error(),
+			})
+			identity.Status.Ready = ptr.To(false)
+			return reconcile.Result{}, err
+		}
+	}
+
+	if changed {
+		// Only the sessions created with other passwords are evicted, unless the username has changed as well.
+		previousUsername, password := credentials.Username, credentials.Password
+		if username, ok := r.usernames.Load(identity.Name); ok && username.(string) != credentials.Username {
+			previousUsername, password = username.(string), ""
+		}
+		evicted := session.Evict(ctx, previousUsername, password)
+		log.Info("Credentials have been rotated", "evictedSessions", evicted)
+
+		identity.Status.LastCredentialsRotationTime = metav1.Now()
+		rotationTime := identity.Status.LastCredentialsRotationTime.UTC().Format(time.RFC3339)
+		conditions.Set(identity, metav1.Condition{
+			Type:    infrav1.VSphereClusterIdentityCredentialsRotatedCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  infrav1.VSphereClusterIdentityCredentialsRotatedReason,
+			Message: fmt.Sprintf("Credentials rotated at %s", rotationTime),
+		})
+		r.Recorder.Eventf(identity, corev1.EventTypeNormal, infrav1.VSphereClusterIdentityCredentialsRotatedReason, "Credentials rotated at %s, logged out %d vCenter sessions using the previous credentials", rotationTime, evicted)
+	}
+	if !conditions.Has(identity, infrav1.VSphereClusterIdentityCredentialsRotatedCondition) {
+		conditions.Set(identity, metav1.Condition{
+			Type:   infrav1.VSphereClusterIdentityCredentialsRotatedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereClusterIdentityCredentialsNotRotatedReason,
+		})
+	}
+	r.usernames.Store(identity.Name, credentials.Username)
+	annotations.AddAnnotations(identity, map[string]string{infrav1.VSphereClusterIdentityCredentialsHashAnnotation: credentials.Hash()})
+
 	deprecatedv1beta1conditions.MarkTrue(identity, infrav1.CredentialsAvailableV1Beta1Condition)
 	conditions.Set(identity, metav1.Condition{
 		Type:   infrav1.VSphereClusterIdentityAvailableCondition,
@@ -183,7 +267,58 @@ func (r clusterIdentityReconciler) Reconcile(ctx context.Context, req reconcile.
 	})
 
 	identity.Status.Ready = ptr.To(true)
-	return reconcile.Result{}, nil
+
+	// Credentials are polled as changes of Secrets and credentials providers do not trigger a reconcile.
+	return reconcile.Result{RequeueAfter: credentialsRotationCheckInterval}, nil
+}
+
+func (r clusterIdentityReconciler) markCredentialsProviderNotAvailable(identity *infrav1.VSphereClusterIdentity, err error) error {
+	deprecatedv1beta1conditions.MarkFalse(identity, infrav1.CredentialsAvailableV1Beta1Condition, infrav1.CredentialsProviderNotAvailableV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%v", err)
+	conditions.Set(identity, metav1.Condition{
+		Type:    infrav1.VSphereClusterIdentityAvailableCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1.VSphereClusterIdentityCredentialsProviderNotAvailableReason,
+		Message: err.Error(),
+	})
+	identity.Status.Ready = ptr.To(false)
+	return pkgerrors.Wrap(err, "failed to get credentials")
+}
+
+// validateCredentials verifies that the credentials can be used to login to the vCenters
+// of all VSphereClusters using the VSphereClusterIdentity.
+func (r clusterIdentityReconciler) validateCredentials(ctx context.Context, identity *infrav1.VSphereClusterIdentity, credentials *pkgidentity.Credentials) error {
+	vsphereClusters := &infrav1.VSphereClusterList{}
+	if err := r.Client.List(ctx, vsphereClusters); err != nil {
+		return pkgerrors.Wrap(err, "failed to list VSphereClusters")
+	}
+
+	validated := sets.Set[string]{}
+	for _, vsphereCluster := range vsphereClusters.Items {
+		ref := vsphereCluster.Spec.IdentityRef
+		if ref.Kind != infrav1.VSphereClusterIdentityKind || ref.Name != identity.Name || vsphereCluster.Spec.Server == "" {
+			continue
+		}
+		if validated.Has(vsphereCluster.Spec.Server) {
+			continue
+		}
+
+		caBundle, err := pkgidentity.GetCABundle(ctx, r.Client, &vsphereCluster, r.ControllerManagerCtx.Namespace)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "failed to get CA bundle for vCenter %s", vsphereCluster.Spec.Server)
+		}
+
+		params := session.NewParams().
+			WithServer(vsphereCluster.Spec.Server).
+			WithThumbprint(vsphereCluster.Spec.Thumbprint).
+			WithCABundle(caBundle).
+			WithInsecure(ptr.Deref(vsphereCluster.Spec.Insecure, false)).
+			WithUserInfo(credentials.Username, credentials.Password)
+		if err := session.Validate(ctx, params); err != nil {
+			return pkgerrors.Wrapf(err, "failed to validate credentials against vCenter %s", vsphereCluster.Spec.Server)
+		}
+		validated.Insert(vsphereCluster.Spec.Server)
+	}
+	return nil
 }
 
 func (r clusterIdentityReconciler) reconcileDelete(ctx context.Context, identity *infrav1.VSphereClusterIdentity) error {
@@ -200,6 +335,14 @@ func (r clusterIdentityReconciler) reconcileDelete(ctx context.Context, identity
 		Reason: infrav1.VSphereClusterIdentityDeletingReason,
 	})
 
+	r.usernames.Delete(identity.Name)
+
+	if identity.Spec.SecretName == "" {
+		// There is no Secret to clean up if the credentials are retrieved from a credentials provider.
+		ctrlutil.RemoveFinalizer(identity, infrav1.VSphereClusterIdentityFinalizer)
+		return nil
+	}
+
 	err := r.Client.Get(ctx, secretKey, secret)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
@@ -17,17 +17,28 @@ limitations under the License.
 package controllers
 
 import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/simulator"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	clusterutilv1 "sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/identity"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/manager"
 )
 
@@ -200,5 +211,150 @@ var _ = Describe("VSphereClusterIdentity Reconciler", func() {
 				return false
 			}, timeout).Should(BeTrue())
 		})
+
+		It("should reconcile an identity using a credentials provider", func() {
+			dir, err := os.MkdirTemp("", "credentials-")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.RemoveAll(dir)
+			Expect(os.WriteFile(filepath.Join(dir, identity.UsernameKey), []byte("user"), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, identity.PasswordKey), []byte("pass"), 0600)).To(Succeed())
+
+			i := &infrav1.VSphereClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "identity-",
+				},
+				Spec: infrav1.VSphereClusterIdentitySpec{
+					CredentialsProvider: infrav1.CredentialsProvider{
+						File: infrav1.FileCredentialsProvider{Path: dir},
+					},
+				},
+			}
+			Expect(testEnv.Create(ctx, i)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := testEnv.Get(ctx, client.ObjectKey{Name: i.Name}, i); err != nil {
+					return false
+				}
+				return ptr.Deref(i.Status.Ready, false) && conditions.IsTrue(i, infrav1.VSphereClusterIdentityAvailableCondition)
+			}, timeout).Should(BeTrue())
+		})
+
+		It("should error if credentials cannot be retrieved from the credentials provider", func() {
+			i := &infrav1.VSphereClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: "identity-",
+				},
+				Spec: infrav1.VSphereClusterIdentitySpec{
+					CredentialsProvider: infrav1.CredentialsProvider{
+						File: infrav1.FileCredentialsProvider{Path: "/non-existent-directory"},
+					},
+				},
+			}
+			Expect(testEnv.Create(ctx, i)).To(Succeed())
+
+			Eventually(func() bool {
+				if err := testEnv.Get(ctx, client.ObjectKey{Name: i.Name}, i); err != nil {
+					return false
+				}
+				return !ptr.Deref(i.Status.Ready, false) && conditions.GetReason(i, infrav1.VSphereClusterIdentityAvailableCondition) == infrav1.VSphereClusterIdentityCredentialsProviderNotAvailableReason
+			}, timeout).Should(BeTrue())
+		})
 	})
 })
+
+func TestClusterIdentityReconciler_CredentialsRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	simr, err := vcsim.NewBuilder().WithModel(simulator.VPX()).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	defer simr.Destroy()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "credentials",
+			Namespace: fake.ControllerManagerNamespace,
+		},
+		Data: map[string][]byte{
+			identity.UsernameKey: []byte(simr.Username()),
+			identity.PasswordKey: []byte(simr.Password()),
+		},
+	}
+	vsphereClusterIdentity := &infrav1.VSphereClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "identity",
+			Finalizers: []string{infrav1.VSphereClusterIdentityFinalizer},
+		},
+		Spec: infrav1.VSphereClusterIdentitySpec{
+			SecretName: secret.Name,
+		},
+	}
+	vsphereCluster := &infrav1.VSphereCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vsphere-cluster",
+			Namespace: fake.Namespace,
+		},
+		Spec: infrav1.VSphereClusterSpec{
+			Server:   simr.ServerURL().Host,
+			Insecure: ptr.To(true),
+			IdentityRef: infrav1.VSphereIdentityReference{
+				Kind: infrav1.VSphereClusterIdentityKind,
+				Name: vsphereClusterIdentity.Name,
+			},
+		},
+	}
+
+	controllerManagerCtx := fake.NewControllerManagerContext(secret, vsphereClusterIdentity, vsphereCluster)
+	recorder := record.NewFakeRecorder(10)
+	r := clusterIdentityReconciler{
+		ControllerManagerCtx: controllerManagerCtx,
+		Client:               controllerManagerCtx.Client,
+		Recorder:             recorder,
+		usernames:            &sync.Map{},
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vsphereClusterIdentity)}
+
+	// Initial credentials are validated and no rotation is reported.
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, req.NamespacedName, vsphereClusterIdentity)).To(Succeed())
+	g.Expect(ptr.Deref(vsphereClusterIdentity.Status.Ready, false)).To(BeTrue())
+	g.Expect(conditions.GetReason(vsphereClusterIdentity, infrav1.VSphereClusterIdentityCredentialsRotatedCondition)).To(Equal(infrav1.VSphereClusterIdentityCredentialsNotRotatedReason))
+	g.Expect(vsphereClusterIdentity.Status.LastCredentialsRotationTime.IsZero()).To(BeTrue())
+	// Only the hash of the credentials is stored.
+	g.Expect(vsphereClusterIdentity.Annotations).To(HaveKeyWithValue(infrav1.VSphereClusterIdentityCredentialsHashAnnotation, identity.Credentials{Username: simr.Username(), Password: simr.Password()}.Hash()))
+	g.Expect(vsphereClusterIdentity.Annotations[infrav1.VSphereClusterIdentityCredentialsHashAnnotation]).ToNot(ContainSubstring(simr.Password()))
+
+	// Rotated credentials which cannot be used to login are not accepted.
+	g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+	secret.Data[identity.PasswordKey] = nil
+	g.Expect(r.Client.Update(ctx, secret)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, req.NamespacedName, vsphereClusterIdentity)).To(Succeed())
+	g.Expect(ptr.Deref(vsphereClusterIdentity.Status.Ready, false)).To(BeFalse())
+	g.Expect(conditions.GetReason(vsphereClusterIdentity, infrav1.VSphereClusterIdentityAvailableCondition)).To(Equal(infrav1.VSphereClusterIdentityCredentialsValidationFailedReason))
+
+	// Valid rotated credentials are accepted and the rotation is reported.
+	secret.Data[identity.PasswordKey] = []byte("rotated-password")
+	g.Expect(r.Client.Update(ctx, secret)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, req.NamespacedName, vsphereClusterIdentity)).To(Succeed())
+	g.Expect(ptr.Deref(vsphereClusterIdentity.Status.Ready, false)).To(BeTrue())
+	g.Expect(conditions.IsTrue(vsphereClusterIdentity, infrav1.VSphereClusterIdentityCredentialsRotatedCondition)).To(BeTrue())
+	g.Expect(vsphereClusterIdentity.Status.LastCredentialsRotationTime.IsZero()).To(BeFalse())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.VSphereClusterIdentityCredentialsRotatedReason)))
+
+	// Rotations are detected after a restart of the controller.
+	r.usernames = &sync.Map{}
+	secret.Data[identity.PasswordKey] = []byte(simr.Password())
+	g.Expect(r.Client.Update(ctx, secret)).To(Succeed())
+
+	_, err = r.Reconcile(ctx, req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, req.NamespacedName, vsphereClusterIdentity)).To(Succeed())
+	g.Expect(vsphereClusterIdentity.Annotations).To(HaveKeyWithValue(infrav1.VSphereClusterIdentityCredentialsHashAnnotation, identity.Credentials{Username: simr.Username(), Password: simr.Password()}.Hash()))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infrav1.VSphereClusterIdentityCredentialsRotatedReason)))
+}
@@ -19,6 +19,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	pkgerrors "github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -85,6 +87,10 @@ func AddVSphereDeploymentZoneControllerToManager(ctx context.Context, controller
 		Complete(ctx, reconciler)
 }
 
+// managedHostsSyncInterval is the interval at which the host group of a failure domain
+// with managed hosts is updated.
+const managedHostsSyncInterval = 5 * time.Minute
+
 type vsphereDeploymentZoneReconciler struct {
 	*capvcontext.ControllerManagerContext
 }
@@ -133,7 +139,7 @@ func (r vsphereDeploymentZoneReconciler) Reconcile(ctx context.Context, request
 		return ctrl.Result{}, r.reconcileDelete(ctx, vsphereDeploymentZoneContext)
 	}
 
-	return ctrl.Result{}, r.reconcileNormal(ctx, vsphereDeploymentZoneContext)
+	return r.reconcileNormal(ctx, vsphereDeploymentZoneContext)
 }
 
 // Patch patches the VSphereDeploymentZone.
@@ -180,15 +186,16 @@ func (r vsphereDeploymentZoneReconciler) patch(ctx context.Context, vsphereDeplo
 			infrav1.VSphereDeploymentZonePlacementConstraintReadyCondition,
 			infrav1.VSphereDeploymentZoneVCenterAvailableCondition,
 			infrav1.VSphereDeploymentZoneFailureDomainValidatedCondition,
+			infrav1.VSphereDeploymentZoneTemplateReplicasReadyCondition,
 		}},
 	)
 }
 
-func (r vsphereDeploymentZoneReconciler) reconcileNormal(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext) error {
+func (r vsphereDeploymentZoneReconciler) reconcileNormal(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext) (reconcile.Result, error) {
 	failureDomain := &infrav1.VSphereFailureDomain{}
 	failureDomainKey := client.ObjectKey{Name: deploymentZoneCtx.VSphereDeploymentZone.Spec.FailureDomain}
 	if err := r.Client.Get(ctx, failureDomainKey, failureDomain); err != nil {
-		return pkgerrors.Wrapf(err, "failed to get VSphereFailureDomain %s", klog.KRef(failureDomainKey.Namespace, failureDomainKey.Name))
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereFailureDomain %s", klog.KRef(failureDomainKey.Namespace, failureDomainKey.Name))
 	}
 
 	authSession, err := r.getVCenterSession(ctx, deploymentZoneCtx, failureDomain.Spec.Topology.Datacenter)
@@ -201,7 +208,7 @@ func (r vsphereDeploymentZoneReconciler) reconcileNormal(ctx context.Context, de
 			Message: err.Error(),
 		})
 		deploymentZoneCtx.VSphereDeploymentZone.Status.Ready = ptr.To(false)
-		return err
+		return reconcile.Result{}, err
 	}
 
 	deploymentZoneCtx.AuthSession = authSession
@@ -214,18 +221,30 @@ func (r vsphereDeploymentZoneReconciler) reconcileNormal(ctx context.Context, de
 
 	if err := r.reconcilePlacementConstraint(ctx, deploymentZoneCtx); err != nil {
 		deploymentZoneCtx.VSphereDeploymentZone.Status.Ready = ptr.To(false)
-		return err
+		return reconcile.Result{}, err
 	}
 
 	// reconcile the failure domain
 	if err := r.reconcileFailureDomain(ctx, deploymentZoneCtx, failureDomain); err != nil {
 		deploymentZoneCtx.VSphereDeploymentZone.Status.Ready = ptr.To(false)
-		return err
+		return reconcile.Result{}, err
 	}
 
 	// Mark the deployment zone as ready.
 	deploymentZoneCtx.VSphereDeploymentZone.Status.Ready = ptr.To(true)
-	return nil
+
+	// The replicas of the templates do not affect the readiness of the deployment zone,
+	// as VMs are cloned from the templates until their replicas are ready.
+	if r.reconcileTemplateReplicas(ctx, deploymentZoneCtx, failureDomain) {
+		return reconcile.Result{RequeueAfter: templateReplicasSyncInterval}, nil
+	}
+
+	// Hosts can be tagged or added to the compute cluster at any time, so the host group
+	// managed for the failure domain is periodically updated.
+	if failureDomain.Spec.Topology.Hosts.IsManaged() {
+		return reconcile.Result{RequeueAfter: managedHostsSyncInterval}, nil
+	}
+	return reconcile.Result{}, nil
 }
 
 func (r vsphereDeploymentZoneReconciler) reconcilePlacementConstraint(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext) error {
@@ -268,20 +287,38 @@ func (r vsphereDeploymentZoneReconciler) reconcilePlacementConstraint(ctx contex
 }
 
 func (r vsphereDeploymentZoneReconciler) getVCenterSession(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext, datacenter string) (*session.Session, error) {
+	spec := deploymentZoneCtx.VSphereDeploymentZone.Spec
+	return getVCenterSession(ctx, r.ControllerManagerContext, spec.Server, spec.CABundleRef, ptr.Deref(spec.Insecure, false), datacenter)
+}
+
+// getVCenterSession returns a session for the vCenter server. The credentials of the first VSphereCluster
+// with an identity for the server are used, or the credentials provided to the manager if there is none.
+// The given CA bundle takes precedence over the settings of the VSphereCluster.
+func getVCenterSession(ctx context.Context, controllerManagerCtx *capvcontext.ControllerManagerContext, server string, caBundleRef infrav1.CABundleReference, insecure bool, datacenter string) (*session.Session, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	params := session.NewParams().
-		WithServer(deploymentZoneCtx.VSphereDeploymentZone.Spec.Server).
+		WithServer(server).
 		WithDatacenter(datacenter).
-		WithUserInfo(r.ControllerManagerContext.Username, r.ControllerManagerContext.Password)
+		WithUserInfo(controllerManagerCtx.Username, controllerManagerCtx.Password).
+		WithInsecure(insecure)
+
+	hasCABundle := caBundleRef.IsDefined()
+	if hasCABundle {
+		caBundle, err := identity.GetCABundleFromReference(ctx, controllerManagerCtx.Client, caBundleRef, controllerManagerCtx.Namespace)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "failed to get CA bundle")
+		}
+		params = params.WithCABundle(caBundle)
+	}
 
 	clusterList := &infrav1.VSphereClusterList{}
-	if err := r.Client.List(ctx, clusterList); err != nil {
+	if err := controllerManagerCtx.Client.List(ctx, clusterList); err != nil {
 		return nil, pkgerrors.Wrapf(err, "failed to list VSphereClusters")
 	}
 
 	for _, vsphereCluster := range clusterList.Items {
-		if deploymentZoneCtx.VSphereDeploymentZone.Spec.Server != vsphereCluster.Spec.Server || !vsphereCluster.Spec.IdentityRef.IsDefined() {
+		if server != vsphereCluster.Spec.Server || !vsphereCluster.Spec.IdentityRef.IsDefined() {
 			continue
 		}
 
@@ -289,13 +326,22 @@ func (r vsphereDeploymentZoneReconciler) getVCenterSession(ctx context.Context,
 		log := log.WithValues("VSphereCluster", klog.KRef(vsphereCluster.Namespace, vsphereCluster.Name))
 		ctx := ctrl.LoggerInto(ctx, log)
 
-		params = params.WithThumbprint(vsphereCluster.Spec.Thumbprint)
 		vsphereCluster := vsphereCluster
-		creds, err := identity.GetCredentials(ctx, r.Client, &vsphereCluster, r.Namespace)
+		creds, err := identity.GetCredentials(ctx, controllerManagerCtx.Client, &vsphereCluster, controllerManagerCtx.Namespace)
 		if err != nil {
 			log.Error(err, "error retrieving credentials from IdentityRef")
 			continue
 		}
+		if !hasCABundle {
+			caBundle, err := identity.GetCABundle(ctx, controllerManagerCtx.Client, &vsphereCluster, controllerManagerCtx.Namespace)
+			if err != nil {
+				log.Error(err, "error retrieving CA bundle")
+				continue
+			}
+			params = params.WithThumbprint(vsphereCluster.Spec.Thumbprint).
+				WithCABundle(caBundle).
+				WithInsecure(insecure || ptr.Deref(vsphereCluster.Spec.Insecure, false))
+		}
 		log.V(4).Info("Using credentials from VSphereCluster IdentityRef to create the authenticated session")
 		params = params.WithUserInfo(creds.Username, creds.Password)
 		return session.GetOrCreate(ctx, params)
@@ -351,6 +397,14 @@ func (r vsphereDeploymentZoneReconciler) reconcileDelete(ctx context.Context, de
 		return pkgerrors.Wrapf(err, "failed to get VSphereFailureDomain")
 	}
 
+	// Delete the VM group, the host group and the VM-Host rule managed for the VSphereFailureDomain
+	// once no other VSphereDeploymentZone uses it.
+	if failureDomain.Spec.Topology.Hosts.IsManaged() && !isUsedByOtherDeploymentZone(failureDomain, deploymentZoneCtx.VSphereDeploymentZone.Name) {
+		if err := r.reconcileDeleteManagedHosts(ctx, deploymentZoneCtx, failureDomain); err != nil {
+			return err
+		}
+	}
+
 	// Reconcile the deletion of the VSphereFailureDomain by removing ownerReferences and deleting if necessary.
 	if err := updateOwnerReferences(ctx, failureDomain, r.Client, func() []metav1.OwnerReference {
 		return clusterutilv1.RemoveOwnerRef(failureDomain.OwnerReferences, metav1.OwnerReference{
@@ -373,6 +427,14 @@ func (r vsphereDeploymentZoneReconciler) reconcileDelete(ctx context.Context, de
 	return nil
 }
 
+// isUsedByOtherDeploymentZone returns true if a VSphereDeploymentZone other than the given one
+// is an owner of the VSphereFailureDomain.
+func isUsedByOtherDeploymentZone(failureDomain *infrav1.VSphereFailureDomain, deploymentZoneName string) bool {
+	return slices.ContainsFunc(failureDomain.OwnerReferences, func(ref metav1.OwnerReference) bool {
+		return ref.Kind == "VSphereDeploymentZone" && ref.Name != deploymentZoneName
+	})
+}
+
 // updateOwnerReferences uses the ownerRef function to calculate the owner references
 // to be set on the object and patches the object.
 func updateOwnerReferences(ctx context.Context, obj client.Object, client client.Client, ownerRefFunc func() []metav1.OwnerReference) error {
@@ -22,6 +22,7 @@ import (
 
 	pkgerrors "github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 	clusterutilv1 "sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -146,6 +147,19 @@ func (r vsphereDeploymentZoneReconciler) reconcileTopology(ctx context.Context,
 	}
 
 	if hostPlacementInfo := topology.Hosts; hostPlacementInfo.IsDefined() {
+		if hostPlacementInfo.IsManaged() {
+			if err := r.reconcileManagedHosts(ctx, deploymentZoneCtx, vsphereFailureDomain); err != nil {
+				deprecatedv1beta1conditions.MarkFalse(deploymentZoneCtx.VSphereDeploymentZone, infrav1.VSphereFailureDomainValidatedV1Beta1Condition, infrav1.HostsMisconfiguredV1Beta1Reason, clusterv1.ConditionSeverityError, "failed to reconcile vm host affinity rule: %v", err)
+				conditions.Set(deploymentZoneCtx.VSphereDeploymentZone, metav1.Condition{
+					Type:    infrav1.VSphereDeploymentZoneFailureDomainValidatedCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  infrav1.VSphereDeploymentZoneFailureDomainHostsMisconfiguredReason,
+					Message: fmt.Sprintf("failed to reconcile vm host affinity rule: %v", err),
+				})
+				return err
+			}
+		}
+
 		rule, err := cluster.VerifyAffinityRule(ctx, deploymentZoneCtx, topology.ComputeCluster, hostPlacementInfo.HostGroupName, hostPlacementInfo.VMGroupName)
 		if err != nil {
 			deprecatedv1beta1conditions.MarkFalse(deploymentZoneCtx.VSphereDeploymentZone, infrav1.VSphereFailureDomainValidatedV1Beta1Condition, infrav1.HostsMisconfiguredV1Beta1Reason, clusterv1.ConditionSeverityError, "vm host affinity does not exist")
@@ -166,6 +180,97 @@ func (r vsphereDeploymentZoneReconciler) reconcileTopology(ctx context.Context,
 	return nil
 }
 
+// reconcileManagedHosts creates the VM group, the host group and the VM-Host rule of the failure domain,
+// and updates the host group with the hosts of the compute cluster selected by the host selector.
+func (r vsphereDeploymentZoneReconciler) reconcileManagedHosts(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext, vsphereFailureDomain *infrav1.VSphereFailureDomain) error {
+	topology := vsphereFailureDomain.Spec.Topology
+	ccr, err := deploymentZoneCtx.AuthSession.Finder.ClusterComputeResource(ctx, topology.ComputeCluster)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to find compute cluster %s", topology.ComputeCluster)
+	}
+
+	managedHosts := getManagedHosts(topology.Hosts)
+	managedHosts.Hosts, err = cluster.FindHostsByTags(ctx, deploymentZoneCtx.AuthSession.TagManager, ccr, topology.Hosts.Managed.HostSelector.MatchTags)
+	if err != nil {
+		return err
+	}
+	if len(managedHosts.Hosts) == 0 {
+		ctrl.LoggerFrom(ctx).Info("WARNING: no host of the compute cluster is selected for the failure domain", "hostGroup", managedHosts.HostGroupName)
+	}
+
+	created, err := r.getCreatedManagedHosts(ctx, deploymentZoneCtx.VSphereDeploymentZone, managedHosts)
+	if err != nil {
+		return err
+	}
+	deploymentZoneCtx.VSphereDeploymentZone.Status.ManagedHosts = created
+	return cluster.ReconcileManagedHosts(ctx, ccr, managedHosts, &deploymentZoneCtx.VSphereDeploymentZone.Status.ManagedHosts)
+}
+
+// reconcileDeleteManagedHosts deletes the VM group, the host group and the VM-Host rule of the failure domain.
+func (r vsphereDeploymentZoneReconciler) reconcileDeleteManagedHosts(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext, vsphereFailureDomain *infrav1.VSphereFailureDomain) error {
+	topology := vsphereFailureDomain.Spec.Topology
+	authSession, err := r.getVCenterSession(ctx, deploymentZoneCtx, topology.Datacenter)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "failed to get vCenter session to delete VM-Host rule of failure domain %s", vsphereFailureDomain.Name)
+	}
+
+	ccr, err := authSession.Finder.ClusterComputeResource(ctx, topology.ComputeCluster)
+	if err != nil {
+		// Nothing to delete if the compute cluster is gone.
+		if isNotFound(err) {
+			return nil
+		}
+		return pkgerrors.Wrapf(err, "unable to find compute cluster %s", topology.ComputeCluster)
+	}
+
+	created, err := r.getCreatedManagedHosts(ctx, deploymentZoneCtx.VSphereDeploymentZone, getManagedHosts(topology.Hosts))
+	if err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Deleting VM-Host rule, VM group and host group of failure domain created by CAPV", "vmGroup", created.VMGroupName, "hostGroup", created.HostGroupName, "rule", created.RuleName)
+	return cluster.DeleteManagedHosts(ctx, ccr, created)
+}
+
+// getCreatedManagedHosts returns the groups and the rule of the failure domain which have been created
+// by CAPV, as recorded by the VSphereDeploymentZone or by any other VSphereDeploymentZone which uses
+// the same failure domain, so that the last VSphereDeploymentZone which is deleted can delete them.
+func (r vsphereDeploymentZoneReconciler) getCreatedManagedHosts(ctx context.Context, vsphereDeploymentZone *infrav1.VSphereDeploymentZone, managedHosts cluster.ManagedHosts) (infrav1.VSphereDeploymentZoneManagedHosts, error) {
+	var zones infrav1.VSphereDeploymentZoneList
+	if err := r.Client.List(ctx, &zones); err != nil {
+		return infrav1.VSphereDeploymentZoneManagedHosts{}, pkgerrors.Wrap(err, "unable to list VSphereDeploymentZones")
+	}
+
+	recorded := []infrav1.VSphereDeploymentZoneManagedHosts{vsphereDeploymentZone.Status.ManagedHosts}
+	for _, zone := range zones.Items {
+		if zone.Name != vsphereDeploymentZone.Name && zone.Spec.FailureDomain == vsphereDeploymentZone.Spec.FailureDomain {
+			recorded = append(recorded, zone.Status.ManagedHosts)
+		}
+	}
+
+	created := infrav1.VSphereDeploymentZoneManagedHosts{}
+	for _, record := range recorded {
+		if record.VMGroupName == managedHosts.VMGroupName {
+			created.VMGroupName = record.VMGroupName
+		}
+		if record.HostGroupName == managedHosts.HostGroupName {
+			created.HostGroupName = record.HostGroupName
+		}
+		if record.RuleName == managedHosts.RuleName {
+			created.RuleName = record.RuleName
+		}
+	}
+	return created, nil
+}
+
+func getManagedHosts(hosts infrav1.FailureDomainHosts) cluster.ManagedHosts {
+	return cluster.ManagedHosts{
+		VMGroupName:   hosts.VMGroupName,
+		HostGroupName: hosts.HostGroupName,
+		RuleName:      hosts.Managed.GetRuleName(hosts.VMGroupName),
+		Mandatory:     ptr.Deref(hosts.Managed.Mandatory, false),
+	}
+}
+
 func (r vsphereDeploymentZoneReconciler) reconcileComputeCluster(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext, vsphereFailureDomain *infrav1.VSphereFailureDomain) error {
 	computeCluster := vsphereFailureDomain.Spec.Topology.ComputeCluster
 	if computeCluster == "" {
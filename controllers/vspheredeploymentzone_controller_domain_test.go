@@ -57,7 +57,8 @@ func TestVsphereDeploymentZoneReconciler_Reconcile_VerifyFailureDomain_ComputeCl
 	params := session.NewParams().
 		WithServer(simr.ServerURL().Host).
 		WithUserInfo(simr.Username(), simr.Password()).
-		WithDatacenter("*")
+		WithDatacenter("*").
+		WithInsecure(true)
 	authSession, err := session.GetOrCreate(ctx, params)
 	g.Expect(err).NotTo(HaveOccurred())
 
@@ -132,7 +133,8 @@ func TestVsphereDeploymentZoneReconciler_Reconcile_VerifyFailureDomain_HostGroup
 	params := session.NewParams().
 		WithServer(simr.ServerURL().Host).
 		WithUserInfo(simr.Username(), simr.Password()).
-		WithDatacenter("*")
+		WithDatacenter("*").
+		WithInsecure(true)
 	authSession, err := session.GetOrCreate(ctx, params)
 	g.Expect(err).NotTo(HaveOccurred())
 
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
+)
+
+// templateReplicasSyncInterval is the interval at which the replicas of the templates are checked
+// while they are being cloned or could not be created.
+const templateReplicasSyncInterval = 30 * time.Second
+
+// reconcileTemplateReplicas ensures that the templates of the VSphereDeploymentZone have a replica
+// on the datastore of the failure domain, and reports the replicas in the status. It returns true
+// if the replicas have to be checked again.
+// Replicas are never deleted, as they are shared with the other VSphereDeploymentZones on the datastore
+// and VMs may still be linked clones of them.
+func (r vsphereDeploymentZoneReconciler) reconcileTemplateReplicas(ctx context.Context, deploymentZoneCtx *capvcontext.VSphereDeploymentZoneContext, vsphereFailureDomain *infrav1.VSphereFailureDomain) bool {
+	log := ctrl.LoggerFrom(ctx)
+	deploymentZone := deploymentZoneCtx.VSphereDeploymentZone
+
+	if len(deploymentZone.Spec.TemplateReplicas) == 0 {
+		deploymentZone.Status.TemplateReplicas = nil
+		conditions.Delete(deploymentZone, infrav1.VSphereDeploymentZoneTemplateReplicasReadyCondition)
+		return false
+	}
+
+	datastore := vsphereFailureDomain.Spec.Topology.Datastore
+	if datastore == "" {
+		deploymentZone.Status.TemplateReplicas = nil
+		conditions.Set(deploymentZone, metav1.Condition{
+			Type:    infrav1.VSphereDeploymentZoneTemplateReplicasReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereDeploymentZoneTemplateReplicasNoDatastoreReason,
+			Message: fmt.Sprintf("VSphereFailureDomain %s has no datastore", vsphereFailureDomain.Name),
+		})
+		return false
+	}
+
+	pendingTaskRefs := map[string]string{}
+	for _, replica := range deploymentZone.Status.TemplateReplicas {
+		pendingTaskRefs[replica.Template] = replica.TaskRef
+	}
+
+	replicas := make([]infrav1.VSphereDeploymentZoneTemplateReplica, 0, len(deploymentZone.Spec.TemplateReplicas))
+	var cloning, failed []string
+	for _, template := range deploymentZone.Spec.TemplateReplicas {
+		replicaPath, taskRef, err := vcenter.ReconcileTemplateReplica(ctx, deploymentZoneCtx.AuthSession, vcenter.TemplateReplica{
+			Template:     template,
+			Datastore:    datastore,
+			ResourcePool: deploymentZone.Spec.PlacementConstraint.ResourcePool,
+		}, pendingTaskRefs[template])
+		replica := infrav1.VSphereDeploymentZoneTemplateReplica{
+			Template: template,
+			Replica:  replicaPath,
+			TaskRef:  taskRef,
+		}
+		switch {
+		case err != nil:
+			log.Error(err, "Failed to reconcile replica of template", "template", template, "datastore", datastore)
+			replica.Message = err.Error()
+			failed = append(failed, template)
+		case replicaPath == "":
+			cloning = append(cloning, template)
+		}
+		replicas = append(replicas, replica)
+	}
+	deploymentZone.Status.TemplateReplicas = replicas
+
+	switch {
+	case len(failed) > 0:
+		conditions.Set(deploymentZone, metav1.Condition{
+			Type:    infrav1.VSphereDeploymentZoneTemplateReplicasReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereDeploymentZoneTemplateReplicasFailedReason,
+			Message: fmt.Sprintf("Replicas of templates %s could not be created on datastore %s", strings.Join(failed, ", "), datastore),
+		})
+	case len(cloning) > 0:
+		conditions.Set(deploymentZone, metav1.Condition{
+			Type:    infrav1.VSphereDeploymentZoneTemplateReplicasReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereDeploymentZoneTemplateReplicasCloningReason,
+			Message: fmt.Sprintf("Replicas of templates %s are being cloned to datastore %s", strings.Join(cloning, ", "), datastore),
+		})
+	default:
+		conditions.Set(deploymentZone, metav1.Condition{
+			Type:   infrav1.VSphereDeploymentZoneTemplateReplicasReadyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereDeploymentZoneTemplateReplicasReadyReason,
+		})
+	}
+	return len(failed) > 0 || len(cloning) > 0
+}
@@ -105,6 +105,7 @@ var _ = Describe("VSphereDeploymentZoneReconciler", func() {
 			},
 			Spec: infrav1.VSphereDeploymentZoneSpec{
 				Server:        simr.ServerURL().Host,
+				Insecure:      ptr.To(true),
 				FailureDomain: vsphereFailureDomain.Name,
 				ControlPlane:  ptr.To(true),
 				PlacementConstraint: infrav1.PlacementConstraint{
@@ -184,6 +185,7 @@ var _ = Describe("VSphereDeploymentZoneReconciler", func() {
 				},
 				Spec: infrav1.VSphereDeploymentZoneSpec{
 					Server:        simr.ServerURL().Host,
+					Insecure:      ptr.To(true),
 					FailureDomain: vsphereFailureDomain.Name,
 					ControlPlane:  ptr.To(true),
 					PlacementConstraint: infrav1.PlacementConstraint{
@@ -351,6 +353,7 @@ func TestVSphereDeploymentZone_Reconcile(t *testing.T) {
 			},
 			Spec: infrav1.VSphereDeploymentZoneSpec{
 				Server:        simr.ServerURL().Host,
+				Insecure:      ptr.To(true),
 				FailureDomain: vsphereFailureDomain.Name,
 				ControlPlane:  ptr.To(true),
 				PlacementConstraint: infrav1.PlacementConstraint{
@@ -421,6 +424,7 @@ func TestVSphereDeploymentZone_Reconcile(t *testing.T) {
 			},
 			Spec: infrav1.VSphereDeploymentZoneSpec{
 				Server:        simr.ServerURL().Host,
+				Insecure:      ptr.To(true),
 				FailureDomain: vsphereFailureDomain.Name,
 				ControlPlane:  ptr.To(true),
 				PlacementConstraint: infrav1.PlacementConstraint{
@@ -459,6 +463,7 @@ func TestVSphereDeploymentZone_Reconcile(t *testing.T) {
 			},
 			Spec: infrav1.VSphereDeploymentZoneSpec{
 				Server:        simr.ServerURL().Host,
+				Insecure:      ptr.To(true),
 				FailureDomain: "fd1",
 				ControlPlane:  ptr.To(true),
 				PlacementConstraint: infrav1.PlacementConstraint{
@@ -494,6 +499,7 @@ func TestVSphereDeploymentZone_Reconcile(t *testing.T) {
 			},
 			Spec: infrav1.VSphereDeploymentZoneSpec{
 				Server:        simr.ServerURL().Host,
+				Insecure:      ptr.To(true),
 				FailureDomain: "fd1",
 				ControlPlane:  ptr.To(true),
 				PlacementConstraint: infrav1.PlacementConstraint{
@@ -619,6 +625,7 @@ func TestVsphereDeploymentZone_Failed_ReconcilePlacementConstraint(t *testing.T)
 				ControllerManagerContext: controllerManagerContext,
 				VSphereDeploymentZone: &infrav1.VSphereDeploymentZone{Spec: infrav1.VSphereDeploymentZoneSpec{
 					Server:              simr.ServerURL().Host,
+					Insecure:            ptr.To(true),
 					FailureDomain:       "blah",
 					ControlPlane:        ptr.To(true),
 					PlacementConstraint: tt.placementConstraint,
@@ -626,7 +633,7 @@ func TestVsphereDeploymentZone_Failed_ReconcilePlacementConstraint(t *testing.T)
 			}
 
 			reconciler := vsphereDeploymentZoneReconciler{controllerManagerContext}
-			err = reconciler.reconcileNormal(ctx, deploymentZoneCtx)
+			_, err = reconciler.reconcileNormal(ctx, deploymentZoneCtx)
 			g.Expect(err).To(HaveOccurred())
 		})
 	}
@@ -0,0 +1,436 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/cluster"
+)
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherefailuredomains,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherefailuredomains/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheredeploymentzones,verbs=get;list;watch
+
+// failureDomainStatusSyncInterval is the interval at which the status of a VSphereFailureDomain,
+// including its capacity, is updated.
+const failureDomainStatusSyncInterval = 5 * time.Minute
+
+// AddVSphereFailureDomainControllerToManager adds the VSphereFailureDomain controller to the provided manager.
+func AddVSphereFailureDomainControllerToManager(ctx context.Context, controllerManagerCtx *capvcontext.ControllerManagerContext, mgr manager.Manager, options controller.Options) error {
+	reconciler := vsphereFailureDomainReconciler{
+		ControllerManagerContext: controllerManagerCtx,
+	}
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "vspherefailuredomain")
+
+	return capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&infrav1.VSphereFailureDomain{}).
+		WithOptions(options).
+		// The server of a VSphereFailureDomain can be taken from the VSphereDeploymentZones which use it.
+		Watches(
+			&infrav1.VSphereDeploymentZone{},
+			handler.EnqueueRequestsFromMapFunc(reconciler.deploymentZoneToFailureDomain)).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, controllerManagerCtx.WatchFilterValue)).
+		Complete(ctx, reconciler)
+}
+
+type vsphereFailureDomainReconciler struct {
+	*capvcontext.ControllerManagerContext
+}
+
+func (r vsphereFailureDomainReconciler) Reconcile(ctx context.Context, request reconcile.Request) (_ reconcile.Result, reterr error) {
+	vsphereFailureDomain := &infrav1.VSphereFailureDomain{}
+	if err := r.Client.Get(ctx, request.NamespacedName, vsphereFailureDomain); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !vsphereFailureDomain.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vsphereFailureDomain, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	defer func() {
+		if err := r.patch(ctx, vsphereFailureDomain, patchHelper); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	return r.reconcileNormal(ctx, vsphereFailureDomain)
+}
+
+// patch patches the VSphereFailureDomain.
+func (r vsphereFailureDomainReconciler) patch(ctx context.Context, vsphereFailureDomain *infrav1.VSphereFailureDomain, patchHelper *patch.Helper) error {
+	if err := conditions.SetSummaryCondition(vsphereFailureDomain, vsphereFailureDomain, infrav1.VSphereFailureDomainReadyCondition,
+		conditions.ForConditionTypes{
+			infrav1.VSphereFailureDomainVCenterAvailableCondition,
+			infrav1.VSphereFailureDomainDatacenterReadyCondition,
+			infrav1.VSphereFailureDomainComputeClusterReadyCondition,
+			infrav1.VSphereFailureDomainDatastoreReadyCondition,
+			infrav1.VSphereFailureDomainNetworksReadyCondition,
+			infrav1.VSphereFailureDomainHostGroupReadyCondition,
+		},
+		// The conditions of the parts of the topology which are not set are not reported.
+		conditions.IgnoreTypesIfMissing{
+			infrav1.VSphereFailureDomainComputeClusterReadyCondition,
+			infrav1.VSphereFailureDomainDatastoreReadyCondition,
+			infrav1.VSphereFailureDomainNetworksReadyCondition,
+			infrav1.VSphereFailureDomainHostGroupReadyCondition,
+		},
+		// Using a custom merge strategy to override reasons applied during merge.
+		conditions.CustomMergeStrategy{
+			MergeStrategy: conditions.DefaultMergeStrategy(
+				// Use custom reasons.
+				conditions.ComputeReasonFunc(conditions.GetDefaultComputeMergeReasonFunc(
+					infrav1.VSphereFailureDomainNotReadyReason,
+					infrav1.VSphereFailureDomainReadyUnknownReason,
+					infrav1.VSphereFailureDomainReadyReason,
+				)),
+			),
+		},
+	); err != nil {
+		return pkgerrors.Wrapf(err, "failed to set %s condition", infrav1.VSphereFailureDomainReadyCondition)
+	}
+
+	return patchHelper.Patch(ctx, vsphereFailureDomain,
+		patch.WithOwnedConditions{Conditions: []string{
+			infrav1.VSphereFailureDomainReadyCondition,
+			infrav1.VSphereFailureDomainVCenterAvailableCondition,
+			infrav1.VSphereFailureDomainDatacenterReadyCondition,
+			infrav1.VSphereFailureDomainComputeClusterReadyCondition,
+			infrav1.VSphereFailureDomainDatastoreReadyCondition,
+			infrav1.VSphereFailureDomainNetworksReadyCondition,
+			infrav1.VSphereFailureDomainHostGroupReadyCondition,
+		}},
+	)
+}
+
+func (r vsphereFailureDomainReconciler) reconcileNormal(ctx context.Context, vsphereFailureDomain *infrav1.VSphereFailureDomain) (reconcile.Result, error) {
+	topology := vsphereFailureDomain.Spec.Topology
+
+	zone, err := r.getServerDeploymentZone(ctx, vsphereFailureDomain)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if zone == nil {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainVCenterAvailableCondition,
+			Status:  metav1.ConditionUnknown,
+			Reason:  infrav1.VSphereFailureDomainVCenterServerUnknownReason,
+			Message: "server is not set and no VSphereDeploymentZone uses the failure domain",
+		})
+		return reconcile.Result{}, nil
+	}
+
+	// The session is created without datacenter, so that a missing datacenter is reported
+	// with its own condition.
+	authSession, err := getVCenterSession(ctx, r.ControllerManagerContext, zone.Spec.Server, zone.Spec.CABundleRef, ptr.Deref(zone.Spec.Insecure, false), "")
+	if err != nil {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainVCenterAvailableCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainVCenterUnreachableReason,
+			Message: err.Error(),
+		})
+		return reconcile.Result{}, err
+	}
+	conditions.Set(vsphereFailureDomain, metav1.Condition{
+		Type:   infrav1.VSphereFailureDomainVCenterAvailableCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereFailureDomainVCenterAvailableReason,
+	})
+
+	// The finder of the session is shared, so a dedicated finder is used to set the datacenter.
+	finder := find.NewFinder(authSession.Client.Client, false)
+	dc, err := finder.Datacenter(ctx, topology.Datacenter)
+	if err != nil {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainDatacenterReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainDatacenterNotFoundReason,
+			Message: fmt.Sprintf("datacenter %s is not found", topology.Datacenter),
+		})
+		// Misconfigurations are reported with conditions, and checked again periodically.
+		return reconcile.Result{RequeueAfter: failureDomainStatusSyncInterval}, nil
+	}
+	finder.SetDatacenter(dc)
+	conditions.Set(vsphereFailureDomain, metav1.Condition{
+		Type:   infrav1.VSphereFailureDomainDatacenterReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereFailureDomainDatacenterReadyReason,
+	})
+
+	hosts, err := r.reconcileComputeCluster(ctx, vsphereFailureDomain, finder)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	datastore := r.reconcileDatastore(ctx, vsphereFailureDomain, finder)
+	r.reconcileNetworks(ctx, vsphereFailureDomain, finder)
+
+	// Gathering the capacity is skipped if it is recent, to avoid updating the status on each reconcile.
+	capacity := vsphereFailureDomain.Status.Capacity
+	if time.Since(capacity.LastUpdateTime.Time) < failureDomainStatusSyncInterval {
+		return reconcile.Result{RequeueAfter: failureDomainStatusSyncInterval}, nil
+	}
+
+	hostsCapacity, err := cluster.GetHostsCapacity(ctx, authSession.Client.Client, hosts)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get capacity of failure domain %s", vsphereFailureDomain.Name)
+	}
+	capacity = infrav1.VSphereFailureDomainCapacity{
+		AvailableHosts: ptr.To(hostsCapacity.AvailableHosts),
+		CPUFreeMHz:     ptr.To(hostsCapacity.CPUFreeMHz),
+		MemoryFreeMiB:  ptr.To(hostsCapacity.MemoryFreeMiB),
+		LastUpdateTime: metav1.Now(),
+	}
+	if datastore != nil {
+		var ds mo.Datastore
+		if err := datastore.Properties(ctx, datastore.Reference(), []string{"summary"}, &ds); err != nil {
+			return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get free space of datastore %s", topology.Datastore)
+		}
+		capacity.DatastoreFreeGiB = ptr.To(ds.Summary.FreeSpace / (1024 * 1024 * 1024))
+	}
+	vsphereFailureDomain.Status.Capacity = capacity
+
+	return reconcile.Result{RequeueAfter: failureDomainStatusSyncInterval}, nil
+}
+
+// reconcileComputeCluster verifies the compute cluster and the host group of the failure domain, and returns
+// the hosts of the failure domain: the hosts of the host group, of the compute cluster or of the datacenter.
+func (r vsphereFailureDomainReconciler) reconcileComputeCluster(ctx context.Context, vsphereFailureDomain *infrav1.VSphereFailureDomain, finder *find.Finder) ([]types.ManagedObjectReference, error) {
+	topology := vsphereFailureDomain.Spec.Topology
+	if topology.ComputeCluster == "" {
+		hostSystems, err := finder.HostSystemList(ctx, "*")
+		if err != nil && !isNotFound(err) {
+			return nil, pkgerrors.Wrapf(err, "failed to list hosts of datacenter %s", topology.Datacenter)
+		}
+		return hostReferences(hostSystems), nil
+	}
+
+	ccr, err := finder.ClusterComputeResource(ctx, topology.ComputeCluster)
+	if err != nil {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainComputeClusterReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainComputeClusterNotFoundReason,
+			Message: fmt.Sprintf("compute cluster %s is not found", topology.ComputeCluster),
+		})
+		if topology.Hosts.IsDefined() {
+			conditions.Set(vsphereFailureDomain, metav1.Condition{
+				Type:    infrav1.VSphereFailureDomainHostGroupReadyCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereFailureDomainHostGroupNotFoundReason,
+				Message: fmt.Sprintf("compute cluster %s is not found", topology.ComputeCluster),
+			})
+		}
+		return nil, nil
+	}
+	conditions.Set(vsphereFailureDomain, metav1.Condition{
+		Type:   infrav1.VSphereFailureDomainComputeClusterReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereFailureDomainComputeClusterReadyReason,
+	})
+
+	if !topology.Hosts.IsDefined() {
+		hostSystems, err := ccr.Hosts(ctx)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "failed to list hosts of compute cluster %s", topology.ComputeCluster)
+		}
+		return hostReferences(hostSystems), nil
+	}
+
+	hostGroup, err := cluster.FindHostGroup(ctx, ccr, topology.Hosts.HostGroupName)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to find host group %s", topology.Hosts.HostGroupName)
+	}
+	if hostGroup == nil {
+		message := fmt.Sprintf("host group %s is not found", topology.Hosts.HostGroupName)
+		if topology.Hosts.IsManaged() {
+			message = fmt.Sprintf("host group %s is created once a VSphereDeploymentZone uses the failure domain", topology.Hosts.HostGroupName)
+		}
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainHostGroupReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainHostGroupNotFoundReason,
+			Message: message,
+		})
+		return nil, nil
+	}
+	if len(hostGroup.Host) == 0 {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainHostGroupReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainHostGroupEmptyReason,
+			Message: fmt.Sprintf("host group %s has no hosts", topology.Hosts.HostGroupName),
+		})
+		return nil, nil
+	}
+
+	rule, err := cluster.FindAffinityRule(ctx, ccr, topology.Hosts.HostGroupName, topology.Hosts.VMGroupName)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to find vm host affinity rule of failure domain %s", vsphereFailureDomain.Name)
+	}
+	if rule == nil {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainHostGroupReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainVMHostRuleNotFoundReason,
+			Message: fmt.Sprintf("vm host affinity rule for VM group %s and host group %s is not found", topology.Hosts.VMGroupName, topology.Hosts.HostGroupName),
+		})
+		return hostGroup.Host, nil
+	}
+	conditions.Set(vsphereFailureDomain, metav1.Condition{
+		Type:   infrav1.VSphereFailureDomainHostGroupReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereFailureDomainHostGroupReadyReason,
+	})
+	return hostGroup.Host, nil
+}
+
+// reconcileDatastore verifies the datastore of the failure domain and returns it, or nil if it is not set or not found.
+func (r vsphereFailureDomainReconciler) reconcileDatastore(ctx context.Context, vsphereFailureDomain *infrav1.VSphereFailureDomain, finder *find.Finder) *object.Datastore {
+	datastore := vsphereFailureDomain.Spec.Topology.Datastore
+	if datastore == "" {
+		return nil
+	}
+
+	ds, err := finder.Datastore(ctx, datastore)
+	if err != nil {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainDatastoreReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainDatastoreNotFoundReason,
+			Message: fmt.Sprintf("datastore %s is not found", datastore),
+		})
+		return nil
+	}
+	conditions.Set(vsphereFailureDomain, metav1.Condition{
+		Type:   infrav1.VSphereFailureDomainDatastoreReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereFailureDomainDatastoreReadyReason,
+	})
+	return ds
+}
+
+// reconcileNetworks verifies the networks of the failure domain.
+func (r vsphereFailureDomainReconciler) reconcileNetworks(ctx context.Context, vsphereFailureDomain *infrav1.VSphereFailureDomain, finder *find.Finder) {
+	topology := vsphereFailureDomain.Spec.Topology
+	networks := slices.Clone(topology.Networks)
+	for _, networkConfig := range topology.NetworkConfigurations {
+		networks = append(networks, networkConfig.NetworkName)
+	}
+	if len(networks) == 0 {
+		return
+	}
+
+	var notFound []string
+	for _, network := range networks {
+		if _, err := finder.Network(ctx, network); err != nil {
+			notFound = append(notFound, network)
+		}
+	}
+	if len(notFound) > 0 {
+		conditions.Set(vsphereFailureDomain, metav1.Condition{
+			Type:    infrav1.VSphereFailureDomainNetworksReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereFailureDomainNetworkNotFoundReason,
+			Message: fmt.Sprintf("networks %s are not found", strings.Join(notFound, ", ")),
+		})
+		return
+	}
+	conditions.Set(vsphereFailureDomain, metav1.Condition{
+		Type:   infrav1.VSphereFailureDomainNetworksReadyCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereFailureDomainNetworksReadyReason,
+	})
+}
+
+// getServerDeploymentZone returns a VSphereDeploymentZone with the server to use for the failure domain: a zone
+// with the server of the failure domain, or the first VSphereDeploymentZone which uses the failure domain.
+// It returns nil if the failure domain has no server and is not used by any VSphereDeploymentZone.
+func (r vsphereFailureDomainReconciler) getServerDeploymentZone(ctx context.Context, vsphereFailureDomain *infrav1.VSphereFailureDomain) (*infrav1.VSphereDeploymentZone, error) {
+	if vsphereFailureDomain.Spec.Server != "" {
+		return &infrav1.VSphereDeploymentZone{Spec: infrav1.VSphereDeploymentZoneSpec{Server: vsphereFailureDomain.Spec.Server}}, nil
+	}
+
+	zones := &infrav1.VSphereDeploymentZoneList{}
+	if err := r.Client.List(ctx, zones); err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to list VSphereDeploymentZones")
+	}
+	slices.SortFunc(zones.Items, func(a, b infrav1.VSphereDeploymentZone) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for i := range zones.Items {
+		if zone := &zones.Items[i]; zone.Spec.FailureDomain == vsphereFailureDomain.Name && zone.Spec.Server != "" {
+			return zone, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r vsphereFailureDomainReconciler) deploymentZoneToFailureDomain(_ context.Context, a client.Object) []reconcile.Request {
+	zone, ok := a.(*infrav1.VSphereDeploymentZone)
+	if !ok || zone.Spec.FailureDomain == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKey{Name: zone.Spec.FailureDomain}}}
+}
+
+func hostReferences(hostSystems []*object.HostSystem) []types.ManagedObjectReference {
+	refs := make([]types.ManagedObjectReference, 0, len(hostSystems))
+	for _, host := range hostSystems {
+		refs = append(refs, host.Reference())
+	}
+	return refs
+}
+
+func isNotFound(err error) bool {
+	var notFoundErr *find.NotFoundError
+	return errors.As(err, &notFoundErr)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/simulator"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
+)
+
+func TestVSphereFailureDomainReconciler_ReconcileNormal(t *testing.T) {
+	model := simulator.VPX()
+	model.Cluster = 2
+
+	simr, err := vcsim.NewBuilder().WithModel(model).Build()
+	if err != nil {
+		t.Fatalf("unable to create simulator %s", err)
+	}
+	defer simr.Destroy()
+
+	newReconciler := func() vsphereFailureDomainReconciler {
+		controllerManagerContext := fake.NewControllerManagerContext()
+		controllerManagerContext.Username = simr.ServerURL().User.Username()
+		controllerManagerContext.Password, _ = simr.ServerURL().User.Password()
+		return vsphereFailureDomainReconciler{controllerManagerContext}
+	}
+
+	t.Run("without server", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereFailureDomain := &infrav1.VSphereFailureDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "fd"},
+			Spec: infrav1.VSphereFailureDomainSpec{
+				Topology: infrav1.Topology{Datacenter: "DC0"},
+			},
+		}
+
+		result, err := newReconciler().reconcileNormal(ctx, vsphereFailureDomain)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(BeZero())
+		g.Expect(conditions.IsUnknown(vsphereFailureDomain, infrav1.VSphereFailureDomainVCenterAvailableCondition)).To(BeTrue())
+	})
+
+	// The server and the settings of the VSphereDeploymentZone which uses the failure domain are used.
+	reconciler := newReconciler()
+	if err := reconciler.Client.Create(ctx, &infrav1.VSphereDeploymentZone{
+		ObjectMeta: metav1.ObjectMeta{Name: "zone"},
+		Spec: infrav1.VSphereDeploymentZoneSpec{
+			Server:        simr.ServerURL().Host,
+			Insecure:      ptr.To(true),
+			FailureDomain: "fd",
+		},
+	}); err != nil {
+		t.Fatalf("unable to create VSphereDeploymentZone %s", err)
+	}
+
+	t.Run("with server of a deployment zone", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereFailureDomain := &infrav1.VSphereFailureDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "fd"},
+			Spec: infrav1.VSphereFailureDomainSpec{
+				Topology: infrav1.Topology{
+					Datacenter:     "DC0",
+					ComputeCluster: "DC0_C0",
+					Datastore:      "LocalDS_0",
+					Networks:       []string{"VM Network", "does-not-exist"},
+				},
+			},
+		}
+
+		result, err := reconciler.reconcileNormal(ctx, vsphereFailureDomain)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.RequeueAfter).To(Equal(failureDomainStatusSyncInterval))
+		g.Expect(conditions.IsTrue(vsphereFailureDomain, infrav1.VSphereFailureDomainVCenterAvailableCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(vsphereFailureDomain, infrav1.VSphereFailureDomainDatacenterReadyCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(vsphereFailureDomain, infrav1.VSphereFailureDomainComputeClusterReadyCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(vsphereFailureDomain, infrav1.VSphereFailureDomainDatastoreReadyCondition)).To(BeTrue())
+		g.Expect(conditions.IsFalse(vsphereFailureDomain, infrav1.VSphereFailureDomainNetworksReadyCondition)).To(BeTrue())
+		g.Expect(conditions.Has(vsphereFailureDomain, infrav1.VSphereFailureDomainHostGroupReadyCondition)).To(BeFalse())
+
+		capacity := vsphereFailureDomain.Status.Capacity
+		g.Expect(capacity.AvailableHosts).To(HaveValue(BeNumerically(">", 0)))
+		g.Expect(capacity.CPUFreeMHz).To(HaveValue(BeNumerically(">", 0)))
+		g.Expect(capacity.MemoryFreeMiB).To(HaveValue(BeNumerically(">", 0)))
+		g.Expect(capacity.DatastoreFreeGiB).ToNot(BeNil())
+		g.Expect(capacity.LastUpdateTime.IsZero()).To(BeFalse())
+	})
+
+	t.Run("with missing host group", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereFailureDomain := &infrav1.VSphereFailureDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "fd"},
+			Spec: infrav1.VSphereFailureDomainSpec{
+				Topology: infrav1.Topology{
+					Datacenter:     "DC0",
+					ComputeCluster: "DC0_C0",
+					Hosts: infrav1.FailureDomainHosts{
+						VMGroupName:   "vm-group",
+						HostGroupName: "host-group",
+					},
+				},
+			},
+		}
+
+		_, err := reconciler.reconcileNormal(ctx, vsphereFailureDomain)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.IsFalse(vsphereFailureDomain, infrav1.VSphereFailureDomainHostGroupReadyCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(vsphereFailureDomain, infrav1.VSphereFailureDomainHostGroupReadyCondition)).To(Equal(infrav1.VSphereFailureDomainHostGroupNotFoundReason))
+		g.Expect(vsphereFailureDomain.Status.Capacity.AvailableHosts).To(Equal(ptr.To[int32](0)))
+	})
+
+	t.Run("with missing datacenter", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereFailureDomain := &infrav1.VSphereFailureDomain{
+			ObjectMeta: metav1.ObjectMeta{Name: "fd"},
+			Spec: infrav1.VSphereFailureDomainSpec{
+				Topology: infrav1.Topology{Datacenter: "does-not-exist"},
+			},
+		}
+
+		_, err := reconciler.reconcileNormal(ctx, vsphereFailureDomain)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(conditions.IsFalse(vsphereFailureDomain, infrav1.VSphereFailureDomainDatacenterReadyCondition)).To(BeTrue())
+	})
+}
@@ -0,0 +1,681 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	clusterutilv1 "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/finalizers"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/util"
+)
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheremachinepools,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheremachinepools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherevms,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools,verbs=get;list;watch
+
+// nodeDrainRequeueInterval is the interval in which the drain of the nodes of VSphereVMs
+// selected for deletion is checked.
+const nodeDrainRequeueInterval = 20 * time.Second
+
+// AddVSphereMachinePoolControllerToManager adds the VSphereMachinePool controller to the provided manager.
+func AddVSphereMachinePoolControllerToManager(ctx context.Context, controllerManagerCtx *capvcontext.ControllerManagerContext, mgr manager.Manager, clusterCache clustercache.ClusterCache, options controller.Options) error {
+	r := &vsphereMachinePoolReconciler{
+		Client:       controllerManagerCtx.Client,
+		ClusterCache: clusterCache,
+	}
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "vspheremachinepool")
+
+	clusterToVSphereMachinePools, err := clusterutilv1.ClusterToTypedObjectsMapper(mgr.GetClient(), &infrav1.VSphereMachinePoolList{}, mgr.GetScheme())
+	if err != nil {
+		return err
+	}
+
+	return capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		// Watch the controlled, infrastructure resource.
+		For(&infrav1.VSphereMachinePool{}).
+		WithOptions(options).
+		// Watch the CAPI resource that owns this infrastructure resource.
+		Watches(
+			&clusterv1.MachinePool{},
+			handler.EnqueueRequestsFromMapFunc(clusterutilv1.MachinePoolToInfrastructureMapFunc(ctx, infrav1.GroupVersion.WithKind("VSphereMachinePool"))),
+		).
+		// Watch the VSphereVMs of the pool, so the status of the pool reflects the status of its VMs.
+		Owns(&infrav1.VSphereVM{}).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, controllerManagerCtx.WatchFilterValue)).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(clusterToVSphereMachinePools),
+			predicates.ClusterPausedTransitionsOrInfrastructureProvisioned(mgr.GetScheme(), predicateLog),
+		).Complete(ctx, r)
+}
+
+type vsphereMachinePoolReconciler struct {
+	Client       client.Client
+	ClusterCache clustercache.ClusterCache
+}
+
+// Reconcile ensures the VSphereVMs of a VSphereMachinePool reflect the desired state of the
+// MachinePool which owns it.
+func (r *vsphereMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vsphereMachinePool := &infrav1.VSphereMachinePool{}
+	if err := r.Client.Get(ctx, req.NamespacedName, vsphereMachinePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	// Fetch the CAPI MachinePool.
+	machinePool, err := clusterutilv1.GetOwnerMachinePool(ctx, r.Client, vsphereMachinePool.ObjectMeta)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get MachinePool for VSphereMachinePool")
+	}
+	if machinePool == nil {
+		// Note: If ownerRef was not set, there are no VSphereVMs to delete. Remove finalizer so deletion can succeed.
+		if !vsphereMachinePool.DeletionTimestamp.IsZero() && ctrlutil.ContainsFinalizer(vsphereMachinePool, infrav1.MachinePoolFinalizer) {
+			patchHelper, err := patch.NewHelper(vsphereMachinePool, r.Client)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			ctrlutil.RemoveFinalizer(vsphereMachinePool, infrav1.MachinePoolFinalizer)
+			return reconcile.Result{}, patchHelper.Patch(ctx, vsphereMachinePool)
+		}
+
+		log.Info("Waiting for MachinePool controller to set OwnerRef on VSphereMachinePool")
+		return reconcile.Result{}, nil
+	}
+	log = log.WithValues("MachinePool", klog.KObj(machinePool))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	cluster, err := clusterutilv1.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get Cluster for VSphereMachinePool")
+	}
+	log = log.WithValues("Cluster", klog.KObj(cluster))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// Add finalizer first if not set to avoid the race condition between init and delete.
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, vsphereMachinePool, infrav1.MachinePoolFinalizer); err != nil || finalizerAdded {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(vsphereMachinePool, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, vsphereMachinePool); err != nil || isPaused || requeue {
+		return ctrl.Result{}, err
+	}
+
+	// Always patch the VSphereMachinePool object.
+	defer func() {
+		if err := conditions.SetSummaryCondition(vsphereMachinePool, vsphereMachinePool, infrav1.VSphereMachinePoolReadyCondition,
+			conditions.ForConditionTypes{
+				infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			},
+			// Using a custom merge strategy to override reasons applied during merge.
+			conditions.CustomMergeStrategy{
+				MergeStrategy: conditions.DefaultMergeStrategy(
+					// Use custom reasons.
+					conditions.ComputeReasonFunc(conditions.GetDefaultComputeMergeReasonFunc(
+						infrav1.VSphereMachinePoolNotReadyReason,
+						infrav1.VSphereMachinePoolReadyUnknownReason,
+						infrav1.VSphereMachinePoolReadyReason,
+					)),
+				),
+			},
+		); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, pkgerrors.Wrapf(err, "failed to set %s condition", infrav1.VSphereMachinePoolReadyCondition)})
+			return
+		}
+
+		if err := patchHelper.Patch(ctx, vsphereMachinePool, patch.WithOwnedConditions{Conditions: []string{
+			clusterv1.PausedCondition,
+			infrav1.VSphereMachinePoolReadyCondition,
+			infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+		}}); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if !vsphereMachinePool.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, r.reconcileDelete(ctx, vsphereMachinePool)
+	}
+
+	return r.reconcileNormal(ctx, cluster, machinePool, vsphereMachinePool)
+}
+
+// reconcileDelete deletes the VSphereVMs of the VSphereMachinePool and removes the finalizer
+// once all of them are gone.
+func (r *vsphereMachinePoolReconciler) reconcileDelete(ctx context.Context, vsphereMachinePool *infrav1.VSphereMachinePool) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	vms, err := r.getVSphereVMs(ctx, vsphereMachinePool)
+	if err != nil {
+		return err
+	}
+
+	if len(vms) == 0 {
+		ctrlutil.RemoveFinalizer(vsphereMachinePool, infrav1.MachinePoolFinalizer)
+		return nil
+	}
+
+	conditions.Set(vsphereMachinePool, metav1.Condition{
+		Type:    infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1.VSphereMachinePoolVirtualMachinesDeletingReason,
+		Message: fmt.Sprintf("Waiting for %d VSphereVMs to be deleted", len(vms)),
+	})
+
+	var errs []error
+	for i := range vms {
+		vm := &vms[i]
+		if !vm.DeletionTimestamp.IsZero() {
+			continue
+		}
+		log.Info("Deleting VSphereVM of VSphereMachinePool", "VSphereVM", klog.KObj(vm))
+		if err := r.Client.Delete(ctx, vm); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to delete VSphereVM %s", klog.KObj(vm)))
+		}
+	}
+	// The VSphereMachinePool is reconciled again when the VSphereVMs are gone.
+	return kerrors.NewAggregate(errs)
+}
+
+// reconcileNormal creates and deletes the VSphereVMs of the VSphereMachinePool according to the
+// replicas of the MachinePool and the rolling update strategy, and updates the status of the pool.
+// The nodes of the VSphereVMs are cordoned and drained before the VSphereVMs are deleted.
+func (r *vsphereMachinePoolReconciler) reconcileNormal(ctx context.Context, cluster *clusterv1.Cluster, machinePool *clusterv1.MachinePool, vsphereMachinePool *infrav1.VSphereMachinePool) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !ptr.Deref(cluster.Status.Initialization.InfrastructureProvisioned, false) {
+		log.Info("Waiting for Cluster InfrastructureProvisioned")
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:   infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereMachinePoolVirtualMachinesWaitingForClusterInfrastructureReadyReason,
+		})
+		return reconcile.Result{}, nil
+	}
+
+	dataSecretName := ptr.Deref(machinePool.Spec.Template.Spec.Bootstrap.DataSecretName, "")
+	if dataSecretName == "" {
+		log.Info("Waiting for bootstrap data to be available")
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:   infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereMachinePoolVirtualMachinesWaitingForBootstrapDataReason,
+		})
+		return reconcile.Result{}, nil
+	}
+
+	vsphereCluster := &infrav1.VSphereCluster{}
+	vsphereClusterKey := apitypes.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Spec.InfrastructureRef.Name}
+	if err := r.Client.Get(ctx, vsphereClusterKey, vsphereCluster); err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereCluster for VSphereMachinePool")
+	}
+
+	templateHash, err := computeMachinePoolTemplateHash(vsphereMachinePool.Spec.Template, dataSecretName)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	vms, err := r.getVSphereVMs(ctx, vsphereMachinePool)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	desired := ptr.Deref(machinePool.Spec.Replicas, 0)
+	maxSurge, maxUnavailable, err := resolveMachinePoolRollingUpdate(vsphereMachinePool.Spec.Strategy.RollingUpdate, desired)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	rollout := computeMachinePoolRollout(vms, templateHash, desired, maxSurge, maxUnavailable)
+
+	var errs []error
+	for _, vm := range rollout.toDelete {
+		log.Info("Selecting VSphereVM of VSphereMachinePool for deletion", "VSphereVM", klog.KObj(vm))
+		patch := client.MergeFrom(vm.DeepCopy())
+		annotations.AddAnnotations(vm, map[string]string{infrav1.MachinePoolDeleteAnnotation: ""})
+		if err := r.Client.Patch(ctx, vm, patch); err != nil && !apierrors.IsNotFound(err) {
+			// The VSphereVM is only drained once the selection is persisted.
+			delete(vm.Annotations, infrav1.MachinePoolDeleteAnnotation)
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to select VSphereVM %s for deletion", klog.KObj(vm)))
+		}
+	}
+
+	// The VSphereVMs selected for deletion are deleted once their nodes are drained.
+	for i := range vms {
+		vm := &vms[i]
+		if !vm.DeletionTimestamp.IsZero() || !isMachinePoolVSphereVMSelectedForDeletion(vm) {
+			continue
+		}
+		drained, err := r.drainNode(ctx, cluster, vm)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if !drained {
+			rollout.draining++
+			continue
+		}
+		log.Info("Deleting VSphereVM of VSphereMachinePool", "VSphereVM", klog.KObj(vm))
+		if err := r.Client.Delete(ctx, vm); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to delete VSphereVM %s", klog.KObj(vm)))
+		}
+	}
+	for range rollout.toCreate {
+		vm := newMachinePoolVSphereVM(cluster, vsphereCluster, vsphereMachinePool, dataSecretName, templateHash)
+		log.Info("Creating VSphereVM for VSphereMachinePool", "VSphereVM", klog.KObj(vm))
+		if err := r.Client.Create(ctx, vm); err != nil {
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to create VSphereVM %s", klog.KObj(vm)))
+		}
+	}
+
+	setMachinePoolStatus(vsphereMachinePool, vms, templateHash, desired, rollout)
+	if err := kerrors.NewAggregate(errs); err != nil {
+		return reconcile.Result{}, err
+	}
+	// Evicting pods does not trigger a reconcile of the VSphereMachinePool.
+	if rollout.draining > 0 {
+		return reconcile.Result{RequeueAfter: nodeDrainRequeueInterval}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// drainNode cordons the node of a VSphereVM selected for deletion and evicts its pods, so the
+// workloads are moved to other nodes before the VSphereVM is deleted. It returns true once the
+// node is drained or if the VSphereVM has no node.
+// Note: Pods of DaemonSets and static pods are not evicted, and evictions which would violate a
+// PodDisruptionBudget are retried until they succeed.
+func (r *vsphereMachinePoolReconciler) drainNode(ctx context.Context, cluster *clusterv1.Cluster, vm *infrav1.VSphereVM) (bool, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("VSphereVM", klog.KObj(vm), "Node", klog.KRef("", vm.Name))
+
+	// VSphereVMs which are not ready have not joined the cluster, and the nodes of a cluster
+	// which is being deleted don't need to be drained.
+	if !ptr.Deref(vm.Status.Ready, false) || !cluster.DeletionTimestamp.IsZero() {
+		return true, nil
+	}
+
+	clusterClient, err := r.ClusterCache.GetUncachedClient(ctx, client.ObjectKeyFromObject(cluster))
+	if err != nil {
+		if pkgerrors.Is(err, clustercache.ErrClusterNotConnected) {
+			log.V(2).Info("Waiting for the connection to the workload cluster to drain the Node")
+			return false, nil
+		}
+		return false, err
+	}
+
+	node := &corev1.Node{}
+	if err := clusterClient.Get(ctx, client.ObjectKey{Name: vm.Name}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, pkgerrors.Wrapf(err, "failed to get Node %s", vm.Name)
+	}
+
+	if !node.Spec.Unschedulable {
+		log.Info("Cordoning Node of VSphereVM")
+		patch := client.MergeFrom(node.DeepCopy())
+		node.Spec.Unschedulable = true
+		if err := clusterClient.Patch(ctx, node, patch); err != nil {
+			return false, pkgerrors.Wrapf(err, "failed to cordon Node %s", node.Name)
+		}
+	}
+
+	pods := &corev1.PodList{}
+	if err := clusterClient.List(ctx, pods, client.MatchingFields{"spec.nodeName": node.Name}); err != nil {
+		return false, pkgerrors.Wrapf(err, "failed to list Pods of Node %s", node.Name)
+	}
+
+	drained := true
+	var errs []error
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isPodEvictable(pod) {
+			continue
+		}
+		drained = false
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		log.Info("Evicting Pod from Node of VSphereVM", "Pod", klog.KObj(pod))
+		eviction := &policyv1.Eviction{ObjectMeta: metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace}}
+		if err := clusterClient.SubResource("eviction").Create(ctx, pod, eviction); err != nil {
+			// The eviction is retried if it would violate a PodDisruptionBudget.
+			if apierrors.IsTooManyRequests(err) || apierrors.IsNotFound(err) {
+				continue
+			}
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to evict Pod %s", klog.KObj(pod)))
+		}
+	}
+	return drained, kerrors.NewAggregate(errs)
+}
+
+// isPodEvictable returns true if a pod has to be evicted to drain its node. Completed pods,
+// static pods and pods of DaemonSets are left on the node.
+func isPodEvictable(pod *corev1.Pod) bool {
+	if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+		return false
+	}
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return false
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return false
+	}
+	return true
+}
+
+// isMachinePoolVSphereVMSelectedForDeletion returns true if the VSphereVM was selected for deletion
+// by a scale down or a rolling update of its VSphereMachinePool.
+func isMachinePoolVSphereVMSelectedForDeletion(vm *infrav1.VSphereVM) bool {
+	_, ok := vm.Annotations[infrav1.MachinePoolDeleteAnnotation]
+	return ok
+}
+
+// getVSphereVMs returns the VSphereVMs of the VSphereMachinePool.
+func (r *vsphereMachinePoolReconciler) getVSphereVMs(ctx context.Context, vsphereMachinePool *infrav1.VSphereMachinePool) ([]infrav1.VSphereVM, error) {
+	vmList := &infrav1.VSphereVMList{}
+	if err := r.Client.List(ctx, vmList,
+		client.InNamespace(vsphereMachinePool.Namespace),
+		client.MatchingLabels{infrav1.MachinePoolNameLabel: vsphereMachinePool.Name}); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list VSphereVMs of VSphereMachinePool")
+	}
+
+	vms := make([]infrav1.VSphereVM, 0, len(vmList.Items))
+	for _, vm := range vmList.Items {
+		if metav1.IsControlledBy(&vm, vsphereMachinePool) {
+			vms = append(vms, vm)
+		}
+	}
+	return vms, nil
+}
+
+// newMachinePoolVSphereVM returns a new VSphereVM for the VSphereMachinePool.
+func newMachinePoolVSphereVM(cluster *clusterv1.Cluster, vsphereCluster *infrav1.VSphereCluster, vsphereMachinePool *infrav1.VSphereMachinePool, dataSecretName, templateHash string) *infrav1.VSphereVM {
+	suffix := utilrand.String(5)
+	name := vsphereMachinePool.Name + "-" + suffix
+	// Windows VM names must have 15 characters length at max.
+	if vsphereMachinePool.Spec.Template.OS == infrav1.Windows && len(name) > 15 {
+		name = strings.TrimSuffix(vsphereMachinePool.Name[0:9], "-") + "-" + suffix
+	}
+
+	vm := &infrav1.VSphereVM{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: vsphereMachinePool.Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel:           cluster.Name,
+				infrav1.MachinePoolNameLabel:         vsphereMachinePool.Name,
+				infrav1.MachinePoolTemplateHashLabel: templateHash,
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(vsphereMachinePool, infrav1.GroupVersion.WithKind("VSphereMachinePool")),
+			},
+		},
+	}
+	vsphereMachinePool.Spec.Template.VirtualMachineCloneSpec.DeepCopyInto(&vm.Spec.VirtualMachineCloneSpec)
+	if vm.Spec.Server == "" {
+		vm.Spec.Server = vsphereCluster.Spec.Server
+	}
+	if vm.Spec.Thumbprint == "" {
+		vm.Spec.Thumbprint = vsphereCluster.Spec.Thumbprint
+	}
+	vm.Spec.BootstrapRef = infrav1.VSphereVMBootstrapReference{
+		Name: dataSecretName,
+	}
+	vm.Spec.PowerOffMode = vsphereMachinePool.Spec.Template.PowerOffMode
+	vm.Spec.GuestSoftPowerOffTimeoutSeconds = vsphereMachinePool.Spec.Template.GuestSoftPowerOffTimeoutSeconds
+	return vm
+}
+
+// computeMachinePoolTemplateHash returns the hash of the machine template and the bootstrap data
+// the VSphereVMs of a VSphereMachinePool are created from.
+func computeMachinePoolTemplateHash(template infrav1.VSphereMachinePoolMachineSpec, dataSecretName string) (string, error) {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return "", pkgerrors.Wrapf(err, "failed to compute template hash of VSphereMachinePool")
+	}
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(data)
+	_, _ = hasher.Write([]byte(dataSecretName))
+	return fmt.Sprintf("%08x", hasher.Sum32()), nil
+}
+
+// resolveMachinePoolRollingUpdate returns the absolute maxSurge and maxUnavailable of the rolling update
+// for the given number of desired replicas.
+func resolveMachinePoolRollingUpdate(rollingUpdate infrav1.VSphereMachinePoolRollingUpdate, desired int32) (int32, int32, error) {
+	maxSurge := ptr.Deref(rollingUpdate.MaxSurge, intstr.FromInt32(1))
+	maxUnavailable := ptr.Deref(rollingUpdate.MaxUnavailable, intstr.FromInt32(0))
+
+	surge, err := intstr.GetScaledValueFromIntOrPercent(&maxSurge, int(desired), true)
+	if err != nil {
+		return 0, 0, pkgerrors.Wrapf(err, "invalid maxSurge of VSphereMachinePool")
+	}
+	unavailable, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(desired), false)
+	if err != nil {
+		return 0, 0, pkgerrors.Wrapf(err, "invalid maxUnavailable of VSphereMachinePool")
+	}
+
+	// The rolling update could not make progress if both values are 0, e.g. when
+	// a percentage is rounded down, so one VM is allowed to be unavailable.
+	if surge == 0 && unavailable == 0 {
+		unavailable = 1
+	}
+	return int32(surge), int32(unavailable), nil //nolint:gosec // The values are scaled from the replicas.
+}
+
+// machinePoolRollout is the set of changes to the VSphereVMs of a VSphereMachinePool.
+type machinePoolRollout struct {
+	// toCreate is the number of VSphereVMs to create.
+	toCreate int32
+	// toDelete are the VSphereVMs to select for deletion.
+	toDelete []*infrav1.VSphereVM
+	// draining is the number of VSphereVMs selected for deletion whose nodes are being drained.
+	draining int32
+}
+
+// computeMachinePoolRollout computes the VSphereVMs to create and delete to move a VSphereMachinePool
+// towards the desired replicas of the current template, without exceeding the desired replicas by more
+// than maxSurge VMs or falling below the desired replicas by more than maxUnavailable ready VMs.
+func computeMachinePoolRollout(vms []infrav1.VSphereVM, templateHash string, desired, maxSurge, maxUnavailable int32) machinePoolRollout {
+	var upToDate, outdated []*infrav1.VSphereVM
+	var available int32
+	for i := range vms {
+		vm := &vms[i]
+		if !vm.DeletionTimestamp.IsZero() || isMachinePoolVSphereVMSelectedForDeletion(vm) {
+			continue
+		}
+		if ptr.Deref(vm.Status.Ready, false) {
+			available++
+		}
+		if vm.Labels[infrav1.MachinePoolTemplateHashLabel] == templateHash {
+			upToDate = append(upToDate, vm)
+		} else {
+			outdated = append(outdated, vm)
+		}
+	}
+	// VSphereVMs which are being drained or deleted still count towards maxSurge, as their VMs exist until the deletion completes.
+	total := int32(len(vms)) //nolint:gosec // The number of VMs is bounded by the replicas.
+
+	rollout := machinePoolRollout{}
+	rollout.toCreate = max(0, min(desired-int32(len(upToDate)), desired+maxSurge-total)) //nolint:gosec // The number of VMs is bounded by the replicas.
+
+	// Delete the VSphereVMs which are not ready first, they do not affect the availability of the pool.
+	sortVSphereVMsForDeletion(upToDate)
+	sortVSphereVMsForDeletion(outdated)
+
+	// Scale down the up-to-date VSphereVMs exceeding the desired replicas.
+	if surplus := len(upToDate) - int(desired); surplus > 0 {
+		for _, vm := range upToDate[:surplus] {
+			rollout.toDelete = append(rollout.toDelete, vm)
+			if ptr.Deref(vm.Status.Ready, false) {
+				available--
+			}
+		}
+	}
+
+	// Replace the outdated VSphereVMs while keeping at least desired-maxUnavailable VMs available.
+	budget := available - (desired - maxUnavailable)
+	for _, vm := range outdated {
+		if ptr.Deref(vm.Status.Ready, false) {
+			if budget <= 0 {
+				break
+			}
+			budget--
+		}
+		rollout.toDelete = append(rollout.toDelete, vm)
+	}
+	return rollout
+}
+
+// sortVSphereVMsForDeletion sorts the VSphereVMs in the order they should be deleted: not ready
+// VSphereVMs first, then the oldest ones.
+func sortVSphereVMsForDeletion(vms []*infrav1.VSphereVM) {
+	slices.SortStableFunc(vms, func(a, b *infrav1.VSphereVM) int {
+		aReady, bReady := ptr.Deref(a.Status.Ready, false), ptr.Deref(b.Status.Ready, false)
+		if aReady != bReady {
+			if !aReady {
+				return -1
+			}
+			return 1
+		}
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// setMachinePoolStatus sets the providerIDList, the replicas and the VirtualMachinesReady condition
+// of the VSphereMachinePool from its VSphereVMs.
+func setMachinePoolStatus(vsphereMachinePool *infrav1.VSphereMachinePool, vms []infrav1.VSphereVM, templateHash string, desired int32, rollout machinePoolRollout) {
+	var replicas, ready, upToDate int32
+	providerIDList := []string{}
+	for _, vm := range vms {
+		if !vm.DeletionTimestamp.IsZero() || isMachinePoolVSphereVMSelectedForDeletion(&vm) {
+			continue
+		}
+		replicas++
+		if ptr.Deref(vm.Status.Ready, false) {
+			ready++
+		}
+		if vm.Labels[infrav1.MachinePoolTemplateHashLabel] == templateHash {
+			upToDate++
+		}
+		if providerID := util.ConvertUUIDToProviderID(vm.Spec.BiosUUID); providerID != "" {
+			providerIDList = append(providerIDList, providerID)
+		}
+	}
+	slices.Sort(providerIDList)
+
+	vsphereMachinePool.Spec.ProviderIDList = providerIDList
+	vsphereMachinePool.Status.Replicas = ptr.To(replicas)
+	vsphereMachinePool.Status.ReadyReplicas = ptr.To(ready)
+	vsphereMachinePool.Status.UpToDateReplicas = ptr.To(upToDate)
+
+	// The pool stays provisioned after its VMs are ready for the first time, the MachinePool
+	// tracks the nodes of the VMs created by scaling and rolling updates afterwards.
+	if ready == desired && upToDate == replicas && replicas == desired {
+		vsphereMachinePool.Status.Initialization.Provisioned = ptr.To(true)
+	}
+
+	message := fmt.Sprintf("%d of %d VSphereVMs ready, %d up-to-date", ready, desired, upToDate)
+	switch {
+	case replicas-upToDate > 0:
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:    infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereMachinePoolVirtualMachinesRollingUpdateReason,
+			Message: message,
+		})
+	case rollout.draining > 0:
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:    infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereMachinePoolVirtualMachinesDrainingNodesReason,
+			Message: fmt.Sprintf("%s, draining the nodes of %d VSphereVMs", message, rollout.draining),
+		})
+	case rollout.toCreate > 0 || replicas < desired:
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:    infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereMachinePoolVirtualMachinesScalingUpReason,
+			Message: message,
+		})
+	case len(rollout.toDelete) > 0 || replicas > desired:
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:    infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereMachinePoolVirtualMachinesScalingDownReason,
+			Message: message,
+		})
+	case ready < desired:
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:    infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereMachinePoolVirtualMachinesNotReadyReason,
+			Message: message,
+		})
+	default:
+		conditions.Set(vsphereMachinePool, metav1.Condition{
+			Type:   infrav1.VSphereMachinePoolVirtualMachinesReadyCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereMachinePoolVirtualMachinesReadyReason,
+		})
+	}
+}
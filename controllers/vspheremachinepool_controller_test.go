@@ -0,0 +1,323 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/controllers/clustercache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func Test_resolveMachinePoolRollingUpdate(t *testing.T) {
+	tests := []struct {
+		name               string
+		rollingUpdate      infrav1.VSphereMachinePoolRollingUpdate
+		desired            int32
+		wantMaxSurge       int32
+		wantMaxUnavailable int32
+	}{
+		{
+			name:               "defaults",
+			desired:            3,
+			wantMaxSurge:       1,
+			wantMaxUnavailable: 0,
+		},
+		{
+			name: "percentages round maxSurge up and maxUnavailable down",
+			rollingUpdate: infrav1.VSphereMachinePoolRollingUpdate{
+				MaxSurge:       ptr.To(intstr.FromString("25%")),
+				MaxUnavailable: ptr.To(intstr.FromString("25%")),
+			},
+			desired:            10,
+			wantMaxSurge:       3,
+			wantMaxUnavailable: 2,
+		},
+		{
+			name: "one VM is allowed to be unavailable if both values are 0",
+			rollingUpdate: infrav1.VSphereMachinePoolRollingUpdate{
+				MaxSurge:       ptr.To(intstr.FromInt32(0)),
+				MaxUnavailable: ptr.To(intstr.FromString("10%")),
+			},
+			desired:            3,
+			wantMaxSurge:       0,
+			wantMaxUnavailable: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			maxSurge, maxUnavailable, err := resolveMachinePoolRollingUpdate(tt.rollingUpdate, tt.desired)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(maxSurge).To(Equal(tt.wantMaxSurge))
+			g.Expect(maxUnavailable).To(Equal(tt.wantMaxUnavailable))
+		})
+	}
+}
+
+func Test_computeMachinePoolRollout(t *testing.T) {
+	now := time.Now()
+	vm := func(name, hash string, ready bool, age time.Duration) infrav1.VSphereVM {
+		return infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				Labels:            map[string]string{infrav1.MachinePoolTemplateHashLabel: hash},
+			},
+			Status: infrav1.VSphereVMStatus{Ready: ptr.To(ready)},
+		}
+	}
+	deleting := func(vm infrav1.VSphereVM) infrav1.VSphereVM {
+		vm.DeletionTimestamp = ptr.To(metav1.NewTime(now))
+		return vm
+	}
+	selected := func(vm infrav1.VSphereVM) infrav1.VSphereVM {
+		vm.Annotations = map[string]string{infrav1.MachinePoolDeleteAnnotation: ""}
+		return vm
+	}
+
+	tests := []struct {
+		name           string
+		vms            []infrav1.VSphereVM
+		desired        int32
+		maxSurge       int32
+		maxUnavailable int32
+		wantCreate     int32
+		wantDelete     []string
+	}{
+		{
+			name:       "scale up from zero",
+			desired:    3,
+			maxSurge:   1,
+			wantCreate: 3,
+		},
+		{
+			name:       "nothing to do",
+			vms:        []infrav1.VSphereVM{vm("a", "new", true, 3), vm("b", "new", true, 2)},
+			desired:    2,
+			maxSurge:   1,
+			wantCreate: 0,
+		},
+		{
+			name:       "scale down deletes not ready VMs first, then the oldest",
+			vms:        []infrav1.VSphereVM{vm("a", "new", true, 3), vm("b", "new", true, 2), vm("c", "new", false, 1), vm("d", "new", true, 4)},
+			desired:    2,
+			maxSurge:   1,
+			wantCreate: 0,
+			wantDelete: []string{"c", "d"},
+		},
+		{
+			name:       "rolling update surges before deleting ready VMs",
+			vms:        []infrav1.VSphereVM{vm("a", "old", true, 3), vm("b", "old", true, 2)},
+			desired:    2,
+			maxSurge:   1,
+			wantCreate: 1,
+		},
+		{
+			name:       "rolling update deletes an old VM once a new VM is ready",
+			vms:        []infrav1.VSphereVM{vm("a", "old", true, 3), vm("b", "old", true, 2), vm("c", "new", true, 1)},
+			desired:    2,
+			maxSurge:   1,
+			wantCreate: 0,
+			wantDelete: []string{"a"},
+		},
+		{
+			name:       "rolling update waits for new VMs to be ready",
+			vms:        []infrav1.VSphereVM{vm("a", "old", true, 3), vm("b", "old", true, 2), vm("c", "new", false, 1)},
+			desired:    2,
+			maxSurge:   1,
+			wantCreate: 0,
+		},
+		{
+			name:       "rolling update deletes old VMs which are not ready",
+			vms:        []infrav1.VSphereVM{vm("a", "old", true, 3), vm("b", "old", false, 2)},
+			desired:    2,
+			maxSurge:   1,
+			wantCreate: 1,
+			wantDelete: []string{"b"},
+		},
+		{
+			name:           "rolling update without surge deletes before creating",
+			vms:            []infrav1.VSphereVM{vm("a", "old", true, 3), vm("b", "old", true, 2), vm("c", "old", true, 1)},
+			desired:        3,
+			maxUnavailable: 1,
+			wantCreate:     0,
+			wantDelete:     []string{"a"},
+		},
+		{
+			name:           "VMs being deleted count towards maxSurge",
+			vms:            []infrav1.VSphereVM{deleting(vm("a", "old", true, 3)), vm("b", "old", true, 2), vm("c", "old", true, 1)},
+			desired:        3,
+			maxUnavailable: 1,
+			wantCreate:     0,
+		},
+		{
+			name:           "VMs selected for deletion count towards maxSurge and are not selected again",
+			vms:            []infrav1.VSphereVM{selected(vm("a", "old", true, 3)), vm("b", "old", true, 2), vm("c", "old", true, 1)},
+			desired:        3,
+			maxUnavailable: 1,
+			wantCreate:     0,
+		},
+		{
+			name:           "VM is created once a deleted VM is gone",
+			vms:            []infrav1.VSphereVM{vm("b", "old", true, 2), vm("c", "old", true, 1)},
+			desired:        3,
+			maxUnavailable: 1,
+			wantCreate:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			rollout := computeMachinePoolRollout(tt.vms, "new", tt.desired, tt.maxSurge, tt.maxUnavailable)
+			g.Expect(rollout.toCreate).To(Equal(tt.wantCreate))
+			deleted := []string{}
+			for _, vm := range rollout.toDelete {
+				deleted = append(deleted, vm.Name)
+			}
+			g.Expect(deleted).To(ConsistOf(tt.wantDelete))
+		})
+	}
+}
+
+func Test_setMachinePoolStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	vsphereMachinePool := &infrav1.VSphereMachinePool{}
+	vms := []infrav1.VSphereVM{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b", Labels: map[string]string{infrav1.MachinePoolTemplateHashLabel: "new"}},
+			Spec:       infrav1.VSphereVMSpec{BiosUUID: "42100000-0000-0000-0000-000000000002"},
+			Status:     infrav1.VSphereVMStatus{Ready: ptr.To(true)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a", Labels: map[string]string{infrav1.MachinePoolTemplateHashLabel: "new"}},
+			Spec:       infrav1.VSphereVMSpec{BiosUUID: "42100000-0000-0000-0000-000000000001"},
+			Status:     infrav1.VSphereVMStatus{Ready: ptr.To(true)},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "c", Labels: map[string]string{infrav1.MachinePoolTemplateHashLabel: "new"}},
+		},
+	}
+
+	setMachinePoolStatus(vsphereMachinePool, vms, "new", 3, machinePoolRollout{})
+	g.Expect(vsphereMachinePool.Spec.ProviderIDList).To(Equal([]string{
+		"vsphere://42100000-0000-0000-0000-000000000001",
+		"vsphere://42100000-0000-0000-0000-000000000002",
+	}))
+	g.Expect(vsphereMachinePool.Status.Replicas).To(Equal(ptr.To[int32](3)))
+	g.Expect(vsphereMachinePool.Status.ReadyReplicas).To(Equal(ptr.To[int32](2)))
+	g.Expect(vsphereMachinePool.Status.UpToDateReplicas).To(Equal(ptr.To[int32](3)))
+	g.Expect(vsphereMachinePool.Status.Initialization.Provisioned).To(BeNil())
+	g.Expect(vsphereMachinePool.Status.Conditions).To(HaveLen(1))
+	g.Expect(vsphereMachinePool.Status.Conditions[0].Reason).To(Equal(infrav1.VSphereMachinePoolVirtualMachinesNotReadyReason))
+
+	vms[2].Status.Ready = ptr.To(true)
+	setMachinePoolStatus(vsphereMachinePool, vms, "new", 3, machinePoolRollout{})
+	g.Expect(vsphereMachinePool.Status.Initialization.Provisioned).To(Equal(ptr.To(true)))
+	g.Expect(vsphereMachinePool.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+
+	// The pool stays provisioned during a rolling update.
+	setMachinePoolStatus(vsphereMachinePool, vms, "newer", 3, machinePoolRollout{toCreate: 1})
+	g.Expect(vsphereMachinePool.Status.Initialization.Provisioned).To(Equal(ptr.To(true)))
+	g.Expect(vsphereMachinePool.Status.Conditions[0].Reason).To(Equal(infrav1.VSphereMachinePoolVirtualMachinesRollingUpdateReason))
+
+	// VSphereVMs selected for deletion are not counted while their nodes are drained.
+	vms[2].Annotations = map[string]string{infrav1.MachinePoolDeleteAnnotation: ""}
+	setMachinePoolStatus(vsphereMachinePool, vms, "new", 2, machinePoolRollout{draining: 1})
+	g.Expect(vsphereMachinePool.Status.Replicas).To(Equal(ptr.To[int32](2)))
+	g.Expect(vsphereMachinePool.Status.Conditions[0].Reason).To(Equal(infrav1.VSphereMachinePoolVirtualMachinesDrainingNodesReason))
+}
+
+func Test_drainNode(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "cluster"}}
+	vm := &infrav1.VSphereVM{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "pool-a"},
+		Status:     infrav1.VSphereVMStatus{Ready: ptr.To(true)},
+	}
+	pod := func(name string, mutate func(*corev1.Pod)) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: name},
+			Spec:       corev1.PodSpec{NodeName: vm.Name},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		if mutate != nil {
+			mutate(pod)
+		}
+		return pod
+	}
+
+	workloadClient := fake.NewClientBuilder().
+		WithObjects(
+			&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: vm.Name}},
+			pod("app", nil),
+			pod("other-node", func(p *corev1.Pod) { p.Spec.NodeName = "pool-b" }),
+			pod("daemonset", func(p *corev1.Pod) {
+				p.OwnerReferences = []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: "DaemonSet", Name: "ds", UID: "ds", Controller: ptr.To(true)}}
+			}),
+			pod("static", func(p *corev1.Pod) { p.Annotations = map[string]string{corev1.MirrorPodAnnotationKey: ""} }),
+			pod("completed", func(p *corev1.Pod) { p.Status.Phase = corev1.PodSucceeded }),
+		).
+		WithIndex(&corev1.Pod{}, "spec.nodeName", func(o client.Object) []string {
+			return []string{o.(*corev1.Pod).Spec.NodeName}
+		}).
+		Build()
+	r := &vsphereMachinePoolReconciler{
+		ClusterCache: clustercache.NewFakeClusterCache(workloadClient, client.ObjectKeyFromObject(cluster)),
+	}
+
+	// The node is cordoned and the pods are evicted.
+	drained, err := r.drainNode(ctx, cluster, vm)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(drained).To(BeFalse())
+	node := &corev1.Node{}
+	g.Expect(workloadClient.Get(ctx, client.ObjectKey{Name: vm.Name}, node)).To(Succeed())
+	g.Expect(node.Spec.Unschedulable).To(BeTrue())
+	err = workloadClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "app"}, &corev1.Pod{})
+	g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	for _, name := range []string{"other-node", "daemonset", "static", "completed"} {
+		g.Expect(workloadClient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: name}, &corev1.Pod{})).To(Succeed())
+	}
+
+	// The node is drained once the evicted pods are gone.
+	drained, err = r.drainNode(ctx, cluster, vm)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(drained).To(BeTrue())
+
+	// VSphereVMs without a node are drained.
+	vm.Name = "pool-c"
+	drained, err = r.drainNode(ctx, cluster, vm)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(drained).To(BeTrue())
+}
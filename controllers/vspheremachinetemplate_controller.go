@@ -0,0 +1,445 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterutilv1 "sigs.k8s.io/cluster-api/util"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/identity"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/template"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+const (
+	// nvidiaVendorID is the PCI vendor ID of NVIDIA devices.
+	nvidiaVendorID = 0x10de
+
+	// defaultNumCPUs and defaultMemoryMiB are the values used by vcenter.Clone
+	// when the corresponding fields are not set in the clone spec.
+	defaultNumCPUs   = 2
+	defaultMemoryMiB = 2048
+
+	// warmPoolRequeueAfter is the interval in which a VSphereMachineTemplate is requeued while
+	// VMs of its warm pool are being cloned.
+	warmPoolRequeueAfter = 20 * time.Second
+)
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheremachinetemplates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheremachinetemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherevms,verbs=get;list;watch
+
+// AddVSphereMachineTemplateControllerToManager adds the VSphereMachineTemplate controller to the provided manager.
+func AddVSphereMachineTemplateControllerToManager(ctx context.Context, controllerManagerCtx *capvcontext.ControllerManagerContext, mgr manager.Manager, options controller.Options) error {
+	r := &vsphereMachineTemplateReconciler{
+		ControllerManagerContext: controllerManagerCtx,
+	}
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "vspheremachinetemplate")
+
+	return capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&infrav1.VSphereMachineTemplate{}).
+		WithOptions(options).
+		// Watch the VSphereVMs cloned from a template, which may adopt a VM of its warm pool.
+		Watches(
+			&infrav1.VSphereVM{},
+			handler.EnqueueRequestsFromMapFunc(vsphereVMToVSphereMachineTemplate),
+		).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(mgr.GetScheme(), predicateLog, controllerManagerCtx.WatchFilterValue)).
+		Complete(ctx, r)
+}
+
+type vsphereMachineTemplateReconciler struct {
+	*capvcontext.ControllerManagerContext
+}
+
+// Reconcile computes the capacity and node info of a VSphereMachineTemplate so cluster-autoscaler
+// is able to scale MachineDeployments using the template from zero, and reconciles the warm pool
+// of the VSphereMachineTemplate.
+func (r *vsphereMachineTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vsphereMachineTemplate := &infrav1.VSphereMachineTemplate{}
+	if err := r.Client.Get(ctx, req.NamespacedName, vsphereMachineTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if !vsphereMachineTemplate.DeletionTimestamp.IsZero() && !controllerutil.ContainsFinalizer(vsphereMachineTemplate, infrav1.MachineTemplateWarmPoolFinalizer) {
+		return reconcile.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(vsphereMachineTemplate, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	defer func() {
+		if err := patchHelper.Patch(ctx, vsphereMachineTemplate); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if !vsphereMachineTemplate.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, vsphereMachineTemplate)
+	}
+
+	spec := vsphereMachineTemplate.Spec.Template.Spec
+	setCapacityFromCloneSpec(vsphereMachineTemplate, spec.VirtualMachineCloneSpec)
+
+	// The finalizer is kept until the warm pool is scaled down to zero.
+	if ptr.Deref(vsphereMachineTemplate.Spec.WarmPool.Size, 0) > 0 {
+		controllerutil.AddFinalizer(vsphereMachineTemplate, infrav1.MachineTemplateWarmPoolFinalizer)
+	}
+	hasWarmPool := controllerutil.ContainsFinalizer(vsphereMachineTemplate, infrav1.MachineTemplateWarmPoolFinalizer)
+
+	vsphereCluster, err := r.getVSphereCluster(ctx, vsphereMachineTemplate)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	server, thumbprint := spec.Server, spec.Thumbprint
+	if vsphereCluster != nil {
+		if server == "" {
+			server = vsphereCluster.Spec.Server
+		}
+		if thumbprint == "" {
+			thumbprint = vsphereCluster.Spec.Thumbprint
+		}
+	}
+	if server == "" || (spec.Template == "" && spec.TemplateSelector == nil && spec.TemplateRef == nil && !hasWarmPool) {
+		log.V(4).Info("Skipping lookup of template information, server or template is not set")
+		return reconcile.Result{}, nil
+	}
+
+	// The template of a VSphereTemplate is looked up and cloned by its instance UUID.
+	cloneSpec := *spec.VirtualMachineCloneSpec.DeepCopy()
+	if spec.TemplateRef != nil {
+		templateUUID, err := govmomi.ResolveTemplateRef(ctx, r.Client, vsphereMachineTemplate.Namespace, *spec.TemplateRef)
+		if err != nil {
+			if errors.Is(err, govmomi.ErrTemplateNotImported) {
+				log.V(4).Info("Skipping lookup of template information, template is not imported yet", "VSphereTemplate", spec.TemplateRef.Name)
+				return reconcile.Result{RequeueAfter: templateImportRequeueAfter}, nil
+			}
+			return reconcile.Result{}, err
+		}
+		cloneSpec.Template = templateUUID
+		cloneSpec.TemplateRef = nil
+	}
+
+	authSession, err := r.retrieveVCenterSession(ctx, vsphereCluster, server, thumbprint, spec.Datacenter)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get vCenter session for VSphereMachineTemplate")
+	}
+
+	// The capacity of content library items is not looked up, as they are not in the inventory.
+	if cloneSpec.Template != "" || (cloneSpec.TemplateSelector != nil && cloneSpec.TemplateSelector.ContentLibrary == "") {
+		if err := r.reconcileTemplateInfo(ctx, authSession, vsphereMachineTemplate, cloneSpec); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if !hasWarmPool {
+		return reconcile.Result{}, nil
+	}
+	return r.reconcileWarmPool(ctx, authSession, vsphereMachineTemplate, cloneSpec)
+}
+
+// reconcileDelete removes the VMs of the warm pool of a deleted VSphereMachineTemplate.
+func (r *vsphereMachineTemplateReconciler) reconcileDelete(ctx context.Context, vsphereMachineTemplate *infrav1.VSphereMachineTemplate) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// The VSphereCluster may be deleted before the VSphereMachineTemplate, in which case
+	// the server of the template and the credentials of the manager are used.
+	vsphereCluster, err := r.getVSphereCluster(ctx, vsphereMachineTemplate)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	spec := vsphereMachineTemplate.Spec.Template.Spec
+	server, thumbprint := spec.Server, spec.Thumbprint
+	if vsphereCluster != nil {
+		if server == "" {
+			server = vsphereCluster.Spec.Server
+		}
+		if thumbprint == "" {
+			thumbprint = vsphereCluster.Spec.Thumbprint
+		}
+	}
+	if server == "" {
+		log.Info("Skipping removal of warm pool, server is not set")
+		controllerutil.RemoveFinalizer(vsphereMachineTemplate, infrav1.MachineTemplateWarmPoolFinalizer)
+		return reconcile.Result{}, nil
+	}
+
+	authSession, err := r.retrieveVCenterSession(ctx, vsphereCluster, server, thumbprint, spec.Datacenter)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get vCenter session for VSphereMachineTemplate")
+	}
+
+	vsphereMachineTemplate.Spec.WarmPool.Size = ptr.To[int32](0)
+	return r.reconcileWarmPool(ctx, authSession, vsphereMachineTemplate, spec.VirtualMachineCloneSpec)
+}
+
+// reconcileTemplateInfo sets the capacity and node info of a VSphereMachineTemplate from the vCenter
+// template the machines are cloned from.
+func (r *vsphereMachineTemplateReconciler) reconcileTemplateInfo(ctx context.Context, authSession *session.Session, vsphereMachineTemplate *infrav1.VSphereMachineTemplate, spec infrav1.VirtualMachineCloneSpec) error {
+	var tpl *object.VirtualMachine
+	var err error
+	if spec.TemplateSelector != nil {
+		tpl, _, err = template.FindTemplateBySelector(ctx, authSession, *spec.TemplateSelector)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "failed to find template by selector")
+		}
+	} else {
+		tpl, err = template.FindTemplate(ctx, authSession, spec.Template)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "failed to find template %q", spec.Template)
+		}
+	}
+	tplInfo, err := template.GetInfo(ctx, tpl)
+	if err != nil {
+		return err
+	}
+
+	setStatusFromTemplateInfo(vsphereMachineTemplate, tplInfo)
+	return nil
+}
+
+// reconcileWarmPool scales the warm pool of a VSphereMachineTemplate to its size and removes the
+// finalizer once the pool is scaled down to zero. The clone tasks of the pool are tracked in the
+// status, because their VMs cannot be found before the clone is completed. The VMs are cloned
+// with the spec.
+func (r *vsphereMachineTemplateReconciler) reconcileWarmPool(ctx context.Context, authSession *session.Session, vsphereMachineTemplate *infrav1.VSphereMachineTemplate, spec infrav1.VirtualMachineCloneSpec) (reconcile.Result, error) {
+	size := ptr.Deref(vsphereMachineTemplate.Spec.WarmPool.Size, 0)
+
+	replicas, pendingTaskRefs, err := vcenter.ReconcileWarmPool(ctx, authSession, vcenter.NewWarmPool(vsphereMachineTemplate, spec), spec, size, vsphereMachineTemplate.Status.WarmPool.PendingTaskRefs)
+	// The clone tasks which were triggered are recorded even if the reconcile failed.
+	vsphereMachineTemplate.Status.WarmPool.PendingTaskRefs = pendingTaskRefs
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to reconcile warm pool")
+	}
+
+	if size == 0 && replicas == 0 && len(pendingTaskRefs) == 0 {
+		vsphereMachineTemplate.Status.WarmPool = infrav1.VSphereMachineWarmPoolStatus{}
+		controllerutil.RemoveFinalizer(vsphereMachineTemplate, infrav1.MachineTemplateWarmPoolFinalizer)
+		return reconcile.Result{}, nil
+	}
+
+	vsphereMachineTemplate.Status.WarmPool = infrav1.VSphereMachineWarmPoolStatus{
+		Replicas:        ptr.To(replicas),
+		PendingReplicas: ptr.To(int32(len(pendingTaskRefs))),
+		PendingTaskRefs: pendingTaskRefs,
+	}
+	if len(pendingTaskRefs) > 0 {
+		return reconcile.Result{RequeueAfter: warmPoolRequeueAfter}, nil
+	}
+	return reconcile.Result{}, nil
+}
+
+// vsphereVMToVSphereMachineTemplate returns a request for the VSphereMachineTemplate the VSphereVM
+// was cloned from.
+func vsphereVMToVSphereMachineTemplate(_ context.Context, o client.Object) []reconcile.Request {
+	name, ok := o.GetAnnotations()[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok {
+		return nil
+	}
+	groupKind := schema.ParseGroupKind(o.GetAnnotations()[clusterv1.TemplateClonedFromGroupKindAnnotation])
+	if groupKind != infrav1.GroupVersion.WithKind("VSphereMachineTemplate").GroupKind() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: name}}}
+}
+
+// getVSphereCluster returns the VSphereCluster of the Cluster the VSphereMachineTemplate belongs to.
+// Returns nil if the VSphereMachineTemplate does not belong to a Cluster, e.g. when it is owned by a ClusterClass.
+func (r *vsphereMachineTemplateReconciler) getVSphereCluster(ctx context.Context, vsphereMachineTemplate *infrav1.VSphereMachineTemplate) (*infrav1.VSphereCluster, error) {
+	var cluster *clusterv1.Cluster
+	var err error
+	if _, ok := vsphereMachineTemplate.Labels[clusterv1.ClusterNameLabel]; ok {
+		cluster, err = clusterutilv1.GetClusterFromMetadata(ctx, r.Client, vsphereMachineTemplate.ObjectMeta)
+	} else {
+		cluster, err = clusterutilv1.GetOwnerCluster(ctx, r.Client, vsphereMachineTemplate.ObjectMeta)
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get Cluster for VSphereMachineTemplate")
+	}
+	if cluster == nil || !cluster.Spec.InfrastructureRef.IsDefined() {
+		return nil, nil
+	}
+
+	vsphereCluster := &infrav1.VSphereCluster{}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.InfrastructureRef.Name}
+	if err := r.Client.Get(ctx, key, vsphereCluster); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get VSphereCluster %s", klog.KRef(key.Namespace, key.Name))
+	}
+	return vsphereCluster, nil
+}
+
+func (r *vsphereMachineTemplateReconciler) retrieveVCenterSession(ctx context.Context, vsphereCluster *infrav1.VSphereCluster, server, thumbprint, datacenter string) (*session.Session, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	params := session.NewParams().
+		WithServer(server).
+		WithDatacenter(datacenter).
+		WithUserInfo(r.ControllerManagerContext.Username, r.ControllerManagerContext.Password).
+		WithThumbprint(thumbprint)
+
+	if vsphereCluster != nil && thumbprint == "" {
+		caBundle, err := identity.GetCABundle(ctx, r.Client, vsphereCluster, r.ControllerManagerContext.Namespace)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "failed to get CA bundle")
+		}
+		params = params.WithCABundle(caBundle).
+			WithInsecure(ptr.Deref(vsphereCluster.Spec.Insecure, false))
+	}
+
+	if vsphereCluster != nil && vsphereCluster.Spec.IdentityRef.IsDefined() {
+		creds, err := identity.GetCredentials(ctx, r.Client, vsphereCluster, r.ControllerManagerContext.Namespace)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "failed to get credentials from IdentityRef")
+		}
+		params = params.WithUserInfo(creds.Username, creds.Password)
+		return session.GetOrCreate(ctx, params)
+	}
+
+	// Fallback to using credentials provided to the manager
+	log.V(4).Info("Using credentials provided to the manager to create the authenticated session")
+	return session.GetOrCreate(ctx, params)
+}
+
+// setCapacityFromCloneSpec sets the capacity of a VSphereMachineTemplate which can be derived from the clone spec.
+// NOTE: The CPU and memory defaults match the ones applied by vcenter.Clone.
+func setCapacityFromCloneSpec(vsphereMachineTemplate *infrav1.VSphereMachineTemplate, spec infrav1.VirtualMachineCloneSpec) {
+	if vsphereMachineTemplate.Status.Capacity == nil {
+		vsphereMachineTemplate.Status.Capacity = corev1.ResourceList{}
+	}
+	capacity := vsphereMachineTemplate.Status.Capacity
+
+	numCPUs := int64(spec.NumCPUs)
+	if numCPUs < defaultNumCPUs {
+		numCPUs = defaultNumCPUs
+	}
+	capacity[infrav1.VSphereResourceCPU] = *resource.NewQuantity(numCPUs, resource.DecimalSI)
+
+	memoryMiB := spec.MemoryMiB
+	if memoryMiB == 0 {
+		memoryMiB = defaultMemoryMiB
+	}
+	capacity[infrav1.VSphereResourceMemory] = *resource.NewQuantity(memoryMiB*1024*1024, resource.BinarySI)
+
+	if gpus := countGPUs(spec.PciDevices); gpus > 0 {
+		capacity[infrav1.VSphereResourceNvidiaGPU] = *resource.NewQuantity(gpus, resource.DecimalSI)
+	} else {
+		delete(capacity, infrav1.VSphereResourceNvidiaGPU)
+	}
+
+	switch spec.OS {
+	case infrav1.Windows:
+		vsphereMachineTemplate.Status.NodeInfo.OperatingSystem = infrav1.OperatingSystemWindows
+	case infrav1.Linux:
+		vsphereMachineTemplate.Status.NodeInfo.OperatingSystem = infrav1.OperatingSystemLinux
+	}
+}
+
+// setStatusFromTemplateInfo sets the capacity and node info of a VSphereMachineTemplate which depend
+// on the vCenter template the machines are cloned from.
+func setStatusFromTemplateInfo(vsphereMachineTemplate *infrav1.VSphereMachineTemplate, tplInfo *template.Info) {
+	spec := vsphereMachineTemplate.Spec.Template.Spec
+
+	// The disk of a linked clone cannot be expanded, so the template's disk size is used.
+	diskGiB := tplInfo.DiskGiB
+	if spec.CloneMode == infrav1.FullClone && spec.DiskGiB > diskGiB {
+		diskGiB = spec.DiskGiB
+	}
+	if diskGiB > 0 {
+		vsphereMachineTemplate.Status.Capacity[infrav1.VSphereResourceEphemeralStorage] = *resource.NewQuantity(int64(diskGiB)*1024*1024*1024, resource.BinarySI)
+	}
+
+	if spec.OS == "" {
+		vsphereMachineTemplate.Status.NodeInfo.OperatingSystem = operatingSystemFromGuestID(tplInfo.GuestID)
+	}
+	if arch := architectureFromGuestID(tplInfo.GuestID); arch != "" {
+		vsphereMachineTemplate.Status.NodeInfo.Architecture = arch
+	}
+}
+
+// countGPUs returns the number of PCI devices which are NVIDIA GPUs, either configured
+// as vGPU or as PCI passthrough device.
+func countGPUs(pciDevices []infrav1.PCIDeviceSpec) int64 {
+	var gpus int64
+	for _, pciDevice := range pciDevices {
+		if pciDevice.VGPUProfile != "" || (pciDevice.VendorID != nil && *pciDevice.VendorID == nvidiaVendorID) {
+			gpus++
+		}
+	}
+	return gpus
+}
+
+// operatingSystemFromGuestID returns the operating system for a vSphere guest ID, e.g. windows2019srv_64Guest.
+func operatingSystemFromGuestID(guestID string) infrav1.OperatingSystem {
+	if guestID == "" {
+		return ""
+	}
+	if strings.HasPrefix(strings.ToLower(guestID), "win") {
+		return infrav1.OperatingSystemWindows
+	}
+	return infrav1.OperatingSystemLinux
+}
+
+// architectureFromGuestID returns the architecture for a vSphere guest ID, e.g. ubuntu64Guest or arm-ubuntu64Guest.
+// Returns an empty string for 32-bit guests or if the architecture cannot be determined.
+func architectureFromGuestID(guestID string) infrav1.Architecture {
+	switch {
+	case strings.HasPrefix(guestID, "arm-"):
+		return infrav1.ArchitectureArm64
+	case strings.HasSuffix(guestID, "64Guest"):
+		return infrav1.ArchitectureAmd64
+	default:
+		return ""
+	}
+}
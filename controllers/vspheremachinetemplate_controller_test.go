@@ -0,0 +1,258 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/simulator"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/template"
+)
+
+func Test_setCapacityFromCloneSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		spec       infrav1.VirtualMachineCloneSpec
+		wantCPU    string
+		wantMemory string
+		wantGPUs   string
+		wantOS     infrav1.OperatingSystem
+	}{
+		{
+			name:       "defaults are applied",
+			spec:       infrav1.VirtualMachineCloneSpec{},
+			wantCPU:    "2",
+			wantMemory: "2Gi",
+		},
+		{
+			name: "uses spec values",
+			spec: infrav1.VirtualMachineCloneSpec{
+				NumCPUs:   8,
+				MemoryMiB: 16384,
+				OS:        infrav1.Windows,
+			},
+			wantCPU:    "8",
+			wantMemory: "16Gi",
+			wantOS:     infrav1.OperatingSystemWindows,
+		},
+		{
+			name: "counts vGPU and NVIDIA passthrough devices",
+			spec: infrav1.VirtualMachineCloneSpec{
+				NumCPUs:   4,
+				MemoryMiB: 4096,
+				OS:        infrav1.Linux,
+				PciDevices: []infrav1.PCIDeviceSpec{
+					{VGPUProfile: "grid_t4-4q"},
+					{DeviceID: ptr.To[int32](0x1eb8), VendorID: ptr.To[int32](0x10de)},
+					{DeviceID: ptr.To[int32](0x1234), VendorID: ptr.To[int32](0x8086)},
+				},
+			},
+			wantCPU:    "4",
+			wantMemory: "4Gi",
+			wantGPUs:   "2",
+			wantOS:     infrav1.OperatingSystemLinux,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			vsphereMachineTemplate := &infrav1.VSphereMachineTemplate{}
+			setCapacityFromCloneSpec(vsphereMachineTemplate, tt.spec)
+
+			capacity := vsphereMachineTemplate.Status.Capacity
+			g.Expect(capacity).To(HaveKeyWithValue(infrav1.VSphereResourceCPU, resource.MustParse(tt.wantCPU)))
+			g.Expect(capacity).To(HaveKeyWithValue(infrav1.VSphereResourceMemory, resource.MustParse(tt.wantMemory)))
+			if tt.wantGPUs != "" {
+				g.Expect(capacity).To(HaveKeyWithValue(infrav1.VSphereResourceNvidiaGPU, resource.MustParse(tt.wantGPUs)))
+			} else {
+				g.Expect(capacity).ToNot(HaveKey(infrav1.VSphereResourceNvidiaGPU))
+			}
+			g.Expect(vsphereMachineTemplate.Status.NodeInfo.OperatingSystem).To(Equal(tt.wantOS))
+		})
+	}
+}
+
+func Test_setStatusFromTemplateInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        infrav1.VirtualMachineCloneSpec
+		info        *template.Info
+		wantStorage string
+		wantOS      infrav1.OperatingSystem
+		wantArch    infrav1.Architecture
+	}{
+		{
+			name:        "linked clone uses the template disk size",
+			spec:        infrav1.VirtualMachineCloneSpec{CloneMode: infrav1.LinkedClone, DiskGiB: 100},
+			info:        &template.Info{GuestID: "ubuntu64Guest", DiskGiB: 20},
+			wantStorage: "20Gi",
+			wantOS:      infrav1.OperatingSystemLinux,
+			wantArch:    infrav1.ArchitectureAmd64,
+		},
+		{
+			name:        "full clone uses the larger spec disk size",
+			spec:        infrav1.VirtualMachineCloneSpec{CloneMode: infrav1.FullClone, DiskGiB: 100},
+			info:        &template.Info{GuestID: "windows2019srv_64Guest", DiskGiB: 20},
+			wantStorage: "100Gi",
+			wantOS:      infrav1.OperatingSystemWindows,
+			wantArch:    infrav1.ArchitectureAmd64,
+		},
+		{
+			name:        "spec OS takes precedence over the guest ID",
+			spec:        infrav1.VirtualMachineCloneSpec{OS: infrav1.Linux},
+			info:        &template.Info{GuestID: "arm-ubuntu64Guest", DiskGiB: 20},
+			wantStorage: "20Gi",
+			wantArch:    infrav1.ArchitectureArm64,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			vsphereMachineTemplate := &infrav1.VSphereMachineTemplate{}
+			vsphereMachineTemplate.Spec.Template.Spec.VirtualMachineCloneSpec = tt.spec
+			setCapacityFromCloneSpec(vsphereMachineTemplate, tt.spec)
+			setStatusFromTemplateInfo(vsphereMachineTemplate, tt.info)
+
+			g.Expect(vsphereMachineTemplate.Status.Capacity).To(HaveKeyWithValue(infrav1.VSphereResourceEphemeralStorage, resource.MustParse(tt.wantStorage)))
+			if tt.wantOS != "" {
+				g.Expect(vsphereMachineTemplate.Status.NodeInfo.OperatingSystem).To(Equal(tt.wantOS))
+			}
+			g.Expect(vsphereMachineTemplate.Status.NodeInfo.Architecture).To(Equal(tt.wantArch))
+		})
+	}
+}
+
+func Test_architectureFromGuestID(t *testing.T) {
+	tests := []struct {
+		guestID string
+		want    infrav1.Architecture
+	}{
+		{guestID: "ubuntu64Guest", want: infrav1.ArchitectureAmd64},
+		{guestID: "vmwarePhoton64Guest", want: infrav1.ArchitectureAmd64},
+		{guestID: "arm-ubuntu64Guest", want: infrav1.ArchitectureArm64},
+		{guestID: "otherGuest", want: ""},
+		{guestID: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.guestID, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(architectureFromGuestID(tt.guestID)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestVSphereMachineTemplateReconciler_Reconcile(t *testing.T) {
+	g := NewWithT(t)
+
+	simr, err := vcsim.NewBuilder().WithModel(simulator.VPX()).Build()
+	g.Expect(err).ToNot(HaveOccurred())
+	defer simr.Destroy()
+
+	vsphereMachineTemplate := &infrav1.VSphereMachineTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "vsphere-machine-template",
+			Namespace: "test",
+		},
+		Spec: infrav1.VSphereMachineTemplateSpec{
+			Template: infrav1.VSphereMachineTemplateResource{
+				Spec: infrav1.VSphereMachineSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						Server:     simr.ServerURL().Host,
+						Thumbprint: simr.Thumbprint(),
+						Datacenter: "DC0",
+						Template:   "DC0_H0_VM0",
+						CloneMode:  infrav1.LinkedClone,
+						NumCPUs:    4,
+						MemoryMiB:  8192,
+					},
+				},
+			},
+		},
+	}
+
+	controllerManagerCtx := fake.NewControllerManagerContext(vsphereMachineTemplate)
+	controllerManagerCtx.Username = simr.Username()
+	controllerManagerCtx.Password = simr.Password()
+	r := vsphereMachineTemplateReconciler{ControllerManagerContext: controllerManagerCtx}
+
+	_, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: util.ObjectKey(vsphereMachineTemplate)})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(r.Client.Get(ctx, util.ObjectKey(vsphereMachineTemplate), vsphereMachineTemplate)).To(Succeed())
+	capacity := vsphereMachineTemplate.Status.Capacity
+	g.Expect(capacity).To(HaveKeyWithValue(infrav1.VSphereResourceCPU, resource.MustParse("4")))
+	g.Expect(capacity).To(HaveKeyWithValue(infrav1.VSphereResourceMemory, resource.MustParse("8Gi")))
+	g.Expect(capacity).To(HaveKey(infrav1.VSphereResourceEphemeralStorage))
+	g.Expect(vsphereMachineTemplate.Status.NodeInfo.OperatingSystem).To(Equal(infrav1.OperatingSystemLinux))
+}
+
+func Test_vsphereVMToVSphereMachineTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []reconcile.Request
+	}{
+		{
+			name: "VSphereVM cloned from a VSphereMachineTemplate",
+			annotations: map[string]string{
+				clusterv1.TemplateClonedFromNameAnnotation:      "md-0",
+				clusterv1.TemplateClonedFromGroupKindAnnotation: "VSphereMachineTemplate.infrastructure.cluster.x-k8s.io",
+			},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "test", Name: "md-0"}}},
+		},
+		{
+			name: "VSphereVM cloned from another kind",
+			annotations: map[string]string{
+				clusterv1.TemplateClonedFromNameAnnotation:      "md-0",
+				clusterv1.TemplateClonedFromGroupKindAnnotation: "VSphereMachineTemplate.vmware.infrastructure.cluster.x-k8s.io",
+			},
+		},
+		{
+			name: "VSphereVM not cloned from a template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			vsphereVM := &infrav1.VSphereVM{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "vsphere-vm",
+					Namespace:   "test",
+					Annotations: tt.annotations,
+				},
+			}
+			g.Expect(vsphereVMToVSphereMachineTemplate(context.Background(), vsphereVM)).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterutilv1 "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/finalizers"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+// templateImportRequeueAfter is the interval in which a VSphereTemplate is requeued while its
+// template is being imported.
+const templateImportRequeueAfter = 30 * time.Second
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheretemplates,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspheretemplates/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vsphereclusters,verbs=get;list;watch
+
+// AddVSphereTemplateControllerToManager adds the VSphereTemplate controller to the provided manager.
+func AddVSphereTemplateControllerToManager(ctx context.Context, controllerManagerCtx *capvcontext.ControllerManagerContext, mgr manager.Manager, options controller.Options) error {
+	r := &vsphereTemplateReconciler{
+		ControllerManagerContext: controllerManagerCtx,
+		imports:                  map[types.NamespacedName]*templateImport{},
+	}
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "vspheretemplate")
+
+	return capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&infrav1.VSphereTemplate{}).
+		WithOptions(options).
+		// The template is imported once the VSphereCluster used to connect to vCenter exists.
+		Watches(
+			&infrav1.VSphereCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.vsphereClusterToTemplates),
+		).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, controllerManagerCtx.WatchFilterValue)).
+		Complete(ctx, r)
+}
+
+type vsphereTemplateReconciler struct {
+	*capvcontext.ControllerManagerContext
+
+	// imports are the imports of templates which are still running or whose result has not been
+	// recorded in the status of the VSphereTemplate yet, keyed by the VSphereTemplate.
+	importsLock sync.Mutex
+	imports     map[types.NamespacedName]*templateImport
+}
+
+// templateImport is the import of a template, which runs in the background as downloading and
+// uploading an OVA takes longer than a reconcile should.
+type templateImport struct {
+	cancel context.CancelFunc
+
+	done          bool
+	instanceUUID  string
+	inventoryPath string
+	err           error
+}
+
+// Reconcile imports the OVA or OVF of a VSphereTemplate into vCenter as a template.
+func (r *vsphereTemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vsphereTemplate := &infrav1.VSphereTemplate{}
+	if err := r.Client.Get(ctx, req.NamespacedName, vsphereTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log = log.WithValues("VSphereCluster", klog.KRef(vsphereTemplate.Namespace, vsphereTemplate.Spec.ClusterName))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// The VSphereCluster is nil if it does not exist.
+	vsphereCluster := &infrav1.VSphereCluster{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: vsphereTemplate.Namespace, Name: vsphereTemplate.Spec.ClusterName}, vsphereCluster); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereCluster for VSphereTemplate")
+		}
+		vsphereCluster = nil
+	}
+
+	// Add finalizer first if not set to avoid the race condition between init and delete.
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, vsphereTemplate, infrav1.TemplateFinalizer); err != nil || finalizerAdded {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(vsphereTemplate, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The VSphereTemplate is paused with the Cluster of its VSphereCluster.
+	if vsphereCluster != nil && vsphereCluster.Labels[clusterv1.ClusterNameLabel] != "" {
+		cluster, err := clusterutilv1.GetClusterFromMetadata(ctx, r.Client, vsphereCluster.ObjectMeta)
+		if err != nil {
+			return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get Cluster for VSphereTemplate")
+		}
+		if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, vsphereTemplate); err != nil || isPaused || requeue {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Always patch the VSphereTemplate object.
+	defer func() {
+		if err := conditions.SetSummaryCondition(vsphereTemplate, vsphereTemplate, infrav1.VSphereTemplateReadyCondition,
+			conditions.ForConditionTypes{
+				infrav1.VSphereTemplateTemplateImportedCondition,
+			},
+			// Using a custom merge strategy to override reasons applied during merge.
+			conditions.CustomMergeStrategy{
+				MergeStrategy: conditions.DefaultMergeStrategy(
+					// Use custom reasons.
+					conditions.ComputeReasonFunc(conditions.GetDefaultComputeMergeReasonFunc(
+						infrav1.VSphereTemplateNotReadyReason,
+						infrav1.VSphereTemplateReadyUnknownReason,
+						infrav1.VSphereTemplateReadyReason,
+					)),
+				),
+			},
+		); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, pkgerrors.Wrapf(err, "failed to set %s condition", infrav1.VSphereTemplateReadyCondition)})
+			return
+		}
+
+		if err := patchHelper.Patch(ctx, vsphereTemplate, patch.WithOwnedConditions{Conditions: []string{
+			clusterv1.PausedCondition,
+			infrav1.VSphereTemplateReadyCondition,
+			infrav1.VSphereTemplateTemplateImportedCondition,
+		}}); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if !vsphereTemplate.DeletionTimestamp.IsZero() {
+		return r.reconcileDelete(ctx, vsphereTemplate, vsphereCluster)
+	}
+
+	return r.reconcileNormal(ctx, vsphereTemplate, vsphereCluster)
+}
+
+// reconcileDelete cancels a running import, removes the template from vCenter if the deletion
+// policy is Delete, and removes the finalizer.
+func (r *vsphereTemplateReconciler) reconcileDelete(ctx context.Context, vsphereTemplate *infrav1.VSphereTemplate, vsphereCluster *infrav1.VSphereCluster) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	conditions.Set(vsphereTemplate, metav1.Condition{
+		Type:   infrav1.VSphereTemplateTemplateImportedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: infrav1.VSphereTemplateTemplateDeletingReason,
+	})
+
+	// Wait for a running import to be cancelled, so it does not leave a template behind.
+	key := client.ObjectKeyFromObject(vsphereTemplate)
+	r.importsLock.Lock()
+	imp, ok := r.imports[key]
+	if ok && !imp.done {
+		imp.cancel()
+		r.importsLock.Unlock()
+		log.Info("Waiting for the import of the template to be cancelled")
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, nil
+	}
+	if ok && imp.done && vsphereTemplate.Status.InstanceUUID == "" {
+		vsphereTemplate.Status.InstanceUUID = imp.instanceUUID
+	}
+	delete(r.imports, key)
+	r.importsLock.Unlock()
+
+	if vsphereTemplate.Spec.DeletionPolicy == infrav1.VSphereTemplateDeletionPolicyDelete && vsphereTemplate.Status.InstanceUUID != "" {
+		if vsphereCluster == nil {
+			log.Info("Retaining template in vCenter as the VSphereCluster does not exist", "instanceUUID", vsphereTemplate.Status.InstanceUUID)
+		} else {
+			authSession, err := r.retrieveVCenterSession(ctx, vsphereTemplate, vsphereCluster)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			log.Info("Deleting template from vCenter", "instanceUUID", vsphereTemplate.Status.InstanceUUID)
+			if err := vcenter.DeleteTemplate(ctx, authSession, vsphereTemplate.Status.InstanceUUID); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	ctrlutil.RemoveFinalizer(vsphereTemplate, infrav1.TemplateFinalizer)
+	return reconcile.Result{}, nil
+}
+
+// reconcileNormal imports the template in the background, records the result of the import,
+// and verifies that an imported template still exists.
+func (r *vsphereTemplateReconciler) reconcileNormal(ctx context.Context, vsphereTemplate *infrav1.VSphereTemplate, vsphereCluster *infrav1.VSphereCluster) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if vsphereCluster == nil {
+		log.Info("Waiting for the VSphereCluster to exist")
+		conditions.Set(vsphereTemplate, metav1.Condition{
+			Type:   infrav1.VSphereTemplateTemplateImportedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereTemplateWaitingForVSphereClusterReason,
+		})
+		return reconcile.Result{}, nil
+	}
+
+	authSession, err := r.retrieveVCenterSession(ctx, vsphereTemplate, vsphereCluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The template is not imported again once it was imported, as VMs may be linked clones of it.
+	if instanceUUID := vsphereTemplate.Status.InstanceUUID; instanceUUID != "" {
+		ref, err := authSession.FindByInstanceUUID(ctx, instanceUUID)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if ref == nil {
+			conditions.Set(vsphereTemplate, metav1.Condition{
+				Type:    infrav1.VSphereTemplateTemplateImportedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereTemplateTemplateNotFoundReason,
+				Message: fmt.Sprintf("template %s does not exist anymore", instanceUUID),
+			})
+			return reconcile.Result{}, nil
+		}
+		conditions.Set(vsphereTemplate, metav1.Condition{
+			Type:   infrav1.VSphereTemplateTemplateImportedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereTemplateTemplateImportedReason,
+		})
+		return reconcile.Result{}, nil
+	}
+
+	key := client.ObjectKeyFromObject(vsphereTemplate)
+	r.importsLock.Lock()
+	defer r.importsLock.Unlock()
+
+	imp, ok := r.imports[key]
+	if !ok {
+		imp = r.startImport(ctx, key, authSession, vsphereTemplate)
+		r.imports[key] = imp
+	}
+	if !imp.done {
+		conditions.Set(vsphereTemplate, metav1.Condition{
+			Type:    infrav1.VSphereTemplateTemplateImportedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereTemplateTemplateImportingReason,
+			Message: fmt.Sprintf("importing %s", vsphereTemplate.Spec.Source.URL),
+		})
+		return reconcile.Result{RequeueAfter: templateImportRequeueAfter}, nil
+	}
+	delete(r.imports, key)
+
+	if imp.err != nil {
+		// The import is not retried if the checksum does not match, as it is unlikely to match
+		// when the OVA or OVF is downloaded again.
+		if errors.Is(imp.err, vcenter.ErrChecksumMismatch) {
+			conditions.Set(vsphereTemplate, metav1.Condition{
+				Type:    infrav1.VSphereTemplateTemplateImportedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereTemplateChecksumMismatchReason,
+				Message: imp.err.Error(),
+			})
+			return reconcile.Result{}, nil
+		}
+		conditions.Set(vsphereTemplate, metav1.Condition{
+			Type:    infrav1.VSphereTemplateTemplateImportedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereTemplateTemplateImportFailedReason,
+			Message: imp.err.Error(),
+		})
+		return reconcile.Result{}, imp.err
+	}
+
+	log.Info("Imported template", "instanceUUID", imp.instanceUUID, "inventoryPath", imp.inventoryPath)
+	vsphereTemplate.Status.InstanceUUID = imp.instanceUUID
+	vsphereTemplate.Status.InventoryPath = imp.inventoryPath
+	conditions.Set(vsphereTemplate, metav1.Condition{
+		Type:   infrav1.VSphereTemplateTemplateImportedCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereTemplateTemplateImportedReason,
+	})
+	return reconcile.Result{}, nil
+}
+
+// startImport starts the import of the template in the background. The import is not cancelled
+// with the context of the reconcile, but when the VSphereTemplate is deleted.
+// NOTE: It must be called with the importsLock held.
+func (r *vsphereTemplateReconciler) startImport(ctx context.Context, key types.NamespacedName, authSession *session.Session, vsphereTemplate *infrav1.VSphereTemplate) *templateImport {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	imp := &templateImport{cancel: cancel}
+
+	templateName := vsphereTemplate.Spec.TemplateName
+	if templateName == "" {
+		templateName = vsphereTemplate.Name
+	}
+	spec := vcenter.TemplateImport{
+		Name:         templateName,
+		Source:       vsphereTemplate.Spec.Source,
+		Datacenter:   vsphereTemplate.Spec.Datacenter,
+		Datastore:    vsphereTemplate.Spec.Datastore,
+		Folder:       vsphereTemplate.Spec.Folder,
+		ResourcePool: vsphereTemplate.Spec.ResourcePool,
+		Network:      vsphereTemplate.Spec.Network,
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Importing template", "url", spec.Source.URL, "templateName", templateName)
+	go func() {
+		defer cancel()
+		instanceUUID, inventoryPath, err := vcenter.ImportTemplate(ctx, authSession, spec)
+
+		r.importsLock.Lock()
+		defer r.importsLock.Unlock()
+		imp.done = true
+		imp.instanceUUID = instanceUUID
+		imp.inventoryPath = inventoryPath
+		imp.err = err
+	}()
+	return imp
+}
+
+// retrieveVCenterSession returns a session to the vCenter of the VSphereCluster.
+func (r *vsphereTemplateReconciler) retrieveVCenterSession(ctx context.Context, vsphereTemplate *infrav1.VSphereTemplate, vsphereCluster *infrav1.VSphereCluster) (*session.Session, error) {
+	authSession, err := (&vsphereMachineTemplateReconciler{ControllerManagerContext: r.ControllerManagerContext}).
+		retrieveVCenterSession(ctx, vsphereCluster, vsphereCluster.Spec.Server, vsphereCluster.Spec.Thumbprint, vsphereTemplate.Spec.Datacenter)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get vCenter session for VSphereCluster %s", klog.KObj(vsphereCluster))
+	}
+	return authSession, nil
+}
+
+// vsphereClusterToTemplates maps a VSphereCluster to the VSphereTemplates which use it.
+func (r *vsphereTemplateReconciler) vsphereClusterToTemplates(ctx context.Context, a client.Object) []reconcile.Request {
+	templateList := &infrav1.VSphereTemplateList{}
+	if err := r.Client.List(ctx, templateList, client.InNamespace(a.GetNamespace())); err != nil {
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, vsphereTemplate := range templateList.Items {
+		if vsphereTemplate.Spec.ClusterName == a.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&vsphereTemplate)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
+)
+
+const testTemplateOVF = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData" xmlns:vssd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData">
+  <References/>
+  <VirtualSystem ovf:id="node">
+    <Info>A virtual machine</Info>
+    <VirtualHardwareSection>
+      <Info>Virtual hardware requirements</Info>
+      <System>
+        <vssd:InstanceID>0</vssd:InstanceID>
+        <vssd:VirtualSystemType>vmx-13</vssd:VirtualSystemType>
+      </System>
+      <Item>
+        <rasd:ElementName>2 virtual CPU(s)</rasd:ElementName>
+        <rasd:InstanceID>1</rasd:InstanceID>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:VirtualQuantity>2</rasd:VirtualQuantity>
+      </Item>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+func TestVSphereTemplateReconciler_Reconcile(t *testing.T) {
+	simr, err := vcsim.NewBuilder().Build()
+	if err != nil {
+		t.Fatalf("unable to create simulator %s", err)
+	}
+	defer simr.Destroy()
+
+	vimClient, err := govmomi.NewClient(ctx, simr.ServerURL(), true)
+	if err != nil {
+		t.Fatalf("unable to create vSphere client %s", err)
+	}
+	finder := find.NewFinder(vimClient.Client)
+
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testTemplateOVF))
+	}))
+	defer imageServer.Close()
+	sum := sha256.Sum256([]byte(testTemplateOVF))
+
+	vsphereCluster := &infrav1.VSphereCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "vsphere-cluster", Namespace: "test"},
+		Spec: infrav1.VSphereClusterSpec{
+			Server:   simr.ServerURL().Host,
+			Insecure: ptr.To(true),
+		},
+	}
+	newTemplate := func(name string) *infrav1.VSphereTemplate {
+		return &infrav1.VSphereTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: infrav1.VSphereTemplateSpec{
+				ClusterName: vsphereCluster.Name,
+				Source: infrav1.VSphereTemplateSource{
+					URL: imageServer.URL + "/node.ovf",
+					Checksum: infrav1.VSphereTemplateChecksum{
+						Algorithm: infrav1.VSphereTemplateChecksumAlgorithmSHA256,
+						Value:     hex.EncodeToString(sum[:]),
+					},
+				},
+				Datastore:    "LocalDS_0",
+				ResourcePool: "/DC0/host/DC0_C0/Resources",
+			},
+		}
+	}
+
+	newReconciler := func(objs ...client.Object) *vsphereTemplateReconciler {
+		controllerManagerContext := fake.NewControllerManagerContext(objs...)
+		controllerManagerContext.Username = simr.ServerURL().User.Username()
+		controllerManagerContext.Password, _ = simr.ServerURL().User.Password()
+		return &vsphereTemplateReconciler{
+			ControllerManagerContext: controllerManagerContext,
+			imports:                  map[types.NamespacedName]*templateImport{},
+		}
+	}
+	// reconcile reconciles the VSphereTemplate until its template is no longer being imported.
+	reconcile := func(g *WithT, r *vsphereTemplateReconciler, vsphereTemplate *infrav1.VSphereTemplate) {
+		g.Eventually(func(g Gomega) {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vsphereTemplate)})
+			g.Expect(err).ToNot(HaveOccurred())
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(vsphereTemplate), vsphereTemplate); apierrors.IsNotFound(err) {
+				return
+			}
+			g.Expect(conditions.Has(vsphereTemplate, infrav1.VSphereTemplateTemplateImportedCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(vsphereTemplate, infrav1.VSphereTemplateTemplateImportedCondition)).ToNot(Equal(infrav1.VSphereTemplateTemplateImportingReason))
+		}).WithTimeout(10 * time.Second).WithPolling(100 * time.Millisecond).Should(Succeed())
+	}
+
+	t.Run("waiting for the VSphereCluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereTemplate := newTemplate("node")
+		r := newReconciler(vsphereTemplate)
+		reconcile(g, r, vsphereTemplate)
+		g.Expect(vsphereTemplate.Finalizers).To(ContainElement(infrav1.TemplateFinalizer))
+		g.Expect(conditions.GetReason(vsphereTemplate, infrav1.VSphereTemplateTemplateImportedCondition)).To(Equal(infrav1.VSphereTemplateWaitingForVSphereClusterReason))
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereTemplate := newTemplate("node-mismatch")
+		vsphereTemplate.Spec.Source.Checksum.Value = hex.EncodeToString(make([]byte, sha256.Size))
+		r := newReconciler(vsphereCluster, vsphereTemplate)
+		reconcile(g, r, vsphereTemplate)
+		g.Expect(vsphereTemplate.Status.InstanceUUID).To(BeEmpty())
+		g.Expect(conditions.IsFalse(vsphereTemplate, infrav1.VSphereTemplateReadyCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(vsphereTemplate, infrav1.VSphereTemplateTemplateImportedCondition)).To(Equal(infrav1.VSphereTemplateChecksumMismatchReason))
+	})
+
+	t.Run("import and delete a template", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereTemplate := newTemplate("node")
+		vsphereTemplate.Spec.DeletionPolicy = infrav1.VSphereTemplateDeletionPolicyDelete
+		r := newReconciler(vsphereCluster, vsphereTemplate)
+		reconcile(g, r, vsphereTemplate)
+		g.Expect(vsphereTemplate.Status.InstanceUUID).ToNot(BeEmpty())
+		g.Expect(vsphereTemplate.Status.InventoryPath).ToNot(BeEmpty())
+		g.Expect(conditions.IsTrue(vsphereTemplate, infrav1.VSphereTemplateReadyCondition)).To(BeTrue())
+
+		tpl, err := finder.VirtualMachine(ctx, vsphereTemplate.Status.InventoryPath)
+		g.Expect(err).ToNot(HaveOccurred())
+		isTemplate, err := tpl.IsTemplate(ctx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isTemplate).To(BeTrue())
+
+		g.Expect(r.Client.Delete(ctx, vsphereTemplate)).To(Succeed())
+		reconcile(g, r, vsphereTemplate)
+		err = r.Client.Get(ctx, client.ObjectKeyFromObject(vsphereTemplate), vsphereTemplate)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		_, err = finder.VirtualMachine(ctx, vsphereTemplate.Status.InventoryPath)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
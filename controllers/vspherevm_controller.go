@@ -120,6 +120,21 @@ func AddVMControllerToManager(ctx context.Context, controllerManagerCtx *capvcon
 			&ipamv1.IPAddressClaim{},
 			handler.EnqueueRequestsFromMapFunc(r.ipAddressClaimToVSphereVM),
 		).
+		// Watch the Machines to remove the bootstrap data from the VM once the Machine has a node.
+		Watches(
+			&clusterv1.Machine{},
+			handler.EnqueueRequestsFromMapFunc(r.machineToVSphereVM),
+			predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					oldMachine := e.ObjectOld.(*clusterv1.Machine)
+					newMachine := e.ObjectNew.(*clusterv1.Machine)
+					return !oldMachine.Status.NodeRef.IsDefined() && newMachine.Status.NodeRef.IsDefined()
+				},
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				DeleteFunc:  func(event.DeleteEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			},
+		).
 		WatchesRawSource(r.clusterCache.GetClusterSource("vspherevm", r.clusterToVSphereVMs)).
 		Complete(ctx, r)
 }
@@ -187,7 +202,8 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 		Reason: infrav1.VSphereVMVCenterAvailableReason,
 	})
 
-	// Fetch the owner VSphereMachine.
+	// Fetch the owner VSphereMachine, or the owner VSphereMachinePool if the VSphereVM
+	// is part of a pool.
 	vsphereMachine, err := util.GetOwnerVSphereMachine(ctx, r.Client, vsphereVM.ObjectMeta)
 	// vsphereMachine can be nil in cases where custom mover other than clusterctl
 	// moves the resources without ownerreferences set
@@ -197,8 +213,7 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereMachine for VSphereVM")
 	}
 	if vsphereMachine == nil {
-		log.Info("Waiting for VSphereMachine controller to set OwnerRef on VSphereVM")
-		return reconcile.Result{}, nil
+		return r.reconcileMachinePoolVM(ctx, vsphereVM, authSession, patchHelper)
 	}
 
 	log = log.WithValues("VSphereMachine", klog.KObj(vsphereMachine))
@@ -226,12 +241,16 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 
 	// AddOwners adds the owners of Machine as k/v pairs to the logger.
 	// Specifically, it will add KubeadmControlPlane, MachineSet and MachineDeployment.
-	ctx, log, err = clog.AddOwners(ctx, r.Client, machine)
+	ctx, _, err = clog.AddOwners(ctx, r.Client, machine)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	failureDomain := machine.Spec.FailureDomain
+	if failureDomain == "" {
+		// The failure domain in which CAPV placed the machine, if any.
+		failureDomain = vsphereMachine.Status.FailureDomain
+	}
 
 	var vsphereFailureDomain *infrav1.VSphereFailureDomain
 	if failureDomain != "" {
@@ -246,11 +265,63 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 		}
 	}
 
+	return r.reconcileVM(ctx, vsphereVM, vsphereFailureDomain, authSession, patchHelper, fetchClusterModuleInput{
+		VSphereCluster: vsphereCluster,
+		Machine:        machine,
+	})
+}
+
+// reconcileMachinePoolVM reconciles a VSphereVM which is part of a VSphereMachinePool.
+func (r vmReconciler) reconcileMachinePoolVM(ctx context.Context, vsphereVM *infrav1.VSphereVM, authSession *session.Session, patchHelper *patch.Helper) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vsphereMachinePool, err := util.GetOwnerVSphereMachinePool(ctx, r.Client, vsphereVM.ObjectMeta)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereMachinePool for VSphereVM")
+	}
+	if vsphereMachinePool == nil {
+		log.Info("Waiting for VSphereMachine controller to set OwnerRef on VSphereVM")
+		return reconcile.Result{}, nil
+	}
+
+	log = log.WithValues("VSphereMachinePool", klog.KObj(vsphereMachinePool))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	vsphereCluster, err := util.GetVSphereClusterFromVSphereMachinePool(ctx, r.Client, vsphereMachinePool)
+	if err != nil || vsphereCluster == nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereCluster from VSphereMachinePool")
+	}
+
+	log = log.WithValues("VSphereCluster", klog.KObj(vsphereCluster))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// Fetch the CAPI MachinePool.
+	machinePool, err := clusterutilv1.GetOwnerMachinePool(ctx, r.Client, vsphereMachinePool.ObjectMeta)
+	if err != nil {
+		return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get MachinePool for VSphereMachinePool")
+	}
+	if machinePool == nil {
+		log.Info("Waiting for MachinePool controller to set OwnerRef on VSphereMachinePool")
+		return reconcile.Result{}, nil
+	}
+	log = log.WithValues("MachinePool", klog.KObj(machinePool))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	return r.reconcileVM(ctx, vsphereVM, nil, authSession, patchHelper, fetchClusterModuleInput{
+		VSphereCluster: vsphereCluster,
+		MachinePool:    machinePool,
+	})
+}
+
+// reconcileVM reconciles the VSphereVM and always patches it when exiting.
+func (r vmReconciler) reconcileVM(ctx context.Context, vsphereVM *infrav1.VSphereVM, vsphereFailureDomain *infrav1.VSphereFailureDomain, authSession *session.Session, patchHelper *patch.Helper, input fetchClusterModuleInput) (_ reconcile.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
 	// Create the VM context for this request.
 	vmContext := &capvcontext.VMContext{
 		ControllerManagerContext: r.ControllerManagerContext,
 		VSphereVM:                vsphereVM,
 		VSphereFailureDomain:     vsphereFailureDomain,
+		Machine:                  input.Machine,
 		Session:                  authSession,
 		PatchHelper:              patchHelper,
 	}
@@ -330,7 +401,7 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 		// This can lead to duplicate tasks being triggered (e.g. VM deletion) and make the controller
 		// wait for longer then required.
 		if vmContext.VSphereVM.Status.TaskRef != originalTaskRef {
-			err = wait.PollUntilContextTimeout(ctx, 5*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
+			err := wait.PollUntilContextTimeout(ctx, 5*time.Millisecond, 5*time.Second, true, func(ctx context.Context) (bool, error) {
 				key := ctrlclient.ObjectKey{Namespace: vmContext.VSphereVM.GetNamespace(), Name: vmContext.VSphereVM.GetName()}
 				cachedVSphereVM := &infrav1.VSphereVM{}
 				if err := r.Client.Get(ctx, key, cachedVSphereVM); err != nil {
@@ -342,10 +413,7 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 		}
 	}()
 
-	return r.reconcile(ctx, vmContext, fetchClusterModuleInput{
-		VSphereCluster: vsphereCluster,
-		Machine:        machine,
-	})
+	return r.reconcile(ctx, vmContext, input)
 }
 
 // reconcile encases the behavior of the controller around cluster module information
@@ -354,15 +422,26 @@ func (r vmReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.R
 // This logic was moved to a smaller function outside the main Reconcile() loop
 // for the ease of testing.
 func (r vmReconciler) reconcile(ctx context.Context, vmCtx *capvcontext.VMContext, input fetchClusterModuleInput) (reconcile.Result, error) {
-	if feature.Gates.Enabled(feature.NodeAntiAffinity) && !ptr.Deref(input.VSphereCluster.Spec.DisableClusterModule, false) {
-		clusterModuleInfo, err := r.fetchClusterModuleInfo(ctx, input)
-		// If cluster module information cannot be fetched for a VM being deleted,
-		// we should not block VM deletion since the cluster module is updated
-		// once the VM gets removed.
-		if err != nil && vmCtx.VSphereVM.ObjectMeta.DeletionTimestamp.IsZero() {
-			return reconcile.Result{}, err
+	if feature.Gates.Enabled(feature.NodeAntiAffinity) {
+		switch {
+		case input.VSphereCluster.Spec.Placement.Strategy == infrav1.DRSRulePlacementStrategy:
+			placementRuleInfo, err := r.fetchPlacementRuleInfo(ctx, vmCtx.VSphereVM, input)
+			// Same as for cluster modules, VM deletion is not blocked if the DRS rule
+			// information cannot be fetched.
+			if err != nil && vmCtx.VSphereVM.ObjectMeta.DeletionTimestamp.IsZero() {
+				return reconcile.Result{}, err
+			}
+			vmCtx.PlacementRuleInfo = placementRuleInfo
+		case !ptr.Deref(input.VSphereCluster.Spec.DisableClusterModule, false):
+			clusterModuleInfo, err := r.fetchClusterModuleInfo(ctx, input)
+			// If cluster module information cannot be fetched for a VM being deleted,
+			// we should not block VM deletion since the cluster module is updated
+			// once the VM gets removed.
+			if err != nil && vmCtx.VSphereVM.ObjectMeta.DeletionTimestamp.IsZero() {
+				return reconcile.Result{}, err
+			}
+			vmCtx.ClusterModuleInfo = clusterModuleInfo
 		}
-		vmCtx.ClusterModuleInfo = clusterModuleInfo
 	}
 
 	// Handle deleted machines
@@ -533,6 +612,11 @@ func (r vmReconciler) reconcileNormal(ctx context.Context, vmCtx *capvcontext.VM
 		Reason: infrav1.VSphereVMVirtualMachineProvisionedReason,
 	})
 	log.Info("VSphereVM is ready")
+
+	// Requeue to remove the bootstrap data from the VM once the delay has passed.
+	if requeueAfter := govmomi.BootstrapDataScrubRequeueAfter(vmCtx.VSphereVM); requeueAfter > 0 {
+		return reconcile.Result{RequeueAfter: requeueAfter}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
@@ -626,6 +710,39 @@ func (r vmReconciler) vsphereClusterToVSphereVMs(ctx context.Context, a ctrlclie
 	return requests
 }
 
+// machineToVSphereVM maps a Machine to the VSphereVM owned by the VSphereMachine of the Machine.
+func (r vmReconciler) machineToVSphereVM(ctx context.Context, a ctrlclient.Object) []reconcile.Request {
+	machine, ok := a.(*clusterv1.Machine)
+	if !ok || machine.Spec.InfrastructureRef.Kind != "VSphereMachine" {
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	vms := &infrav1.VSphereVMList{}
+	err := r.Client.List(ctx, vms, ctrlclient.InNamespace(machine.Namespace), ctrlclient.MatchingLabels(
+		map[string]string{
+			clusterv1.ClusterNameLabel: machine.Spec.ClusterName,
+		},
+	))
+	if err != nil {
+		return requests
+	}
+	for _, vm := range vms.Items {
+		for _, ref := range vm.OwnerReferences {
+			if ref.Kind == "VSphereMachine" && ref.Name == machine.Spec.InfrastructureRef.Name {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: apitypes.NamespacedName{
+						Name:      vm.Name,
+						Namespace: vm.Namespace,
+					},
+				})
+				break
+			}
+		}
+	}
+	return requests
+}
+
 func (r vmReconciler) ipAddressClaimToVSphereVM(_ context.Context, a ctrlclient.Object) []reconcile.Request {
 	ipAddressClaim, ok := a.(*ipamv1.IPAddressClaim)
 	if !ok {
@@ -662,6 +779,9 @@ func (r vmReconciler) retrieveVcenterSession(ctx context.Context, vsphereVM *inf
 	cluster, err := clusterutilv1.GetClusterFromMetadata(ctx, r.Client, vsphereVM.ObjectMeta)
 	if err != nil {
 		log.V(4).Info("Using credentials provided to the manager to create the authenticated session, VSphereVM is missing cluster label or cluster does not exist")
+		if params, err = r.withVCenterTLSSettings(ctx, params, vsphereVM, nil); err != nil {
+			return nil, err
+		}
 		return session.GetOrCreate(ctx, params)
 	}
 
@@ -676,9 +796,16 @@ func (r vmReconciler) retrieveVcenterSession(ctx context.Context, vsphereVM *inf
 	err = r.Client.Get(ctx, key, vsphereCluster)
 	if err != nil {
 		log.V(4).Info("Using credentials provided to the manager to create the authenticated session, failed to get VSphereCluster")
+		if params, err = r.withVCenterTLSSettings(ctx, params, vsphereVM, nil); err != nil {
+			return nil, err
+		}
 		return session.GetOrCreate(ctx, params)
 	}
 
+	if params, err = r.withVCenterTLSSettings(ctx, params, vsphereVM, vsphereCluster); err != nil {
+		return nil, err
+	}
+
 	if vsphereCluster.Spec.IdentityRef.IsDefined() {
 		creds, err := identity.GetCredentials(ctx, r.Client, vsphereCluster, r.ControllerManagerContext.Namespace)
 		if err != nil {
@@ -693,12 +820,115 @@ func (r vmReconciler) retrieveVcenterSession(ctx context.Context, vsphereVM *inf
 	return session.GetOrCreate(ctx, params)
 }
 
+// withVCenterTLSSettings adds the CA bundle and the insecure setting of the VSphereCluster to the
+// params. The thumbprint of the VSphereVM is copied from the VSphereCluster, so the certificate is
+// verified with the settings of the VSphereCluster if the VSphereVM does not set a thumbprint. If
+// the VSphereCluster of the VSphereVM is not known, e.g. because the VSphereVM has no cluster label
+// or the VSphereCluster is already deleted, the settings of a VSphereCluster in the namespace of the
+// VSphereVM with the same server are used. If there is none, the system trust store is used.
+func (r vmReconciler) withVCenterTLSSettings(ctx context.Context, params *session.Params, vsphereVM *infrav1.VSphereVM, vsphereCluster *infrav1.VSphereCluster) (*session.Params, error) {
+	if vsphereVM.Spec.Thumbprint != "" {
+		return params, nil
+	}
+
+	if vsphereCluster == nil {
+		clusterList := &infrav1.VSphereClusterList{}
+		if err := r.Client.List(ctx, clusterList, ctrlclient.InNamespace(vsphereVM.Namespace)); err != nil {
+			return nil, pkgerrors.Wrapf(err, "failed to list VSphereClusters")
+		}
+		for i := range clusterList.Items {
+			if clusterList.Items[i].Spec.Server == vsphereVM.Spec.Server {
+				vsphereCluster = &clusterList.Items[i]
+				break
+			}
+		}
+		if vsphereCluster == nil {
+			return params, nil
+		}
+	}
+
+	caBundle, err := identity.GetCABundle(ctx, r.Client, vsphereCluster, r.ControllerManagerContext.Namespace)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to get CA bundle")
+	}
+	return params.WithCABundle(caBundle).
+		WithInsecure(ptr.Deref(vsphereCluster.Spec.Insecure, false)), nil
+}
+
 func (r vmReconciler) fetchClusterModuleInfo(ctx context.Context, clusterModInput fetchClusterModuleInput) (*string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	owner, err := r.fetchPlacementOwner(ctx, clusterModInput)
+	if err != nil || owner == nil {
+		return nil, err
+	}
+
+	for _, mod := range clusterModInput.VSphereCluster.Spec.ClusterModules {
+		if mod.TargetObjectName == owner.GetName() {
+			log.V(4).Info("Cluster module found", "moduleUUID", mod.ModuleUUID)
+			return ptr.To(mod.ModuleUUID), nil
+		}
+	}
+	log.V(4).Info("No cluster module found")
+	return nil, nil
+}
+
+// fetchPlacementRuleInfo returns the DRS rule of the KubeadmControlPlane, MachineDeployment or
+// MachinePool of the VSphereVM, with the BIOS UUIDs of the other VMs of the object.
+func (r vmReconciler) fetchPlacementRuleInfo(ctx context.Context, vsphereVM *infrav1.VSphereVM, input fetchClusterModuleInput) (*capvcontext.PlacementRuleInfo, error) {
+	owner, err := r.fetchPlacementOwner(ctx, input)
+	if err != nil || owner == nil {
+		return nil, err
+	}
+
+	placement := input.VSphereCluster.Spec.Placement
+	ruleType := placement.WorkerRule
+	listOptions := []ctrlclient.ListOption{ctrlclient.InNamespace(vsphereVM.Namespace)}
+	var ownerKey string
+	switch {
+	case input.MachinePool != nil:
+		ownerKey = appendMPKey(owner.GetName())
+		listOptions = append(listOptions, ctrlclient.MatchingLabels{infrav1.MachinePoolNameLabel: vsphereVM.Labels[infrav1.MachinePoolNameLabel]})
+	case util.IsControlPlaneMachine(input.Machine):
+		ruleType = placement.ControlPlaneRule
+		ownerKey = appendKCPKey(owner.GetName())
+		listOptions = append(listOptions,
+			ctrlclient.MatchingLabels{clusterv1.ClusterNameLabel: vsphereVM.Labels[clusterv1.ClusterNameLabel]},
+			ctrlclient.HasLabels{clusterv1.MachineControlPlaneLabel})
+	default:
+		ownerKey = owner.GetName()
+		listOptions = append(listOptions, ctrlclient.MatchingLabels{
+			clusterv1.ClusterNameLabel:           vsphereVM.Labels[clusterv1.ClusterNameLabel],
+			clusterv1.MachineDeploymentNameLabel: owner.GetName(),
+		})
+	}
+
+	vsphereVMs := &infrav1.VSphereVMList{}
+	if err := r.Client.List(ctx, vsphereVMs, listOptions...); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list VSphereVMs of %s", klog.KObj(owner))
+	}
+	members := []string{}
+	for _, vm := range vsphereVMs.Items {
+		if vm.Name == vsphereVM.Name || !vm.DeletionTimestamp.IsZero() || vm.Spec.BiosUUID == "" {
+			continue
+		}
+		members = append(members, vm.Spec.BiosUUID)
+	}
+
+	return &capvcontext.PlacementRuleInfo{
+		Name:     fmt.Sprintf("capv-%s-%s", input.VSphereCluster.Namespace, ownerKey),
+		Affinity: ruleType == infrav1.AffinityPlacementRuleType,
+		Members:  members,
+	}, nil
+}
+
+// fetchPlacementOwner returns the KubeadmControlPlane, MachineDeployment or MachinePool the VMs of
+// which are placed relative to each other, or nil if the owner does not exist anymore.
+func (r vmReconciler) fetchPlacementOwner(ctx context.Context, clusterModInput fetchClusterModuleInput) (ctrlclient.Object, error) {
 	var (
 		owner ctrlclient.Object
 		err   error
 	)
-	log := ctrl.LoggerFrom(ctx)
 	machine := clusterModInput.Machine
 
 	input := util.FetchObjectInput{
@@ -706,9 +936,13 @@ func (r vmReconciler) fetchClusterModuleInfo(ctx context.Context, clusterModInpu
 		Object: machine,
 	}
 	// TODO (srm09): Figure out a way to find the latest version of the CRD
-	if util.IsControlPlaneMachine(machine) {
+	switch {
+	case clusterModInput.MachinePool != nil:
+		// The VMs of a MachinePool are anti-affined in the cluster module of the MachinePool.
+		owner = clusterModInput.MachinePool
+	case util.IsControlPlaneMachine(machine):
 		owner, err = util.FetchControlPlaneOwnerObject(ctx, input)
-	} else {
+	default:
 		owner, err = util.FetchMachineDeploymentOwnerObject(ctx, input)
 	}
 	if err != nil {
@@ -719,18 +953,11 @@ func (r vmReconciler) fetchClusterModuleInfo(ctx context.Context, clusterModInpu
 		}
 		return nil, err
 	}
-
-	for _, mod := range clusterModInput.VSphereCluster.Spec.ClusterModules {
-		if mod.TargetObjectName == owner.GetName() {
-			log.V(4).Info("Cluster module found", "moduleUUID", mod.ModuleUUID)
-			return ptr.To(mod.ModuleUUID), nil
-		}
-	}
-	log.V(4).Info("No cluster module found")
-	return nil, nil
+	return owner, nil
 }
 
 type fetchClusterModuleInput struct {
 	VSphereCluster *infrav1.VSphereCluster
 	Machine        *clusterv1.Machine
+	MachinePool    *clusterv1.MachinePool
 }
@@ -81,6 +81,9 @@ func TestReconcileNormal_WaitingForIPAddrAllocation(t *testing.T) {
 					Name:      "valid-vsphere-cluster",
 					Namespace: "test",
 				},
+				Spec: infrav1.VSphereClusterSpec{
+					Insecure: ptr.To(true),
+				},
 			}
 
 			cluster = &clusterv1.Cluster{
@@ -414,6 +417,7 @@ func TestRetrievingVCenterCredentialsFromCluster(t *testing.T) {
 			Namespace: "test",
 		},
 		Spec: infrav1.VSphereClusterSpec{
+			Insecure: ptr.To(true),
 			IdentityRef: infrav1.VSphereIdentityReference{
 				Kind: infrav1.SecretKind,
 				Name: secret.Name,
@@ -506,6 +510,32 @@ func TestRetrievingVCenterCredentialsFromCluster(t *testing.T) {
 	},
 	)
 
+	t.Run("Use the TLS settings of a VSphereCluster with the same server if the VSphereVM has no cluster", func(t *testing.T) {
+		vsphereCluster := vsphereCluster.DeepCopy()
+		vsphereCluster.Spec.Server = simr.ServerURL().Host
+		vsphereVM := vsphereVM.DeepCopy()
+		delete(vsphereVM.Labels, clusterv1.ClusterNameLabel)
+
+		controllerMgrContext := fake.NewControllerManagerContext(vsphereVM, vsphereCluster)
+		controllerMgrContext.Username = simr.Username()
+		controllerMgrContext.Password = simr.Password()
+		r := vmReconciler{
+			Recorder:                 apirecord.NewFakeRecorder(100),
+			ControllerManagerContext: controllerMgrContext,
+		}
+
+		g := NewWithT(t)
+		_, err := r.retrieveVcenterSession(context.Background(), vsphereVM)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		// Without a VSphereCluster allowing insecure connections the certificate of vcsim can't be verified.
+		vsphereCluster.Spec.Insecure = nil
+		g.Expect(r.Client.Update(context.Background(), vsphereCluster)).To(Succeed())
+		_, err = r.retrieveVcenterSession(context.Background(), vsphereVM)
+		g.Expect(err).To(HaveOccurred())
+	},
+	)
+
 	t.Run("Error if cluster infrastructureRef is nil", func(t *testing.T) {
 		cluster := &clusterv1.Cluster{
 			ObjectMeta: metav1.ObjectMeta{
@@ -735,3 +765,67 @@ func createMachineOwnerHierarchy(machine *clusterv1.Machine) []client.Object {
 		})
 	return objs
 }
+
+func Test_fetchPlacementRuleInfo(t *testing.T) {
+	ns := "test"
+	vsphereCluster := &infrav1.VSphereCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "valid-vsphere-cluster",
+			Namespace: ns,
+		},
+		Spec: infrav1.VSphereClusterSpec{
+			Placement: infrav1.VSphereClusterPlacement{
+				Strategy:   infrav1.DRSRulePlacementStrategy,
+				WorkerRule: infrav1.AffinityPlacementRuleType,
+			},
+		},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: ns,
+			Labels: map[string]string{
+				clusterv1.ClusterNameLabel: "valid-cluster",
+			},
+		},
+	}
+	newVSphereVM := func(name, biosUUID, machineDeployment string) *infrav1.VSphereVM {
+		return &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel:           "valid-cluster",
+					clusterv1.MachineDeploymentNameLabel: machineDeployment,
+				},
+			},
+			Spec: infrav1.VSphereVMSpec{BiosUUID: biosUUID},
+		}
+	}
+	vsphereVM := newVSphereVM("foo", "", "foo-md")
+
+	initObjs := []client.Object{
+		vsphereCluster,
+		machine,
+		vsphereVM,
+		newVSphereVM("bar", "265104de-1472-547c-b873-6dc7883fb6cb", "foo-md"),
+		newVSphereVM("baz", "", "foo-md"),
+		newVSphereVM("qux", "3a2d5b0e-7a4c-4a0e-9a4f-6a2f3c1b2d4e", "other-md"),
+	}
+	initObjs = append(initObjs, createMachineOwnerHierarchy(machine)...)
+	r := vmReconciler{
+		ControllerManagerContext: fake.NewControllerManagerContext(initObjs...),
+	}
+
+	g := NewWithT(t)
+	ruleInfo, err := r.fetchPlacementRuleInfo(ctx, vsphereVM, fetchClusterModuleInput{
+		VSphereCluster: vsphereCluster,
+		Machine:        machine,
+	})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ruleInfo).To(Equal(&capvcontext.PlacementRuleInfo{
+		Name:     "capv-test-foo-md",
+		Affinity: true,
+		Members:  []string{"265104de-1472-547c-b873-6dc7883fb6cb"},
+	}))
+}
@@ -0,0 +1,396 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	clusterutilv1 "sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	capicontrollerutil "sigs.k8s.io/cluster-api/util/controller"
+	"sigs.k8s.io/cluster-api/util/finalizers"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi"
+)
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherevmsnapshots,verbs=get;list;watch;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherevmsnapshots/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=vspherevms,verbs=get;list;watch
+
+// AddVSphereVMSnapshotControllerToManager adds the VSphereVMSnapshot controller to the provided manager.
+func AddVSphereVMSnapshotControllerToManager(ctx context.Context, controllerManagerCtx *capvcontext.ControllerManagerContext, mgr manager.Manager, options controller.Options) error {
+	r := vsphereVMSnapshotReconciler{
+		ControllerManagerContext: controllerManagerCtx,
+	}
+	predicateLog := ctrl.LoggerFrom(ctx).WithValues("controller", "vspherevmsnapshot")
+
+	return capicontrollerutil.NewControllerManagedBy(mgr, predicateLog).
+		For(&infrav1.VSphereVMSnapshot{}).
+		WithOptions(options).
+		// The snapshot is created once the virtual machine of the VSphereVM is provisioned.
+		Watches(
+			&infrav1.VSphereVM{},
+			handler.EnqueueRequestsFromMapFunc(r.vsphereVMToSnapshots),
+		).
+		WithEventFilter(predicates.ResourceHasFilterLabel(mgr.GetScheme(), predicateLog, controllerManagerCtx.WatchFilterValue)).
+		Complete(ctx, r)
+}
+
+type vsphereVMSnapshotReconciler struct {
+	*capvcontext.ControllerManagerContext
+}
+
+// Reconcile ensures the snapshot of the virtual machine of a VSphereVM reflects the
+// VSphereVMSnapshot.
+func (r vsphereVMSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vsphereVMSnapshot := &infrav1.VSphereVMSnapshot{}
+	if err := r.Client.Get(ctx, req.NamespacedName, vsphereVMSnapshot); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	log = log.WithValues("VSphereVM", klog.KRef(vsphereVMSnapshot.Namespace, vsphereVMSnapshot.Spec.VMName))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	// The VSphereVM is nil if it does not exist.
+	vsphereVM := &infrav1.VSphereVM{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: vsphereVMSnapshot.Namespace, Name: vsphereVMSnapshot.Spec.VMName}, vsphereVM); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get VSphereVM for VSphereVMSnapshot")
+		}
+		vsphereVM = nil
+	}
+
+	// Add finalizer first if not set to avoid the race condition between init and delete.
+	if finalizerAdded, err := finalizers.EnsureFinalizer(ctx, r.Client, vsphereVMSnapshot, infrav1.VMSnapshotFinalizer); err != nil || finalizerAdded {
+		return ctrl.Result{}, err
+	}
+
+	patchHelper, err := patch.NewHelper(vsphereVMSnapshot, r.Client)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// The VSphereVMSnapshot is paused with the Cluster of its VSphereVM.
+	if vsphereVM != nil && vsphereVM.Labels[clusterv1.ClusterNameLabel] != "" {
+		cluster, err := clusterutilv1.GetClusterFromMetadata(ctx, r.Client, vsphereVM.ObjectMeta)
+		if err != nil {
+			return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to get Cluster for VSphereVMSnapshot")
+		}
+		if isPaused, requeue, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, vsphereVMSnapshot); err != nil || isPaused || requeue {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Always patch the VSphereVMSnapshot object.
+	defer func() {
+		if err := conditions.SetSummaryCondition(vsphereVMSnapshot, vsphereVMSnapshot, infrav1.VSphereVMSnapshotReadyCondition,
+			conditions.ForConditionTypes{
+				infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+				infrav1.VSphereVMSnapshotRevertedCondition,
+			},
+			// The Reverted condition is only set once a revert is requested.
+			conditions.IgnoreTypesIfMissing{
+				infrav1.VSphereVMSnapshotRevertedCondition,
+			},
+			// Using a custom merge strategy to override reasons applied during merge.
+			conditions.CustomMergeStrategy{
+				MergeStrategy: conditions.DefaultMergeStrategy(
+					// Use custom reasons.
+					conditions.ComputeReasonFunc(conditions.GetDefaultComputeMergeReasonFunc(
+						infrav1.VSphereVMSnapshotNotReadyReason,
+						infrav1.VSphereVMSnapshotReadyUnknownReason,
+						infrav1.VSphereVMSnapshotReadyReason,
+					)),
+				),
+			},
+		); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, pkgerrors.Wrapf(err, "failed to set %s condition", infrav1.VSphereVMSnapshotReadyCondition)})
+			return
+		}
+
+		if err := patchHelper.Patch(ctx, vsphereVMSnapshot, patch.WithOwnedConditions{Conditions: []string{
+			clusterv1.PausedCondition,
+			infrav1.VSphereVMSnapshotReadyCondition,
+			infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+			infrav1.VSphereVMSnapshotRevertedCondition,
+		}}); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	if !vsphereVMSnapshot.DeletionTimestamp.IsZero() {
+		return reconcile.Result{}, r.reconcileDelete(ctx, vsphereVMSnapshot, vsphereVM)
+	}
+
+	return r.reconcileNormal(ctx, vsphereVMSnapshot, vsphereVM)
+}
+
+// reconcileDelete removes the snapshot from the virtual machine and removes the finalizer.
+func (r vsphereVMSnapshotReconciler) reconcileDelete(ctx context.Context, vsphereVMSnapshot *infrav1.VSphereVMSnapshot, vsphereVM *infrav1.VSphereVM) error {
+	// The snapshots of a virtual machine are deleted with it.
+	if vsphereVMSnapshot.Status.SnapshotRef == "" || vsphereVM == nil || vsphereVM.Spec.BiosUUID == "" {
+		ctrlutil.RemoveFinalizer(vsphereVMSnapshot, infrav1.VMSnapshotFinalizer)
+		return nil
+	}
+
+	conditions.Set(vsphereVMSnapshot, metav1.Condition{
+		Type:   infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+		Status: metav1.ConditionFalse,
+		Reason: infrav1.VSphereVMSnapshotSnapshotDeletingReason,
+	})
+
+	vmCtx, err := r.getVMContext(ctx, vsphereVM)
+	if err != nil {
+		return err
+	}
+	if err := govmomi.RemoveVMSnapshot(ctx, vmCtx, vsphereVMSnapshot.Status.SnapshotRef); err != nil {
+		return err
+	}
+
+	ctrlutil.RemoveFinalizer(vsphereVMSnapshot, infrav1.VMSnapshotFinalizer)
+	return nil
+}
+
+// reconcileNormal creates the snapshot of the virtual machine, reverts the virtual machine to the
+// snapshot when requested, and applies the retention limits.
+func (r vsphereVMSnapshotReconciler) reconcileNormal(ctx context.Context, vsphereVMSnapshot *infrav1.VSphereVMSnapshot, vsphereVM *infrav1.VSphereVM) (reconcile.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if vsphereVM == nil || vsphereVM.Spec.BiosUUID == "" {
+		log.Info("Waiting for the virtual machine of the VSphereVM to be provisioned")
+		conditions.Set(vsphereVMSnapshot, metav1.Condition{
+			Type:   infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereVMSnapshotWaitingForVirtualMachineReason,
+		})
+		return reconcile.Result{}, nil
+	}
+
+	// The VSphereVMSnapshot is garbage collected with the VSphereVM, whose virtual machine is
+	// deleted with its snapshots.
+	vsphereVMSnapshot.SetOwnerReferences(clusterutilv1.EnsureOwnerRef(vsphereVMSnapshot.GetOwnerReferences(), metav1.OwnerReference{
+		APIVersion: infrav1.GroupVersion.String(),
+		Kind:       "VSphereVM",
+		Name:       vsphereVM.Name,
+		UID:        vsphereVM.UID,
+	}))
+
+	// vSphere does not support snapshots of virtual machines with PCI passthrough devices.
+	if vsphereVMSnapshot.Status.SnapshotRef == "" && len(vsphereVM.Spec.PciDevices) > 0 {
+		conditions.Set(vsphereVMSnapshot, metav1.Condition{
+			Type:    infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMSnapshotPCIPassthroughDevicesReason,
+			Message: govmomi.ErrPCIPassthroughDevices.Error(),
+		})
+		return reconcile.Result{}, nil
+	}
+
+	vmCtx, err := r.getVMContext(ctx, vsphereVM)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if vsphereVMSnapshot.Status.SnapshotRef == "" {
+		snapshot, err := govmomi.CreateVMSnapshot(ctx, vmCtx, vsphereVMSnapshot.Name, vsphereVMSnapshot.Spec)
+		if err != nil {
+			if errors.Is(err, govmomi.ErrPCIPassthroughDevices) {
+				conditions.Set(vsphereVMSnapshot, metav1.Condition{
+					Type:    infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+					Status:  metav1.ConditionFalse,
+					Reason:  infrav1.VSphereVMSnapshotPCIPassthroughDevicesReason,
+					Message: err.Error(),
+				})
+				return reconcile.Result{}, nil
+			}
+			conditions.Set(vsphereVMSnapshot, metav1.Condition{
+				Type:    infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereVMSnapshotSnapshotCreationFailedReason,
+				Message: err.Error(),
+			})
+			return reconcile.Result{}, err
+		}
+		vsphereVMSnapshot.Status.SnapshotRef = snapshot.Ref
+		vsphereVMSnapshot.Status.CreationTime = metav1.NewTime(snapshot.CreateTime)
+		// The revert requested when the VSphereVMSnapshot is created is the snapshot itself.
+		vsphereVMSnapshot.Status.LastRevertRequestID = vsphereVMSnapshot.Spec.RevertRequestID
+
+		if err := r.reconcileMaxSnapshots(ctx, vsphereVMSnapshot); err != nil {
+			return reconcile.Result{}, err
+		}
+	} else {
+		snapshot, err := govmomi.GetVMSnapshot(ctx, vmCtx, vsphereVMSnapshot.Status.SnapshotRef)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if snapshot == nil {
+			conditions.Set(vsphereVMSnapshot, metav1.Condition{
+				Type:    infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereVMSnapshotSnapshotNotFoundReason,
+				Message: fmt.Sprintf("snapshot %s does not exist anymore", vsphereVMSnapshot.Status.SnapshotRef),
+			})
+			return reconcile.Result{}, nil
+		}
+	}
+	conditions.Set(vsphereVMSnapshot, metav1.Condition{
+		Type:   infrav1.VSphereVMSnapshotSnapshotCreatedCondition,
+		Status: metav1.ConditionTrue,
+		Reason: infrav1.VSphereVMSnapshotSnapshotCreatedReason,
+	})
+
+	if revertRequestID := vsphereVMSnapshot.Spec.RevertRequestID; revertRequestID != "" && revertRequestID != vsphereVMSnapshot.Status.LastRevertRequestID {
+		log.Info("Reverting virtual machine to snapshot", "revertRequestID", revertRequestID)
+		if err := govmomi.RevertVMSnapshot(ctx, vmCtx, vsphereVMSnapshot.Status.SnapshotRef); err != nil {
+			conditions.Set(vsphereVMSnapshot, metav1.Condition{
+				Type:    infrav1.VSphereVMSnapshotRevertedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereVMSnapshotRevertFailedReason,
+				Message: err.Error(),
+			})
+			return reconcile.Result{}, err
+		}
+		vsphereVMSnapshot.Status.LastRevertRequestID = revertRequestID
+		vsphereVMSnapshot.Status.LastRevertTime = metav1.Now()
+		conditions.Set(vsphereVMSnapshot, metav1.Condition{
+			Type:   infrav1.VSphereVMSnapshotRevertedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereVMSnapshotRevertedReason,
+		})
+	}
+
+	if maxAgeSeconds := vsphereVMSnapshot.Spec.Retention.MaxAgeSeconds; maxAgeSeconds > 0 {
+		expiresIn := time.Until(vsphereVMSnapshot.Status.CreationTime.Add(time.Duration(maxAgeSeconds) * time.Second))
+		if expiresIn > 0 {
+			return reconcile.Result{RequeueAfter: expiresIn}, nil
+		}
+		log.Info("Deleting VSphereVMSnapshot which is older than the maximum age", "maxAgeSeconds", maxAgeSeconds)
+		if err := r.Client.Delete(ctx, vsphereVMSnapshot); err != nil && !apierrors.IsNotFound(err) {
+			return reconcile.Result{}, pkgerrors.Wrapf(err, "failed to delete VSphereVMSnapshot %s", klog.KObj(vsphereVMSnapshot))
+		}
+	}
+	return reconcile.Result{}, nil
+}
+
+// reconcileMaxSnapshots deletes the oldest VSphereVMSnapshots of the VSphereVM, so that no more
+// than the maximum number of snapshots of the VSphereVMSnapshot are kept.
+func (r vsphereVMSnapshotReconciler) reconcileMaxSnapshots(ctx context.Context, vsphereVMSnapshot *infrav1.VSphereVMSnapshot) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	maxSnapshots := int(vsphereVMSnapshot.Spec.Retention.MaxSnapshots)
+	if maxSnapshots == 0 {
+		return nil
+	}
+
+	snapshots, err := r.getVSphereVMSnapshots(ctx, vsphereVMSnapshot.Namespace, vsphereVMSnapshot.Spec.VMName)
+	if err != nil {
+		return err
+	}
+	others := make([]infrav1.VSphereVMSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.Name != vsphereVMSnapshot.Name && snapshot.Status.SnapshotRef != "" && snapshot.DeletionTimestamp.IsZero() {
+			others = append(others, snapshot)
+		}
+	}
+	// The VSphereVMSnapshot itself counts towards the limit.
+	if len(others) < maxSnapshots {
+		return nil
+	}
+	sort.SliceStable(others, func(i, j int) bool {
+		return others[i].Status.CreationTime.Before(&others[j].Status.CreationTime)
+	})
+
+	var errs []error
+	for i := range others[:len(others)-maxSnapshots+1] {
+		snapshot := &others[i]
+		log.Info("Deleting VSphereVMSnapshot to keep the maximum number of snapshots", "VSphereVMSnapshot", klog.KObj(snapshot), "maxSnapshots", maxSnapshots)
+		if err := r.Client.Delete(ctx, snapshot); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to delete VSphereVMSnapshot %s", klog.KObj(snapshot)))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// getVMContext returns a VMContext with a session to the vCenter of the VSphereVM.
+func (r vsphereVMSnapshotReconciler) getVMContext(ctx context.Context, vsphereVM *infrav1.VSphereVM) (*capvcontext.VMContext, error) {
+	authSession, err := vmReconciler{ControllerManagerContext: r.ControllerManagerContext}.retrieveVcenterSession(ctx, vsphereVM)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to get vCenter session for VSphereVM %s", klog.KObj(vsphereVM))
+	}
+	return &capvcontext.VMContext{
+		ControllerManagerContext: r.ControllerManagerContext,
+		VSphereVM:                vsphereVM,
+		Session:                  authSession,
+	}, nil
+}
+
+// getVSphereVMSnapshots returns the VSphereVMSnapshots of the VSphereVM.
+func (r vsphereVMSnapshotReconciler) getVSphereVMSnapshots(ctx context.Context, namespace, vmName string) ([]infrav1.VSphereVMSnapshot, error) {
+	snapshotList := &infrav1.VSphereVMSnapshotList{}
+	if err := r.Client.List(ctx, snapshotList, client.InNamespace(namespace)); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list VSphereVMSnapshots")
+	}
+
+	snapshots := make([]infrav1.VSphereVMSnapshot, 0, len(snapshotList.Items))
+	for _, snapshot := range snapshotList.Items {
+		if snapshot.Spec.VMName == vmName {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// vsphereVMToSnapshots maps a VSphereVM to its VSphereVMSnapshots.
+func (r vsphereVMSnapshotReconciler) vsphereVMToSnapshots(ctx context.Context, a client.Object) []reconcile.Request {
+	snapshots, err := r.getVSphereVMSnapshots(ctx, a.GetNamespace(), a.GetName())
+	if err != nil {
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&snapshot)})
+	}
+	return requests
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
+)
+
+func TestVSphereVMSnapshotReconciler_Reconcile(t *testing.T) {
+	simr, err := vcsim.NewBuilder().Build()
+	if err != nil {
+		t.Fatalf("unable to create simulator %s", err)
+	}
+	defer simr.Destroy()
+
+	vimClient, err := govmomi.NewClient(ctx, simr.ServerURL(), true)
+	if err != nil {
+		t.Fatalf("unable to create vSphere client %s", err)
+	}
+	vm, err := find.NewFinder(vimClient.Client).VirtualMachine(ctx, "DC0_H0_VM0")
+	if err != nil {
+		t.Fatalf("unable to find VM %s", err)
+	}
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "test"},
+		Spec: clusterv1.ClusterSpec{
+			InfrastructureRef: clusterv1.ContractVersionedObjectReference{
+				APIGroup: infrav1.GroupVersion.Group,
+				Kind:     "VSphereCluster",
+				Name:     "vsphere-cluster",
+			},
+		},
+	}
+	vsphereCluster := &infrav1.VSphereCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "vsphere-cluster", Namespace: "test"},
+		Spec: infrav1.VSphereClusterSpec{
+			Server:   simr.ServerURL().Host,
+			Insecure: ptr.To(true),
+		},
+	}
+	newVSphereVM := func(name string) *infrav1.VSphereVM {
+		return &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "test",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+			},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{Server: simr.ServerURL().Host},
+				BiosUUID:                vm.UUID(ctx),
+			},
+		}
+	}
+	newSnapshot := func(name string) *infrav1.VSphereVMSnapshot {
+		return &infrav1.VSphereVMSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+			Spec:       infrav1.VSphereVMSnapshotSpec{VMName: "vm"},
+		}
+	}
+
+	newReconciler := func(objs ...client.Object) vsphereVMSnapshotReconciler {
+		controllerManagerContext := fake.NewControllerManagerContext(append([]client.Object{cluster, vsphereCluster}, objs...)...)
+		controllerManagerContext.Username = simr.ServerURL().User.Username()
+		controllerManagerContext.Password, _ = simr.ServerURL().User.Password()
+		return vsphereVMSnapshotReconciler{controllerManagerContext}
+	}
+	// reconcile reconciles the VSphereVMSnapshot until the finalizer and the Paused condition are set.
+	reconcile := func(g *WithT, r vsphereVMSnapshotReconciler, vsphereVMSnapshot *infrav1.VSphereVMSnapshot) ctrl.Result {
+		var result ctrl.Result
+		for range 3 {
+			var err error
+			result, err = r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(vsphereVMSnapshot)})
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(vsphereVMSnapshot), vsphereVMSnapshot); !apierrors.IsNotFound(err) {
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+		return result
+	}
+
+	t.Run("waiting for the VSphereVM", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereVMSnapshot := newSnapshot("snapshot")
+		r := newReconciler(vsphereVMSnapshot)
+		reconcile(g, r, vsphereVMSnapshot)
+		g.Expect(vsphereVMSnapshot.Finalizers).To(ContainElement(infrav1.VMSnapshotFinalizer))
+		g.Expect(conditions.GetReason(vsphereVMSnapshot, infrav1.VSphereVMSnapshotSnapshotCreatedCondition)).To(Equal(infrav1.VSphereVMSnapshotWaitingForVirtualMachineReason))
+	})
+
+	t.Run("VSphereVM with PCI devices", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereVM := newVSphereVM("vm")
+		vsphereVM.Spec.PciDevices = []infrav1.PCIDeviceSpec{{DeviceID: ptr.To[int32](1234), VendorID: ptr.To[int32](5678)}}
+		vsphereVMSnapshot := newSnapshot("snapshot")
+		r := newReconciler(vsphereVM, vsphereVMSnapshot)
+		reconcile(g, r, vsphereVMSnapshot)
+		g.Expect(vsphereVMSnapshot.Status.SnapshotRef).To(BeEmpty())
+		g.Expect(conditions.IsFalse(vsphereVMSnapshot, infrav1.VSphereVMSnapshotReadyCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(vsphereVMSnapshot, infrav1.VSphereVMSnapshotSnapshotCreatedCondition)).To(Equal(infrav1.VSphereVMSnapshotPCIPassthroughDevicesReason))
+	})
+
+	t.Run("create, revert and delete a snapshot", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereVMSnapshot := newSnapshot("snapshot")
+		vsphereVMSnapshot.Spec.RevertRequestID = "1"
+		r := newReconciler(newVSphereVM("vm"), vsphereVMSnapshot)
+		reconcile(g, r, vsphereVMSnapshot)
+		g.Expect(vsphereVMSnapshot.Status.SnapshotRef).ToNot(BeEmpty())
+		g.Expect(vsphereVMSnapshot.Status.CreationTime.IsZero()).To(BeFalse())
+		g.Expect(vsphereVMSnapshot.OwnerReferences).To(HaveLen(1))
+		g.Expect(conditions.IsTrue(vsphereVMSnapshot, infrav1.VSphereVMSnapshotReadyCondition)).To(BeTrue())
+		// The revert request set at creation does not revert the VM.
+		g.Expect(vsphereVMSnapshot.Status.LastRevertTime.IsZero()).To(BeTrue())
+		g.Expect(conditions.Has(vsphereVMSnapshot, infrav1.VSphereVMSnapshotRevertedCondition)).To(BeFalse())
+
+		vsphereVMSnapshot.Spec.RevertRequestID = "2"
+		g.Expect(r.Client.Update(ctx, vsphereVMSnapshot)).To(Succeed())
+		reconcile(g, r, vsphereVMSnapshot)
+		g.Expect(vsphereVMSnapshot.Status.LastRevertRequestID).To(Equal("2"))
+		g.Expect(vsphereVMSnapshot.Status.LastRevertTime.IsZero()).To(BeFalse())
+		g.Expect(conditions.IsTrue(vsphereVMSnapshot, infrav1.VSphereVMSnapshotRevertedCondition)).To(BeTrue())
+
+		g.Expect(r.Client.Delete(ctx, vsphereVMSnapshot)).To(Succeed())
+		reconcile(g, r, vsphereVMSnapshot)
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(vsphereVMSnapshot), vsphereVMSnapshot)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		g := NewWithT(t)
+
+		oldest := newSnapshot("oldest")
+		oldest.Status.SnapshotRef = "snapshot-1"
+		oldest.Status.CreationTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+		older := newSnapshot("older")
+		older.Status.SnapshotRef = "snapshot-2"
+		older.Status.CreationTime = metav1.NewTime(time.Now().Add(-time.Hour))
+		vsphereVMSnapshot := newSnapshot("snapshot")
+		vsphereVMSnapshot.Spec.Retention = infrav1.VSphereVMSnapshotRetention{MaxSnapshots: 2, MaxAgeSeconds: 3600}
+		r := newReconciler(newVSphereVM("vm"), oldest, older, vsphereVMSnapshot)
+		result := reconcile(g, r, vsphereVMSnapshot)
+		g.Expect(vsphereVMSnapshot.Status.SnapshotRef).ToNot(BeEmpty())
+		g.Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+
+		snapshots, err := r.getVSphereVMSnapshots(ctx, "test", "vm")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(snapshots).To(HaveLen(2))
+		g.Expect(snapshots).ToNot(ContainElement(HaveField("Name", "oldest")))
+	})
+}
@@ -0,0 +1,497 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inplaceupdate contains the handlers for the in-place update hooks of CAPV.
+//
+// The handlers declare the changes to a VSphereMachine which vSphere can apply to the
+// existing VM: tags, custom VMX keys, CPU and memory, and disk growth for full clones.
+// All other changes are declined, so Cluster API falls back to a rollout.
+// The changes are applied by the VSphereMachine and VSphereVM controllers; the
+// UpdateMachine hook only reports if they have been applied.
+package inplaceupdate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+
+	pkgerrors "github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/klog/v2"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/index"
+)
+
+// retryAfterSeconds is the interval at which the UpdateMachine hook is called
+// while the changes are being applied to the VM.
+const retryAfterSeconds = 15
+
+// ExtensionHandlers provides a common struct shared across the in-place update hook handlers.
+type ExtensionHandlers struct {
+	decoder runtime.Decoder
+	client  client.Client
+}
+
+// NewExtensionHandlers returns a new ExtensionHandlers for the in-place update hook handlers.
+func NewExtensionHandlers(client client.Client) *ExtensionHandlers {
+	scheme := runtime.NewScheme()
+	_ = infrav1.AddToScheme(scheme)
+	return &ExtensionHandlers{
+		client:  client,
+		decoder: serializer.NewCodecFactory(scheme).UniversalDeserializer(),
+	}
+}
+
+// DoCanUpdateMachine implements the CanUpdateMachine hook.
+func (h *ExtensionHandlers) DoCanUpdateMachine(ctx context.Context, req *runtimehooksv1.CanUpdateMachineRequest, resp *runtimehooksv1.CanUpdateMachineResponse) {
+	log := ctrl.LoggerFrom(ctx).WithValues("Machine", klog.KObj(&req.Desired.Machine))
+	log.V(4).Info("CanUpdateMachine is called")
+
+	current, err := h.decodeVSphereMachine(req.Current.InfrastructureMachine)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+	desired, err := h.decodeVSphereMachine(req.Desired.InfrastructureMachine)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+
+	// Only changes to VSphereMachines can be handled, decline all changes to other objects.
+	if current == nil || desired == nil {
+		resp.Status = runtimehooksv1.ResponseStatusSuccess
+		return
+	}
+
+	disksExtendable, err := h.areDisksExtendable(ctx, current)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+
+	canUpdateVSphereMachineSpec(&current.Spec, &desired.Spec, disksExtendable)
+
+	patch, err := createJSONPatch(req.Current.InfrastructureMachine.Raw, current)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+	resp.InfrastructureMachinePatch = runtimehooksv1.Patch{
+		PatchType: runtimehooksv1.JSONPatchType,
+		Patch:     patch,
+	}
+	resp.Status = runtimehooksv1.ResponseStatusSuccess
+}
+
+// DoCanUpdateMachineSet implements the CanUpdateMachineSet hook.
+func (h *ExtensionHandlers) DoCanUpdateMachineSet(ctx context.Context, req *runtimehooksv1.CanUpdateMachineSetRequest, resp *runtimehooksv1.CanUpdateMachineSetResponse) {
+	log := ctrl.LoggerFrom(ctx).WithValues("MachineSet", klog.KObj(&req.Desired.MachineSet))
+	log.V(4).Info("CanUpdateMachineSet is called")
+
+	current, err := h.decodeVSphereMachineTemplate(req.Current.InfrastructureMachineTemplate)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+	desired, err := h.decodeVSphereMachineTemplate(req.Desired.InfrastructureMachineTemplate)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+
+	// Only changes to VSphereMachineTemplates can be handled, decline all changes to other objects.
+	if current == nil || desired == nil {
+		resp.Status = runtimehooksv1.ResponseStatusSuccess
+		return
+	}
+
+	// The clone mode of the existing VMs is not known for a MachineSet, disks can only be
+	// extended if the VMs are full clones, as the disks of linked clones are delta disks.
+	disksExtendable := current.Spec.Template.Spec.CloneMode == infrav1.FullClone
+	canUpdateVSphereMachineSpec(&current.Spec.Template.Spec, &desired.Spec.Template.Spec, disksExtendable)
+
+	patch, err := createJSONPatch(req.Current.InfrastructureMachineTemplate.Raw, current)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+	resp.InfrastructureMachineTemplatePatch = runtimehooksv1.Patch{
+		PatchType: runtimehooksv1.JSONPatchType,
+		Patch:     patch,
+	}
+	resp.Status = runtimehooksv1.ResponseStatusSuccess
+}
+
+// DoUpdateMachine implements the UpdateMachine hook.
+// When the hook is called, the desired spec has already been written to the VSphereMachine;
+// the hook reports the update as completed once the VSphereVM has been reconciled with
+// the desired spec and the changes have been applied to the VM.
+func (h *ExtensionHandlers) DoUpdateMachine(ctx context.Context, req *runtimehooksv1.UpdateMachineRequest, resp *runtimehooksv1.UpdateMachineResponse) {
+	log := ctrl.LoggerFrom(ctx).WithValues("Machine", klog.KObj(&req.Desired.Machine))
+	log.V(4).Info("UpdateMachine is called")
+	defer func() {
+		log.V(4).Info("UpdateMachine response", "status", resp.Status, "message", resp.Message, "retryAfterSeconds", resp.RetryAfterSeconds)
+	}()
+
+	vsphereMachine, err := h.decodeVSphereMachine(req.Desired.InfrastructureMachine)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+	if vsphereMachine == nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = "UpdateMachine is only supported for VSphereMachines"
+		return
+	}
+
+	vsphereVM, err := h.getVSphereVM(ctx, vsphereMachine)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+
+	message, done, err := getUpdateStatus(vsphereMachine, vsphereVM)
+	if err != nil {
+		resp.Status = runtimehooksv1.ResponseStatusFailure
+		resp.Message = err.Error()
+		return
+	}
+
+	resp.Status = runtimehooksv1.ResponseStatusSuccess
+	resp.Message = message
+	if !done {
+		resp.RetryAfterSeconds = retryAfterSeconds
+	}
+}
+
+// canUpdateVSphereMachineSpec copies the changes from desired to current which can be
+// applied to the existing VM. All other changes are declined by leaving current as is.
+// Disk growth is only accepted if disksExtendable is true.
+func canUpdateVSphereMachineSpec(current, desired *infrav1.VSphereMachineSpec, disksExtendable bool) {
+	// The power off settings are only used when the VM is deleted.
+	current.PowerOffMode = desired.PowerOffMode
+	current.GuestSoftPowerOffTimeoutSeconds = desired.GuestSoftPowerOffTimeoutSeconds
+
+	canUpdateVirtualMachineCloneSpec(&current.VirtualMachineCloneSpec, &desired.VirtualMachineCloneSpec, disksExtendable)
+}
+
+// canUpdateVirtualMachineCloneSpec copies the changes from desired to current which can be
+// applied to the existing VM.
+func canUpdateVirtualMachineCloneSpec(current, desired *infrav1.VirtualMachineCloneSpec, disksExtendable bool) {
+	// Tags and custom VMX keys are only added to or changed on the existing VM,
+	// removing them requires a new VM.
+	if isSubset(current.TagIDs, desired.TagIDs) {
+		current.TagIDs = desired.TagIDs
+	}
+	if isSubset(slices.Collect(maps.Keys(current.CustomVMXKeys)), slices.Collect(maps.Keys(desired.CustomVMXKeys))) {
+		current.CustomVMXKeys = desired.CustomVMXKeys
+	}
+
+	// CPU and memory are changed according to the resizePolicy. Unsetting a value
+	// doesn't revert the VM to the value of the template, so it requires a new VM.
+	if desired.NumCPUs != 0 {
+		current.NumCPUs = desired.NumCPUs
+	}
+	if desired.NumCoresPerSocket != nil && *desired.NumCoresPerSocket != 0 {
+		current.NumCoresPerSocket = desired.NumCoresPerSocket
+	}
+	if desired.MemoryMiB != 0 {
+		current.MemoryMiB = desired.MemoryMiB
+	}
+	if canUpdateResources(current.Resources, desired.Resources) {
+		current.Resources = desired.Resources
+	}
+	current.ResizePolicy = desired.ResizePolicy
+
+	// Disks are extended, but never shrunk.
+	if disksExtendable {
+		if desired.DiskGiB >= current.DiskGiB {
+			current.DiskGiB = desired.DiskGiB
+		}
+		if canExtendDisks(current.AdditionalDisksGiB, desired.AdditionalDisksGiB) {
+			current.AdditionalDisksGiB = desired.AdditionalDisksGiB
+		}
+		if canExtendDataDisks(current.DataDisks, desired.DataDisks) {
+			current.DataDisks = desired.DataDisks
+		}
+	}
+	current.DiskGrowHint = desired.DiskGrowHint
+
+	// Drift remediation only changes how the existing VM is reconciled.
+	current.DriftRemediation = desired.DriftRemediation
+
+	// Changes to the network, including the MTU, are declined: cloud-init doesn't apply
+	// the network configuration in the metadata again once the node has booted.
+}
+
+// canUpdateResources returns true if all the resource settings which are set in current
+// are still set in desired, as settings which are unset are not reverted on the VM.
+func canUpdateResources(current, desired infrav1.VirtualMachineResources) bool {
+	if !current.Requests.CPU.IsZero() && desired.Requests.CPU.IsZero() {
+		return false
+	}
+	if !current.Requests.Memory.IsZero() && desired.Requests.Memory.IsZero() {
+		return false
+	}
+	if !current.Limits.CPU.IsZero() && desired.Limits.CPU.IsZero() {
+		return false
+	}
+	if !current.Limits.Memory.IsZero() && desired.Limits.Memory.IsZero() {
+		return false
+	}
+	if current.Shares.CPU != 0 && desired.Shares.CPU == 0 {
+		return false
+	}
+	return current.Shares.Memory == 0 || desired.Shares.Memory != 0
+}
+
+// canExtendDisks returns true if desired has the same number of disks as current
+// and none of them is smaller.
+func canExtendDisks(current, desired []int32) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	for i := range current {
+		if desired[i] < current[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// canExtendDataDisks returns true if desired only differs from current in the size of
+// the data disks and none of them is smaller.
+func canExtendDataDisks(current, desired []infrav1.VSphereDisk) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	for i := range current {
+		if desired[i].SizeGiB < current[i].SizeGiB {
+			return false
+		}
+		currentDisk, desiredDisk := current[i], desired[i]
+		currentDisk.SizeGiB, desiredDisk.SizeGiB = 0, 0
+		if currentDisk != desiredDisk {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubset returns true if all elements of a are also in b.
+func isSubset(a, b []string) bool {
+	for _, s := range a {
+		if !slices.Contains(b, s) {
+			return false
+		}
+	}
+	return true
+}
+
+// getUpdateStatus returns a message describing the status of the in-place update
+// and whether the changes have been applied to the VM.
+// An error is returned if the changes can't be applied to the VM.
+func getUpdateStatus(vsphereMachine *infrav1.VSphereMachine, vsphereVM *infrav1.VSphereVM) (string, bool, error) {
+	if !reflect.DeepEqual(inPlaceUpdatableFields(vsphereMachine.Spec.VirtualMachineCloneSpec), inPlaceUpdatableFields(vsphereVM.Spec.VirtualMachineCloneSpec)) {
+		return fmt.Sprintf("Waiting for the spec of VSphereVM %s to be updated", vsphereVM.Name), false, nil
+	}
+
+	// The Ready condition is set on every reconcile, so it reports if the VSphereVM
+	// has been reconciled since the spec has been updated.
+	ready := conditions.Get(vsphereVM, infrav1.VSphereVMReadyCondition)
+	if ready == nil || ready.ObservedGeneration != vsphereVM.Generation {
+		return fmt.Sprintf("Waiting for VSphereVM %s to be reconciled", vsphereVM.Name), false, nil
+	}
+
+	if resized := conditions.Get(vsphereVM, infrav1.VSphereVMVirtualMachineResizedCondition); resized != nil && resized.Status == metav1.ConditionFalse {
+		if resized.Reason == infrav1.VSphereVMVirtualMachineResizePowerCycleRequiredReason {
+			return "", false, pkgerrors.Errorf("changes to CPU and memory of VSphereVM %s require a power cycle which is not allowed by the resizePolicy", vsphereVM.Name)
+		}
+		return fmt.Sprintf("Resizing VSphereVM %s: %s", vsphereVM.Name, resized.Message), false, nil
+	}
+
+	if disksResized := conditions.Get(vsphereVM, infrav1.VSphereVMVirtualMachineDisksResizedCondition); disksResized != nil && disksResized.Status == metav1.ConditionFalse {
+		if disksResized.Reason == infrav1.VSphereVMVirtualMachineDisksShrinkNotSupportedReason {
+			return "", false, pkgerrors.Errorf("disks of VSphereVM %s can't be shrunk: %s", vsphereVM.Name, disksResized.Message)
+		}
+		if disksResized.Reason == infrav1.VSphereVMVirtualMachineDisksDeltaDiskNotResizableReason {
+			return "", false, pkgerrors.Errorf("disks of VSphereVM %s can't be extended: %s", vsphereVM.Name, disksResized.Message)
+		}
+		return fmt.Sprintf("Resizing disks of VSphereVM %s: %s", vsphereVM.Name, disksResized.Message), false, nil
+	}
+
+	if vsphereVM.Status.TaskRef != "" {
+		return fmt.Sprintf("Waiting for task %s of VSphereVM %s to complete", vsphereVM.Status.TaskRef, vsphereVM.Name), false, nil
+	}
+
+	if ready.Status != metav1.ConditionTrue {
+		return fmt.Sprintf("Waiting for VSphereVM %s to be ready", vsphereVM.Name), false, nil
+	}
+
+	return fmt.Sprintf("Updated VSphereVM %s", vsphereVM.Name), true, nil
+}
+
+// inPlaceUpdatableFields returns a VirtualMachineCloneSpec with only the fields set
+// which can be updated in-place.
+func inPlaceUpdatableFields(spec infrav1.VirtualMachineCloneSpec) infrav1.VirtualMachineCloneSpec {
+	return infrav1.VirtualMachineCloneSpec{
+		TagIDs:             spec.TagIDs,
+		CustomVMXKeys:      spec.CustomVMXKeys,
+		NumCPUs:            spec.NumCPUs,
+		NumCoresPerSocket:  spec.NumCoresPerSocket,
+		MemoryMiB:          spec.MemoryMiB,
+		Resources:          spec.Resources,
+		ResizePolicy:       spec.ResizePolicy,
+		DiskGiB:            spec.DiskGiB,
+		AdditionalDisksGiB: spec.AdditionalDisksGiB,
+		DataDisks:          spec.DataDisks,
+		DiskGrowHint:       spec.DiskGrowHint,
+		DriftRemediation:   spec.DriftRemediation,
+	}
+}
+
+// getVSphereVM returns the VSphereVM owned by the VSphereMachine. The name of the VSphereVM
+// depends on the naming strategy and the OS of the VSphereMachine, so it is found through
+// its owner reference.
+func (h *ExtensionHandlers) getVSphereVM(ctx context.Context, vsphereMachine *infrav1.VSphereMachine) (*infrav1.VSphereVM, error) {
+	vsphereVMList := &infrav1.VSphereVMList{}
+	if err := h.client.List(ctx, vsphereVMList,
+		client.InNamespace(vsphereMachine.Namespace),
+		client.MatchingFields{index.VSphereVMVSphereMachineNameField: vsphereMachine.Name},
+	); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list VSphereVMs of VSphereMachine %s", klog.KObj(vsphereMachine))
+	}
+	for i := range vsphereVMList.Items {
+		vsphereVM := &vsphereVMList.Items[i]
+		for _, ref := range vsphereVM.OwnerReferences {
+			if ref.Kind == "VSphereMachine" && ref.Name == vsphereMachine.Name && ref.UID == vsphereMachine.UID {
+				return vsphereVM, nil
+			}
+		}
+	}
+	return nil, apierrors.NewNotFound(infrav1.GroupVersion.WithResource("vspherevms").GroupResource(), vsphereMachine.Name)
+}
+
+// areDisksExtendable returns true if the disks of the VM of the VSphereMachine can be extended.
+// The disks of a linked clone, of an instant clone and of a VM with snapshots are backed by
+// delta disks, which can't be extended.
+func (h *ExtensionHandlers) areDisksExtendable(ctx context.Context, vsphereMachine *infrav1.VSphereMachine) (bool, error) {
+	vsphereVM, err := h.getVSphereVM(ctx, vsphereMachine)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if vsphereVM.Status.CloneMode != infrav1.FullClone {
+		return false, nil
+	}
+
+	snapshots := &infrav1.VSphereVMSnapshotList{}
+	if err := h.client.List(ctx, snapshots,
+		client.InNamespace(vsphereVM.Namespace),
+		client.MatchingFields{index.VSphereVMSnapshotVMNameField: vsphereVM.Name},
+	); err != nil {
+		return false, pkgerrors.Wrapf(err, "failed to list VSphereVMSnapshots of VSphereVM %s", klog.KObj(vsphereVM))
+	}
+	if len(snapshots.Items) > 0 {
+		return false, nil
+	}
+	return true, nil
+}
+
+// decodeVSphereMachine decodes a VSphereMachine.
+// It returns nil if the object is not a VSphereMachine of the govmomi API.
+func (h *ExtensionHandlers) decodeVSphereMachine(raw runtime.RawExtension) (*infrav1.VSphereMachine, error) {
+	obj, err := h.decode(raw)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+	vsphereMachine, ok := obj.(*infrav1.VSphereMachine)
+	if !ok {
+		return nil, nil
+	}
+	return vsphereMachine, nil
+}
+
+// decodeVSphereMachineTemplate decodes a VSphereMachineTemplate.
+// It returns nil if the object is not a VSphereMachineTemplate of the govmomi API.
+func (h *ExtensionHandlers) decodeVSphereMachineTemplate(raw runtime.RawExtension) (*infrav1.VSphereMachineTemplate, error) {
+	obj, err := h.decode(raw)
+	if err != nil || obj == nil {
+		return nil, err
+	}
+	vsphereMachineTemplate, ok := obj.(*infrav1.VSphereMachineTemplate)
+	if !ok {
+		return nil, nil
+	}
+	return vsphereMachineTemplate, nil
+}
+
+// decode decodes an object of the govmomi API.
+// It returns nil if the object is of another API, e.g. of the supervisor API.
+func (h *ExtensionHandlers) decode(raw runtime.RawExtension) (runtime.Object, error) {
+	obj, _, err := h.decoder.Decode(raw.Raw, nil, nil)
+	if err != nil {
+		if runtime.IsNotRegisteredError(err) {
+			return nil, nil
+		}
+		return nil, pkgerrors.Wrap(err, "failed to decode object")
+	}
+	return obj, nil
+}
+
+// createJSONPatch creates a RFC 6902 JSON patch from the original and the modified object.
+func createJSONPatch(marshalledOriginal []byte, modified runtime.Object) ([]byte, error) {
+	marshalledModified, err := json.Marshal(modified)
+	if err != nil {
+		return nil, pkgerrors.Errorf("failed to marshal modified object: %v", err)
+	}
+
+	patch, err := jsonpatch.CreatePatch(marshalledOriginal, marshalledModified)
+	if err != nil {
+		return nil, pkgerrors.Errorf("failed to create patch: %v", err)
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return nil, pkgerrors.Errorf("failed to marshal patch: %v", err)
+	}
+
+	return patchBytes, nil
+}
@@ -0,0 +1,432 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inplaceupdate
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"gomodules.xyz/jsonpatch/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/api/runtime/hooks/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/index"
+)
+
+func Test_canUpdateVSphereMachineSpec(t *testing.T) {
+	tests := []struct {
+		name          string
+		update        func(spec *infrav1.VSphereMachineSpec)
+		linkedClone   bool
+		wantCanUpdate bool
+	}{
+		{
+			name:          "tags can be added",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.TagIDs = append(spec.TagIDs, "tag-2") },
+			wantCanUpdate: true,
+		},
+		{
+			name:          "tags can't be removed",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.TagIDs = nil },
+			wantCanUpdate: false,
+		},
+		{
+			name: "custom VMX keys can be changed",
+			update: func(spec *infrav1.VSphereMachineSpec) {
+				spec.CustomVMXKeys = map[string]string{"foo": "baz", "bar": "foo"}
+			},
+			wantCanUpdate: true,
+		},
+		{
+			name:          "custom VMX keys can't be removed",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.CustomVMXKeys = map[string]string{"bar": "foo"} },
+			wantCanUpdate: false,
+		},
+		{
+			name: "CPU, memory and resources can be changed",
+			update: func(spec *infrav1.VSphereMachineSpec) {
+				spec.NumCPUs = 8
+				spec.NumCoresPerSocket = ptr.To[int32](4)
+				spec.MemoryMiB = 16384
+				spec.Resources.Shares.CPU = 4000
+				spec.ResizePolicy = infrav1.VirtualMachineResizePolicyPowerCycle
+			},
+			wantCanUpdate: true,
+		},
+		{
+			name:          "CPU can't be unset",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.NumCPUs = 0 },
+			wantCanUpdate: false,
+		},
+		{
+			name:          "resources can't be unset",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.Resources.Shares.CPU = 0 },
+			wantCanUpdate: false,
+		},
+		{
+			name: "disks can be extended",
+			update: func(spec *infrav1.VSphereMachineSpec) {
+				spec.DiskGiB = 50
+				spec.AdditionalDisksGiB = []int32{20}
+				spec.DataDisks[0].SizeGiB = 100
+				spec.DiskGrowHint = infrav1.DiskGrowHintGuestInfo
+			},
+			wantCanUpdate: true,
+		},
+		{
+			name:          "disks of a linked clone can't be extended",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.DiskGiB = 50 },
+			linkedClone:   true,
+			wantCanUpdate: false,
+		},
+		{
+			name:          "disks can't be shrunk",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.DiskGiB = 10 },
+			wantCanUpdate: false,
+		},
+		{
+			name: "data disks can't be added",
+			update: func(spec *infrav1.VSphereMachineSpec) {
+				spec.DataDisks = append(spec.DataDisks, infrav1.VSphereDisk{Name: "logs", SizeGiB: 10})
+			},
+			wantCanUpdate: false,
+		},
+		{
+			name: "data disks can't be changed",
+			update: func(spec *infrav1.VSphereMachineSpec) {
+				spec.DataDisks[0].ProvisioningMode = infrav1.ThickProvisioningMode
+			},
+			wantCanUpdate: false,
+		},
+		{
+			name:          "the MTU can't be changed",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.Network.Devices[0].MTU = ptr.To[int64](9000) },
+			wantCanUpdate: false,
+		},
+		{
+			name:          "the network can't be changed",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.Network.Devices[0].NetworkName = "other-network" },
+			wantCanUpdate: false,
+		},
+		{
+			name:          "the template can't be changed",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.Template = "ubuntu-2404" },
+			wantCanUpdate: false,
+		},
+		{
+			name:          "the power off mode can be changed",
+			update:        func(spec *infrav1.VSphereMachineSpec) { spec.PowerOffMode = infrav1.VirtualMachinePowerOpModeHard },
+			wantCanUpdate: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			current := newVSphereMachine().Spec
+			desired := current.DeepCopy()
+			tt.update(desired)
+
+			canUpdateVSphereMachineSpec(&current, desired, !tt.linkedClone)
+			g.Expect(reflect.DeepEqual(current, *desired)).To(Equal(tt.wantCanUpdate))
+		})
+	}
+}
+
+func TestDoCanUpdateMachine(t *testing.T) {
+	current := newVSphereMachine()
+	desired := current.DeepCopy()
+	desired.Spec.NumCPUs = 8
+	desired.Spec.DiskGiB = 50
+	desired.Spec.Template = "ubuntu-2404"
+
+	// The name of the VSphereVM is generated with the naming strategy of the VSphereMachine.
+	vmName := current.Name + "-vm"
+	newVSphereVM := func(cloneMode infrav1.CloneMode) *infrav1.VSphereVM {
+		return &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      vmName,
+				Namespace: current.Namespace,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "VSphereMachine",
+					Name:       current.Name,
+					UID:        current.UID,
+				}},
+			},
+			Status: infrav1.VSphereVMStatus{CloneMode: cloneMode},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		objects   []client.Object
+		wantPaths []string
+	}{
+		{
+			name:      "disks of a full clone can be extended",
+			objects:   []client.Object{newVSphereVM(infrav1.FullClone)},
+			wantPaths: []string{"/spec/numCPUs", "/spec/diskGiB"},
+		},
+		{
+			name:      "disks of a linked clone can't be extended",
+			objects:   []client.Object{newVSphereVM(infrav1.LinkedClone)},
+			wantPaths: []string{"/spec/numCPUs"},
+		},
+		{
+			name: "disks of a VM with snapshots can't be extended",
+			objects: []client.Object{
+				newVSphereVM(infrav1.FullClone),
+				&infrav1.VSphereVMSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot-1", Namespace: current.Namespace},
+					Spec:       infrav1.VSphereVMSnapshotSpec{VMName: vmName},
+				},
+			},
+			wantPaths: []string{"/spec/numCPUs"},
+		},
+		{
+			name: "snapshots of other VMs are ignored",
+			objects: []client.Object{
+				newVSphereVM(infrav1.FullClone),
+				&infrav1.VSphereVMSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot-1", Namespace: current.Namespace},
+					Spec:       infrav1.VSphereVMSnapshotSpec{VMName: "other-vm"},
+				},
+			},
+			wantPaths: []string{"/spec/numCPUs", "/spec/diskGiB"},
+		},
+		{
+			name:      "disks can't be extended before the VSphereVM exists",
+			wantPaths: []string{"/spec/numCPUs"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			h := NewExtensionHandlers(newFakeClient(scheme, tt.objects...))
+			req := &runtimehooksv1.CanUpdateMachineRequest{
+				Current: runtimehooksv1.CanUpdateMachineRequestObjects{InfrastructureMachine: toRawExtension(g, current)},
+				Desired: runtimehooksv1.CanUpdateMachineRequestObjects{InfrastructureMachine: toRawExtension(g, desired)},
+			}
+			resp := &runtimehooksv1.CanUpdateMachineResponse{}
+			h.DoCanUpdateMachine(context.Background(), req, resp)
+			g.Expect(resp.Status).To(Equal(runtimehooksv1.ResponseStatusSuccess))
+			g.Expect(resp.InfrastructureMachinePatch.PatchType).To(Equal(runtimehooksv1.JSONPatchType))
+
+			var operations []jsonpatch.Operation
+			g.Expect(json.Unmarshal(resp.InfrastructureMachinePatch.Patch, &operations)).To(Succeed())
+			var paths []string
+			for _, operation := range operations {
+				paths = append(paths, operation.Path)
+			}
+			g.Expect(paths).To(ConsistOf(tt.wantPaths))
+		})
+	}
+}
+
+func TestDoUpdateMachine(t *testing.T) {
+	vsphereMachine := newVSphereMachine()
+
+	newVSphereVM := func(update func(vm *infrav1.VSphereVM)) *infrav1.VSphereVM {
+		vm := &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       vsphereMachine.Name,
+				Namespace:  vsphereMachine.Namespace,
+				Generation: 2,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: infrav1.GroupVersion.String(),
+					Kind:       "VSphereMachine",
+					Name:       vsphereMachine.Name,
+					UID:        vsphereMachine.UID,
+				}},
+			},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: *vsphereMachine.Spec.VirtualMachineCloneSpec.DeepCopy(),
+			},
+			Status: infrav1.VSphereVMStatus{
+				Conditions: []metav1.Condition{
+					{Type: infrav1.VSphereVMReadyCondition, Status: metav1.ConditionTrue, ObservedGeneration: 2},
+				},
+			},
+		}
+		if update != nil {
+			update(vm)
+		}
+		return vm
+	}
+
+	tests := []struct {
+		name      string
+		vsphereVM *infrav1.VSphereVM
+		wantDone  bool
+		wantErr   bool
+	}{
+		{
+			name:      "update is done when the VSphereVM is ready",
+			vsphereVM: newVSphereVM(nil),
+			wantDone:  true,
+		},
+		{
+			name:      "VSphereVM with a name from the naming strategy is found by its owner",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) { vm.Name = "machine-1-vm" }),
+			wantDone:  true,
+		},
+		{
+			name:      "waits for the spec of the VSphereVM to be updated",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) { vm.Spec.NumCPUs = 2 }),
+		},
+		{
+			name:      "waits for the VSphereVM to be reconciled",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) { vm.Generation = 3 }),
+		},
+		{
+			name:      "waits for tasks to complete",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) { vm.Status.TaskRef = "task-1" }),
+		},
+		{
+			name: "waits for the VM to be resized",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) {
+				vm.Status.Conditions = append(vm.Status.Conditions, metav1.Condition{
+					Type:   infrav1.VSphereVMVirtualMachineResizedCondition,
+					Status: metav1.ConditionFalse,
+					Reason: infrav1.VSphereVMVirtualMachineResizingReason,
+				})
+			}),
+		},
+		{
+			name: "fails when the VM requires a power cycle",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) {
+				vm.Status.Conditions = append(vm.Status.Conditions, metav1.Condition{
+					Type:   infrav1.VSphereVMVirtualMachineResizedCondition,
+					Status: metav1.ConditionFalse,
+					Reason: infrav1.VSphereVMVirtualMachineResizePowerCycleRequiredReason,
+				})
+			}),
+			wantErr: true,
+		},
+		{
+			name: "fails when disks would have to be shrunk",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) {
+				vm.Status.Conditions = append(vm.Status.Conditions, metav1.Condition{
+					Type:   infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+					Status: metav1.ConditionFalse,
+					Reason: infrav1.VSphereVMVirtualMachineDisksShrinkNotSupportedReason,
+				})
+			}),
+			wantErr: true,
+		},
+		{
+			name: "fails when delta disks would have to be extended",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) {
+				vm.Status.Conditions = append(vm.Status.Conditions, metav1.Condition{
+					Type:   infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+					Status: metav1.ConditionFalse,
+					Reason: infrav1.VSphereVMVirtualMachineDisksDeltaDiskNotResizableReason,
+				})
+			}),
+			wantErr: true,
+		},
+		{
+			name:      "fails when the VSphereVM does not exist",
+			vsphereVM: newVSphereVM(func(vm *infrav1.VSphereVM) { vm.OwnerReferences = nil }),
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			c := newFakeClient(scheme, tt.vsphereVM)
+
+			h := NewExtensionHandlers(c)
+			req := &runtimehooksv1.UpdateMachineRequest{
+				Desired: runtimehooksv1.UpdateMachineRequestObjects{InfrastructureMachine: toRawExtension(g, vsphereMachine)},
+			}
+			resp := &runtimehooksv1.UpdateMachineResponse{}
+			h.DoUpdateMachine(context.Background(), req, resp)
+
+			if tt.wantErr {
+				g.Expect(resp.Status).To(Equal(runtimehooksv1.ResponseStatusFailure))
+				return
+			}
+			g.Expect(resp.Status).To(Equal(runtimehooksv1.ResponseStatusSuccess))
+			if tt.wantDone {
+				g.Expect(resp.RetryAfterSeconds).To(BeZero())
+			} else {
+				g.Expect(resp.RetryAfterSeconds).ToNot(BeZero())
+			}
+		})
+	}
+}
+
+func newFakeClient(scheme *runtime.Scheme, objects ...client.Object) client.Client {
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(&infrav1.VSphereVM{}, index.VSphereVMVSphereMachineNameField, index.VSphereVMByVSphereMachineName).
+		WithIndex(&infrav1.VSphereVMSnapshot{}, index.VSphereVMSnapshotVMNameField, index.VSphereVMSnapshotByVMName).
+		Build()
+}
+
+func newVSphereMachine() *infrav1.VSphereMachine {
+	return &infrav1.VSphereMachine{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: infrav1.GroupVersion.String(),
+			Kind:       "VSphereMachine",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: "default",
+			UID:       "uid-1",
+		},
+		Spec: infrav1.VSphereMachineSpec{
+			VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+				Template:           "ubuntu-2204",
+				TagIDs:             []string{"tag-1"},
+				CustomVMXKeys:      map[string]string{"foo": "bar"},
+				NumCPUs:            4,
+				MemoryMiB:          8192,
+				Resources:          infrav1.VirtualMachineResources{Shares: infrav1.VirtualMachineResourceShares{CPU: 2000}},
+				DiskGiB:            25,
+				AdditionalDisksGiB: []int32{10},
+				DataDisks:          []infrav1.VSphereDisk{{Name: "data", SizeGiB: 50}},
+				Network: infrav1.NetworkSpec{
+					Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "network-1"}},
+				},
+			},
+		},
+	}
+}
+
+func toRawExtension(g *WithT, obj runtime.Object) runtime.RawExtension {
+	raw, err := json.Marshal(obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	return runtime.RawExtension{Raw: raw}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package index contains the field indexes which are used to find the objects
+// related to a VSphereMachine or a VSphereVM in the cache.
+package index
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+const (
+	// VSphereVMVSphereMachineNameField is the field of the index of VSphereVMs by the name
+	// of the VSphereMachine owning them.
+	VSphereVMVSphereMachineNameField = "vsphereMachineName"
+
+	// VSphereVMSnapshotVMNameField is the field of the index of VSphereVMSnapshots by the
+	// name of their VSphereVM.
+	VSphereVMSnapshotVMNameField = "spec.vmName"
+)
+
+// AddDefaultIndexes adds the indexes of the VSphereVMs and VSphereVMSnapshots to the manager.
+func AddDefaultIndexes(ctx context.Context, mgr ctrl.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &infrav1.VSphereVM{}, VSphereVMVSphereMachineNameField, VSphereVMByVSphereMachineName); err != nil {
+		return pkgerrors.Wrapf(err, "error setting index field %s", VSphereVMVSphereMachineNameField)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &infrav1.VSphereVMSnapshot{}, VSphereVMSnapshotVMNameField, VSphereVMSnapshotByVMName); err != nil {
+		return pkgerrors.Wrapf(err, "error setting index field %s", VSphereVMSnapshotVMNameField)
+	}
+	return nil
+}
+
+// VSphereVMByVSphereMachineName contains the logic to index VSphereVMs by the name of
+// the VSphereMachine owning them.
+func VSphereVMByVSphereMachineName(o client.Object) []string {
+	vsphereVM, ok := o.(*infrav1.VSphereVM)
+	if !ok {
+		return nil
+	}
+	for _, ref := range vsphereVM.OwnerReferences {
+		if ref.Kind == "VSphereMachine" && ref.APIVersion == infrav1.GroupVersion.String() {
+			return []string{ref.Name}
+		}
+	}
+	return nil
+}
+
+// VSphereVMSnapshotByVMName contains the logic to index VSphereVMSnapshots by the name
+// of their VSphereVM.
+func VSphereVMSnapshotByVMName(o client.Object) []string {
+	snapshot, ok := o.(*infrav1.VSphereVMSnapshot)
+	if !ok || snapshot.Spec.VMName == "" {
+		return nil
+	}
+	return []string{snapshot.Spec.VMName}
+}
@@ -62,6 +62,7 @@ import (
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-vsphere/feature"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/index"
 	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
 	"sigs.k8s.io/cluster-api-provider-vsphere/internal/webhooks"
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
@@ -231,7 +232,10 @@ func NewTestEnvironment(ctx context.Context) *TestEnvironment {
 		Password:   simr.Password(),
 		Converter:  conversionapi.DefaultConverterFor(vmoprv1alpha5.GroupVersion),
 	}
-	managerOpts.AddToManager = func(_ context.Context, _ *capvcontext.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	managerOpts.AddToManager = func(ctx context.Context, _ *capvcontext.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+		if err := index.AddDefaultIndexes(ctx, mgr); err != nil {
+			return err
+		}
 		if err := (&webhooks.VSphereCluster{}).SetupWebhookWithManager(mgr); err != nil {
 			return err
 		}
@@ -241,7 +245,7 @@ func NewTestEnvironment(ctx context.Context) *TestEnvironment {
 		if err := (&webhooks.VSphereClusterIdentity{}).SetupWebhookWithManager(mgr); err != nil {
 			return err
 		}
-		if err := (&webhooks.VSphereMachine{}).SetupWebhookWithManager(mgr); err != nil {
+		if err := (&webhooks.VSphereMachine{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 			return err
 		}
 
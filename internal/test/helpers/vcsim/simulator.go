@@ -19,12 +19,14 @@ package vcsim
 
 import (
 	"context"
+	"encoding/pem"
 	"fmt"
 	"net/url"
 
 	"github.com/onsi/gomega/gbytes"
 	"github.com/vmware/govmomi/simulator"
 	_ "github.com/vmware/govmomi/vapi/simulator" // run init func to register the tagging API endpoints.
+	"github.com/vmware/govmomi/vim25/soap"
 )
 
 // Simulator binds together a vcsim model and its server.
@@ -44,6 +46,17 @@ func (s Simulator) ServerURL() *url.URL {
 	return s.server.URL
 }
 
+// CABundle returns the PEM encoded certificate of the Simulator's server,
+// which can be used as CA bundle to verify the connection to the Simulator.
+func (s Simulator) CABundle() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.server.Certificate().Raw})
+}
+
+// Thumbprint returns the SHA-256 thumbprint of the certificate of the Simulator's server.
+func (s Simulator) Thumbprint() string {
+	return soap.ThumbprintSHA256(s.server.Certificate())
+}
+
 // Run a govc command on the Simulator.
 func (s Simulator) Run(commandStr string, buffers ...*gbytes.Buffer) error {
 	pwd, _ := s.server.URL.User.Password()
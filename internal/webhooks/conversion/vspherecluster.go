@@ -45,6 +45,12 @@ func ConvertVSphereClusterV1Beta1ToHub(_ context.Context, src *infrav1beta1.VSph
 		return err
 	}
 
+	if ok {
+		dst.Spec.CABundleRef = restored.Spec.CABundleRef
+		dst.Spec.Insecure = restored.Spec.Insecure
+		dst.Spec.Placement = restored.Spec.Placement
+	}
+
 	clusterv1.Convert_bool_To_Pointer_bool(src.Spec.DisableClusterModule, ok, restored.Spec.DisableClusterModule, &dst.Spec.DisableClusterModule)
 
 	if len(src.Spec.ClusterModules) == len(dst.Spec.ClusterModules) {
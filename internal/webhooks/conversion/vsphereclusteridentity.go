@@ -44,6 +44,12 @@ func ConvertVSphereClusterIdentityV1Beta1ToHub(_ context.Context, src *infrav1be
 		return err
 	}
 
+	if ok {
+		dst.Spec.CredentialsProvider = restored.Spec.CredentialsProvider
+		dst.Spec.CABundleRef = restored.Spec.CABundleRef
+		dst.Status.LastCredentialsRotationTime = restored.Status.LastCredentialsRotationTime
+	}
+
 	clusterv1.Convert_bool_To_Pointer_bool(src.Status.Ready, ok, restored.Status.Ready, &dst.Status.Ready)
 	return nil
 }
@@ -44,6 +44,12 @@ func ConvertVSphereClusterTemplateV1Beta1ToHub(_ context.Context, src *infrav1be
 		return err
 	}
 
+	if ok {
+		dst.Spec.Template.Spec.CABundleRef = restored.Spec.Template.Spec.CABundleRef
+		dst.Spec.Template.Spec.Insecure = restored.Spec.Template.Spec.Insecure
+		dst.Spec.Template.Spec.Placement = restored.Spec.Template.Spec.Placement
+	}
+
 	clusterv1.Convert_bool_To_Pointer_bool(src.Spec.Template.Spec.DisableClusterModule, ok, restored.Spec.Template.Spec.DisableClusterModule, &dst.Spec.Template.Spec.DisableClusterModule)
 
 	if len(src.Spec.Template.Spec.ClusterModules) == len(dst.Spec.Template.Spec.ClusterModules) {
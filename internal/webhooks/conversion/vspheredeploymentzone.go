@@ -19,6 +19,7 @@ package conversion
 import (
 	"context"
 
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta1"
@@ -32,10 +33,31 @@ var VSphereDeploymentZone = conversion.NewHubSpokeConverter(&infrav1.VSphereDepl
 
 // ConvertVSphereDeploymentZoneV1Beta1ToHub converts a v1beta1 VSphereDeploymentZone to a hub VSphereDeploymentZone.
 func ConvertVSphereDeploymentZoneV1Beta1ToHub(_ context.Context, src *infrav1beta1.VSphereDeploymentZone, dst *infrav1.VSphereDeploymentZone) error {
-	return infrav1beta1.Convert_v1beta1_VSphereDeploymentZone_To_v1beta2_VSphereDeploymentZone(src, dst, nil)
+	if err := infrav1beta1.Convert_v1beta1_VSphereDeploymentZone_To_v1beta2_VSphereDeploymentZone(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &infrav1.VSphereDeploymentZone{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		dst.Spec.CABundleRef = restored.Spec.CABundleRef
+		dst.Spec.Insecure = restored.Spec.Insecure
+		dst.Spec.TemplateReplicas = restored.Spec.TemplateReplicas
+		dst.Status.TemplateReplicas = restored.Status.TemplateReplicas
+		dst.Status.ManagedHosts = restored.Status.ManagedHosts
+	}
+	return nil
 }
 
 // ConvertVSphereDeploymentZoneHubToV1Beta1 converts a hub VSphereDeploymentZone to a v1beta1 VSphereDeploymentZone.
 func ConvertVSphereDeploymentZoneHubToV1Beta1(_ context.Context, src *infrav1.VSphereDeploymentZone, dst *infrav1beta1.VSphereDeploymentZone) error {
-	return infrav1beta1.Convert_v1beta2_VSphereDeploymentZone_To_v1beta1_VSphereDeploymentZone(src, dst, nil)
+	if err := infrav1beta1.Convert_v1beta2_VSphereDeploymentZone_To_v1beta1_VSphereDeploymentZone(src, dst, nil); err != nil {
+		return err
+	}
+
+	return utilconversion.MarshalDataUnsafeNoCopy(src, dst)
 }
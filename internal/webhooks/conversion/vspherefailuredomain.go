@@ -19,6 +19,7 @@ package conversion
 import (
 	"context"
 
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta1"
@@ -32,7 +33,22 @@ var VSphereFailureDomain = conversion.NewHubSpokeConverter(&infrav1.VSphereFailu
 
 // ConvertVSphereFailureDomainV1Beta1ToHub converts a v1beta1 VSphereFailureDomain to a hub VSphereFailureDomain.
 func ConvertVSphereFailureDomainV1Beta1ToHub(_ context.Context, src *infrav1beta1.VSphereFailureDomain, dst *infrav1.VSphereFailureDomain) error {
-	return infrav1beta1.Convert_v1beta1_VSphereFailureDomain_To_v1beta2_VSphereFailureDomain(src, dst, nil)
+	if err := infrav1beta1.Convert_v1beta1_VSphereFailureDomain_To_v1beta2_VSphereFailureDomain(src, dst, nil); err != nil {
+		return err
+	}
+
+	restored := &infrav1.VSphereFailureDomain{}
+	ok, err := utilconversion.UnmarshalData(src, restored)
+	if err != nil {
+		return err
+	}
+
+	if ok {
+		dst.Spec.Server = restored.Spec.Server
+		dst.Spec.Topology.Hosts.Managed = restored.Spec.Topology.Hosts.Managed
+		dst.Status = restored.Status
+	}
+	return nil
 }
 
 // ConvertVSphereFailureDomainHubToV1Beta1 converts a hub VSphereFailureDomain to a v1beta1 VSphereFailureDomain.
@@ -44,5 +60,5 @@ func ConvertVSphereFailureDomainHubToV1Beta1(_ context.Context, src *infrav1.VSp
 	if dst.Spec.Topology.ComputeCluster != nil && *dst.Spec.Topology.ComputeCluster == "" {
 		dst.Spec.Topology.ComputeCluster = nil
 	}
-	return nil
+	return utilconversion.MarshalDataUnsafeNoCopy(src, dst)
 }
@@ -45,6 +45,22 @@ func ConvertVSphereMachineV1Beta1ToHub(_ context.Context, src *infrav1beta1.VSph
 		return err
 	}
 
+	if ok {
+		dst.Spec.TemplateSelector = restored.Spec.TemplateSelector
+		dst.Spec.ContentLibraryItem = restored.Spec.ContentLibraryItem
+		dst.Spec.Sysprep = restored.Spec.Sysprep
+		dst.Spec.ResizePolicy = restored.Spec.ResizePolicy
+		dst.Spec.DiskGrowHint = restored.Spec.DiskGrowHint
+		dst.Spec.DriftRemediation = restored.Spec.DriftRemediation
+		dst.Spec.DatastoreCluster = restored.Spec.DatastoreCluster
+		dst.Spec.InstantCloneParent = restored.Spec.InstantCloneParent
+		dst.Spec.SnapshotPolicy = restored.Spec.SnapshotPolicy
+		dst.Spec.TemplateRef = restored.Spec.TemplateRef
+		dst.Spec.BootstrapDataDelivery = restored.Spec.BootstrapDataDelivery
+		dst.Spec.BootstrapDataScrub = restored.Spec.BootstrapDataScrub
+		dst.Status.FailureDomain = restored.Status.FailureDomain
+	}
+
 	clusterv1.Convert_int32_To_Pointer_int32(src.Spec.NumCoresPerSocket, ok, restored.Spec.NumCoresPerSocket, &dst.Spec.NumCoresPerSocket)
 
 	if len(src.Spec.Network.Routes) == len(dst.Spec.Network.Routes) {
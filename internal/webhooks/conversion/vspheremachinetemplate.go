@@ -44,6 +44,23 @@ func ConvertVSphereMachineTemplateV1Beta1ToHub(_ context.Context, src *infrav1be
 		return err
 	}
 
+	if ok {
+		dst.Status = restored.Status
+		dst.Spec.WarmPool = restored.Spec.WarmPool
+		dst.Spec.Template.Spec.TemplateSelector = restored.Spec.Template.Spec.TemplateSelector
+		dst.Spec.Template.Spec.ContentLibraryItem = restored.Spec.Template.Spec.ContentLibraryItem
+		dst.Spec.Template.Spec.Sysprep = restored.Spec.Template.Spec.Sysprep
+		dst.Spec.Template.Spec.ResizePolicy = restored.Spec.Template.Spec.ResizePolicy
+		dst.Spec.Template.Spec.DiskGrowHint = restored.Spec.Template.Spec.DiskGrowHint
+		dst.Spec.Template.Spec.DriftRemediation = restored.Spec.Template.Spec.DriftRemediation
+		dst.Spec.Template.Spec.DatastoreCluster = restored.Spec.Template.Spec.DatastoreCluster
+		dst.Spec.Template.Spec.InstantCloneParent = restored.Spec.Template.Spec.InstantCloneParent
+		dst.Spec.Template.Spec.SnapshotPolicy = restored.Spec.Template.Spec.SnapshotPolicy
+		dst.Spec.Template.Spec.TemplateRef = restored.Spec.Template.Spec.TemplateRef
+		dst.Spec.Template.Spec.BootstrapDataDelivery = restored.Spec.Template.Spec.BootstrapDataDelivery
+		dst.Spec.Template.Spec.BootstrapDataScrub = restored.Spec.Template.Spec.BootstrapDataScrub
+	}
+
 	clusterv1.Convert_int32_To_Pointer_int32(src.Spec.Template.Spec.NumCoresPerSocket, ok, restored.Spec.Template.Spec.NumCoresPerSocket, &dst.Spec.Template.Spec.NumCoresPerSocket)
 
 	if len(src.Spec.Template.Spec.Network.Routes) == len(dst.Spec.Template.Spec.Network.Routes) {
@@ -45,6 +45,27 @@ func ConvertVSphereVMV1Beta1ToHub(_ context.Context, src *infrav1beta1.VSphereVM
 		return err
 	}
 
+	if ok {
+		dst.Spec.TemplateSelector = restored.Spec.TemplateSelector
+		dst.Spec.ContentLibraryItem = restored.Spec.ContentLibraryItem
+		dst.Spec.Sysprep = restored.Spec.Sysprep
+		dst.Spec.ResizePolicy = restored.Spec.ResizePolicy
+		dst.Spec.DiskGrowHint = restored.Spec.DiskGrowHint
+		dst.Spec.DriftRemediation = restored.Spec.DriftRemediation
+		dst.Spec.DatastoreCluster = restored.Spec.DatastoreCluster
+		dst.Spec.InstantCloneParent = restored.Spec.InstantCloneParent
+		dst.Spec.SnapshotPolicy = restored.Spec.SnapshotPolicy
+		dst.Spec.TemplateRef = restored.Spec.TemplateRef
+		dst.Spec.BootstrapDataDelivery = restored.Spec.BootstrapDataDelivery
+		dst.Spec.BootstrapDataScrub = restored.Spec.BootstrapDataScrub
+		dst.Status.TemplateUUID = restored.Status.TemplateUUID
+		dst.Status.TemplateLibraryItemID = restored.Status.TemplateLibraryItemID
+		dst.Status.Drift = restored.Status.Drift
+		dst.Status.Datastore = restored.Status.Datastore
+		dst.Status.BootstrapISO = restored.Status.BootstrapISO
+		dst.Status.ContentLibraryItemID = restored.Status.ContentLibraryItemID
+	}
+
 	clusterv1.Convert_int32_To_Pointer_int32(src.Spec.NumCoresPerSocket, ok, restored.Spec.NumCoresPerSocket, &dst.Spec.NumCoresPerSocket)
 
 	if src.Spec.BootstrapRef != nil {
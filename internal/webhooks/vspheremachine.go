@@ -27,9 +27,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/index"
 	"sigs.k8s.io/cluster-api-provider-vsphere/internal/webhooks/conversion"
 )
 
@@ -37,7 +39,11 @@ import (
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-infrastructure-cluster-x-k8s-io-v1beta2-vspheremachine,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=vspheremachines,versions=v1beta2,name=default.vspheremachine.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
 
 // VSphereMachine implements a validation and defaulting webhook for VSphereMachine.
-type VSphereMachine struct{}
+type VSphereMachine struct {
+	// Client is used to check whether the disks of the VM of a VSphereMachine can be extended.
+	// The check is skipped if it is not set.
+	Client client.Reader
+}
 
 var _ admission.Validator[*infrav1.VSphereMachine] = &VSphereMachine{}
 var _ admission.Defaulter[*infrav1.VSphereMachine] = &VSphereMachine{}
@@ -84,7 +90,7 @@ func (webhook *VSphereMachine) ValidateCreate(_ context.Context, obj *infrav1.VS
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *VSphereMachine) ValidateUpdate(_ context.Context, oldTyped, newTyped *infrav1.VSphereMachine) (admission.Warnings, error) {
+func (webhook *VSphereMachine) ValidateUpdate(ctx context.Context, oldTyped, newTyped *infrav1.VSphereMachine) (admission.Warnings, error) {
 	var allErrs field.ErrorList
 
 	if newTyped.Spec.GuestSoftPowerOffTimeoutSeconds != 0 {
@@ -106,12 +112,27 @@ func (webhook *VSphereMachine) ValidateUpdate(_ context.Context, oldTyped, newTy
 	newVSphereMachineSpec := newVSphereMachine["spec"].(map[string]interface{})
 	oldVSphereMachineSpec := oldVSphereMachine["spec"].(map[string]interface{})
 
-	allowChangeKeys := []string{"providerID", "powerOffMode", "guestSoftPowerOffTimeoutSeconds"}
+	// Allow changes to the CPU and memory settings which are applied to the existing VM
+	// according to the resizePolicy, and to the tags and custom VMX keys which are
+	// applied to the existing VM, and to the fields for which drift is remediated.
+	allowChangeKeys := []string{"providerID", "powerOffMode", "guestSoftPowerOffTimeoutSeconds", "numCPUs", "numCoresPerSocket", "memoryMiB", "resources", "resizePolicy", "tagIDs", "customVMXKeys", "driftRemediation"}
 	for _, key := range allowChangeKeys {
 		delete(oldVSphereMachineSpec, key)
 		delete(newVSphereMachineSpec, key)
 	}
 
+	// Allow increasing disk sizes, which extends the disks of the existing VM.
+	allErrs = append(allErrs, validateDiskSizeUpdate(oldTyped.Spec.VirtualMachineCloneSpec, newTyped.Spec.VirtualMachineCloneSpec)...)
+	if webhook.Client != nil && isDiskSizeIncreased(oldTyped.Spec.VirtualMachineCloneSpec, newTyped.Spec.VirtualMachineCloneSpec) {
+		errs, err := webhook.validateDisksExtendable(ctx, newTyped)
+		if err != nil {
+			return nil, apierrors.NewInternalError(err)
+		}
+		allErrs = append(allErrs, errs...)
+	}
+	deleteDiskSizeKeys(oldVSphereMachineSpec)
+	deleteDiskSizeKeys(newVSphereMachineSpec)
+
 	newVSphereMachineNetwork := newVSphereMachineSpec["network"].(map[string]interface{})
 	oldVSphereMachineNetwork := oldVSphereMachineSpec["network"].(map[string]interface{})
 
@@ -141,6 +162,115 @@ func (webhook *VSphereMachine) ValidateDelete(_ context.Context, _ *infrav1.VSph
 	return nil, nil
 }
 
+// validateDiskSizeUpdate validates that disk sizes are only increased, as increasing
+// them extends the disks of the existing VM while disks are never shrunk.
+func validateDiskSizeUpdate(oldSpec, newSpec infrav1.VirtualMachineCloneSpec) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if oldSpec.DiskGiB != 0 && newSpec.DiskGiB < oldSpec.DiskGiB {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "diskGiB"), newSpec.DiskGiB, "cannot be decreased"))
+	}
+
+	if len(oldSpec.AdditionalDisksGiB) != len(newSpec.AdditionalDisksGiB) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "additionalDisksGiB"), "the number of additional disks cannot be modified"))
+	} else {
+		for i := range newSpec.AdditionalDisksGiB {
+			if newSpec.AdditionalDisksGiB[i] < oldSpec.AdditionalDisksGiB[i] {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "additionalDisksGiB").Index(i), newSpec.AdditionalDisksGiB[i], "cannot be decreased"))
+			}
+		}
+	}
+
+	// Other changes to data disks are caught when comparing the specs without sizeGiB.
+	if len(oldSpec.DataDisks) == len(newSpec.DataDisks) {
+		for i := range newSpec.DataDisks {
+			if newSpec.DataDisks[i].SizeGiB < oldSpec.DataDisks[i].SizeGiB {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "dataDisks").Index(i).Child("sizeGiB"), newSpec.DataDisks[i].SizeGiB, "cannot be decreased"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// isDiskSizeIncreased returns true if the size of any disk has been increased.
+func isDiskSizeIncreased(oldSpec, newSpec infrav1.VirtualMachineCloneSpec) bool {
+	if newSpec.DiskGiB > oldSpec.DiskGiB {
+		return true
+	}
+	for i := range min(len(oldSpec.AdditionalDisksGiB), len(newSpec.AdditionalDisksGiB)) {
+		if newSpec.AdditionalDisksGiB[i] > oldSpec.AdditionalDisksGiB[i] {
+			return true
+		}
+	}
+	for i := range min(len(oldSpec.DataDisks), len(newSpec.DataDisks)) {
+		if newSpec.DataDisks[i].SizeGiB > oldSpec.DataDisks[i].SizeGiB {
+			return true
+		}
+	}
+	return false
+}
+
+// validateDisksExtendable validates that the disks of the VM of the VSphereMachine can be extended.
+// The disks of a linked clone, of an instant clone and of a VM with snapshots are backed by delta disks,
+// which can't be extended.
+func (webhook *VSphereMachine) validateDisksExtendable(ctx context.Context, vsphereMachine *infrav1.VSphereMachine) (field.ErrorList, error) {
+	var allErrs field.ErrorList
+
+	// The name of the VSphereVM depends on the naming strategy and the OS of the VSphereMachine,
+	// so it is found through its owner reference.
+	vsphereVMList := &infrav1.VSphereVMList{}
+	if err := webhook.Client.List(ctx, vsphereVMList,
+		client.InNamespace(vsphereMachine.Namespace),
+		client.MatchingFields{index.VSphereVMVSphereMachineNameField: vsphereMachine.Name},
+	); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list VSphereVMs of VSphereMachine %s/%s", vsphereMachine.Namespace, vsphereMachine.Name)
+	}
+	var vsphereVM *infrav1.VSphereVM
+	for i := range vsphereVMList.Items {
+		for _, ref := range vsphereVMList.Items[i].OwnerReferences {
+			if ref.Kind == "VSphereMachine" && ref.Name == vsphereMachine.Name && ref.UID == vsphereMachine.UID {
+				vsphereVM = &vsphereVMList.Items[i]
+				break
+			}
+		}
+	}
+	if vsphereVM == nil {
+		return nil, nil
+	}
+	if vsphereVM.Status.CloneMode == infrav1.LinkedClone || vsphereVM.Status.CloneMode == infrav1.InstantClone {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), fmt.Sprintf("disks cannot be extended, VM %s is a %s", vsphereVM.Name, vsphereVM.Status.CloneMode)))
+		return allErrs, nil
+	}
+
+	snapshots := &infrav1.VSphereVMSnapshotList{}
+	if err := webhook.Client.List(ctx, snapshots,
+		client.InNamespace(vsphereVM.Namespace),
+		client.MatchingFields{index.VSphereVMSnapshotVMNameField: vsphereVM.Name},
+	); err != nil {
+		return nil, pkgerrors.Wrapf(err, "failed to list VSphereVMSnapshots of VSphereVM %s/%s", vsphereVM.Namespace, vsphereVM.Name)
+	}
+	if len(snapshots.Items) > 0 {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), fmt.Sprintf("disks cannot be extended, VM %s has snapshots", vsphereVM.Name)))
+	}
+	return allErrs, nil
+}
+
+// deleteDiskSizeKeys deletes the keys which define disk sizes from an unstructured spec,
+// so the remaining spec can be compared to detect other changes.
+func deleteDiskSizeKeys(spec map[string]interface{}) {
+	delete(spec, "diskGiB")
+	delete(spec, "additionalDisksGiB")
+	delete(spec, "diskGrowHint")
+	if dataDisks, ok := spec["dataDisks"].([]interface{}); ok {
+		for _, dataDisk := range dataDisks {
+			if dataDisk, ok := dataDisk.(map[string]interface{}); ok {
+				delete(dataDisk, "sizeGiB")
+			}
+		}
+	}
+}
+
 func validatePCIDevices(devices []infrav1.PCIDeviceSpec) field.ErrorList {
 	var allErrs field.ErrorList
 
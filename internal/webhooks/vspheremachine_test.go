@@ -21,9 +21,14 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/index"
 )
 
 var someProviderID = "vsphere://42305f0b-dad7-1d3d-5727-0eaffffffffc"
@@ -141,6 +146,7 @@ func TestVSphereMachine_ValidateUpdate(t *testing.T) {
 		name              string
 		oldVSphereMachine *infrav1.VSphereMachine
 		vsphereMachine    *infrav1.VSphereMachine
+		objects           []client.Object
 		wantErr           bool
 	}{
 		{
@@ -197,10 +203,116 @@ func TestVSphereMachine_ValidateUpdate(t *testing.T) {
 			vsphereMachine:    createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil),
 			wantErr:           false,
 		},
+		{
+			name:              "CPU, memory and resizePolicy can be updated",
+			oldVSphereMachine: createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil),
+			vsphereMachine: func() *infrav1.VSphereMachine {
+				m := createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil)
+				m.Spec.NumCPUs = 4
+				m.Spec.NumCoresPerSocket = ptr.To[int32](2)
+				m.Spec.MemoryMiB = 8192
+				m.Spec.Resources.Shares.CPU = 2000
+				m.Spec.ResizePolicy = infrav1.VirtualMachineResizePolicyPowerCycle
+				return m
+			}(),
+			wantErr: false,
+		},
+		{
+			name:              "tags and custom VMX keys can be updated",
+			oldVSphereMachine: createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil),
+			vsphereMachine: func() *infrav1.VSphereMachine {
+				m := createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil)
+				m.Spec.TagIDs = []string{"urn:vmomi:InventoryServiceTag:tag-1:GLOBAL"}
+				m.Spec.CustomVMXKeys = map[string]string{"foo": "bar"}
+				return m
+			}(),
+			wantErr: false,
+		},
+		{
+			name:              "drift remediation can be updated",
+			oldVSphereMachine: createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil),
+			vsphereMachine: func() *infrav1.VSphereMachine {
+				m := createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeSoft, 0, nil)
+				m.Spec.DriftRemediation = []infrav1.VirtualMachineDriftRemediationField{infrav1.VirtualMachineDriftRemediationFieldFolder}
+				return m
+			}(),
+			wantErr: false,
+		},
+		{
+			name:              "disk sizes can be increased",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, []infrav1.VSphereDisk{{Name: "data", SizeGiB: 10}}),
+			vsphereMachine:    createVSphereMachineWithDisks(30, []infrav1.VSphereDisk{{Name: "data", SizeGiB: 20}}),
+			wantErr:           false,
+		},
+		{
+			name:              "disk sizes can be increased for a full clone",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, nil),
+			vsphereMachine:    createVSphereMachineWithDisks(30, nil),
+			objects:           []client.Object{createVSphereVMWithCloneMode(infrav1.FullClone)},
+			wantErr:           false,
+		},
+		{
+			name:              "disk sizes cannot be increased for a linked clone",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, nil),
+			vsphereMachine:    createVSphereMachineWithDisks(30, nil),
+			objects:           []client.Object{createVSphereVMWithCloneMode(infrav1.LinkedClone)},
+			wantErr:           true,
+		},
+		{
+			name:              "disk sizes cannot be increased for a VM with snapshots",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, nil),
+			vsphereMachine:    createVSphereMachineWithDisks(30, nil),
+			objects: []client.Object{
+				createVSphereVMWithCloneMode(infrav1.FullClone),
+				&infrav1.VSphereVMSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "default"},
+					Spec:       infrav1.VSphereVMSnapshotSpec{VMName: "machine-vm"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:              "disk sizes can be increased if only other VMs have snapshots",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, nil),
+			vsphereMachine:    createVSphereMachineWithDisks(30, nil),
+			objects: []client.Object{
+				createVSphereVMWithCloneMode(infrav1.FullClone),
+				&infrav1.VSphereVMSnapshot{
+					ObjectMeta: metav1.ObjectMeta{Name: "snapshot", Namespace: "default"},
+					Spec:       infrav1.VSphereVMSnapshotSpec{VMName: "other-vm"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:              "diskGiB cannot be decreased",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, nil),
+			vsphereMachine:    createVSphereMachineWithDisks(10, nil),
+			wantErr:           true,
+		},
+		{
+			name:              "data disk size cannot be decreased",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, []infrav1.VSphereDisk{{Name: "data", SizeGiB: 10}}),
+			vsphereMachine:    createVSphereMachineWithDisks(20, []infrav1.VSphereDisk{{Name: "data", SizeGiB: 5}}),
+			wantErr:           true,
+		},
+		{
+			name:              "data disks cannot be added",
+			oldVSphereMachine: createVSphereMachineWithDisks(20, []infrav1.VSphereDisk{{Name: "data", SizeGiB: 10}}),
+			vsphereMachine:    createVSphereMachineWithDisks(20, []infrav1.VSphereDisk{{Name: "data", SizeGiB: 10}, {Name: "logs", SizeGiB: 10}}),
+			wantErr:           true,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(*testing.T) {
-			webhook := &VSphereMachine{}
+			scheme := runtime.NewScheme()
+			g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+			webhook := &VSphereMachine{Client: fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(tc.objects...).
+				WithIndex(&infrav1.VSphereVM{}, index.VSphereVMVSphereMachineNameField, index.VSphereVMByVSphereMachineName).
+				WithIndex(&infrav1.VSphereVMSnapshot{}, index.VSphereVMSnapshotVMNameField, index.VSphereVMSnapshotByVMName).
+				Build()}
 			_, err := webhook.ValidateUpdate(context.Background(), tc.oldVSphereMachine, tc.vsphereMachine)
 			if tc.wantErr {
 				g.Expect(err).To(HaveOccurred())
@@ -233,3 +345,31 @@ func createVSphereMachine(server string, providerID string, ips []string, powerO
 	}
 	return VSphereMachine
 }
+
+func createVSphereMachineWithDisks(diskGiB int32, dataDisks []infrav1.VSphereDisk) *infrav1.VSphereMachine {
+	vsphereMachine := createVSphereMachine("foo.com", someProviderID, []string{"192.168.0.1/32"}, infrav1.VirtualMachinePowerOpModeHard, 0, nil)
+	vsphereMachine.Name = "machine"
+	vsphereMachine.Namespace = "default"
+	vsphereMachine.UID = "machine-uid"
+	vsphereMachine.Spec.DiskGiB = diskGiB
+	vsphereMachine.Spec.DataDisks = dataDisks
+	return vsphereMachine
+}
+
+// createVSphereVMWithCloneMode returns the VSphereVM of the VSphereMachine created by createVSphereMachineWithDisks,
+// named by a naming strategy.
+func createVSphereVMWithCloneMode(cloneMode infrav1.CloneMode) *infrav1.VSphereVM {
+	return &infrav1.VSphereVM{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-vm",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: infrav1.GroupVersion.String(),
+				Kind:       "VSphereMachine",
+				Name:       "machine",
+				UID:        "machine-uid",
+			}},
+		},
+		Status: infrav1.VSphereVMStatus{CloneMode: cloneMode},
+	}
+}
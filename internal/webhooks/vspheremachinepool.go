@@ -0,0 +1,107 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/validate-infrastructure-cluster-x-k8s-io-v1beta2-vspheremachinepool,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=infrastructure.cluster.x-k8s.io,resources=vspheremachinepools,versions=v1beta2,name=validation.vspheremachinepool.infrastructure.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1
+
+// VSphereMachinePool implements a validation webhook for VSphereMachinePool.
+type VSphereMachinePool struct{}
+
+var _ admission.Validator[*infrav1.VSphereMachinePool] = &VSphereMachinePool{}
+
+func (webhook *VSphereMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &infrav1.VSphereMachinePool{}).
+		WithValidator(webhook).
+		Complete()
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (webhook *VSphereMachinePool) ValidateCreate(_ context.Context, obj *infrav1.VSphereMachinePool) (admission.Warnings, error) {
+	return nil, AggregateObjErrors(obj.GroupVersionKind().GroupKind(), obj.Name, validateVSphereMachinePool(obj))
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (webhook *VSphereMachinePool) ValidateUpdate(_ context.Context, _, newTyped *infrav1.VSphereMachinePool) (admission.Warnings, error) {
+	// The template is mutable, changes are rolled out to the VSphereVMs of the pool.
+	return nil, AggregateObjErrors(newTyped.GroupVersionKind().GroupKind(), newTyped.Name, validateVSphereMachinePool(newTyped))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (webhook *VSphereMachinePool) ValidateDelete(_ context.Context, _ *infrav1.VSphereMachinePool) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func validateVSphereMachinePool(obj *infrav1.VSphereMachinePool) field.ErrorList {
+	var allErrs field.ErrorList
+	spec := obj.Spec.Template
+	templatePath := field.NewPath("spec", "template")
+
+	for i, device := range spec.Network.Devices {
+		if len(device.IPAddrs) != 0 {
+			allErrs = append(allErrs, field.Forbidden(templatePath.Child("network", "devices").Index(i).Child("ipAddrs"), "cannot be set in machine pools"))
+		}
+	}
+	if spec.HardwareVersion != "" {
+		r := regexp.MustCompile("^vmx-[1-9][0-9]?$")
+		if !r.MatchString(spec.HardwareVersion) {
+			allErrs = append(allErrs, field.Invalid(templatePath.Child("hardwareVersion"), spec.HardwareVersion, "should be a valid VM hardware version, example vmx-17"))
+		}
+	}
+	if spec.GuestSoftPowerOffTimeoutSeconds != 0 && spec.PowerOffMode != infrav1.VirtualMachinePowerOpModeTrySoft {
+		allErrs = append(allErrs, field.Invalid(templatePath.Child("guestSoftPowerOffTimeoutSeconds"), spec.GuestSoftPowerOffTimeoutSeconds, "should not be set unless the powerOffMode is trySoft"))
+	}
+	allErrs = append(allErrs, validatePCIDevices(spec.PciDevices)...)
+
+	rollingUpdate := obj.Spec.Strategy.RollingUpdate
+	rollingUpdatePath := field.NewPath("spec", "strategy", "rollingUpdate")
+	maxSurge, surgeErrs := validateIntOrPercent(rollingUpdate.MaxSurge, rollingUpdatePath.Child("maxSurge"), 1)
+	allErrs = append(allErrs, surgeErrs...)
+	maxUnavailable, unavailableErrs := validateIntOrPercent(rollingUpdate.MaxUnavailable, rollingUpdatePath.Child("maxUnavailable"), 0)
+	allErrs = append(allErrs, unavailableErrs...)
+	if len(surgeErrs) == 0 && len(unavailableErrs) == 0 && maxSurge == 0 && maxUnavailable == 0 {
+		allErrs = append(allErrs, field.Invalid(rollingUpdatePath.Child("maxUnavailable"), rollingUpdate.MaxUnavailable, "cannot be 0 when maxSurge is 0"))
+	}
+	return allErrs
+}
+
+// validateIntOrPercent validates an absolute number or a percentage, and returns its value
+// scaled to 100 replicas.
+func validateIntOrPercent(value *intstr.IntOrString, fldPath *field.Path, defaultValue int) (int, field.ErrorList) {
+	if value == nil {
+		return defaultValue, nil
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true)
+	if err != nil {
+		return 0, field.ErrorList{field.Invalid(fldPath, value.String(), err.Error())}
+	}
+	if scaled < 0 {
+		return 0, field.ErrorList{field.Invalid(fldPath, value.String(), "must not be negative")}
+	}
+	return scaled, nil
+}
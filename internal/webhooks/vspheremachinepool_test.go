@@ -0,0 +1,116 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func TestVSphereMachinePool_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modifyFn func(*infrav1.VSphereMachinePool)
+		wantErr  bool
+	}{
+		{
+			name:     "valid VSphereMachinePool",
+			modifyFn: func(*infrav1.VSphereMachinePool) {},
+		},
+		{
+			name: "IPs set in the template",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Template.Network.Devices = []infrav1.NetworkDeviceSpec{{IPAddrs: []string{"192.168.0.1/32"}}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "incorrect hardware version",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Template.HardwareVersion = "vmx-0"
+			},
+			wantErr: true,
+		},
+		{
+			name: "guestSoftPowerOffTimeoutSeconds set without trySoft powerOffMode",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Template.PowerOffMode = infrav1.VirtualMachinePowerOpModeHard
+				p.Spec.Template.GuestSoftPowerOffTimeoutSeconds = 60
+			},
+			wantErr: true,
+		},
+		{
+			name: "percentages for maxSurge and maxUnavailable",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("25%"))
+				p.Spec.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromString("25%"))
+			},
+		},
+		{
+			name: "invalid maxSurge",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromString("many"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "maxSurge and maxUnavailable are both 0",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(0))
+			},
+			wantErr: true,
+		},
+		{
+			name: "maxSurge is 0 and maxUnavailable is set",
+			modifyFn: func(p *infrav1.VSphereMachinePool) {
+				p.Spec.Strategy.RollingUpdate.MaxSurge = ptr.To(intstr.FromInt32(0))
+				p.Spec.Strategy.RollingUpdate.MaxUnavailable = ptr.To(intstr.FromInt32(1))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			vsphereMachinePool := &infrav1.VSphereMachinePool{
+				Spec: infrav1.VSphereMachinePoolSpec{
+					Template: infrav1.VSphereMachinePoolMachineSpec{
+						VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+							Server:   "foo.com",
+							Template: "ubuntu",
+						},
+					},
+				},
+			}
+			tt.modifyFn(vsphereMachinePool)
+
+			webhook := &VSphereMachinePool{}
+			_, err := webhook.ValidateCreate(context.Background(), vsphereMachinePool)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
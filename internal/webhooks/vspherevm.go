@@ -105,8 +105,11 @@ func (webhook *VSphereVM) ValidateUpdate(_ context.Context, oldTyped, newTyped *
 	newVSphereVMSpec := newVSphereVM["spec"].(map[string]interface{})
 	oldVSphereVMSpec := oldVSphereVM["spec"].(map[string]interface{})
 
-	// Allow changes to bootstrapRef, thumbprint, powerOffMode, guestSoftPowerOffTimeout.
-	keys := []string{"bootstrapRef", "thumbprint", "powerOffMode", "guestSoftPowerOffTimeoutSeconds"}
+	// Allow changes to bootstrapRef, thumbprint, powerOffMode, guestSoftPowerOffTimeout,
+	// to the CPU and memory settings which are applied according to the resizePolicy
+	// to the tags and custom VMX keys which are applied to the existing VM and to the
+	// fields for which drift is remediated.
+	keys := []string{"bootstrapRef", "thumbprint", "powerOffMode", "guestSoftPowerOffTimeoutSeconds", "numCPUs", "numCoresPerSocket", "memoryMiB", "resources", "resizePolicy", "tagIDs", "customVMXKeys", "driftRemediation"}
 	// Allow changes to os only if the old spec has empty OS field.
 	if oldTyped.Spec.OS == "" {
 		keys = append(keys, "os")
@@ -118,6 +121,11 @@ func (webhook *VSphereVM) ValidateUpdate(_ context.Context, oldTyped, newTyped *
 	webhook.deleteSpecKeys(oldVSphereVMSpec, keys)
 	webhook.deleteSpecKeys(newVSphereVMSpec, keys)
 
+	// Allow increasing disk sizes, which extends the disks of the existing VM.
+	allErrs = append(allErrs, validateDiskSizeUpdate(oldTyped.Spec.VirtualMachineCloneSpec, newTyped.Spec.VirtualMachineCloneSpec)...)
+	deleteDiskSizeKeys(oldVSphereVMSpec)
+	deleteDiskSizeKeys(newVSphereVMSpec)
+
 	newVSphereVMNetwork := newVSphereVMSpec["network"].(map[string]interface{})
 	oldVSphereVMNetwork := oldVSphereVMSpec["network"].(map[string]interface{})
 
@@ -198,6 +198,40 @@ func TestVSphereVM_ValidateUpdate(t *testing.T) {
 			vSphereVM:    createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0),
 			wantErr:      true,
 		},
+		{
+			name:         "CPU, memory and resizePolicy can be updated",
+			oldVSphereVM: createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0),
+			vSphereVM: func() *infrav1.VSphereVM {
+				vm := createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0)
+				vm.Spec.NumCPUs = 4
+				vm.Spec.MemoryMiB = 8192
+				vm.Spec.Resources.Shares.Memory = 2000
+				vm.Spec.ResizePolicy = infrav1.VirtualMachineResizePolicyPowerCycle
+				return vm
+			}(),
+			wantErr: false,
+		},
+		{
+			name:         "tags and custom VMX keys can be updated",
+			oldVSphereVM: createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0),
+			vSphereVM: func() *infrav1.VSphereVM {
+				vm := createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0)
+				vm.Spec.TagIDs = []string{"urn:vmomi:InventoryServiceTag:tag-1:GLOBAL"}
+				vm.Spec.CustomVMXKeys = map[string]string{"foo": "bar"}
+				return vm
+			}(),
+			wantErr: false,
+		},
+		{
+			name:         "drift remediation can be updated",
+			oldVSphereVM: createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0),
+			vSphereVM: func() *infrav1.VSphereVM {
+				vm := createVSphereVM("vsphere-vm-1", "foo.com", biosUUID, "AA:BB:CC:DD:EE", []string{"192.168.0.1/32"}, infrav1.VSphereVMBootstrapReference{}, infrav1.Linux, infrav1.VirtualMachinePowerOpModeTrySoft, 0)
+				vm.Spec.DriftRemediation = []infrav1.VirtualMachineDriftRemediationField{infrav1.VirtualMachineDriftRemediationFieldNetwork}
+				return vm
+			}(),
+			wantErr: false,
+		},
 	}
 	for _, tc := range tests {
 		t.Run(tc.name, func(*testing.T) {
@@ -73,10 +73,12 @@ import (
 	"sigs.k8s.io/cluster-api-provider-vsphere/controllers"
 	"sigs.k8s.io/cluster-api-provider-vsphere/controllers/vmware"
 	"sigs.k8s.io/cluster-api-provider-vsphere/feature"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/index"
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/conversion"
 	conversionapi "sigs.k8s.io/cluster-api-provider-vsphere/pkg/conversion/api"
 	vmoprvhub "sigs.k8s.io/cluster-api-provider-vsphere/pkg/conversion/api/vmoperator/hub"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/identity"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/manager"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/vmoperator"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
@@ -105,16 +107,22 @@ var (
 	watchNamespace              string
 	vmOperatorAPIVersion        string
 	featureGates                string
+	credentialsDir              string
+	credentialsPluginDir        string
 
 	clusterCacheConcurrency           int
 	vSphereClusterConcurrency         int
 	vSphereMachineConcurrency         int
 	vSphereMachineTemplateConcurrency int
+	vSphereMachinePoolConcurrency     int
+	vSphereVMSnapshotConcurrency      int
+	vSphereTemplateConcurrency        int
 	providerServiceAccountConcurrency int
 	serviceDiscoveryConcurrency       int
 	vSphereVMConcurrency              int
 	vSphereClusterIdentityConcurrency int
 	vSphereDeploymentZoneConcurrency  int
+	vSphereFailureDomainConcurrency   int
 	virtualMachineGroupConcurrency    int
 	skipCRDMigrationPhases            []string
 
@@ -150,6 +158,15 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&vSphereMachineTemplateConcurrency, "vspheremachinetemplate-concurrency", 10,
 		"Number of vSphere machine templates to process simultaneously")
 
+	fs.IntVar(&vSphereMachinePoolConcurrency, "vspheremachinepool-concurrency", 10,
+		"Number of vSphere machine pools to process simultaneously")
+
+	fs.IntVar(&vSphereVMSnapshotConcurrency, "vspherevmsnapshot-concurrency", 10,
+		"Number of vSphere vm snapshots to process simultaneously")
+
+	fs.IntVar(&vSphereTemplateConcurrency, "vspheretemplate-concurrency", 10,
+		"Number of vSphere templates to process simultaneously")
+
 	fs.IntVar(&providerServiceAccountConcurrency, "providerserviceaccount-concurrency", 50,
 		"Number of provider service accounts to process simultaneously")
 
@@ -165,6 +182,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&vSphereDeploymentZoneConcurrency, "vspheredeploymentzone-concurrency", 10,
 		"Number of vSphere deployment zones to process simultaneously")
 
+	fs.IntVar(&vSphereFailureDomainConcurrency, "vspherefailuredomain-concurrency", 10,
+		"Number of vSphere failure domains to process simultaneously")
+
 	fs.IntVar(&virtualMachineGroupConcurrency, "virtualmachinegroup-concurrency", 50,
 		"Number of virtual machine group to process simultaneously")
 
@@ -181,6 +201,27 @@ func InitFlags(fs *pflag.FlagSet) {
 		"path to CAPV's credentials file",
 	)
 
+	fs.StringVar(
+		&credentialsDir,
+		"credentials-dir",
+		"",
+		"path to the directory containing the files which can be used by VSphereClusterIdentities with a file credentials provider or as the service account token of a Vault credentials provider. Both are disabled if not set.",
+	)
+
+	fs.StringVar(
+		&credentialsPluginDir,
+		"credentials-plugin-dir",
+		"",
+		"path to the directory containing the plugins which can be used by VSphereClusterIdentities with an exec credentials provider. The exec credentials provider is disabled if not set.",
+	)
+
+	fs.IntVar(
+		&managerOpts.MaxConcurrentVCenterCalls,
+		"vcenter-max-concurrent-calls",
+		0,
+		"The maximum number of concurrent API calls per vCenter, across all sessions of the vCenter. Long polls waiting for task updates and content library deployments are not limited. The number of concurrent calls is not limited if set to 0.",
+	)
+
 	fs.StringVar(
 		&managerOpts.NetworkProvider,
 		"network-provider",
@@ -566,7 +607,7 @@ func main() {
 			crdMigratorConfig[&infrav1.VSphereVM{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
 			crdMigratorConfig[&infrav1.VSphereClusterIdentity{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
 			crdMigratorConfig[&infrav1.VSphereDeploymentZone{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
-			crdMigratorConfig[&infrav1.VSphereFailureDomain{}] = crdmigrator.ByObjectConfig{UseCache: true}
+			crdMigratorConfig[&infrav1.VSphereFailureDomain{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
 		}
 		if isSupervisorCRDLoaded {
 			crdMigratorConfig[&vmwarev1.VSphereCluster{}] = crdmigrator.ByObjectConfig{UseCache: true, UseStatusForStorageVersionMigration: true}
@@ -618,6 +659,9 @@ func main() {
 		managerOpts.NetworkProvider = manager.ConvertNetworkProviderName(managerOpts.NetworkProvider)
 	}
 
+	identity.SetCredentialsDir(credentialsDir)
+	identity.SetExecPluginDir(credentialsPluginDir)
+
 	mgr, err := manager.New(ctx, managerOpts)
 	if err != nil {
 		setupLog.Error(err, "Error creating manager")
@@ -645,6 +689,10 @@ func main() {
 }
 
 func setupVAPIControllers(ctx context.Context, controllerCtx *capvcontext.ControllerManagerContext, mgr ctrlmgr.Manager, clusterCache clustercache.ClusterCache) error {
+	if err := index.AddDefaultIndexes(ctx, mgr); err != nil {
+		return err
+	}
+
 	if err := (&webhooks.VSphereCluster{}).SetupWebhookWithManager(mgr); err != nil {
 		return err
 	}
@@ -662,6 +710,10 @@ func setupVAPIControllers(ctx context.Context, controllerCtx *capvcontext.Contro
 		return err
 	}
 
+	if err := (&webhooks.VSphereMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+		return err
+	}
+
 	if err := (&webhooks.VSphereVM{}).SetupWebhookWithManager(mgr); err != nil {
 		return err
 	}
@@ -686,6 +738,22 @@ func setupVAPIControllers(ctx context.Context, controllerCtx *capvcontext.Contro
 	if err := controllers.AddVsphereClusterIdentityControllerToManager(ctx, controllerCtx, mgr, concurrency(vSphereClusterIdentityConcurrency)); err != nil {
 		return err
 	}
+	if err := controllers.AddVSphereMachineTemplateControllerToManager(ctx, controllerCtx, mgr, concurrency(vSphereMachineTemplateConcurrency)); err != nil {
+		return err
+	}
+	if err := controllers.AddVSphereMachinePoolControllerToManager(ctx, controllerCtx, mgr, clusterCache, concurrency(vSphereMachinePoolConcurrency)); err != nil {
+		return err
+	}
+	if err := controllers.AddVSphereVMSnapshotControllerToManager(ctx, controllerCtx, mgr, concurrency(vSphereVMSnapshotConcurrency)); err != nil {
+		return err
+	}
+	if err := controllers.AddVSphereTemplateControllerToManager(ctx, controllerCtx, mgr, concurrency(vSphereTemplateConcurrency)); err != nil {
+		return err
+	}
+
+	if err := controllers.AddVSphereFailureDomainControllerToManager(ctx, controllerCtx, mgr, concurrency(vSphereFailureDomainConcurrency)); err != nil {
+		return err
+	}
 
 	return controllers.AddVSphereDeploymentZoneControllerToManager(ctx, controllerCtx, mgr, concurrency(vSphereDeploymentZoneConcurrency))
 }
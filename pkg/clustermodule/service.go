@@ -30,7 +30,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
 )
 
-const validMachineTemplate = "VSphereMachineTemplate"
+const (
+	validMachineTemplate = "VSphereMachineTemplate"
+	validMachinePool     = "VSphereMachinePool"
+)
 
 type service struct {
 	ControllerManagerContext *capvcontext.ControllerManagerContext
@@ -52,29 +55,29 @@ func (s *service) Create(ctx context.Context, clusterCtx *capvcontext.ClusterCon
 	if err != nil {
 		return "", pkgerrors.Wrapf(err, "error fetching template ref for object %s/%s", wrapper.GetNamespace(), wrapper.GetName())
 	}
-	if templateRef.Kind != validMachineTemplate {
+	if templateRef.Kind != validMachineTemplate && templateRef.Kind != validMachinePool {
 		// since this is a heterogeneous cluster, we should skip cluster module creation for non VSphereMachine objects
 		log.V(4).Info("Skipping module creation for non-VSphereMachine objects")
 		return "", nil
 	}
 
-	template, err := s.fetchMachineTemplate(ctx, wrapper, templateRef.Name)
+	cloneSpec, err := s.fetchCloneSpec(ctx, wrapper, templateRef)
 	if err != nil {
 		return "", pkgerrors.Wrapf(err, "error fetching machine template for object %s/%s", wrapper.GetNamespace(), wrapper.GetName())
 	}
-	if server := template.Spec.Template.Spec.Server; server != clusterCtx.VSphereCluster.Spec.Server {
+	if server := cloneSpec.Server; server != clusterCtx.VSphereCluster.Spec.Server {
 		log.V(4).Info("Skipping module creation for object since template uses a different server", "server", server)
 		return "", nil
 	}
 
-	vCenterSession, err := s.fetchSessionForObject(ctx, clusterCtx, template)
+	vCenterSession, err := s.fetchSessionForObject(ctx, clusterCtx, cloneSpec)
 	if err != nil {
 		return "", pkgerrors.Wrapf(err, "error fetching session for object %s/%s", wrapper.GetNamespace(), wrapper.GetName())
 	}
 
 	// Fetch the compute cluster resource by tracing the owner of the resource pool in use.
 	// TODO (srm09): How do we support Multi AZ scenarios here
-	computeClusterRef, err := getComputeClusterResource(ctx, vCenterSession, template.Spec.Template.Spec.ResourcePool)
+	computeClusterRef, err := getComputeClusterResource(ctx, vCenterSession, cloneSpec.ResourcePool)
 	if err != nil {
 		return "", pkgerrors.Wrapf(err, "error fetching compute cluster resource")
 	}
@@ -93,12 +96,12 @@ func (s *service) DoesExist(ctx context.Context, clusterCtx *capvcontext.Cluster
 		return false, pkgerrors.Wrapf(err, "error fetching template ref for object %s/%s", wrapper.GetNamespace(), wrapper.GetName())
 	}
 
-	template, err := s.fetchMachineTemplate(ctx, wrapper, templateRef.Name)
+	cloneSpec, err := s.fetchCloneSpec(ctx, wrapper, templateRef)
 	if err != nil {
 		return false, pkgerrors.Wrapf(err, "error fetching machine template for object %s/%s", wrapper.GetNamespace(), wrapper.GetName())
 	}
 
-	vCenterSession, err := s.fetchSessionForObject(ctx, clusterCtx, template)
+	vCenterSession, err := s.fetchSessionForObject(ctx, clusterCtx, cloneSpec)
 	if err != nil {
 		return false, pkgerrors.Wrapf(err, "error fetching session for object %s/%s", wrapper.GetNamespace(), wrapper.GetName())
 	}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
@@ -79,6 +80,46 @@ func TestService_Create(t *testing.T) {
 			g.Expect(err).ToNot(gomega.HaveOccurred())
 			g.Expect(moduleUUID).To(gomega.BeEmpty())
 		})
+
+		t.Run("when machine pool uses a different vCenter URL", func(t *testing.T) {
+			mp := &clusterv1.MachinePool{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: clusterv1.GroupVersion.String(),
+					Kind:       "MachinePool",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "mp",
+					Namespace: fake.Namespace,
+					Labels:    map[string]string{clusterv1.ClusterNameLabel: fake.Clusterv1a2Name},
+				},
+			}
+			mp.Spec.Template.Spec.InfrastructureRef = clusterv1.ContractVersionedObjectReference{
+				Kind: "VSphereMachinePool",
+				Name: "blah-pool",
+			}
+
+			machinePool := &infrav1.VSphereMachinePool{
+				TypeMeta: metav1.TypeMeta{Kind: "VSphereMachinePool"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "blah-pool",
+					Namespace: fake.Namespace,
+				},
+				Spec: infrav1.VSphereMachinePoolSpec{
+					Template: infrav1.VSphereMachinePoolMachineSpec{
+						VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{Server: fmt.Sprintf("not.%s", fake.VCenterURL)},
+					},
+				},
+			}
+
+			g := gomega.NewWithT(t)
+			controllerManagerContext := fake.NewControllerManagerContext(mp, machinePool)
+			clusterCtx := fake.NewClusterContext(ctx, controllerManagerContext)
+			svc := NewService(controllerManagerContext, controllerManagerContext.Client)
+
+			moduleUUID, err := svc.Create(ctx, clusterCtx, NewWrapper(mp))
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(moduleUUID).To(gomega.BeEmpty())
+		})
 	})
 
 	t.Run("Create, DoesExist and Remove works", func(t *testing.T) {
@@ -112,6 +153,7 @@ func TestService_Create(t *testing.T) {
 		controllerManagerContext := fake.NewControllerManagerContext(md, machineTemplate)
 		clusterCtx := fake.NewClusterContext(context.Background(), controllerManagerContext)
 		clusterCtx.VSphereCluster.Spec.Server = simr.ServerURL().Host
+		clusterCtx.VSphereCluster.Spec.Insecure = ptr.To(true)
 		controllerManagerContext.Username = simr.Username()
 		controllerManagerContext.Password = simr.Password()
 
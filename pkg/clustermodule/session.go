@@ -22,6 +22,7 @@ import (
 	pkgerrors "github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,10 +32,10 @@ import (
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
 )
 
-func (s *service) fetchSessionForObject(ctx context.Context, clusterCtx *capvcontext.ClusterContext, template *infrav1.VSphereMachineTemplate) (*session.Session, error) {
+func (s *service) fetchSessionForObject(ctx context.Context, clusterCtx *capvcontext.ClusterContext, cloneSpec *infrav1.VirtualMachineCloneSpec) (*session.Session, error) {
 	params := s.newParams(*clusterCtx)
 	// Datacenter is necessary since we use the finder.
-	params = params.WithDatacenter(template.Spec.Template.Spec.Datacenter)
+	params = params.WithDatacenter(cloneSpec.Datacenter)
 
 	return s.fetchSession(ctx, clusterCtx, params)
 }
@@ -42,10 +43,17 @@ func (s *service) fetchSessionForObject(ctx context.Context, clusterCtx *capvcon
 func (s *service) newParams(clusterCtx capvcontext.ClusterContext) *session.Params {
 	return session.NewParams().
 		WithServer(clusterCtx.VSphereCluster.Spec.Server).
-		WithThumbprint(clusterCtx.VSphereCluster.Spec.Thumbprint)
+		WithThumbprint(clusterCtx.VSphereCluster.Spec.Thumbprint).
+		WithInsecure(ptr.Deref(clusterCtx.VSphereCluster.Spec.Insecure, false))
 }
 
 func (s *service) fetchSession(ctx context.Context, clusterCtx *capvcontext.ClusterContext, params *session.Params) (*session.Session, error) {
+	caBundle, err := identity.GetCABundle(ctx, s.Client, clusterCtx.VSphereCluster, s.ControllerManagerContext.Namespace)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to get CA bundle")
+	}
+	params = params.WithCABundle(caBundle)
+
 	if clusterCtx.VSphereCluster.Spec.IdentityRef.IsDefined() {
 		creds, err := identity.GetCredentials(ctx, s.Client, clusterCtx.VSphereCluster, s.ControllerManagerContext.Namespace)
 		if err != nil {
@@ -77,13 +85,24 @@ func (s *service) fetchTemplateRef(ctx context.Context, input Wrapper) (*corev1.
 	return &objRef, nil
 }
 
-func (s *service) fetchMachineTemplate(ctx context.Context, input Wrapper, templateName string) (*infrav1.VSphereMachineTemplate, error) {
-	template := &infrav1.VSphereMachineTemplate{}
-	if err := s.Client.Get(ctx, client.ObjectKey{
-		Name:      templateName,
+// fetchCloneSpec returns the clone spec of the VSphereMachineTemplate or the VSphereMachinePool
+// referenced by the object.
+func (s *service) fetchCloneSpec(ctx context.Context, input Wrapper, templateRef *corev1.ObjectReference) (*infrav1.VirtualMachineCloneSpec, error) {
+	key := client.ObjectKey{
+		Name:      templateRef.Name,
 		Namespace: input.GetNamespace(),
-	}, template); err != nil {
+	}
+	if templateRef.Kind == validMachinePool {
+		machinePool := &infrav1.VSphereMachinePool{}
+		if err := s.Client.Get(ctx, key, machinePool); err != nil {
+			return nil, err
+		}
+		return &machinePool.Spec.Template.VirtualMachineCloneSpec, nil
+	}
+
+	template := &infrav1.VSphereMachineTemplate{}
+	if err := s.Client.Get(ctx, key, template); err != nil {
 		return nil, err
 	}
-	return template, nil
+	return &template.Spec.Template.Spec.VirtualMachineCloneSpec, nil
 }
@@ -41,6 +41,9 @@ func NewWrapper(obj client.Object) Wrapper {
 	if kcp, ok := obj.(*controlplanev1.KubeadmControlPlane); ok {
 		return kcpWrapper{kcp}
 	}
+	if mp, ok := obj.(*clusterv1.MachinePool); ok {
+		return mpWrapper{mp}
+	}
 	md, _ := obj.(*clusterv1.MachineDeployment)
 	return mdWrapper{md}
 }
@@ -68,3 +71,15 @@ func (w mdWrapper) GetTemplatePath() []string {
 func (w mdWrapper) IsControlPlane() bool {
 	return false
 }
+
+type mpWrapper struct {
+	*clusterv1.MachinePool
+}
+
+func (w mpWrapper) GetTemplatePath() []string {
+	return []string{"spec", "template", "spec", "infrastructureRef"}
+}
+
+func (w mpWrapper) IsControlPlane() bool {
+	return false
+}
@@ -53,6 +53,10 @@ func NewControllerManagerContext(initObjects ...client.Object) *capvcontext.Cont
 
 	clientWithObjects := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(
 		&infrav1.VSphereVM{},
+		&infrav1.VSphereMachineTemplate{},
+		&infrav1.VSphereVMSnapshot{},
+		&infrav1.VSphereTemplate{},
+		&infrav1.VSphereClusterIdentity{},
 		&vmwarev1.VSphereCluster{},
 		&clusterv1.Cluster{},
 	).WithObjects(initObjects...).Build()
@@ -32,10 +32,23 @@ import (
 type VMContext struct {
 	*ControllerManagerContext
 	ClusterModuleInfo    *string
+	PlacementRuleInfo    *PlacementRuleInfo
 	VSphereVM            *infrav1.VSphereVM
 	PatchHelper          *patch.Helper
 	Session              *session.Session
 	VSphereFailureDomain *infrav1.VSphereFailureDomain
+	Machine              *clusterv1.Machine
+}
+
+// PlacementRuleInfo describes the DRS VM-VM rule of the VMs of a KubeadmControlPlane,
+// MachineDeployment or MachinePool.
+type PlacementRuleInfo struct {
+	// Name is the name of the DRS rule.
+	Name string
+	// Affinity is true for an affinity rule, and false for an anti-affinity rule.
+	Affinity bool
+	// Members are the BIOS UUIDs of the other VMs of the object.
+	Members []string
 }
 
 // String returns VSphereVMGroupVersionKind VSphereVMNamespace/VSphereVMName.
@@ -0,0 +1,99 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+// CABundleKey is the default key of the CA bundle in a ConfigMap or Secret.
+const CABundleKey = "ca.crt"
+
+// GetCABundle returns the CA bundle used to verify the vCenter server certificate of the VSphereCluster.
+// The CA bundle of the VSphereCluster takes precedence over the CA bundle of the VSphereClusterIdentity
+// referenced by the VSphereCluster. It returns nil if the VSphereCluster sets a thumbprint or if no CA bundle is set.
+func GetCABundle(ctx context.Context, c client.Client, cluster *infrav1.VSphereCluster, controllerNamespace string) ([]byte, error) {
+	if c == nil {
+		return nil, errors.New("kubernetes client is required")
+	}
+	if cluster == nil {
+		return nil, errors.New("vsphere cluster is required")
+	}
+
+	if cluster.Spec.Thumbprint != "" {
+		return nil, nil
+	}
+	if cluster.Spec.CABundleRef.IsDefined() {
+		return GetCABundleFromReference(ctx, c, cluster.Spec.CABundleRef, cluster.Namespace)
+	}
+
+	ref := cluster.Spec.IdentityRef
+	if ref.Kind != infrav1.VSphereClusterIdentityKind {
+		return nil, nil
+	}
+	identity := &infrav1.VSphereClusterIdentity{}
+	if err := c.Get(ctx, client.ObjectKey{Name: ref.Name}, identity); err != nil {
+		return nil, err
+	}
+	if !identity.Spec.CABundleRef.IsDefined() {
+		return nil, nil
+	}
+	return GetCABundleFromReference(ctx, c, identity.Spec.CABundleRef, controllerNamespace)
+}
+
+// GetCABundleFromReference returns the CA bundle stored in the ConfigMap or Secret referenced by ref.
+func GetCABundleFromReference(ctx context.Context, c client.Client, ref infrav1.CABundleReference, namespace string) ([]byte, error) {
+	key := ref.Key
+	if key == "" {
+		key = CABundleKey
+	}
+	objKey := client.ObjectKey{Namespace: namespace, Name: ref.Name}
+
+	var caBundle []byte
+	switch ref.Kind {
+	case infrav1.ConfigMapCABundleKind:
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, objKey, configMap); err != nil {
+			return nil, err
+		}
+		if val, ok := configMap.Data[key]; ok {
+			caBundle = []byte(val)
+		} else {
+			caBundle = configMap.BinaryData[key]
+		}
+	case infrav1.SecretCABundleKind:
+		secret := &corev1.Secret{}
+		if err := c.Get(ctx, objKey, secret); err != nil {
+			return nil, err
+		}
+		caBundle = secret.Data[key]
+	default:
+		return nil, fmt.Errorf("unknown kind %s used for CA bundle", ref.Kind)
+	}
+
+	if len(caBundle) == 0 {
+		return nil, fmt.Errorf("%s %s does not contain a CA bundle in key %s", ref.Kind, objKey, key)
+	}
+	return caBundle, nil
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func TestGetCABundle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = infrav1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-ca", Namespace: "default"},
+			Data:       map[string]string{CABundleKey: "cluster-ca"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-key-ca", Namespace: "default"},
+			Data:       map[string][]byte{"vcenter.pem": []byte("custom-key-ca")},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity-ca", Namespace: "capv-system"},
+			Data:       map[string]string{CABundleKey: "identity-ca"},
+		},
+		&infrav1.VSphereClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity-with-ca"},
+			Spec: infrav1.VSphereClusterIdentitySpec{
+				SecretName:  "credentials",
+				CABundleRef: infrav1.CABundleReference{Kind: infrav1.ConfigMapCABundleKind, Name: "identity-ca"},
+			},
+		},
+		&infrav1.VSphereClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: "identity-without-ca"},
+			Spec:       infrav1.VSphereClusterIdentitySpec{SecretName: "credentials"},
+		},
+	).Build()
+
+	tests := []struct {
+		name    string
+		spec    infrav1.VSphereClusterSpec
+		want    []byte
+		wantErr bool
+	}{
+		{
+			name: "returns nil without CA bundle",
+			spec: infrav1.VSphereClusterSpec{},
+		},
+		{
+			name: "returns nil if a thumbprint is set",
+			spec: infrav1.VSphereClusterSpec{
+				Thumbprint:  "thumbprint",
+				IdentityRef: infrav1.VSphereIdentityReference{Kind: infrav1.VSphereClusterIdentityKind, Name: "identity-with-ca"},
+			},
+		},
+		{
+			name: "returns the CA bundle of the VSphereCluster",
+			spec: infrav1.VSphereClusterSpec{
+				CABundleRef: infrav1.CABundleReference{Kind: infrav1.ConfigMapCABundleKind, Name: "cluster-ca"},
+				IdentityRef: infrav1.VSphereIdentityReference{Kind: infrav1.VSphereClusterIdentityKind, Name: "identity-with-ca"},
+			},
+			want: []byte("cluster-ca"),
+		},
+		{
+			name: "returns the CA bundle of a Secret with a custom key",
+			spec: infrav1.VSphereClusterSpec{
+				CABundleRef: infrav1.CABundleReference{Kind: infrav1.SecretCABundleKind, Name: "custom-key-ca", Key: "vcenter.pem"},
+			},
+			want: []byte("custom-key-ca"),
+		},
+		{
+			name: "returns the CA bundle of the VSphereClusterIdentity",
+			spec: infrav1.VSphereClusterSpec{
+				IdentityRef: infrav1.VSphereIdentityReference{Kind: infrav1.VSphereClusterIdentityKind, Name: "identity-with-ca"},
+			},
+			want: []byte("identity-ca"),
+		},
+		{
+			name: "returns nil if the VSphereClusterIdentity has no CA bundle",
+			spec: infrav1.VSphereClusterSpec{
+				IdentityRef: infrav1.VSphereIdentityReference{Kind: infrav1.VSphereClusterIdentityKind, Name: "identity-without-ca"},
+			},
+		},
+		{
+			name: "fails if the key does not exist",
+			spec: infrav1.VSphereClusterSpec{
+				CABundleRef: infrav1.CABundleReference{Kind: infrav1.SecretCABundleKind, Name: "custom-key-ca"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the object does not exist",
+			spec: infrav1.VSphereClusterSpec{
+				CABundleRef: infrav1.CABundleReference{Kind: infrav1.ConfigMapCABundleKind, Name: "does-not-exist"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := &infrav1.VSphereCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			caBundle, err := GetCABundle(context.Background(), c, cluster, "capv-system")
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(caBundle).To(Equal(tt.want))
+		})
+	}
+}
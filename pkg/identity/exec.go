@@ -0,0 +1,115 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+const (
+	// execTimeout is the maximum time a credentials plugin is allowed to run.
+	execTimeout = 30 * time.Second
+
+	// maxExecStderrLength is the maximum length of the plugin stderr output included in errors.
+	maxExecStderrLength = 512
+)
+
+// execPluginDir is the directory containing the plugins which can be run by the exec credentials provider.
+// The exec credentials provider is disabled if it is empty.
+var execPluginDir string
+
+// SetExecPluginDir sets the directory containing the plugins which can be run by the exec credentials provider.
+// Only binaries inside of this directory can be run, as VSphereClusterIdentities could otherwise
+// be used to run arbitrary commands inside of the controller.
+func SetExecPluginDir(dir string) {
+	execPluginDir = dir
+}
+
+// execProvider retrieves the credentials by running a plugin which writes them as JSON to stdout, e.g.
+//
+//	{"username": "administrator@vsphere.local", "password": "secret"}
+type execProvider struct {
+	command string
+	args    []string
+	env     []string
+}
+
+func newExecProvider(config infrav1.ExecCredentialsProvider) (*execProvider, error) {
+	if execPluginDir == "" {
+		return nil, errors.New("exec credentials provider is disabled, the controller has been started without a credentials plugin directory")
+	}
+	if strings.ContainsRune(config.Command, filepath.Separator) || config.Command == "." || config.Command == ".." {
+		return nil, fmt.Errorf("invalid credentials plugin %q, it must be the name of a binary in the credentials plugin directory", config.Command)
+	}
+
+	env := make([]string, 0, len(config.Env))
+	for _, envVar := range config.Env {
+		env = append(env, fmt.Sprintf("%s=%s", envVar.Name, envVar.Value))
+	}
+
+	return &execProvider{
+		command: filepath.Join(execPluginDir, config.Command),
+		args:    config.Args,
+		env:     env,
+	}, nil
+}
+
+func (p *execProvider) GetCredentials(ctx context.Context) (*Credentials, error) {
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.command, p.args...) //nolint:gosec // The command is restricted to the credentials plugin directory.
+	cmd.Env = append(os.Environ(), p.env...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := stderr.String()
+		if len(msg) > maxExecStderrLength {
+			msg = msg[:maxExecStderrLength]
+		}
+		return nil, fmt.Errorf("failed to run credentials plugin %s: %w: %s", filepath.Base(p.command), err, strings.TrimSpace(msg))
+	}
+
+	output := struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}{}
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("failed to parse output of credentials plugin %s: %w", filepath.Base(p.command), err)
+	}
+
+	credentials := &Credentials{
+		Username: output.Username,
+		Password: output.Password,
+	}
+	if err := validateCredentials(credentials); err != nil {
+		return nil, fmt.Errorf("invalid credentials returned by credentials plugin %s: %w", filepath.Base(p.command), err)
+	}
+	return credentials, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+// credentialsDir is the directory containing the files which can be read by the file credentials provider
+// and by the Kubernetes auth method of the Vault credentials provider.
+// Both are disabled if it is empty.
+var credentialsDir string
+
+// SetCredentialsDir sets the directory containing the files which can be read by the file credentials provider
+// and by the Kubernetes auth method of the Vault credentials provider.
+// Only files inside of this directory can be read, as VSphereClusterIdentities could otherwise
+// be used to read arbitrary files inside of the controller, e.g. its service account token.
+func SetCredentialsDir(dir string) {
+	credentialsDir = dir
+}
+
+// fileProvider reads the credentials from the username and password files in a directory,
+// e.g. a projected volume or a volume of the Secrets Store CSI driver.
+type fileProvider struct {
+	path string
+}
+
+func newFileProvider(config infrav1.FileCredentialsProvider) (*fileProvider, error) {
+	if _, err := credentialsDirPath(config.Path); err != nil {
+		return nil, fmt.Errorf("invalid credentials path: %w", err)
+	}
+	return &fileProvider{
+		path: config.Path,
+	}, nil
+}
+
+func (p *fileProvider) GetCredentials(_ context.Context) (*Credentials, error) {
+	username, err := readCredentialsFile(filepath.Join(p.path, UsernameKey))
+	if err != nil {
+		return nil, err
+	}
+	password, err := readCredentialsFile(filepath.Join(p.path, PasswordKey))
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := &Credentials{
+		Username: username,
+		Password: password,
+	}
+	if err := validateCredentials(credentials); err != nil {
+		return nil, fmt.Errorf("invalid credentials in %s: %w", p.path, err)
+	}
+	return credentials, nil
+}
+
+// credentialsDirPath returns the path relative to the credentials directory,
+// or an error if the path is not inside of the credentials directory.
+func credentialsDirPath(path string) (string, error) {
+	if credentialsDir == "" {
+		return "", errors.New("the controller has been started without a credentials directory")
+	}
+	rel, err := filepath.Rel(credentialsDir, path)
+	if err != nil || !filepath.IsLocal(rel) {
+		return "", fmt.Errorf("%s is not inside of the credentials directory %s", path, credentialsDir)
+	}
+	return rel, nil
+}
+
+// readCredentialsFile reads a file inside of the credentials directory.
+func readCredentialsFile(path string) (string, error) {
+	rel, err := credentialsDirPath(path)
+	if err != nil {
+		return "", err
+	}
+	// The file is opened in the credentials directory, so symlinks can't be used to escape it.
+	f, err := os.OpenInRoot(credentialsDir, rel)
+	if err != nil {
+		return "", fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	defer f.Close()
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read credentials file: %w", err)
+	}
+	// Trailing newlines are stripped as they are usually added by mistake when creating the files.
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
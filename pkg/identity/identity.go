@@ -19,6 +19,7 @@ package identity
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"strings"
@@ -45,6 +46,12 @@ type Credentials struct {
 	Password string
 }
 
+// Hash returns the hex encoded SHA-256 hash of the credentials, which can be stored to detect
+// changes of the credentials without storing the credentials themselves.
+func (c Credentials) Hash() string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(c.Username+"\x00"+c.Password)))
+}
+
 // GetCredentials returns the VCenter credentials for the VSphereCluster.
 func GetCredentials(ctx context.Context, c client.Client, cluster *infrav1.VSphereCluster, controllerNamespace string) (*Credentials, error) {
 	if err := validateInputs(c, cluster); err != nil {
@@ -52,15 +59,17 @@ func GetCredentials(ctx context.Context, c client.Client, cluster *infrav1.VSphe
 	}
 
 	ref := cluster.Spec.IdentityRef
-	secret := &corev1.Secret{}
-	var secretKey client.ObjectKey
 
 	switch ref.Kind {
 	case infrav1.SecretKind:
-		secretKey = client.ObjectKey{
-			Namespace: cluster.Namespace,
-			Name:      ref.Name,
+		provider := &secretProvider{
+			client: c,
+			key: client.ObjectKey{
+				Namespace: cluster.Namespace,
+				Name:      ref.Name,
+			},
 		}
+		return provider.GetCredentials(ctx)
 	case infrav1.VSphereClusterIdentityKind:
 		identity := &infrav1.VSphereClusterIdentity{}
 		key := client.ObjectKey{
@@ -94,24 +103,14 @@ func GetCredentials(ctx context.Context, c client.Client, cluster *infrav1.VSphe
 			return nil, fmt.Errorf("namespace %s is not allowed to use specifified identity", cluster.Namespace)
 		}
 
-		secretKey = client.ObjectKey{
-			Name:      identity.Spec.SecretName,
-			Namespace: controllerNamespace,
+		provider, err := NewProvider(c, identity, controllerNamespace)
+		if err != nil {
+			return nil, err
 		}
+		return provider.GetCredentials(ctx)
 	default:
 		return nil, fmt.Errorf("unknown type %s used for Identity", ref.Kind)
 	}
-
-	if err := c.Get(ctx, secretKey, secret); err != nil {
-		return nil, err
-	}
-
-	credentials := &Credentials{
-		Username: getData(secret, UsernameKey),
-		Password: getData(secret, PasswordKey),
-	}
-
-	return credentials, nil
 }
 
 func validateInputs(c client.Client, cluster *infrav1.VSphereCluster) error {
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+// Provider retrieves the credentials used with the VSphere API.
+type Provider interface {
+	// GetCredentials returns the current credentials.
+	// Credentials are retrieved on every call so they can be rotated at the source.
+	GetCredentials(ctx context.Context) (*Credentials, error)
+}
+
+// NewProvider returns the Provider for the credentials of a VSphereClusterIdentity.
+func NewProvider(c client.Client, identity *infrav1.VSphereClusterIdentity, controllerNamespace string) (Provider, error) {
+	if identity.Spec.SecretName != "" {
+		return &secretProvider{
+			client: c,
+			key: client.ObjectKey{
+				Namespace: controllerNamespace,
+				Name:      identity.Spec.SecretName,
+			},
+		}, nil
+	}
+
+	credentialsProvider := identity.Spec.CredentialsProvider
+	switch {
+	case credentialsProvider.File.Path != "":
+		return newFileProvider(credentialsProvider.File)
+	case credentialsProvider.Vault.Address != "":
+		return newVaultProvider(c, credentialsProvider.Vault, controllerNamespace)
+	case credentialsProvider.Exec.Command != "":
+		return newExecProvider(credentialsProvider.Exec)
+	default:
+		return nil, fmt.Errorf("no credentials source configured for VSphereClusterIdentity %s", identity.Name)
+	}
+}
+
+// secretProvider reads the credentials from a Secret.
+type secretProvider struct {
+	client client.Client
+	key    client.ObjectKey
+}
+
+func (p *secretProvider) GetCredentials(ctx context.Context) (*Credentials, error) {
+	secret := &corev1.Secret{}
+	if err := p.client.Get(ctx, p.key, secret); err != nil {
+		return nil, err
+	}
+
+	credentials := &Credentials{
+		Username: getData(secret, UsernameKey),
+		Password: getData(secret, PasswordKey),
+	}
+
+	return credentials, nil
+}
+
+func validateCredentials(credentials *Credentials) error {
+	if credentials.Username == "" {
+		return errors.New("username is empty")
+	}
+	if credentials.Password == "" {
+		return errors.New("password is empty")
+	}
+	return nil
+}
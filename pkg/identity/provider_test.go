@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func TestFileProvider(t *testing.T) {
+	g := NewWithT(t)
+
+	credentialsDir := t.TempDir()
+	dir := filepath.Join(credentialsDir, "vcenter")
+	g.Expect(os.Mkdir(dir, 0700)).To(Succeed())
+	g.Expect(os.WriteFile(filepath.Join(dir, UsernameKey), []byte("user\n"), 0600)).To(Succeed())
+
+	newIdentity := func(path string) *infrav1.VSphereClusterIdentity {
+		return &infrav1.VSphereClusterIdentity{
+			Spec: infrav1.VSphereClusterIdentitySpec{
+				CredentialsProvider: infrav1.CredentialsProvider{
+					File: infrav1.FileCredentialsProvider{Path: path},
+				},
+			},
+		}
+	}
+
+	// The file credentials provider is disabled without a credentials directory.
+	_, err := NewProvider(nil, newIdentity(dir), "")
+	g.Expect(err).To(HaveOccurred())
+
+	SetCredentialsDir(credentialsDir)
+	defer SetCredentialsDir("")
+
+	// Files outside of the credentials directory can't be read.
+	_, err = NewProvider(nil, newIdentity(filepath.Join(credentialsDir, "..")), "")
+	g.Expect(err).To(HaveOccurred())
+	_, err = NewProvider(nil, newIdentity("/var/run/secrets/kubernetes.io/serviceaccount"), "")
+	g.Expect(err).To(HaveOccurred())
+
+	provider, err := NewProvider(nil, newIdentity(dir), "")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = provider.GetCredentials(context.Background())
+	g.Expect(err).To(HaveOccurred())
+
+	g.Expect(os.WriteFile(filepath.Join(dir, PasswordKey), []byte("pass"), 0600)).To(Succeed())
+	credentials, err := provider.GetCredentials(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(credentials).To(Equal(&Credentials{Username: "user", Password: "pass"}))
+}
+
+func TestExecProvider(t *testing.T) {
+	pluginDir := t.TempDir()
+	writePlugin := func(g *WithT, name, script string) {
+		g.Expect(os.WriteFile(filepath.Join(pluginDir, name), []byte("#!/bin/sh\n"+script), 0700)).To(Succeed()) //nolint:gosec // The plugin must be executable.
+	}
+
+	tests := []struct {
+		name            string
+		pluginDir       string
+		config          infrav1.ExecCredentialsProvider
+		script          string
+		want            *Credentials
+		wantProviderErr bool
+		wantErr         bool
+	}{
+		{
+			name:      "returns credentials from the plugin",
+			pluginDir: pluginDir,
+			config: infrav1.ExecCredentialsProvider{
+				Command: "valid",
+				Args:    []string{"user"},
+				Env:     []infrav1.ExecEnvVar{{Name: "PLUGIN_PASSWORD", Value: "pass"}},
+			},
+			script: `echo "{\"username\": \"$1\", \"password\": \"$PLUGIN_PASSWORD\"}"`,
+			want:   &Credentials{Username: "user", Password: "pass"},
+		},
+		{
+			name:            "fails if the exec credentials provider is disabled",
+			pluginDir:       "",
+			config:          infrav1.ExecCredentialsProvider{Command: "disabled"},
+			wantProviderErr: true,
+		},
+		{
+			name:            "fails if the command is outside of the plugin directory",
+			pluginDir:       pluginDir,
+			config:          infrav1.ExecCredentialsProvider{Command: "../valid"},
+			wantProviderErr: true,
+		},
+		{
+			name:      "fails if the plugin fails",
+			pluginDir: pluginDir,
+			config:    infrav1.ExecCredentialsProvider{Command: "failing"},
+			script:    "echo failed >&2; exit 1",
+			wantErr:   true,
+		},
+		{
+			name:      "fails if the plugin returns empty credentials",
+			pluginDir: pluginDir,
+			config:    infrav1.ExecCredentialsProvider{Command: "empty"},
+			script:    `echo "{}"`,
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			SetExecPluginDir(tt.pluginDir)
+			defer SetExecPluginDir("")
+			if tt.script != "" {
+				writePlugin(g, tt.config.Command, tt.script)
+			}
+
+			identity := &infrav1.VSphereClusterIdentity{
+				Spec: infrav1.VSphereClusterIdentitySpec{
+					CredentialsProvider: infrav1.CredentialsProvider{Exec: tt.config},
+				},
+			}
+			provider, err := NewProvider(nil, identity, "")
+			if tt.wantProviderErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			credentials, err := provider.GetCredentials(context.Background())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(credentials).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestVaultProvider(t *testing.T) {
+	// fakeVault implements the subset of the Vault API used by the Vault credentials provider.
+	fakeVault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/auth/kubernetes/login":
+			body := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["role"] != "capv" || body["jwt"] != "service-account-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"errors": ["invalid role or jwt"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"auth": {"client_token": "login-token", "lease_duration": 3600}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/secret/data/vsphere":
+			if token := r.Header.Get("X-Vault-Token"); token != "login-token" && token != "static-token" {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`{"errors": ["permission denied"]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"data": {"data": {"username": "user", "password": "pass"}, "metadata": {"version": 1}}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/kv/vsphere":
+			_, _ = w.Write([]byte(`{"data": {"username": "kv1-user", "password": "kv1-pass"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer fakeVault.Close()
+
+	credentialsDir := t.TempDir()
+	tokenPath := filepath.Join(credentialsDir, "token")
+	if err := os.WriteFile(tokenPath, []byte("service-account-token"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	SetCredentialsDir(credentialsDir)
+	defer SetCredentialsDir("")
+
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "capv-system"},
+		Data:       map[string][]byte{VaultTokenKey: []byte("static-token")},
+	}
+	c := fake.NewClientBuilder().WithObjects(tokenSecret).Build()
+
+	tests := []struct {
+		name            string
+		config          infrav1.VaultCredentialsProvider
+		want            *Credentials
+		wantProviderErr bool
+		wantErr         bool
+	}{
+		{
+			name: "reads a KV version 2 secret using the Kubernetes auth method",
+			config: infrav1.VaultCredentialsProvider{
+				Address:        fakeVault.URL,
+				Path:           "secret/data/vsphere",
+				KubernetesAuth: infrav1.VaultKubernetesAuth{Role: "capv", TokenPath: tokenPath},
+			},
+			want: &Credentials{Username: "user", Password: "pass"},
+		},
+		{
+			name: "fails if the token is outside of the credentials directory",
+			config: infrav1.VaultCredentialsProvider{
+				Address:        fakeVault.URL,
+				Path:           "secret/data/vsphere",
+				KubernetesAuth: infrav1.VaultKubernetesAuth{Role: "capv", TokenPath: "/var/run/secrets/kubernetes.io/serviceaccount/token"},
+			},
+			wantProviderErr: true,
+		},
+		{
+			name: "reads a KV version 1 secret using a token from a Secret",
+			config: infrav1.VaultCredentialsProvider{
+				Address:         fakeVault.URL,
+				Path:            "kv/vsphere",
+				TokenSecretName: "vault-token",
+			},
+			want: &Credentials{Username: "kv1-user", Password: "kv1-pass"},
+		},
+		{
+			name: "fails if login fails",
+			config: infrav1.VaultCredentialsProvider{
+				Address:        fakeVault.URL,
+				Path:           "secret/data/vsphere",
+				KubernetesAuth: infrav1.VaultKubernetesAuth{Role: "unknown", TokenPath: tokenPath},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the token Secret does not exist",
+			config: infrav1.VaultCredentialsProvider{
+				Address:         fakeVault.URL,
+				Path:            "secret/data/vsphere",
+				TokenSecretName: "does-not-exist",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails if the secret does not exist",
+			config: infrav1.VaultCredentialsProvider{
+				Address:         fakeVault.URL,
+				Path:            "secret/data/does-not-exist",
+				TokenSecretName: "vault-token",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			identity := &infrav1.VSphereClusterIdentity{
+				Spec: infrav1.VSphereClusterIdentitySpec{
+					CredentialsProvider: infrav1.CredentialsProvider{Vault: tt.config},
+				},
+			}
+			provider, err := NewProvider(c, identity, "capv-system")
+			if tt.wantProviderErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			credentials, err := provider.GetCredentials(context.Background())
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(credentials).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,239 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package identity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+const (
+	// VaultTokenKey is the key used for the Vault token in the Secret referenced by tokenSecretName.
+	VaultTokenKey = "token"
+
+	defaultVaultKubernetesAuthMountPath = "kubernetes"
+
+	vaultRequestTimeout  = 30 * time.Second
+	maxVaultResponseSize = 1 << 20
+)
+
+var (
+	// vaultHTTPClient is the client used for requests to Vault.
+	vaultHTTPClient = &http.Client{Timeout: vaultRequestTimeout}
+
+	// vaultTokenCache caches the tokens obtained via the Kubernetes auth method,
+	// so Vault is not logged into every time credentials are required.
+	vaultTokenCache = map[string]vaultToken{}
+	vaultTokenMU    sync.Mutex
+)
+
+type vaultToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// vaultProvider reads the credentials from a HashiCorp Vault compatible KV secrets engine.
+type vaultProvider struct {
+	client              client.Client
+	controllerNamespace string
+	config              infrav1.VaultCredentialsProvider
+}
+
+func newVaultProvider(c client.Client, config infrav1.VaultCredentialsProvider, controllerNamespace string) (*vaultProvider, error) {
+	if config.KubernetesAuth.Role != "" {
+		if _, err := credentialsDirPath(config.KubernetesAuth.TokenPath); err != nil {
+			return nil, fmt.Errorf("invalid Vault token path: %w", err)
+		}
+	}
+	return &vaultProvider{
+		client:              c,
+		controllerNamespace: controllerNamespace,
+		config:              config,
+	}, nil
+}
+
+func (p *vaultProvider) GetCredentials(ctx context.Context) (*Credentials, error) {
+	token, err := p.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Data map[string]any `json:"data"`
+	}{}
+	url := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(p.config.Address, "/"), strings.TrimPrefix(p.config.Path, "/"))
+	statusCode, err := p.do(ctx, http.MethodGet, url, token, nil, &response)
+	if err != nil {
+		if statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+			p.invalidateToken()
+		}
+		return nil, fmt.Errorf("failed to read secret %s from Vault: %w", p.config.Path, err)
+	}
+
+	// KV version 2 secrets engines nest the secret in an additional data field.
+	data := response.Data
+	if nested, ok := data["data"].(map[string]any); ok {
+		data = nested
+	}
+	username, _ := data[UsernameKey].(string)
+	password, _ := data[PasswordKey].(string)
+
+	credentials := &Credentials{
+		Username: username,
+		Password: password,
+	}
+	if err := validateCredentials(credentials); err != nil {
+		return nil, fmt.Errorf("invalid credentials in Vault secret %s: %w", p.config.Path, err)
+	}
+	return credentials, nil
+}
+
+// getToken returns the token used to authenticate with Vault.
+func (p *vaultProvider) getToken(ctx context.Context) (string, error) {
+	if p.config.TokenSecretName != "" {
+		secret := &corev1.Secret{}
+		key := client.ObjectKey{Namespace: p.controllerNamespace, Name: p.config.TokenSecretName}
+		if err := p.client.Get(ctx, key, secret); err != nil {
+			return "", err
+		}
+		token := getData(secret, VaultTokenKey)
+		if token == "" {
+			return "", fmt.Errorf("secret %s does not contain a Vault token", p.config.TokenSecretName)
+		}
+		return token, nil
+	}
+
+	cacheKey := p.tokenCacheKey()
+	vaultTokenMU.Lock()
+	cached, ok := vaultTokenCache[cacheKey]
+	vaultTokenMU.Unlock()
+	if ok && time.Now().Before(cached.expiresAt) {
+		return cached.token, nil
+	}
+
+	auth := p.config.KubernetesAuth
+	if auth.Role == "" {
+		return "", errors.New("neither kubernetesAuth nor tokenSecretName is set")
+	}
+	mountPath := auth.MountPath
+	if mountPath == "" {
+		mountPath = defaultVaultKubernetesAuthMountPath
+	}
+
+	// The service account token is read on every login, as projected tokens are rotated by the kubelet.
+	jwt, err := readCredentialsFile(auth.TokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read service account token: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"role": auth.Role,
+		"jwt":  strings.TrimSpace(jwt),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	response := struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int64  `json:"lease_duration"`
+		} `json:"auth"`
+	}{}
+	url := fmt.Sprintf("%s/v1/auth/%s/login", strings.TrimSuffix(p.config.Address, "/"), strings.Trim(mountPath, "/"))
+	if _, err := p.do(ctx, http.MethodPost, url, "", body, &response); err != nil {
+		return "", fmt.Errorf("failed to login to Vault with role %s: %w", auth.Role, err)
+	}
+	if response.Auth.ClientToken == "" {
+		return "", fmt.Errorf("failed to login to Vault with role %s: response does not contain a token", auth.Role)
+	}
+
+	// Tokens are renewed by logging in again once 80% of their lease duration has passed.
+	leaseDuration := time.Duration(response.Auth.LeaseDuration) * time.Second
+	vaultTokenMU.Lock()
+	vaultTokenCache[cacheKey] = vaultToken{
+		token:     response.Auth.ClientToken,
+		expiresAt: time.Now().Add(leaseDuration * 4 / 5),
+	}
+	vaultTokenMU.Unlock()
+
+	return response.Auth.ClientToken, nil
+}
+
+func (p *vaultProvider) invalidateToken() {
+	vaultTokenMU.Lock()
+	defer vaultTokenMU.Unlock()
+	delete(vaultTokenCache, p.tokenCacheKey())
+}
+
+func (p *vaultProvider) tokenCacheKey() string {
+	auth := p.config.KubernetesAuth
+	return strings.Join([]string{p.config.Address, p.config.Namespace, auth.MountPath, auth.Role, auth.TokenPath}, "#")
+}
+
+// do sends a request to Vault and decodes the JSON response into out.
+// It returns the HTTP status code of the response, if any.
+func (p *vaultProvider) do(ctx context.Context, method, url, token string, body []byte, out any) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("X-Vault-Token", token)
+	}
+	if p.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	resp, err := vaultHTTPClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxVaultResponseSize))
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Vault returns errors as {"errors": ["..."]}.
+		vaultErr := struct {
+			Errors []string `json:"errors"`
+		}{}
+		_ = json.Unmarshal(respBody, &vaultErr)
+		return resp.StatusCode, fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, ", "))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.StatusCode, nil
+}
@@ -45,6 +45,7 @@ import (
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 	vmoprvhub "sigs.k8s.io/cluster-api-provider-vsphere/pkg/conversion/api/vmoperator/hub"
 	conversionclient "sigs.k8s.io/cluster-api-provider-vsphere/pkg/conversion/client"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
 )
 
 // Manager is a CAPV controller manager.
@@ -111,6 +112,8 @@ func New(ctx context.Context, opts Options) (Manager, error) {
 		WatchFilterValue: opts.WatchFilterValue,
 	}
 
+	session.SetMaxConcurrentCallsPerServer(opts.MaxConcurrentVCenterCalls)
+
 	// Add the requested items to the manager.
 	if err := opts.AddToManager(ctx, controllerManagerContext, mgr); err != nil {
 		return nil, pkgerrors.Wrap(err, "failed to add resources to the manager")
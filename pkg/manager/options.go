@@ -67,6 +67,12 @@ type Options struct {
 	// CredentialsFile is the file that contains credentials of CAPV
 	CredentialsFile string
 
+	// MaxConcurrentVCenterCalls is the maximum number of concurrent API calls per vCenter,
+	// across all sessions of the vCenter.
+	//
+	// Defaults to 0, which does not limit the number of concurrent calls.
+	MaxConcurrentVCenterCalls int
+
 	KubeConfig *rest.Config
 
 	// AddToManager is a function that can be optionally specified with
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	deprecatedv1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+// DefaultMachinePoolBootstrapDataScrubDelay is the delay after which the bootstrap data is removed
+// from the VMs of a VSphereMachinePool which have no delay set, as they have no Machine whose node
+// could be waited for.
+const DefaultMachinePoolBootstrapDataScrubDelay = 10 * time.Minute
+
+// reconcileBootstrapDataScrub removes the bootstrap data from the guestinfo of the VM once its Machine
+// has a node, or once the delay has passed since the VM has been provisioned, so the secrets in it are
+// not readable by users with read access to the VM for its whole lifetime. The metadata is kept, so
+// changes to it are still applied to the VM.
+func (vms *VMService) reconcileBootstrapDataScrub(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// The seed ISO is removed by reconcileBootstrapISO instead.
+	if virtualMachineCtx.VSphereVM.Spec.BootstrapDataDelivery == infrav1.BootstrapDataDeliveryNoCloudISO {
+		return true, nil
+	}
+
+	scrubbed, err := vms.getExtraConfigValue(ctx, virtualMachineCtx, extra.BootstrapDataScrubbedKey)
+	if err != nil {
+		return false, err
+	}
+	if scrubbed != "" {
+		deprecatedv1beta1conditions.MarkTrue(virtualMachineCtx.VSphereVM, infrav1.BootstrapDataScrubbedV1Beta1Condition)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:   infrav1.VSphereVMBootstrapDataScrubbedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereVMBootstrapDataScrubbedReason,
+		})
+		return true, nil
+	}
+
+	if virtualMachineCtx.VSphereVM.Spec.BootstrapDataScrub.Policy == infrav1.BootstrapDataScrubPolicyRetain {
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.BootstrapDataScrubbedV1Beta1Condition, infrav1.BootstrapDataRetainedV1Beta1Reason, clusterv1.ConditionSeverityInfo, "")
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:   infrav1.VSphereVMBootstrapDataScrubbedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereVMBootstrapDataRetainedReason,
+		})
+		return true, nil
+	}
+
+	if due, message := isBootstrapDataScrubDue(virtualMachineCtx); !due {
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.BootstrapDataScrubbedV1Beta1Condition, infrav1.WaitingForNodeV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", message)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMBootstrapDataScrubbedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMBootstrapDataWaitingForNodeReason,
+			Message: message,
+		})
+		return true, nil
+	}
+
+	log.Info("Scrubbing bootstrap data from guestinfo of VM")
+	var extraConfig extra.Config
+	extraConfig.ScrubBootstrapData(time.Now())
+	task, err := virtualMachineCtx.Obj.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+		ExtraConfig: extraConfig,
+	})
+	if err != nil {
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.BootstrapDataScrubbedV1Beta1Condition, infrav1.BootstrapDataScrubFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%v", err)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMBootstrapDataScrubbedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMBootstrapDataScrubFailedReason,
+			Message: err.Error(),
+		})
+		return false, pkgerrors.Wrapf(err, "unable to scrub bootstrap data from vm %s", virtualMachineCtx)
+	}
+
+	virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+	log.Info("Wait for VM bootstrap data to be scrubbed")
+	return false, nil
+}
+
+// isBootstrapDataScrubDue returns true if the Machine of the VM has a node, or if the delay has passed
+// since the VM has been provisioned. Otherwise it returns a message describing what the scrub waits for.
+func isBootstrapDataScrubDue(virtualMachineCtx *virtualMachineContext) (bool, string) {
+	if virtualMachineCtx.Machine != nil && virtualMachineCtx.Machine.Status.NodeRef.IsDefined() {
+		return true, ""
+	}
+
+	delay := bootstrapDataScrubDelay(virtualMachineCtx.VSphereVM)
+	if delay == 0 {
+		return false, "Waiting for the Machine to have a node"
+	}
+	if provisioned := conditions.Get(virtualMachineCtx.VSphereVM, infrav1.VSphereVMVirtualMachineProvisionedCondition); provisioned != nil && provisioned.Status == metav1.ConditionTrue {
+		if time.Since(provisioned.LastTransitionTime.Time) >= delay {
+			return true, ""
+		}
+	}
+	return false, fmt.Sprintf("Waiting for the Machine to have a node or for %s after the VM has been provisioned", delay)
+}
+
+// BootstrapDataScrubRequeueAfter returns the time after which the VSphereVM has to be reconciled again
+// to remove the bootstrap data from the guestinfo or the seed ISO of its VM once the delay has passed,
+// or zero if the scrub does not wait for the delay.
+func BootstrapDataScrubRequeueAfter(vsphereVM *infrav1.VSphereVM) time.Duration {
+	delay := bootstrapDataScrubDelay(vsphereVM)
+	if delay == 0 {
+		return 0
+	}
+	if vsphereVM.Spec.BootstrapDataDelivery == infrav1.BootstrapDataDeliveryNoCloudISO {
+		if vsphereVM.Status.BootstrapISO == "" || vsphereVM.Spec.BootstrapDataScrub.Policy == infrav1.BootstrapDataScrubPolicyRetain {
+			return 0
+		}
+	} else {
+		scrubbed := conditions.Get(vsphereVM, infrav1.VSphereVMBootstrapDataScrubbedCondition)
+		if scrubbed == nil || scrubbed.Reason != infrav1.VSphereVMBootstrapDataWaitingForNodeReason {
+			return 0
+		}
+	}
+	provisioned := conditions.Get(vsphereVM, infrav1.VSphereVMVirtualMachineProvisionedCondition)
+	if provisioned == nil || provisioned.Status != metav1.ConditionTrue {
+		return 0
+	}
+	return max(time.Until(provisioned.LastTransitionTime.Add(delay)), time.Second)
+}
+
+// bootstrapDataScrubDelay returns the delay after which the bootstrap data is removed from the VM even
+// if its Machine has no node, which defaults to DefaultMachinePoolBootstrapDataScrubDelay for the VMs of
+// a VSphereMachinePool, or zero if the scrub only waits for the node.
+func bootstrapDataScrubDelay(vsphereVM *infrav1.VSphereVM) time.Duration {
+	if delaySeconds := vsphereVM.Spec.BootstrapDataScrub.DelaySeconds; delaySeconds > 0 {
+		return time.Duration(delaySeconds) * time.Second
+	}
+	if _, ok := vsphereVM.Labels[infrav1.MachinePoolNameLabel]; ok {
+		return DefaultMachinePoolBootstrapDataScrubDelay
+	}
+	return 0
+}
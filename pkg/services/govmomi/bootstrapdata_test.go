@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+func Test_reconcileBootstrapDataScrub(t *testing.T) {
+	// setup returns a context for the DC0_H0_VM0 VM with bootstrap data and metadata in its guestinfo.
+	setup := func(ctx context.Context, g *WithT, c *vim25.Client) *virtualMachineContext {
+		vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var extraConfig extra.Config
+		extraConfig.SetCloudInitUserData([]byte("#cloud-config"))
+		extraConfig.SetCloudInitMetadata([]byte("instance-id: vsphereVM1"))
+		task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{ExtraConfig: extraConfig})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(task.Wait(ctx)).To(Succeed())
+
+		vmCtx := emptyVirtualMachineContext()
+		vmCtx.Session = &session.Session{Client: &govmomi.Client{Client: c}}
+		vmCtx.Obj = vm
+		vmCtx.Ref = vm.Reference()
+		vmCtx.VSphereVM = &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vsphereVM1",
+				Namespace: "my-namespace",
+			},
+		}
+		vmCtx.Machine = &clusterv1.Machine{}
+		return vmCtx
+	}
+
+	waitForTask := func(ctx context.Context, g *WithT, c *vim25.Client, vmCtx *virtualMachineContext) {
+		g.Expect(vmCtx.VSphereVM.Status.TaskRef).ToNot(BeEmpty())
+		task := object.NewTask(c, types.ManagedObjectReference{Type: "Task", Value: vmCtx.VSphereVM.Status.TaskRef})
+		g.Expect(task.Wait(ctx)).To(Succeed())
+		vmCtx.VSphereVM.Status.TaskRef = ""
+	}
+
+	extraConfigValue := func(ctx context.Context, g *WithT, vmCtx *virtualMachineContext, key string) string {
+		value, err := (&VMService{}).getExtraConfigValue(ctx, vmCtx, key)
+		g.Expect(err).ToNot(HaveOccurred())
+		return value
+	}
+
+	expectCondition := func(g *WithT, vmCtx *virtualMachineContext, status metav1.ConditionStatus, reason string) {
+		condition := conditions.Get(vmCtx.VSphereVM, infrav1.VSphereVMBootstrapDataScrubbedCondition)
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(status))
+		g.Expect(condition.Reason).To(Equal(reason))
+	}
+
+	t.Run("bootstrap data is kept until the Machine has a node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+
+			ok, err := (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			expectCondition(g, vmCtx, metav1.ConditionFalse, infrav1.VSphereVMBootstrapDataWaitingForNodeReason)
+			g.Expect(extraConfigValue(ctx, g, vmCtx, "guestinfo.userdata")).ToNot(BeEmpty())
+			g.Expect(BootstrapDataScrubRequeueAfter(vmCtx.VSphereVM)).To(BeZero())
+			return nil
+		})
+	})
+
+	t.Run("bootstrap data is scrubbed once the Machine has a node", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.Machine.Status.NodeRef = clusterv1.MachineNodeReference{Name: "vsphereVM1"}
+
+			ok, err := (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, g, c, vmCtx)
+
+			for _, key := range []string{"guestinfo.userdata", "guestinfo.userdata.encoding"} {
+				g.Expect(extraConfigValue(ctx, g, vmCtx, key)).To(BeEmpty())
+			}
+			g.Expect(extraConfigValue(ctx, g, vmCtx, "guestinfo.metadata")).ToNot(BeEmpty())
+			g.Expect(extraConfigValue(ctx, g, vmCtx, extra.BootstrapDataScrubbedKey)).ToNot(BeEmpty())
+
+			ok, err = (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			expectCondition(g, vmCtx, metav1.ConditionTrue, infrav1.VSphereVMBootstrapDataScrubbedReason)
+			return nil
+		})
+	})
+
+	t.Run("bootstrap data is scrubbed once the delay has passed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.VSphereVM.Spec.BootstrapDataScrub.DelaySeconds = 60
+			vmCtx.VSphereVM.Status.Conditions = []metav1.Condition{{
+				Type:               infrav1.VSphereVMVirtualMachineProvisionedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             infrav1.VSphereVMVirtualMachineProvisionedReason,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-30 * time.Second)),
+			}}
+
+			ok, err := (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			expectCondition(g, vmCtx, metav1.ConditionFalse, infrav1.VSphereVMBootstrapDataWaitingForNodeReason)
+			g.Expect(BootstrapDataScrubRequeueAfter(vmCtx.VSphereVM)).To(BeNumerically("~", 30*time.Second, 5*time.Second))
+
+			vmCtx.VSphereVM.Status.Conditions = []metav1.Condition{{
+				Type:               infrav1.VSphereVMVirtualMachineProvisionedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             infrav1.VSphereVMVirtualMachineProvisionedReason,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-60 * time.Second)),
+			}}
+			ok, err = (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, g, c, vmCtx)
+			g.Expect(extraConfigValue(ctx, g, vmCtx, "guestinfo.userdata")).To(BeEmpty())
+			return nil
+		})
+	})
+
+	t.Run("bootstrap data is retained with the Retain policy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.Machine.Status.NodeRef = clusterv1.MachineNodeReference{Name: "vsphereVM1"}
+			vmCtx.VSphereVM.Spec.BootstrapDataScrub.Policy = infrav1.BootstrapDataScrubPolicyRetain
+
+			ok, err := (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			expectCondition(g, vmCtx, metav1.ConditionFalse, infrav1.VSphereVMBootstrapDataRetainedReason)
+			g.Expect(extraConfigValue(ctx, g, vmCtx, "guestinfo.userdata")).ToNot(BeEmpty())
+			return nil
+		})
+	})
+
+	t.Run("bootstrap data of a VSphereMachinePool VM is scrubbed after the default delay", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.Machine = nil
+			vmCtx.VSphereVM.Labels = map[string]string{infrav1.MachinePoolNameLabel: "pool1"}
+			vmCtx.VSphereVM.Status.Conditions = []metav1.Condition{{
+				Type:               infrav1.VSphereVMVirtualMachineProvisionedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             infrav1.VSphereVMVirtualMachineProvisionedReason,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			}}
+
+			ok, err := (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			expectCondition(g, vmCtx, metav1.ConditionFalse, infrav1.VSphereVMBootstrapDataWaitingForNodeReason)
+			g.Expect(BootstrapDataScrubRequeueAfter(vmCtx.VSphereVM)).To(BeNumerically("~", DefaultMachinePoolBootstrapDataScrubDelay-time.Minute, 5*time.Second))
+
+			vmCtx.VSphereVM.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(-DefaultMachinePoolBootstrapDataScrubDelay))
+			ok, err = (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, g, c, vmCtx)
+			g.Expect(extraConfigValue(ctx, g, vmCtx, "guestinfo.userdata")).To(BeEmpty())
+			return nil
+		})
+	})
+
+	t.Run("metadata is still updated once the bootstrap data has been scrubbed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.Machine.Status.NodeRef = clusterv1.MachineNodeReference{Name: "vsphereVM1"}
+			vmCtx.State = &services.VirtualMachine{}
+
+			ok, err := (&VMService{}).reconcileBootstrapDataScrub(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, g, c, vmCtx)
+
+			metadata := extraConfigValue(ctx, g, vmCtx, "guestinfo.metadata")
+			ok, err = (&VMService{}).reconcileMetadata(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, g, c, vmCtx)
+			g.Expect(extraConfigValue(ctx, g, vmCtx, "guestinfo.metadata")).ToNot(BeElementOf(metadata, ""))
+
+			ok, err = (&VMService{}).reconcileMetadata(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			return nil
+		})
+	})
+}
+
+func Test_BootstrapDataScrubRequeueAfter_BootstrapISO(t *testing.T) {
+	g := NewWithT(t)
+
+	vsphereVM := &infrav1.VSphereVM{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{infrav1.MachinePoolNameLabel: "pool1"},
+		},
+		Spec: infrav1.VSphereVMSpec{
+			VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+				BootstrapDataDelivery: infrav1.BootstrapDataDeliveryNoCloudISO,
+			},
+		},
+		Status: infrav1.VSphereVMStatus{
+			BootstrapISO: "[LocalDS_0] capv-cidata/my-namespace/vsphereVM1.iso",
+			Conditions: []metav1.Condition{{
+				Type:               infrav1.VSphereVMVirtualMachineProvisionedCondition,
+				Status:             metav1.ConditionTrue,
+				Reason:             infrav1.VSphereVMVirtualMachineProvisionedReason,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			}},
+		},
+	}
+	g.Expect(BootstrapDataScrubRequeueAfter(vsphereVM)).To(BeNumerically("~", DefaultMachinePoolBootstrapDataScrubDelay-time.Minute, 5*time.Second))
+
+	// The seed ISO of a VM with the Retain policy is only deleted together with the VM.
+	vsphereVM.Spec.BootstrapDataScrub.Policy = infrav1.BootstrapDataScrubPolicyRetain
+	g.Expect(BootstrapDataScrubRequeueAfter(vsphereVM)).To(BeZero())
+}
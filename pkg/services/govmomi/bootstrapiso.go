@@ -0,0 +1,147 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
+)
+
+// reconcileBootstrapISOMetadata renders the seed ISO of the VM again if the metadata changed,
+// e.g. once the MAC addresses of the network devices are known. The ISO is only rendered again
+// until the VM is powered on for the first time, as the guest reads it on the first boot.
+func (vms *VMService) reconcileBootstrapISOMetadata(ctx context.Context, virtualMachineCtx *virtualMachineContext, metadata []byte) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	isoPath := virtualMachineCtx.VSphereVM.Status.BootstrapISO
+	if isoPath == "" {
+		return true, nil
+	}
+
+	existingMetadata, err := vms.getMetadata(ctx, virtualMachineCtx, extra.BootstrapISOMetadataKey)
+	if err != nil {
+		return false, err
+	}
+	if string(metadata) == existingMetadata {
+		return true, nil
+	}
+
+	powerState, err := vms.getPowerState(ctx, virtualMachineCtx)
+	if err != nil {
+		return false, err
+	}
+	if powerState != infrav1.VirtualMachinePowerStatePoweredOff {
+		return true, nil
+	}
+
+	bootstrapData, _, err := vms.getBootstrapData(ctx, &virtualMachineCtx.VMContext)
+	if err != nil {
+		return false, err
+	}
+	iso, err := vcenter.NewBootstrapISO(bootstrapData, metadata)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to render seed ISO for %s", virtualMachineCtx)
+	}
+
+	log.Info("Updating seed ISO", "path", isoPath)
+	if err := vcenter.UploadBootstrapISO(ctx, virtualMachineCtx.Session, isoPath, iso); err != nil {
+		return false, err
+	}
+
+	var extraConfig extra.Config
+	extraConfig.SetBootstrapISOMetadata(metadata)
+	task, err := virtualMachineCtx.Obj.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+		ExtraConfig: extraConfig,
+	})
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to set seed ISO metadata on vm %s", virtualMachineCtx)
+	}
+
+	virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+	log.Info("Wait for VM seed ISO metadata to be updated")
+	return false, nil
+}
+
+// reconcileBootstrapISO ejects the seed ISO from the CD-ROM of the VM and deletes it once the
+// Machine of the VM has a node, or once the delay of the bootstrap data scrub has passed since the
+// VM has been provisioned, so the bootstrap data does not remain on the datastore. The ISO of a VM
+// of a VSphereMachinePool, which has no Machine, is deleted after the delay.
+func (vms *VMService) reconcileBootstrapISO(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	isoPath := virtualMachineCtx.VSphereVM.Status.BootstrapISO
+	if isoPath == "" || virtualMachineCtx.VSphereVM.Spec.BootstrapDataScrub.Policy == infrav1.BootstrapDataScrubPolicyRetain {
+		return true, nil
+	}
+	if due, _ := isBootstrapDataScrubDue(virtualMachineCtx); !due {
+		return true, nil
+	}
+
+	devices, err := virtualMachineCtx.Obj.Device(ctx)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "error getting devices for %s", virtualMachineCtx)
+	}
+	if cdrom := vcenter.FindBootstrapISOCdrom(devices, isoPath); cdrom != nil {
+		cdrom = devices.EjectIso(cdrom)
+		cdrom.Connectable = &types.VirtualDeviceConnectInfo{
+			AllowGuestControl: true,
+		}
+		log.Info("Ejecting seed ISO", "path", isoPath)
+		task, err := virtualMachineCtx.Obj.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+			DeviceChange: []types.BaseVirtualDeviceConfigSpec{
+				&types.VirtualDeviceConfigSpec{
+					Operation: types.VirtualDeviceConfigSpecOperationEdit,
+					Device:    cdrom,
+				},
+			},
+		})
+		if err != nil {
+			return false, pkgerrors.Wrapf(err, "unable to eject seed ISO from vm %s", virtualMachineCtx)
+		}
+		virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+		log.Info("Wait for VM seed ISO to be ejected")
+		return false, nil
+	}
+
+	if err := deleteBootstrapISO(ctx, &virtualMachineCtx.VMContext); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// deleteBootstrapISO deletes the seed ISO of the VM, if it has one, and clears its path in the status.
+func deleteBootstrapISO(ctx context.Context, vmCtx *capvcontext.VMContext) error {
+	isoPath := vmCtx.VSphereVM.Status.BootstrapISO
+	if isoPath == "" {
+		return nil
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Deleting seed ISO", "path", isoPath)
+	if err := vcenter.DeleteBootstrapISO(ctx, vmCtx.Session, isoPath); err != nil {
+		return err
+	}
+	vmCtx.VSphereVM.Status.BootstrapISO = ""
+	return nil
+}
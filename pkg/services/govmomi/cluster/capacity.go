@@ -0,0 +1,68 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+)
+
+// HostsCapacity is the free capacity of a set of hosts.
+type HostsCapacity struct {
+	// AvailableHosts is the number of connected hosts which are not in maintenance mode.
+	AvailableHosts int32
+	// CPUFreeMHz is the free CPU of the available hosts in MHz.
+	CPUFreeMHz int64
+	// MemoryFreeMiB is the free memory of the available hosts in MiB.
+	MemoryFreeMiB int64
+}
+
+// GetHostsCapacity returns the free capacity of the hosts. Hosts which are not connected
+// or are in maintenance mode are ignored.
+func GetHostsCapacity(ctx context.Context, client *vim25.Client, hosts []types.ManagedObjectReference) (HostsCapacity, error) {
+	capacity := HostsCapacity{}
+	if len(hosts) == 0 {
+		return capacity, nil
+	}
+
+	var hostMos []mo.HostSystem
+	if err := property.DefaultCollector(client).Retrieve(ctx, hosts, []string{"summary"}, &hostMos); err != nil {
+		return capacity, pkgerrors.Wrap(err, "unable to get summary of hosts")
+	}
+
+	for _, host := range hostMos {
+		summary := host.Summary
+		if summary.Runtime == nil || summary.Runtime.ConnectionState != types.HostSystemConnectionStateConnected || summary.Runtime.InMaintenanceMode {
+			continue
+		}
+		if summary.Hardware == nil {
+			continue
+		}
+
+		capacity.AvailableHosts++
+		cpuTotalMHz := int64(summary.Hardware.CpuMhz) * int64(summary.Hardware.NumCpuCores)
+		capacity.CPUFreeMHz += max(cpuTotalMHz-int64(summary.QuickStats.OverallCpuUsage), 0)
+		memoryTotalMiB := summary.Hardware.MemorySize / (1024 * 1024)
+		capacity.MemoryFreeMiB += max(memoryTotalMiB-int64(summary.QuickStats.OverallMemoryUsage), 0)
+	}
+	return capacity, nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+)
+
+func Test_GetHostsCapacity(t *testing.T) {
+	g := NewWithT(t)
+	sim, err := vcsim.NewBuilder().Build()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer sim.Destroy()
+
+	ctx := context.Background()
+	client, _ := govmomi.NewClient(ctx, sim.ServerURL(), true)
+	finder := find.NewFinder(client.Client, false)
+
+	dc, _ := finder.DatacenterOrDefault(ctx, "DC0")
+	finder.SetDatacenter(dc)
+
+	ccr, err := finder.ClusterComputeResource(ctx, "DC0_C0")
+	g.Expect(err).NotTo(HaveOccurred())
+	hosts, err := ccr.Hosts(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	capacity, err := GetHostsCapacity(ctx, client.Client, nil)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(capacity).To(Equal(HostsCapacity{}))
+
+	refs := []types.ManagedObjectReference{}
+	for _, host := range hosts {
+		refs = append(refs, host.Reference())
+	}
+	capacity, err = GetHostsCapacity(ctx, client.Client, refs)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(capacity.AvailableHosts).To(BeEquivalentTo(len(hosts)))
+	g.Expect(capacity.CPUFreeMHz).To(BeNumerically(">", 0))
+	g.Expect(capacity.MemoryFreeMiB).To(BeNumerically(">", 0))
+
+	oneHost, err := GetHostsCapacity(ctx, client.Client, refs[:1])
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(oneHost.AvailableHosts).To(BeEquivalentTo(1))
+	g.Expect(oneHost.CPUFreeMHz).To(BeNumerically("<", capacity.CPUFreeMHz))
+}
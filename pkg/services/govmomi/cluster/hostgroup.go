@@ -0,0 +1,266 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"slices"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+// ManagedHosts is the VM group, host group and VM-Host rule of a failure domain which are
+// created and kept up to date by CAPV.
+type ManagedHosts struct {
+	VMGroupName   string
+	HostGroupName string
+	RuleName      string
+	Mandatory     bool
+	// Hosts are the members of the host group.
+	Hosts []types.ManagedObjectReference
+}
+
+// FindHostsByTags returns the hosts of the compute cluster which have all the given tags.
+func FindHostsByTags(ctx context.Context, tagManager *tags.Manager, ccr *object.ClusterComputeResource, matchTags []infrav1.HostTag) ([]types.ManagedObjectReference, error) {
+	if tagManager == nil {
+		return nil, pkgerrors.New("unable to find hosts by tags: tag manager is not initialized")
+	}
+
+	clusterHosts, err := ccr.Hosts(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to list hosts of compute cluster %s", ccr.Reference().Value)
+	}
+	candidates := make([]types.ManagedObjectReference, 0, len(clusterHosts))
+	for _, host := range clusterHosts {
+		candidates = append(candidates, host.Reference())
+	}
+
+	for _, matchTag := range matchTags {
+		tag, err := tagManager.GetTagForCategory(ctx, matchTag.Name, matchTag.Category)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to find tag %q in category %q", matchTag.Name, matchTag.Category)
+		}
+		objs, err := tagManager.ListAttachedObjects(ctx, tag.ID)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to list objects with tag %q in category %q", matchTag.Name, matchTag.Category)
+		}
+
+		var refs []types.ManagedObjectReference
+		for _, obj := range objs {
+			if ref := obj.Reference(); ref.Type == "HostSystem" && slices.Contains(candidates, ref) {
+				refs = append(refs, ref)
+			}
+		}
+		candidates = refs
+	}
+	return candidates, nil
+}
+
+// FindHostGroup returns the host group of the compute cluster with the given name, or nil if there is none.
+func FindHostGroup(ctx context.Context, ccr *object.ClusterComputeResource, hostGroupName string) (*types.ClusterHostGroup, error) {
+	clusterConfigInfoEx, err := ccr.Configuration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hostGroup, _ := findGroup(clusterConfigInfoEx.Group, hostGroupName).(*types.ClusterHostGroup)
+	return hostGroup, nil
+}
+
+// ReconcileManagedHosts creates the VM group, the host group and the VM-Host rule in the compute
+// cluster if they don't exist, and updates the members of the host group and the rule otherwise.
+// The members of the VM group are left untouched, as VMs are added to it when they are created.
+// The names of the groups and the rule it creates are recorded in created. Existing groups and
+// rules which are not recorded in created have not been created by CAPV, and are not taken over.
+func ReconcileManagedHosts(ctx context.Context, ccr *object.ClusterComputeResource, managedHosts ManagedHosts, created *infrav1.VSphereDeploymentZoneManagedHosts) error {
+	clusterConfigInfoEx, err := ccr.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	existingVMGroup := findGroup(clusterConfigInfoEx.Group, managedHosts.VMGroupName)
+	if existingVMGroup != nil && created.VMGroupName != managedHosts.VMGroupName {
+		return pkgerrors.Errorf("VM group %s already exists and has not been created by CAPV", managedHosts.VMGroupName)
+	}
+	existingHostGroup := findGroup(clusterConfigInfoEx.Group, managedHosts.HostGroupName)
+	if existingHostGroup != nil && created.HostGroupName != managedHosts.HostGroupName {
+		return pkgerrors.Errorf("host group %s already exists and has not been created by CAPV", managedHosts.HostGroupName)
+	}
+	existingRule := findRule(clusterConfigInfoEx.Rule, managedHosts.RuleName)
+	if existingRule != nil && created.RuleName != managedHosts.RuleName {
+		return pkgerrors.Errorf("rule %s already exists and has not been created by CAPV", managedHosts.RuleName)
+	}
+
+	groupSpecs := []types.ClusterGroupSpec{}
+	if existingVMGroup == nil {
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+			Info:            &types.ClusterVmGroup{ClusterGroupInfo: types.ClusterGroupInfo{Name: managedHosts.VMGroupName}},
+		})
+	}
+
+	hostGroup := &types.ClusterHostGroup{
+		ClusterGroupInfo: types.ClusterGroupInfo{Name: managedHosts.HostGroupName},
+		Host:             managedHosts.Hosts,
+	}
+	switch {
+	case existingHostGroup == nil:
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationAdd},
+			Info:            hostGroup,
+		})
+	case !sameHosts(existingHostGroup, managedHosts.Hosts):
+		groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+			ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: types.ArrayUpdateOperationEdit},
+			Info:            hostGroup,
+		})
+	}
+
+	// The groups and the rule are recorded before they are created, so that they are still known
+	// to be created by CAPV if the task fails after creating them.
+	created.VMGroupName = managedHosts.VMGroupName
+	created.HostGroupName = managedHosts.HostGroupName
+	if len(groupSpecs) > 0 {
+		if err := reconfigure(ctx, ccr, &types.ClusterConfigSpecEx{GroupSpec: groupSpecs}); err != nil {
+			return pkgerrors.Wrapf(err, "failed to update VM group %s and host group %s", managedHosts.VMGroupName, managedHosts.HostGroupName)
+		}
+	}
+
+	rule := &types.ClusterVmHostRuleInfo{
+		ClusterRuleInfo: types.ClusterRuleInfo{
+			Name:        managedHosts.RuleName,
+			Enabled:     ptr.To(true),
+			Mandatory:   ptr.To(managedHosts.Mandatory),
+			UserCreated: ptr.To(true),
+		},
+		VmGroupName:         managedHosts.VMGroupName,
+		AffineHostGroupName: managedHosts.HostGroupName,
+	}
+	operation := types.ArrayUpdateOperationAdd
+	if existingRule != nil {
+		existing, ok := existingRule.(*types.ClusterVmHostRuleInfo)
+		if ok && existing.VmGroupName == rule.VmGroupName && existing.AffineHostGroupName == rule.AffineHostGroupName &&
+			ptr.Deref(existing.Enabled, false) && ptr.Deref(existing.Mandatory, false) == managedHosts.Mandatory {
+			return nil
+		}
+		operation = types.ArrayUpdateOperationEdit
+		rule.Key = existingRule.GetClusterRuleInfo().Key
+	}
+
+	created.RuleName = managedHosts.RuleName
+	spec := &types.ClusterConfigSpecEx{
+		RulesSpec: []types.ClusterRuleSpec{
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{Operation: operation},
+				Info:            rule,
+			},
+		},
+	}
+	if err := reconfigure(ctx, ccr, spec); err != nil {
+		return pkgerrors.Wrapf(err, "failed to update VM-Host rule %s", managedHosts.RuleName)
+	}
+	return nil
+}
+
+// DeleteManagedHosts deletes the VM-Host rule, the VM group and the host group which have been
+// created by CAPV from the compute cluster. Groups and rules which don't exist are ignored.
+func DeleteManagedHosts(ctx context.Context, ccr *object.ClusterComputeResource, created infrav1.VSphereDeploymentZoneManagedHosts) error {
+	clusterConfigInfoEx, err := ccr.Configuration(ctx)
+	if err != nil {
+		return err
+	}
+
+	// The rule has to be deleted before the groups it references.
+	if rule := findRule(clusterConfigInfoEx.Rule, created.RuleName); created.RuleName != "" && rule != nil {
+		spec := &types.ClusterConfigSpecEx{
+			RulesSpec: []types.ClusterRuleSpec{
+				{
+					ArrayUpdateSpec: types.ArrayUpdateSpec{
+						Operation: types.ArrayUpdateOperationRemove,
+						RemoveKey: rule.GetClusterRuleInfo().Key,
+					},
+				},
+			},
+		}
+		if err := reconfigure(ctx, ccr, spec); err != nil {
+			return pkgerrors.Wrapf(err, "failed to delete VM-Host rule %s", created.RuleName)
+		}
+	}
+
+	groupSpecs := []types.ClusterGroupSpec{}
+	for _, groupName := range []string{created.VMGroupName, created.HostGroupName} {
+		if groupName != "" && findGroup(clusterConfigInfoEx.Group, groupName) != nil {
+			groupSpecs = append(groupSpecs, types.ClusterGroupSpec{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{
+					Operation: types.ArrayUpdateOperationRemove,
+					RemoveKey: groupName,
+				},
+			})
+		}
+	}
+	if len(groupSpecs) == 0 {
+		return nil
+	}
+	if err := reconfigure(ctx, ccr, &types.ClusterConfigSpecEx{GroupSpec: groupSpecs}); err != nil {
+		return pkgerrors.Wrapf(err, "failed to delete VM group %s and host group %s", created.VMGroupName, created.HostGroupName)
+	}
+	return nil
+}
+
+func reconfigure(ctx context.Context, ccr *object.ClusterComputeResource, spec *types.ClusterConfigSpecEx) error {
+	task, err := ccr.Reconfigure(ctx, spec, true)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+func findGroup(groups []types.BaseClusterGroupInfo, name string) types.BaseClusterGroupInfo {
+	for _, group := range groups {
+		if group.GetClusterGroupInfo().Name == name {
+			return group
+		}
+	}
+	return nil
+}
+
+func findRule(rules []types.BaseClusterRuleInfo, name string) types.BaseClusterRuleInfo {
+	for _, rule := range rules {
+		if rule.GetClusterRuleInfo().Name == name {
+			return rule
+		}
+	}
+	return nil
+}
+
+func sameHosts(group types.BaseClusterGroupInfo, hosts []types.ManagedObjectReference) bool {
+	hostGroup, ok := group.(*types.ClusterHostGroup)
+	if !ok || len(hostGroup.Host) != len(hosts) {
+		return false
+	}
+	for _, host := range hosts {
+		if !slices.Contains(hostGroup.Host, host) {
+			return false
+		}
+	}
+	return true
+}
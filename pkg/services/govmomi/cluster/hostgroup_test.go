@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+)
+
+func Test_ManagedHosts(t *testing.T) {
+	g := NewWithT(t)
+	sim, err := vcsim.NewBuilder().Build()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer sim.Destroy()
+
+	ctx := context.Background()
+	client, _ := govmomi.NewClient(ctx, sim.ServerURL(), true)
+	finder := find.NewFinder(client.Client, false)
+
+	dc, _ := finder.DatacenterOrDefault(ctx, "DC0")
+	finder.SetDatacenter(dc)
+
+	ccr, err := finder.ClusterComputeResource(ctx, "DC0_C0")
+	g.Expect(err).NotTo(HaveOccurred())
+	hosts, err := ccr.Hosts(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(len(hosts)).To(BeNumerically(">=", 2))
+
+	managedHosts := ManagedHosts{
+		VMGroupName:   "zone-a-vms",
+		HostGroupName: "zone-a-hosts",
+		RuleName:      "zone-a-vms-rule",
+		Hosts:         []types.ManagedObjectReference{hosts[0].Reference()},
+	}
+
+	created := infrav1.VSphereDeploymentZoneManagedHosts{}
+	g.Expect(ReconcileManagedHosts(ctx, ccr, managedHosts, &created)).To(Succeed())
+	g.Expect(created).To(Equal(infrav1.VSphereDeploymentZoneManagedHosts{
+		VMGroupName:   managedHosts.VMGroupName,
+		HostGroupName: managedHosts.HostGroupName,
+		RuleName:      managedHosts.RuleName,
+	}))
+
+	hostRefs, err := ListHostsFromGroup(ctx, ccr, managedHosts.HostGroupName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hostRefs).To(ConsistOf(hosts[0].Reference()))
+
+	clusterConfigInfoEx, err := ccr.Configuration(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(findGroup(clusterConfigInfoEx.Group, managedHosts.VMGroupName)).To(BeAssignableToTypeOf(&types.ClusterVmGroup{}))
+	rule, ok := findRule(clusterConfigInfoEx.Rule, managedHosts.RuleName).(*types.ClusterVmHostRuleInfo)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(rule.VmGroupName).To(Equal(managedHosts.VMGroupName))
+	g.Expect(rule.AffineHostGroupName).To(Equal(managedHosts.HostGroupName))
+	g.Expect(rule.Mandatory).To(Equal(ptr.To(false)))
+
+	// Hosts added to the selection are added to the host group and the rule is updated.
+	managedHosts.Hosts = append(managedHosts.Hosts, hosts[1].Reference())
+	managedHosts.Mandatory = true
+	g.Expect(ReconcileManagedHosts(ctx, ccr, managedHosts, &created)).To(Succeed())
+
+	hostRefs, err = ListHostsFromGroup(ctx, ccr, managedHosts.HostGroupName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(hostRefs).To(ConsistOf(hosts[0].Reference(), hosts[1].Reference()))
+
+	verifiedRule, err := VerifyAffinityRule(ctx, testComputeClusterCtx{finder: finder}, "DC0_C0", managedHosts.HostGroupName, managedHosts.VMGroupName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(verifiedRule.IsMandatory()).To(BeTrue())
+	g.Expect(verifiedRule.Disabled()).To(BeFalse())
+
+	// Groups and rules which have not been created by CAPV are neither taken over nor deleted.
+	g.Expect(ReconcileManagedHosts(ctx, ccr, managedHosts, &infrav1.VSphereDeploymentZoneManagedHosts{})).To(MatchError(ContainSubstring("has not been created by CAPV")))
+	g.Expect(DeleteManagedHosts(ctx, ccr, infrav1.VSphereDeploymentZoneManagedHosts{})).To(Succeed())
+	clusterConfigInfoEx, err = ccr.Configuration(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(findGroup(clusterConfigInfoEx.Group, managedHosts.VMGroupName)).NotTo(BeNil())
+	g.Expect(findRule(clusterConfigInfoEx.Rule, managedHosts.RuleName)).NotTo(BeNil())
+
+	g.Expect(DeleteManagedHosts(ctx, ccr, created)).To(Succeed())
+
+	clusterConfigInfoEx, err = ccr.Configuration(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(findGroup(clusterConfigInfoEx.Group, managedHosts.VMGroupName)).To(BeNil())
+	g.Expect(findGroup(clusterConfigInfoEx.Group, managedHosts.HostGroupName)).To(BeNil())
+	g.Expect(findRule(clusterConfigInfoEx.Rule, managedHosts.RuleName)).To(BeNil())
+
+	// Deleting again is a no-op.
+	g.Expect(DeleteManagedHosts(ctx, ccr, created)).To(Succeed())
+}
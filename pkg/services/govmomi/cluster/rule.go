@@ -20,6 +20,7 @@ import (
 	"context"
 
 	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/types"
 	"k8s.io/utils/ptr"
 )
@@ -57,15 +58,35 @@ func VerifyAffinityRule(ctx context.Context, computeClusterCtx computeClusterCon
 		return nil, pkgerrors.Wrapf(err, "unable to list rules for compute cluster %s", clusterName)
 	}
 
+	if rule := findAffinityRule(rules, hostGroupName, vmGroupName); rule != nil {
+		return rule, nil
+	}
+	return nil, pkgerrors.New("no matching affinity rule found/exists")
+}
+
+// FindAffinityRule returns the affinity rule of the compute cluster for a given hostGroup and vmGroup,
+// or nil if there is none.
+func FindAffinityRule(ctx context.Context, ccr *object.ClusterComputeResource, hostGroupName, vmGroupName string) (Rule, error) {
+	clusterConfigInfoEx, err := ccr.Configuration(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rule := findAffinityRule(clusterConfigInfoEx.Rule, hostGroupName, vmGroupName); rule != nil {
+		return rule, nil
+	}
+	return nil, nil
+}
+
+func findAffinityRule(rules []types.BaseClusterRuleInfo, hostGroupName, vmGroupName string) Rule {
 	for _, rule := range rules {
 		if vmHostRuleInfo, ok := rule.(*types.ClusterVmHostRuleInfo); ok {
 			if vmHostRuleInfo.AffineHostGroupName == hostGroupName &&
 				vmHostRuleInfo.VmGroupName == vmGroupName {
-				return vmHostAffinityRule{vmHostRuleInfo}, nil
+				return vmHostAffinityRule{vmHostRuleInfo}
 			}
 		}
 	}
-	return nil, pkgerrors.New("no matching affinity rule found/exists")
+	return nil
 }
 
 func listRules(ctx context.Context, computeClusterCtx computeClusterContext, clusterName string) ([]types.BaseClusterRuleInfo, error) {
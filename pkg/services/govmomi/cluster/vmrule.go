@@ -0,0 +1,166 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+)
+
+// FindComputeClusterOfVM returns the compute cluster the VM runs in, or nil if the VM runs
+// on a standalone host.
+func FindComputeClusterOfVM(ctx context.Context, vm *object.VirtualMachine) (*object.ClusterComputeResource, error) {
+	rp, err := vm.ResourcePool(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get resource pool of vm %s", vm.Reference().Value)
+	}
+	owner, err := rp.Owner(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get owner of resource pool %s", rp.Reference().Value)
+	}
+	if owner.Reference().Type != "ClusterComputeResource" {
+		return nil, nil
+	}
+	return object.NewClusterComputeResource(vm.Client(), owner.Reference()), nil
+}
+
+// vmRuleMutexes holds a mutex per compute cluster, keyed by the vCenter and the compute cluster.
+var vmRuleMutexes sync.Map
+
+// LockVMRules serializes the updates of the DRS VM-VM rules of the compute cluster, as the VMs of
+// a rule are replaced as a whole when a VM is added or removed. It returns the function releasing
+// the lock.
+func LockVMRules(ccr *object.ClusterComputeResource) func() {
+	key := ccr.Client().URL().Host + "/" + ccr.Reference().Value
+	mu, _ := vmRuleMutexes.LoadOrStore(key, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
+
+// FindVMRule returns the DRS VM-VM affinity or anti-affinity rule with the given name,
+// or nil if the compute cluster has no such rule.
+func FindVMRule(ctx context.Context, ccr *object.ClusterComputeResource, ruleName string) (*VMRule, error) {
+	clusterConfigInfoEx, err := ccr.Configuration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range clusterConfigInfoEx.Rule {
+		switch rule.(type) {
+		case *types.ClusterAffinityRuleSpec, *types.ClusterAntiAffinityRuleSpec:
+			if rule.GetClusterRuleInfo().Name == ruleName {
+				return &VMRule{ccr, rule}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// CreateVMRule creates a DRS VM-VM affinity or anti-affinity rule with the given VMs.
+// DRS rules require at least two VMs.
+func CreateVMRule(ctx context.Context, ccr *object.ClusterComputeResource, ruleName string, affinity bool, vms []types.ManagedObjectReference) (*object.Task, error) {
+	ruleInfo := types.ClusterRuleInfo{
+		Name:        ruleName,
+		Enabled:     ptr.To(true),
+		UserCreated: ptr.To(true),
+	}
+	var rule types.BaseClusterRuleInfo = &types.ClusterAntiAffinityRuleSpec{ClusterRuleInfo: ruleInfo, Vm: vms}
+	if affinity {
+		rule = &types.ClusterAffinityRuleSpec{ClusterRuleInfo: ruleInfo, Vm: vms}
+	}
+
+	spec := &types.ClusterConfigSpecEx{
+		RulesSpec: []types.ClusterRuleSpec{
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{
+					Operation: types.ArrayUpdateOperationAdd,
+				},
+				Info: rule,
+			},
+		},
+	}
+	return ccr.Reconfigure(ctx, spec, true)
+}
+
+// VMRule is a DRS VM-VM affinity or anti-affinity rule of a compute cluster.
+type VMRule struct {
+	*object.ClusterComputeResource
+	types.BaseClusterRuleInfo
+}
+
+// HasVM returns true if the VM is a member of the rule.
+func (vr VMRule) HasVM(vmObj types.ManagedObjectReference) bool {
+	return slices.Contains(vr.listVMs(), vmObj)
+}
+
+// ListVMs returns the VMs of the rule.
+func (vr VMRule) ListVMs() []types.ManagedObjectReference {
+	return slices.Clone(vr.listVMs())
+}
+
+// SetVMs replaces the VMs of the rule.
+func (vr VMRule) SetVMs(ctx context.Context, vms []types.ManagedObjectReference) (*object.Task, error) {
+	switch rule := vr.BaseClusterRuleInfo.(type) {
+	case *types.ClusterAffinityRuleSpec:
+		rule.Vm = vms
+	case *types.ClusterAntiAffinityRuleSpec:
+		rule.Vm = vms
+	}
+
+	spec := &types.ClusterConfigSpecEx{
+		RulesSpec: []types.ClusterRuleSpec{
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{
+					Operation: types.ArrayUpdateOperationEdit,
+				},
+				Info: vr.BaseClusterRuleInfo,
+			},
+		},
+	}
+	return vr.ClusterComputeResource.Reconfigure(ctx, spec, true)
+}
+
+// Remove deletes the rule from the compute cluster.
+func (vr VMRule) Remove(ctx context.Context) (*object.Task, error) {
+	spec := &types.ClusterConfigSpecEx{
+		RulesSpec: []types.ClusterRuleSpec{
+			{
+				ArrayUpdateSpec: types.ArrayUpdateSpec{
+					Operation: types.ArrayUpdateOperationRemove,
+					RemoveKey: vr.GetClusterRuleInfo().Key,
+				},
+			},
+		},
+	}
+	return vr.ClusterComputeResource.Reconfigure(ctx, spec, true)
+}
+
+func (vr VMRule) listVMs() []types.ManagedObjectReference {
+	switch rule := vr.BaseClusterRuleInfo.(type) {
+	case *types.ClusterAffinityRuleSpec:
+		return rule.Vm
+	case *types.ClusterAntiAffinityRuleSpec:
+		return rule.Vm
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/vim25/types"
+
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+)
+
+func Test_VMRule(t *testing.T) {
+	g := NewWithT(t)
+	sim, err := vcsim.NewBuilder().Build()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer sim.Destroy()
+
+	ctx := context.Background()
+	client, _ := govmomi.NewClient(ctx, sim.ServerURL(), true)
+	finder := find.NewFinder(client.Client, false)
+
+	dc, _ := finder.DatacenterOrDefault(ctx, "DC0")
+	finder.SetDatacenter(dc)
+
+	vmOne, err := finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+	g.Expect(err).NotTo(HaveOccurred())
+	vmTwo, err := finder.VirtualMachine(ctx, "DC0_C0_RP0_VM1")
+	g.Expect(err).NotTo(HaveOccurred())
+	standaloneVM, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+	g.Expect(err).NotTo(HaveOccurred())
+
+	ccr, err := FindComputeClusterOfVM(ctx, standaloneVM)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ccr).To(BeNil())
+
+	ccr, err = FindComputeClusterOfVM(ctx, vmOne)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(ccr).NotTo(BeNil())
+
+	ruleName := "blah-vm-rule"
+	rule, err := FindVMRule(ctx, ccr, ruleName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rule).To(BeNil())
+
+	task, err := CreateVMRule(ctx, ccr, ruleName, false, []types.ManagedObjectReference{vmOne.Reference(), vmTwo.Reference()})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Wait(ctx)).To(Succeed())
+
+	rule, err = FindVMRule(ctx, ccr, ruleName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rule).NotTo(BeNil())
+	g.Expect(rule.BaseClusterRuleInfo).To(BeAssignableToTypeOf(&types.ClusterAntiAffinityRuleSpec{}))
+	g.Expect(rule.HasVM(vmOne.Reference())).To(BeTrue())
+	g.Expect(rule.HasVM(standaloneVM.Reference())).To(BeFalse())
+
+	task, err = rule.SetVMs(ctx, []types.ManagedObjectReference{vmTwo.Reference()})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Wait(ctx)).To(Succeed())
+
+	rule, err = FindVMRule(ctx, ccr, ruleName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rule.ListVMs()).To(ConsistOf(vmTwo.Reference()))
+
+	task, err = rule.Remove(ctx)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(task.Wait(ctx)).To(Succeed())
+
+	rule, err = FindVMRule(ctx, ccr, ruleName)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(rule).To(BeNil())
+}
+
+func Test_LockVMRules(t *testing.T) {
+	g := NewWithT(t)
+	sim, err := vcsim.NewBuilder().Build()
+	g.Expect(err).NotTo(HaveOccurred())
+	defer sim.Destroy()
+
+	ctx := context.Background()
+	client, _ := govmomi.NewClient(ctx, sim.ServerURL(), true)
+	finder := find.NewFinder(client.Client, false)
+
+	dc, _ := finder.DatacenterOrDefault(ctx, "DC0")
+	finder.SetDatacenter(dc)
+
+	vm, err := finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+	g.Expect(err).NotTo(HaveOccurred())
+	ccr, err := FindComputeClusterOfVM(ctx, vm)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	unlock := LockVMRules(ccr)
+
+	// The rules of the compute cluster can't be locked again until they are unlocked.
+	locked := make(chan struct{})
+	go func() {
+		LockVMRules(ccr)()
+		close(locked)
+	}()
+	g.Consistently(locked).ShouldNot(BeClosed())
+
+	unlock()
+	g.Eventually(locked).Should(BeClosed())
+}
@@ -20,17 +20,50 @@ import (
 	"context"
 
 	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/mo"
 	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
 
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
 )
 
-// createVM creates a new VM with the data in the VMContext passed. This method does not wait
-// for the new VM to be created.
+// createVM creates a new VM with the data in the VMContext passed, or adopts a VM of the warm pool
+// of the VSphereMachineTemplate of the VSphereVM. This method does not wait for the new VM to be
+// created.
 func createVM(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []byte, format bootstrapv1.Format) error {
 	if !vmCtx.Session.IsVC() {
 		return pkgerrors.Errorf("expected VCenter client got %v", vmCtx.Session.ServiceContent.About.ApiType)
 	}
+	if err := resolveTemplateRef(ctx, vmCtx); err != nil {
+		return err
+	}
+	adopted, err := adoptWarmPoolVM(ctx, vmCtx, bootstrapData, format)
+	if err != nil || adopted {
+		return err
+	}
 	return vcenter.Clone(ctx, vmCtx, bootstrapData, format)
 }
+
+// reconcileContentLibraryDeployment configures a VM which was deployed from a content library
+// item but did not get the config of the VSphereVM, e.g. because the controller restarted during
+// the deployment. The deployment is complete once the VM has the instance UUID of the VSphereVM.
+func (vms *VMService) reconcileContentLibraryDeployment(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	if virtualMachineCtx.VSphereVM.Status.ContentLibraryItemID == "" {
+		return true, nil
+	}
+
+	var obj mo.VirtualMachine
+	if err := virtualMachineCtx.Obj.Properties(ctx, virtualMachineCtx.Ref, []string{"config.instanceUuid"}, &obj); err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to get instance UUID of %s", virtualMachineCtx)
+	}
+	if obj.Config != nil && obj.Config.InstanceUuid == string(virtualMachineCtx.VSphereVM.UID) {
+		virtualMachineCtx.VSphereVM.Status.ContentLibraryItemID = ""
+		return true, nil
+	}
+
+	bootstrapData, format, err := vms.getBootstrapData(ctx, &virtualMachineCtx.VMContext)
+	if err != nil {
+		return false, err
+	}
+	return false, vcenter.ConfigureDeployedVM(ctx, &virtualMachineCtx.VMContext, virtualMachineCtx.Obj, bootstrapData, format)
+}
@@ -59,7 +59,8 @@ func TestCreate(t *testing.T) {
 			session.NewParams().
 				WithServer(vmContext.VSphereVM.Spec.Server).
 				WithUserInfo(simr.Username(), simr.Password()).
-				WithDatacenter("*"))
+				WithDatacenter("*").
+				WithInsecure(true))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	deprecatedv1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+const (
+	// primaryDiskName is the name used for the primary disk of a VM
+	// in logs, conditions and the disk grow hint.
+	primaryDiskName = "primary"
+
+	kibPerGiB = 1024 * 1024
+)
+
+// reconcileDisks extends the disks of an existing VM if diskGiB, additionalDisksGiB
+// or the sizeGiB of a data disk has been increased. Disks are never shrunk.
+func (vms *VMService) reconcileDisks(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	devices, err := virtualMachineCtx.Obj.Device(ctx)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "error getting devices of VM %s", virtualMachineCtx)
+	}
+
+	deviceChange, extended, shrinkRequested, deltaDisks := getDiskResizeSpecs(virtualMachineCtx.VSphereVM, devices)
+
+	if len(deviceChange) > 0 {
+		log.Info("Extending disks of VM", "disks", extended)
+		configSpec := types.VirtualMachineConfigSpec{
+			DeviceChange: deviceChange,
+		}
+		if virtualMachineCtx.VSphereVM.Spec.DiskGrowHint == infrav1.DiskGrowHintGuestInfo {
+			var extraConfig extra.Config
+			extraConfig.SetDiskGrowHint(extended, time.Now())
+			configSpec.ExtraConfig = extraConfig
+		}
+
+		task, err := virtualMachineCtx.Obj.Reconfigure(ctx, configSpec)
+		if err != nil {
+			deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.DisksResizedV1Beta1Condition, infrav1.DisksResizeFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%v", err)
+			conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+				Type:    infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereVMVirtualMachineDisksResizeFailedReason,
+				Message: err.Error(),
+			})
+			return false, pkgerrors.Wrapf(err, "error triggering reconfigure op to extend disks of VM %s", virtualMachineCtx)
+		}
+		message := fmt.Sprintf("Extending disks %s", strings.Join(extended, ", "))
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.DisksResizedV1Beta1Condition, infrav1.DisksResizingV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", message)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMVirtualMachineDisksResizingReason,
+			Message: message,
+		})
+		virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+		return false, nil
+	}
+
+	if len(deltaDisks) > 0 {
+		message := fmt.Sprintf("Disks %s are backed by a delta disk, e.g. of a linked clone or of a VM with snapshots, and can't be extended", strings.Join(deltaDisks, ", "))
+		log.Info(message)
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.DisksResizedV1Beta1Condition, infrav1.DeltaDiskNotResizableV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", message)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMVirtualMachineDisksDeltaDiskNotResizableReason,
+			Message: message,
+		})
+		return true, nil
+	}
+
+	if len(shrinkRequested) > 0 {
+		message := fmt.Sprintf("Disks %s are larger than their desired size and can't be shrunk", strings.Join(shrinkRequested, ", "))
+		log.Info(message)
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.DisksResizedV1Beta1Condition, infrav1.DiskShrinkNotSupportedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", message)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMVirtualMachineDisksShrinkNotSupportedReason,
+			Message: message,
+		})
+		return true, nil
+	}
+
+	// Only report the condition once a change has been applied to the VM.
+	if conditions.Has(virtualMachineCtx.VSphereVM, infrav1.VSphereVMVirtualMachineDisksResizedCondition) {
+		deprecatedv1beta1conditions.MarkTrue(virtualMachineCtx.VSphereVM, infrav1.DisksResizedV1Beta1Condition)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:   infrav1.VSphereVMVirtualMachineDisksResizedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereVMVirtualMachineDisksResizedReason,
+		})
+	}
+	return true, nil
+}
+
+// getDiskResizeSpecs returns the device changes required to extend the disks of the VM to
+// the sizes in the spec of the VSphereVM together with the names of the extended disks,
+// the names of the disks which are larger than their desired size, and the names of the disks
+// which are smaller than their desired size but backed by a delta disk.
+//
+// The disks of the VM are matched to the spec in the order in which they have been created
+// by Clone: the primary disk, the additional disks of the template and finally the data disks.
+// Disks backed by a delta disk, e.g. the disks of a linked clone, can't be extended.
+func getDiskResizeSpecs(vsphereVM *infrav1.VSphereVM, devices object.VirtualDeviceList) ([]types.BaseVirtualDeviceConfigSpec, []string, []string, []string) {
+	spec := vsphereVM.Spec
+	disks := devices.SelectByType((*types.VirtualDisk)(nil))
+
+	templateDisks := len(disks) - len(spec.DataDisks)
+	if templateDisks < 1 {
+		// The data disks of the VM don't match the spec, only the primary disk can be matched.
+		templateDisks = min(len(disks), 1)
+	}
+
+	var deviceChange []types.BaseVirtualDeviceConfigSpec
+	var extended, shrinkRequested, deltaDisks []string
+	for i, device := range disks {
+		disk := device.(*types.VirtualDisk)
+
+		var name string
+		var desiredCapacityKB int64
+		switch {
+		case i == 0:
+			name = primaryDiskName
+			desiredCapacityKB = int64(spec.DiskGiB) * kibPerGiB
+		case i < templateDisks:
+			name = fmt.Sprintf("additional-%d", i-1)
+			if len(spec.AdditionalDisksGiB) > i-1 {
+				desiredCapacityKB = int64(spec.AdditionalDisksGiB[i-1]) * kibPerGiB
+			}
+		case i-templateDisks < len(spec.DataDisks):
+			name = spec.DataDisks[i-templateDisks].Name
+			desiredCapacityKB = int64(spec.DataDisks[i-templateDisks].SizeGiB) * kibPerGiB
+		}
+
+		if desiredCapacityKB == 0 || desiredCapacityKB == disk.CapacityInKB {
+			continue
+		}
+		if isDeltaDisk(disk) {
+			if desiredCapacityKB > disk.CapacityInKB {
+				deltaDisks = append(deltaDisks, name)
+			}
+			continue
+		}
+		if desiredCapacityKB < disk.CapacityInKB {
+			shrinkRequested = append(shrinkRequested, name)
+			continue
+		}
+
+		disk.CapacityInKB = desiredCapacityKB
+		disk.CapacityInBytes = desiredCapacityKB * 1024
+		deviceChange = append(deviceChange, &types.VirtualDeviceConfigSpec{
+			Operation: types.VirtualDeviceConfigSpecOperationEdit,
+			Device:    disk,
+		})
+		extended = append(extended, name)
+	}
+	return deviceChange, extended, shrinkRequested, deltaDisks
+}
+
+// isDeltaDisk returns true if the disk is backed by a delta disk which can't be extended.
+func isDeltaDisk(disk *types.VirtualDisk) bool {
+	backing, ok := disk.Backing.(*types.VirtualDiskFlatVer2BackingInfo)
+	return ok && backing.Parent != nil
+}
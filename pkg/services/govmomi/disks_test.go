@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func Test_reconcileDisks(t *testing.T) {
+	var vmCtx *virtualMachineContext
+	var g *WithT
+	var vms *VMService
+
+	before := func() {
+		vmCtx = emptyVirtualMachineContext()
+		vmCtx.Client = fake.NewClientBuilder().Build()
+
+		vms = &VMService{}
+	}
+
+	newVSphereVM := func(cloneSpec infrav1.VirtualMachineCloneSpec) *infrav1.VSphereVM {
+		return &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vsphereVM1",
+				Namespace: "my-namespace",
+			},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: cloneSpec,
+			},
+		}
+	}
+
+	t.Run("extends the primary disk of a running VM and sets the grow hint", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{
+				DiskGiB:      20,
+				DiskGrowHint: infrav1.DiskGrowHintGuestInfo,
+			})
+
+			ok, err := vms.reconcileDisks(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			g.Expect(conditions.GetReason(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineDisksResizedCondition)).To(Equal(infrav1.VSphereVMVirtualMachineDisksResizingReason))
+
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).ToNot(BeEmpty())
+			task := object.NewTask(c, types.ManagedObjectReference{Type: "Task", Value: vmCtx.VSphereVM.Status.TaskRef})
+			g.Expect(task.Wait(ctx)).To(Succeed())
+
+			devices, err := vm.Device(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			disk := devices.SelectByType((*types.VirtualDisk)(nil))[0].(*types.VirtualDisk)
+			g.Expect(disk.CapacityInKB).To(Equal(int64(20 * 1024 * 1024)))
+
+			var o mo.VirtualMachine
+			g.Expect(vm.Properties(ctx, vm.Reference(), []string{"config.extraConfig"}, &o)).To(Succeed())
+			g.Expect(o.Config.ExtraConfig).To(ContainElement(&types.OptionValue{Key: "guestinfo.capv.disks.grow.disks", Value: primaryDiskName}))
+
+			ok, err = vms.reconcileDisks(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(conditions.IsTrue(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineDisksResizedCondition)).To(BeTrue())
+			return nil
+		})
+	})
+
+	t.Run("does not shrink disks", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{
+				DiskGiB: 1,
+			})
+
+			ok, err := vms.reconcileDisks(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			g.Expect(conditions.GetReason(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineDisksResizedCondition)).To(Equal(infrav1.VSphereVMVirtualMachineDisksShrinkNotSupportedReason))
+			return nil
+		})
+	})
+}
+
+func Test_getDiskResizeSpecs(t *testing.T) {
+	newDisk := func(key int32, sizeGiB int64, delta bool) *types.VirtualDisk {
+		backing := &types.VirtualDiskFlatVer2BackingInfo{}
+		if delta {
+			backing.Parent = &types.VirtualDiskFlatVer2BackingInfo{}
+		}
+		return &types.VirtualDisk{
+			VirtualDevice: types.VirtualDevice{Key: key, Backing: backing},
+			CapacityInKB:  sizeGiB * 1024 * 1024,
+		}
+	}
+
+	tests := []struct {
+		name                string
+		spec                infrav1.VirtualMachineCloneSpec
+		devices             object.VirtualDeviceList
+		wantExtended        []string
+		wantShrinkRequested []string
+		wantDeltaDisks      []string
+	}{
+		{
+			name:    "disks match the spec",
+			spec:    infrav1.VirtualMachineCloneSpec{DiskGiB: 20, DataDisks: []infrav1.VSphereDisk{{Name: "data", SizeGiB: 10}}},
+			devices: object.VirtualDeviceList{newDisk(1, 20, false), newDisk(2, 10, false)},
+		},
+		{
+			name:    "sizes are not set",
+			spec:    infrav1.VirtualMachineCloneSpec{},
+			devices: object.VirtualDeviceList{newDisk(1, 20, false), newDisk(2, 10, false)},
+		},
+		{
+			name: "extends primary, additional and data disks",
+			spec: infrav1.VirtualMachineCloneSpec{
+				DiskGiB:            30,
+				AdditionalDisksGiB: []int32{15},
+				DataDisks:          []infrav1.VSphereDisk{{Name: "data", SizeGiB: 50}},
+			},
+			devices:      object.VirtualDeviceList{newDisk(1, 20, false), newDisk(2, 10, false), newDisk(3, 40, false)},
+			wantExtended: []string{primaryDiskName, "additional-0", "data"},
+		},
+		{
+			name:                "reports disks which would need to be shrunk",
+			spec:                infrav1.VirtualMachineCloneSpec{DiskGiB: 10, DataDisks: []infrav1.VSphereDisk{{Name: "data", SizeGiB: 20}}},
+			devices:             object.VirtualDeviceList{newDisk(1, 20, false), newDisk(2, 10, false)},
+			wantExtended:        []string{"data"},
+			wantShrinkRequested: []string{primaryDiskName},
+		},
+		{
+			name:           "reports delta disks which would need to be extended",
+			spec:           infrav1.VirtualMachineCloneSpec{DiskGiB: 30, DataDisks: []infrav1.VSphereDisk{{Name: "data", SizeGiB: 20}}},
+			devices:        object.VirtualDeviceList{newDisk(1, 20, true), newDisk(2, 10, false)},
+			wantExtended:   []string{"data"},
+			wantDeltaDisks: []string{primaryDiskName},
+		},
+		{
+			name:    "ignores delta disks which are larger than their desired size",
+			spec:    infrav1.VirtualMachineCloneSpec{DiskGiB: 10},
+			devices: object.VirtualDeviceList{newDisk(1, 20, true)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			vsphereVM := &infrav1.VSphereVM{Spec: infrav1.VSphereVMSpec{VirtualMachineCloneSpec: tt.spec}}
+			deviceChange, extended, shrinkRequested, deltaDisks := getDiskResizeSpecs(vsphereVM, tt.devices)
+			g.Expect(extended).To(Equal(tt.wantExtended))
+			g.Expect(shrinkRequested).To(Equal(tt.wantShrinkRequested))
+			g.Expect(deltaDisks).To(Equal(tt.wantDeltaDisks))
+			g.Expect(deviceChange).To(HaveLen(len(tt.wantExtended)))
+		})
+	}
+}
@@ -0,0 +1,293 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	deprecatedv1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+// driftedNetworkDevice is a network device of a VM which is not connected to
+// the network in the spec.
+type driftedNetworkDevice struct {
+	device  types.BaseVirtualDevice
+	backing types.BaseVirtualDeviceBackingInfo
+}
+
+// vmDrift is the result of comparing the configuration of a VM with the spec.
+type vmDrift struct {
+	drift        []infrav1.VirtualMachineDrift
+	folder       *object.Folder
+	resourcePool *object.ResourcePool
+	devices      []driftedNetworkDevice
+}
+
+// reconcileDrift compares the configuration of an existing VM with the spec, records
+// the differences in status.drift and in the VirtualMachineInSync condition and
+// changes the fields listed in driftRemediation back to the desired value.
+func (vms *VMService) reconcileDrift(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var virtualMachine mo.VirtualMachine
+	if err := virtualMachineCtx.Obj.Properties(ctx, virtualMachineCtx.Obj.Reference(), []string{
+		"config.hardware",
+		"parent",
+		"resourcePool",
+	}, &virtualMachine); err != nil {
+		return false, pkgerrors.Wrapf(err, "error getting configuration of VM %s", virtualMachineCtx.VSphereVM.Name)
+	}
+
+	d, err := getDrift(ctx, virtualMachineCtx, virtualMachine)
+	if err != nil {
+		return false, err
+	}
+	virtualMachineCtx.VSphereVM.Status.Drift = d.drift
+
+	if len(d.drift) == 0 {
+		deprecatedv1beta1conditions.MarkTrue(virtualMachineCtx.VSphereVM, infrav1.VMInSyncV1Beta1Condition)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:   infrav1.VSphereVMVirtualMachineInSyncCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereVMVirtualMachineInSyncReason,
+		})
+		return true, nil
+	}
+
+	paths := make([]string, 0, len(d.drift))
+	for _, drift := range d.drift {
+		paths = append(paths, drift.Path)
+	}
+	message := fmt.Sprintf("VM has drifted from the spec: %s", strings.Join(paths, ", "))
+
+	task, err := remediateDrift(ctx, virtualMachineCtx, d)
+	if err != nil {
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMInSyncV1Beta1Condition, infrav1.DriftRemediationFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%v", err)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMVirtualMachineInSyncCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMVirtualMachineDriftRemediationFailedReason,
+			Message: err.Error(),
+		})
+		return false, err
+	}
+	if task != nil {
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMInSyncV1Beta1Condition, infrav1.RemediatingDriftV1Beta1Reason, clusterv1.ConditionSeverityInfo, "%s", message)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMVirtualMachineInSyncCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMVirtualMachineRemediatingDriftReason,
+			Message: message,
+		})
+		virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+		return false, nil
+	}
+
+	log.V(4).Info("VM has drifted from the spec", "paths", paths)
+	deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMInSyncV1Beta1Condition, infrav1.DriftedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%s", message)
+	conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+		Type:    infrav1.VSphereVMVirtualMachineInSyncCondition,
+		Status:  metav1.ConditionFalse,
+		Reason:  infrav1.VSphereVMVirtualMachineDriftedReason,
+		Message: message,
+	})
+	return true, nil
+}
+
+// getDrift compares the configuration of the VM with the spec.
+// Fields which are not set in the spec are not compared.
+func getDrift(ctx context.Context, virtualMachineCtx *virtualMachineContext, vm mo.VirtualMachine) (vmDrift, error) {
+	d := vmDrift{}
+	spec := virtualMachineCtx.VSphereVM.Spec
+	finder := virtualMachineCtx.Session.Finder
+
+	if vm.Config != nil {
+		hardware := vm.Config.Hardware
+		if spec.NumCPUs != 0 && spec.NumCPUs != hardware.NumCPU {
+			d.add("numCPUs", strconv.Itoa(int(spec.NumCPUs)), strconv.Itoa(int(hardware.NumCPU)))
+		}
+		// A numCoresPerSocket of 0 lets vSphere assign the value at power on.
+		if ptr.Deref(spec.NumCoresPerSocket, 0) != 0 && *spec.NumCoresPerSocket != ptr.Deref(hardware.NumCoresPerSocket, 0) {
+			d.add("numCoresPerSocket", strconv.Itoa(int(*spec.NumCoresPerSocket)), strconv.Itoa(int(ptr.Deref(hardware.NumCoresPerSocket, 0))))
+		}
+		if spec.MemoryMiB != 0 && spec.MemoryMiB != int64(hardware.MemoryMB) {
+			d.add("memoryMiB", strconv.FormatInt(spec.MemoryMiB, 10), strconv.Itoa(int(hardware.MemoryMB)))
+		}
+	}
+
+	if spec.Folder != "" && vm.Parent != nil {
+		folder, err := finder.FolderOrDefault(ctx, spec.Folder)
+		if err != nil {
+			return d, pkgerrors.Wrapf(err, "unable to get folder for %q", virtualMachineCtx)
+		}
+		if folder.Reference() != *vm.Parent {
+			d.add("folder", spec.Folder, objectName(ctx, virtualMachineCtx, *vm.Parent))
+			d.folder = folder
+		}
+	}
+
+	if spec.ResourcePool != "" && vm.ResourcePool != nil {
+		pool, err := finder.ResourcePoolOrDefault(ctx, spec.ResourcePool)
+		if err != nil {
+			return d, pkgerrors.Wrapf(err, "unable to get resource pool for %q", virtualMachineCtx)
+		}
+		if pool.Reference() != *vm.ResourcePool {
+			d.add("resourcePool", spec.ResourcePool, objectName(ctx, virtualMachineCtx, *vm.ResourcePool))
+			d.resourcePool = pool
+		}
+	}
+
+	if vm.Config == nil || len(spec.Network.Devices) == 0 {
+		return d, nil
+	}
+
+	nics := object.VirtualDeviceList(vm.Config.Hardware.Device).SelectByType((*types.VirtualEthernetCard)(nil))
+	if len(nics) != len(spec.Network.Devices) {
+		d.add("network.devices", strconv.Itoa(len(spec.Network.Devices)), strconv.Itoa(len(nics)))
+		return d, nil
+	}
+	for i, device := range spec.Network.Devices {
+		ref, err := finder.Network(ctx, device.NetworkName)
+		if err != nil {
+			return d, pkgerrors.Wrapf(err, "unable to find network %q", device.NetworkName)
+		}
+		backing, err := ref.EthernetCardBackingInfo(ctx)
+		if err != nil {
+			return d, pkgerrors.Wrapf(err, "unable to create new ethernet card backing info for network %q on %q", device.NetworkName, virtualMachineCtx)
+		}
+		actual := nics[i].GetVirtualDevice().Backing
+		if networkBackingMatches(backing, actual) {
+			continue
+		}
+		d.add(fmt.Sprintf("network.devices[%d].networkName", i), device.NetworkName, networkBackingName(ctx, virtualMachineCtx, actual))
+		d.devices = append(d.devices, driftedNetworkDevice{device: nics[i], backing: backing})
+	}
+
+	return d, nil
+}
+
+func (d *vmDrift) add(path, desired, actual string) {
+	d.drift = append(d.drift, infrav1.VirtualMachineDrift{Path: path, Desired: desired, Actual: actual})
+}
+
+// remediateDrift triggers a task which changes the first drifted field listed in
+// driftRemediation back to the desired value. It returns nil if there is nothing to remediate.
+func remediateDrift(ctx context.Context, virtualMachineCtx *virtualMachineContext, d vmDrift) (*object.Task, error) {
+	log := ctrl.LoggerFrom(ctx)
+	remediation := virtualMachineCtx.VSphereVM.Spec.DriftRemediation
+
+	if d.folder != nil && slices.Contains(remediation, infrav1.VirtualMachineDriftRemediationFieldFolder) {
+		log.Info("Moving VM back into folder", "folder", virtualMachineCtx.VSphereVM.Spec.Folder)
+		task, err := d.folder.MoveInto(ctx, []types.ManagedObjectReference{virtualMachineCtx.Ref})
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error triggering move op for VM %s", virtualMachineCtx)
+		}
+		return task, nil
+	}
+
+	if d.resourcePool != nil && slices.Contains(remediation, infrav1.VirtualMachineDriftRemediationFieldResourcePool) {
+		log.Info("Relocating VM back into resource pool", "resourcePool", virtualMachineCtx.VSphereVM.Spec.ResourcePool)
+		poolRef := d.resourcePool.Reference()
+		task, err := virtualMachineCtx.Obj.Relocate(ctx, types.VirtualMachineRelocateSpec{Pool: &poolRef}, types.VirtualMachineMovePriorityDefaultPriority)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error triggering relocate op for VM %s", virtualMachineCtx)
+		}
+		return task, nil
+	}
+
+	if len(d.devices) > 0 && slices.Contains(remediation, infrav1.VirtualMachineDriftRemediationFieldNetwork) {
+		var deviceChange []types.BaseVirtualDeviceConfigSpec
+		for _, drifted := range d.devices {
+			drifted.device.GetVirtualDevice().Backing = drifted.backing
+			deviceChange = append(deviceChange, &types.VirtualDeviceConfigSpec{
+				Operation: types.VirtualDeviceConfigSpecOperationEdit,
+				Device:    drifted.device,
+			})
+		}
+		log.Info("Connecting network devices of VM back to their networks", "count", len(deviceChange))
+		task, err := virtualMachineCtx.Obj.Reconfigure(ctx, types.VirtualMachineConfigSpec{DeviceChange: deviceChange})
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error triggering reconfigure op for VM %s", virtualMachineCtx)
+		}
+		return task, nil
+	}
+
+	return nil, nil
+}
+
+// networkBackingMatches returns true if the actual backing of a network device
+// connects it to the same network as the desired backing.
+func networkBackingMatches(desired, actual types.BaseVirtualDeviceBackingInfo) bool {
+	switch desired := desired.(type) {
+	case *types.VirtualEthernetCardNetworkBackingInfo:
+		actual, ok := actual.(*types.VirtualEthernetCardNetworkBackingInfo)
+		if !ok {
+			return false
+		}
+		if desired.Network != nil && actual.Network != nil {
+			return *desired.Network == *actual.Network
+		}
+		return desired.DeviceName == actual.DeviceName
+	case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+		actual, ok := actual.(*types.VirtualEthernetCardDistributedVirtualPortBackingInfo)
+		return ok && desired.Port.PortgroupKey == actual.Port.PortgroupKey
+	case *types.VirtualEthernetCardOpaqueNetworkBackingInfo:
+		actual, ok := actual.(*types.VirtualEthernetCardOpaqueNetworkBackingInfo)
+		return ok && desired.OpaqueNetworkId == actual.OpaqueNetworkId
+	default:
+		return false
+	}
+}
+
+// networkBackingName returns a human readable name for the network a device is connected to.
+func networkBackingName(ctx context.Context, virtualMachineCtx *virtualMachineContext, backing types.BaseVirtualDeviceBackingInfo) string {
+	switch backing := backing.(type) {
+	case *types.VirtualEthernetCardNetworkBackingInfo:
+		return backing.DeviceName
+	case *types.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+		// The key of a distributed port group is its managed object ID.
+		return objectName(ctx, virtualMachineCtx, types.ManagedObjectReference{Type: "DistributedVirtualPortgroup", Value: backing.Port.PortgroupKey})
+	case *types.VirtualEthernetCardOpaqueNetworkBackingInfo:
+		return backing.OpaqueNetworkId
+	default:
+		return ""
+	}
+}
+
+// objectName returns the name of a managed object, or its ID if the name can't be retrieved.
+func objectName(ctx context.Context, virtualMachineCtx *virtualMachineContext, ref types.ManagedObjectReference) string {
+	name, err := object.NewCommon(virtualMachineCtx.Session.Client.Client, ref).ObjectName(ctx)
+	if err != nil {
+		return ref.Value
+	}
+	return name
+}
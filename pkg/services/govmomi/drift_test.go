@@ -0,0 +1,173 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+func Test_reconcileDrift(t *testing.T) {
+	// setup returns a context for the DC0_H0_VM0 VM which is in sync with the spec.
+	setup := func(ctx context.Context, g *WithT, c *vim25.Client) *virtualMachineContext {
+		finder := find.NewFinder(c)
+		dc, err := finder.Datacenter(ctx, "DC0")
+		g.Expect(err).ToNot(HaveOccurred())
+		finder.SetDatacenter(dc)
+
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		vmCtx := emptyVirtualMachineContext()
+		vmCtx.Session = &session.Session{Client: &govmomi.Client{Client: c}, Finder: finder}
+		vmCtx.Obj = vm
+		vmCtx.Ref = vm.Reference()
+		vmCtx.VSphereVM = &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vsphereVM1",
+				Namespace: "my-namespace",
+			},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					NumCPUs:      1,
+					MemoryMiB:    32,
+					Folder:       "/DC0/vm",
+					ResourcePool: "/DC0/host/DC0_H0/Resources",
+					Network: infrav1.NetworkSpec{
+						Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "DC0_DVPG0"}},
+					},
+				},
+			},
+		}
+		return vmCtx
+	}
+
+	waitForTask := func(ctx context.Context, g *WithT, c *vim25.Client, vmCtx *virtualMachineContext) {
+		g.Expect(vmCtx.VSphereVM.Status.TaskRef).ToNot(BeEmpty())
+		task := object.NewTask(c, types.ManagedObjectReference{Type: "Task", Value: vmCtx.VSphereVM.Status.TaskRef})
+		g.Expect(task.Wait(ctx)).To(Succeed())
+		vmCtx.VSphereVM.Status.TaskRef = ""
+	}
+
+	t.Run("VM in sync with the spec", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+
+			ok, err := (&VMService{}).reconcileDrift(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.Drift).To(BeEmpty())
+			g.Expect(conditions.IsTrue(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineInSyncCondition)).To(BeTrue())
+			return nil
+		})
+	})
+
+	t.Run("drift is reported if not remediated", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.VSphereVM.Spec.NumCPUs = 4
+			vmCtx.VSphereVM.Spec.Network.Devices[0].NetworkName = "VM Network"
+
+			ok, err := (&VMService{}).reconcileDrift(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			g.Expect(vmCtx.VSphereVM.Status.Drift).To(ConsistOf(
+				infrav1.VirtualMachineDrift{Path: "numCPUs", Desired: "4", Actual: "1"},
+				infrav1.VirtualMachineDrift{Path: "network.devices[0].networkName", Desired: "VM Network", Actual: "DC0_DVPG0"},
+			))
+			condition := conditions.Get(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineInSyncCondition)
+			g.Expect(condition).ToNot(BeNil())
+			g.Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			g.Expect(condition.Reason).To(Equal(infrav1.VSphereVMVirtualMachineDriftedReason))
+			return nil
+		})
+	})
+
+	t.Run("drift of the folder is remediated", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmFolder, err := vmCtx.Session.Finder.Folder(ctx, "/DC0/vm")
+			g.Expect(err).ToNot(HaveOccurred())
+			folder, err := vmFolder.CreateFolder(ctx, "other")
+			g.Expect(err).ToNot(HaveOccurred())
+			vmCtx.VSphereVM.Spec.Folder = "/DC0/vm/other"
+			vmCtx.VSphereVM.Spec.DriftRemediation = []infrav1.VirtualMachineDriftRemediationField{infrav1.VirtualMachineDriftRemediationFieldFolder}
+
+			ok, err := (&VMService{}).reconcileDrift(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			g.Expect(vmCtx.VSphereVM.Status.Drift).To(ConsistOf(
+				infrav1.VirtualMachineDrift{Path: "folder", Desired: "/DC0/vm/other", Actual: "vm"},
+			))
+			g.Expect(conditions.GetReason(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineInSyncCondition)).To(Equal(infrav1.VSphereVMVirtualMachineRemediatingDriftReason))
+			waitForTask(ctx, g, c, vmCtx)
+
+			var o mo.VirtualMachine
+			g.Expect(vmCtx.Obj.Properties(ctx, vmCtx.Obj.Reference(), []string{"parent"}, &o)).To(Succeed())
+			g.Expect(*o.Parent).To(Equal(folder.Reference()))
+
+			ok, err = (&VMService{}).reconcileDrift(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.Drift).To(BeEmpty())
+			return nil
+		})
+	})
+
+	t.Run("drift of the network is remediated", func(t *testing.T) {
+		g := NewWithT(t)
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vmCtx := setup(ctx, g, c)
+			vmCtx.VSphereVM.Spec.Network.Devices[0].NetworkName = "VM Network"
+			vmCtx.VSphereVM.Spec.DriftRemediation = []infrav1.VirtualMachineDriftRemediationField{infrav1.VirtualMachineDriftRemediationFieldNetwork}
+
+			ok, err := (&VMService{}).reconcileDrift(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, g, c, vmCtx)
+
+			ok, err = (&VMService{}).reconcileDrift(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.Drift).To(BeEmpty())
+			g.Expect(conditions.IsTrue(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineInSyncCondition)).To(BeTrue())
+			return nil
+		})
+	})
+}
@@ -19,6 +19,8 @@ package extra
 
 import (
 	"encoding/base64"
+	"strings"
+	"time"
 
 	"github.com/vmware/govmomi/vim25/types"
 )
@@ -31,6 +33,39 @@ const (
 	guestInfoIgnitionEncoding  = "guestinfo.ignition.config.data.encoding"
 	guestInfoCloudInitData     = "guestinfo.userdata"
 	guestInfoCloudInitEncoding = "guestinfo.userdata.encoding"
+	guestInfoMetadata          = "guestinfo.metadata"
+	guestInfoMetadataEncoding  = "guestinfo.metadata.encoding"
+	guestInfoDiskGrowDisks     = "guestinfo.capv.disks.grow.disks"
+	guestInfoDiskGrowTimestamp = "guestinfo.capv.disks.grow.timestamp"
+
+	// WarmPoolOwnerKey is the key which identifies the warm pool a VM belongs to.
+	// It is not prefixed with guestinfo, so it is not visible inside of the guest.
+	WarmPoolOwnerKey = "capv.warmpool.owner"
+
+	// TemplateReplicaSourceKey is the key with the instance UUID of the template a template replica
+	// is cloned from. It is not prefixed with guestinfo, so it is not visible inside of the guest.
+	TemplateReplicaSourceKey = "capv.templatereplica.source"
+
+	// TemplateSourceKey is the key with the checksum of the OVA or OVF a template is imported from.
+	// It is not prefixed with guestinfo, so it is not visible inside of the guest.
+	TemplateSourceKey = "capv.template.source"
+
+	// BootstrapISOMetadataKey is the key with the metadata in the NoCloud seed ISO of a VM.
+	// It is not prefixed with guestinfo, so it is not visible inside of the guest.
+	BootstrapISOMetadataKey = "capv.bootstrapiso.metadata"
+
+	// BootstrapDataScrubbedKey is the key with the time at which the bootstrap data was removed
+	// from the guestinfo of a VM.
+	// It is not prefixed with guestinfo, so it is not visible inside of the guest.
+	BootstrapDataScrubbedKey = "capv.bootstrapdata.scrubbed"
+
+	// GuestCustomizationKey is the key which marks a VM whose guest customization is pending.
+	// It is not prefixed with guestinfo, so it is not visible inside of the guest.
+	GuestCustomizationKey = "capv.guestcustomization"
+
+	// GuestCustomizationPending is the value of GuestCustomizationKey of a VM whose guest
+	// has not been customized yet.
+	GuestCustomizationPending = "pending"
 )
 
 // SetCustomVMXKeys sets the custom VMX keys as
@@ -56,11 +91,11 @@ func (e *Config) SetCloudInitUserData(data []byte) {
 func (e *Config) SetCloudInitMetadata(data []byte) {
 	*e = append(*e,
 		&types.OptionValue{
-			Key:   "guestinfo.metadata",
+			Key:   guestInfoMetadata,
 			Value: e.encode(data),
 		},
 		&types.OptionValue{
-			Key:   "guestinfo.metadata.encoding",
+			Key:   guestInfoMetadataEncoding,
 			Value: "base64",
 		},
 	)
@@ -72,6 +107,93 @@ func (e *Config) SetIgnitionUserData(data []byte) {
 	e.setUserData(guestInfoIgnitionData, guestInfoIgnitionEncoding, data)
 }
 
+// SetDiskGrowHint sets the names of the extended disks as a comma-separated list at
+// the key "guestinfo.capv.disks.grow.disks" and the time of the extension at the key
+// "guestinfo.capv.disks.grow.timestamp" in RFC3339 format.
+func (e *Config) SetDiskGrowHint(disks []string, timestamp time.Time) {
+	*e = append(*e,
+		&types.OptionValue{
+			Key:   guestInfoDiskGrowDisks,
+			Value: strings.Join(disks, ","),
+		},
+		&types.OptionValue{
+			Key:   guestInfoDiskGrowTimestamp,
+			Value: timestamp.UTC().Format(time.RFC3339),
+		},
+	)
+}
+
+// SetWarmPoolOwner sets the owner of the warm pool the VM belongs to at the key
+// "capv.warmpool.owner". An empty owner removes the key, i.e. the VM from the pool.
+func (e *Config) SetWarmPoolOwner(owner string) {
+	*e = append(*e, &types.OptionValue{
+		Key:   WarmPoolOwnerKey,
+		Value: owner,
+	})
+}
+
+// SetTemplateReplicaSource sets the instance UUID of the template a template replica is cloned
+// from at the key "capv.templatereplica.source".
+func (e *Config) SetTemplateReplicaSource(templateUUID string) {
+	*e = append(*e, &types.OptionValue{
+		Key:   TemplateReplicaSourceKey,
+		Value: templateUUID,
+	})
+}
+
+// SetTemplateSource sets the checksum of the OVA or OVF a template is imported from at the key
+// "capv.template.source".
+func (e *Config) SetTemplateSource(checksum string) {
+	*e = append(*e, &types.OptionValue{
+		Key:   TemplateSourceKey,
+		Value: checksum,
+	})
+}
+
+// SetBootstrapISOMetadata sets the metadata in the NoCloud seed ISO of the VM at the key
+// "capv.bootstrapiso.metadata" as a base64-encoded string.
+func (e *Config) SetBootstrapISOMetadata(data []byte) {
+	*e = append(*e, &types.OptionValue{
+		Key:   BootstrapISOMetadataKey,
+		Value: e.encode(data),
+	})
+}
+
+// ScrubBootstrapData blanks the cloud-init user data and the ignition user data together with
+// their encodings, which removes them from the VM, and sets the time of the removal at the key
+// "capv.bootstrapdata.scrubbed" in RFC3339 format. The cloud-init metadata is kept, as it holds
+// no secrets and the instance-id and the network configuration in it are still read by the guest.
+func (e *Config) ScrubBootstrapData(timestamp time.Time) {
+	for _, key := range []string{
+		guestInfoCloudInitData,
+		guestInfoCloudInitEncoding,
+		guestInfoIgnitionData,
+		guestInfoIgnitionEncoding,
+	} {
+		*e = append(*e, &types.OptionValue{
+			Key:   key,
+			Value: "",
+		})
+	}
+	*e = append(*e, &types.OptionValue{
+		Key:   BootstrapDataScrubbedKey,
+		Value: timestamp.UTC().Format(time.RFC3339),
+	})
+}
+
+// SetGuestCustomizationPending marks the guest customization of the VM as pending at the key
+// "capv.guestcustomization". Setting it to false removes the key.
+func (e *Config) SetGuestCustomizationPending(pending bool) {
+	value := ""
+	if pending {
+		value = GuestCustomizationPending
+	}
+	*e = append(*e, &types.OptionValue{
+		Key:   GuestCustomizationKey,
+		Value: value,
+	})
+}
+
 // setUserData sets the user data at the provided key
 // as a base64-encoded string.
 func (e *Config) setUserData(userdataKey, encodingKey string, data []byte) {
@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	ginkgotypes "github.com/onsi/ginkgo/v2/types"
@@ -85,6 +86,115 @@ var _ = Describe("Config_SetCloudInitMetadata", func() {
 	)
 })
 
+var _ = Describe("Config_SetDiskGrowHint", func() {
+	Context("we set the disk grow hint for some disks", func() {
+		var config Config
+		config.SetDiskGrowHint([]string{"primary", "data"}, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+		It("sets the extended disks as a comma-separated list", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "guestinfo.capv.disks.grow.disks",
+				Value: "primary,data",
+			}))
+		})
+
+		It("sets the timestamp of the extension", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "guestinfo.capv.disks.grow.timestamp",
+				Value: "2026-01-02T03:04:05Z",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Config_SetWarmPoolOwner", func() {
+	Context("we set the owner of the warm pool", func() {
+		var config Config
+		config.SetWarmPoolOwner("default/md-0")
+
+		It("sets the owner at a key which is not visible in the guest", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "capv.warmpool.owner",
+				Value: "default/md-0",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Config_SetTemplateReplicaSource", func() {
+	Context("we set the source of a template replica", func() {
+		var config Config
+		config.SetTemplateReplicaSource("5016e1a9-2d3b-4d5e-9b6a-6f0e1f2a3b4c")
+
+		It("sets the instance UUID of the template at a key which is not visible in the guest", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "capv.templatereplica.source",
+				Value: "5016e1a9-2d3b-4d5e-9b6a-6f0e1f2a3b4c",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Config_SetTemplateSource", func() {
+	Context("we set the source of an imported template", func() {
+		var config Config
+		config.SetTemplateSource("SHA256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08")
+
+		It("sets the checksum of the source at a key which is not visible in the guest", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "capv.template.source",
+				Value: "SHA256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+			}))
+		})
+	})
+})
+
+var _ = Describe("Config_SetBootstrapISOMetadata", func() {
+	Context("we set the metadata in the seed ISO of a VM", func() {
+		var config Config
+		config.SetBootstrapISOMetadata([]byte("instance-id: test-vm"))
+
+		It("sets the encoded metadata at a key which is not visible in the guest", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "capv.bootstrapiso.metadata",
+				Value: base64Encode("instance-id: test-vm"),
+			}))
+		})
+	})
+})
+
+var _ = Describe("Config_ScrubBootstrapData", func() {
+	Context("we scrub the bootstrap data of a VM", func() {
+		var config Config
+		config.ScrubBootstrapData(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+
+		It("blanks the user data and its encoding", func() {
+			for _, key := range []string{
+				"guestinfo.userdata",
+				"guestinfo.userdata.encoding",
+				"guestinfo.ignition.config.data",
+				"guestinfo.ignition.config.data.encoding",
+			} {
+				Expect(config).To(ContainElement(&types.OptionValue{
+					Key:   key,
+					Value: "",
+				}))
+			}
+		})
+
+		It("keeps the metadata", func() {
+			Expect(config).ToNot(ContainElement(HaveField("Key", HavePrefix("guestinfo.metadata"))))
+		})
+
+		It("sets the time of the removal at a key which is not visible in the guest", func() {
+			Expect(config).To(ContainElement(&types.OptionValue{
+				Key:   "capv.bootstrapdata.scrubbed",
+				Value: "2026-01-02T03:04:05Z",
+			}))
+		})
+	})
+})
+
 func base64Encode(s string) string {
 	return base64.StdEncoding.EncodeToString([]byte(s))
 }
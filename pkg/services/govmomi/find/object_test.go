@@ -140,7 +140,8 @@ func setupSimulatorAndSession(model *simulator.Model) (*vcsim.Simulator, *sessio
 		session.NewParams().
 			WithServer(sim.ServerURL().Host).
 			WithUserInfo(sim.Username(), sim.Password()).
-			WithDatacenter(datacenterName),
+			WithDatacenter(datacenterName).
+			WithInsecure(true),
 	)
 	if err != nil {
 		return sim, nil, resourceCount{}, err
@@ -0,0 +1,168 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"slices"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/cluster"
+)
+
+// reconcilePlacementRuleMembership ensures the VM is a member of the DRS VM-VM rule of its
+// KubeadmControlPlane, MachineDeployment or MachinePool in its compute cluster.
+// As DRS rules require at least two VMs, the rule is created with all the VMs of the object
+// in the compute cluster once there are two of them.
+// The rule is read and updated while holding the lock of the compute cluster until the update
+// completes, so VMs which are added concurrently don't overwrite each other.
+func (vms *VMService) reconcilePlacementRuleMembership(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	ruleInfo := virtualMachineCtx.PlacementRuleInfo
+	if ruleInfo == nil {
+		return true, nil
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("drsRule", ruleInfo.Name)
+
+	ccr, err := cluster.FindComputeClusterOfVM(ctx, virtualMachineCtx.Obj)
+	if err != nil {
+		return false, err
+	}
+	if ccr == nil {
+		log.V(4).Info("VM does not run in a compute cluster, skipping DRS rule membership")
+		return true, nil
+	}
+
+	unlock := cluster.LockVMRules(ccr)
+	defer unlock()
+
+	rule, err := cluster.FindVMRule(ctx, ccr, ruleInfo.Name)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to find DRS rule %s", ruleInfo.Name)
+	}
+	if rule != nil && rule.HasVM(virtualMachineCtx.Ref) {
+		return true, nil
+	}
+
+	members, err := vms.findPlacementRuleMembers(ctx, virtualMachineCtx, ccr)
+	if err != nil {
+		return false, err
+	}
+
+	var task *object.Task
+	switch {
+	case rule != nil:
+		for _, member := range rule.ListVMs() {
+			if !slices.Contains(members, member) {
+				members = append(members, member)
+			}
+		}
+		task, err = rule.SetVMs(ctx, members)
+		if err != nil {
+			return false, pkgerrors.Wrapf(err, "failed to add VM %s to DRS rule %s", virtualMachineCtx.VSphereVM.Name, ruleInfo.Name)
+		}
+		log.Info("Adding VM to DRS rule")
+	case len(members) < 2:
+		log.V(4).Info("Waiting for a second VM in the compute cluster to create the DRS rule")
+		return true, nil
+	default:
+		task, err = cluster.CreateVMRule(ctx, ccr, ruleInfo.Name, ruleInfo.Affinity, members)
+		if err != nil {
+			return false, pkgerrors.Wrapf(err, "failed to create DRS rule %s", ruleInfo.Name)
+		}
+		log.Info("Creating DRS rule")
+	}
+
+	if err := task.Wait(ctx); err != nil {
+		return false, pkgerrors.Wrapf(err, "failed to update DRS rule %s", ruleInfo.Name)
+	}
+	return true, nil
+}
+
+// removeFromPlacementRule removes the VM from the DRS VM-VM rule of its KubeadmControlPlane,
+// MachineDeployment or MachinePool. The rule is deleted if less than two VMs remain in it.
+// Like when adding VMs, the rule is updated while holding the lock of the compute cluster.
+func (vms *VMService) removeFromPlacementRule(ctx context.Context, virtualMachineCtx *virtualMachineContext) error {
+	ruleInfo := virtualMachineCtx.PlacementRuleInfo
+	if ruleInfo == nil {
+		return nil
+	}
+	log := ctrl.LoggerFrom(ctx).WithValues("drsRule", ruleInfo.Name)
+
+	ccr, err := cluster.FindComputeClusterOfVM(ctx, virtualMachineCtx.Obj)
+	if err != nil || ccr == nil {
+		return err
+	}
+
+	unlock := cluster.LockVMRules(ccr)
+	defer unlock()
+
+	rule, err := cluster.FindVMRule(ctx, ccr, ruleInfo.Name)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to find DRS rule %s", ruleInfo.Name)
+	}
+	if rule == nil || !rule.HasVM(virtualMachineCtx.Ref) {
+		return nil
+	}
+
+	members := []types.ManagedObjectReference{}
+	for _, member := range rule.ListVMs() {
+		if member != virtualMachineCtx.Ref {
+			members = append(members, member)
+		}
+	}
+
+	var task *object.Task
+	if len(members) < 2 {
+		log.Info("Deleting DRS rule")
+		task, err = rule.Remove(ctx)
+	} else {
+		log.Info("Removing VM from DRS rule")
+		task, err = rule.SetVMs(ctx, members)
+	}
+	if err != nil {
+		return pkgerrors.Wrapf(err, "failed to remove VM %s from DRS rule %s", virtualMachineCtx.VSphereVM.Name, ruleInfo.Name)
+	}
+	return task.Wait(ctx)
+}
+
+// findPlacementRuleMembers returns the VM and the other VMs of its object which run in the
+// same compute cluster.
+func (vms *VMService) findPlacementRuleMembers(ctx context.Context, virtualMachineCtx *virtualMachineContext, ccr *object.ClusterComputeResource) ([]types.ManagedObjectReference, error) {
+	members := []types.ManagedObjectReference{virtualMachineCtx.Ref}
+	for _, biosUUID := range virtualMachineCtx.PlacementRuleInfo.Members {
+		ref, err := virtualMachineCtx.Session.FindByBIOSUUID(ctx, biosUUID)
+		if err != nil {
+			return nil, err
+		}
+		if ref == nil || slices.Contains(members, ref.Reference()) {
+			continue
+		}
+
+		memberCCR, err := cluster.FindComputeClusterOfVM(ctx, object.NewVirtualMachine(virtualMachineCtx.Session.Client.Client, ref.Reference()))
+		if err != nil {
+			return nil, err
+		}
+		if memberCCR != nil && memberCCR.Reference() == ccr.Reference() {
+			members = append(members, ref.Reference())
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	deprecatedv1beta1conditions "sigs.k8s.io/cluster-api/util/conditions/deprecated/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
+)
+
+// reconcileHardwareResources applies changes to numCPUs, numCoresPerSocket, memoryMiB and
+// resources to an existing VM.
+// Changes which can be applied to a running VM are applied right away, all other changes
+// are applied once the VM is powered off. If the resizePolicy is PowerCycle, the VM is powered
+// off to apply them; it is powered on again by reconcilePowerState.
+func (vms *VMService) reconcileHardwareResources(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	var virtualMachine mo.VirtualMachine
+	if err := virtualMachineCtx.Obj.Properties(ctx, virtualMachineCtx.Obj.Reference(), []string{
+		"config.hardware",
+		"config.cpuHotAddEnabled",
+		"config.cpuHotRemoveEnabled",
+		"config.memoryHotAddEnabled",
+		"config.cpuAllocation",
+		"config.memoryAllocation",
+		"runtime.powerState",
+	}, &virtualMachine); err != nil {
+		return false, pkgerrors.Wrapf(err, "error getting hardware information from VM %s", virtualMachineCtx.VSphereVM.Name)
+	}
+
+	configSpec, requiresPowerOff := getResizeConfigSpec(virtualMachineCtx.VSphereVM, virtualMachine)
+
+	if configSpec != nil {
+		log.Info("Reconfiguring CPU and memory of VM",
+			"numCPUs", configSpec.NumCPUs, "numCoresPerSocket", ptr.Deref(configSpec.NumCoresPerSocket, 0), "memoryMiB", configSpec.MemoryMB)
+		task, err := virtualMachineCtx.Obj.Reconfigure(ctx, *configSpec)
+		if err != nil {
+			deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMResizedV1Beta1Condition, infrav1.ResizeFailedV1Beta1Reason, clusterv1.ConditionSeverityWarning, "%v", err)
+			conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+				Type:    infrav1.VSphereVMVirtualMachineResizedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereVMVirtualMachineResizeFailedReason,
+				Message: err.Error(),
+			})
+			return false, pkgerrors.Wrapf(err, "error triggering reconfigure op for VM %s", virtualMachineCtx)
+		}
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMResizedV1Beta1Condition, infrav1.ResizingV1Beta1Reason, clusterv1.ConditionSeverityInfo, "")
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:   infrav1.VSphereVMVirtualMachineResizedCondition,
+			Status: metav1.ConditionFalse,
+			Reason: infrav1.VSphereVMVirtualMachineResizingReason,
+		})
+		virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+		return false, nil
+	}
+
+	if requiresPowerOff {
+		if virtualMachineCtx.VSphereVM.Spec.ResizePolicy != infrav1.VirtualMachineResizePolicyPowerCycle {
+			log.Info("Changes to CPU and memory of VM require a power cycle which is not allowed by the resizePolicy")
+			deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMResizedV1Beta1Condition, infrav1.PowerCycleRequiredV1Beta1Reason, clusterv1.ConditionSeverityWarning,
+				"Changes to CPU and memory require the VM to be powered off")
+			conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+				Type:    infrav1.VSphereVMVirtualMachineResizedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereVMVirtualMachineResizePowerCycleRequiredReason,
+				Message: "Changes to CPU and memory require the VM to be powered off",
+			})
+			return true, nil
+		}
+
+		log.Info("Powering off VM to apply changes to CPU and memory")
+		task, err := virtualMachineCtx.Obj.PowerOff(ctx)
+		if err != nil {
+			return false, pkgerrors.Wrapf(err, "failed to trigger power off op for vm %s", virtualMachineCtx)
+		}
+		deprecatedv1beta1conditions.MarkFalse(virtualMachineCtx.VSphereVM, infrav1.VMResizedV1Beta1Condition, infrav1.ResizingV1Beta1Reason, clusterv1.ConditionSeverityInfo, "Powering off VM")
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:    infrav1.VSphereVMVirtualMachineResizedCondition,
+			Status:  metav1.ConditionFalse,
+			Reason:  infrav1.VSphereVMVirtualMachineResizingReason,
+			Message: "Powering off VM",
+		})
+		virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+		return false, nil
+	}
+
+	// Only report the condition once a change has been applied to the VM.
+	if conditions.Has(virtualMachineCtx.VSphereVM, infrav1.VSphereVMVirtualMachineResizedCondition) {
+		deprecatedv1beta1conditions.MarkTrue(virtualMachineCtx.VSphereVM, infrav1.VMResizedV1Beta1Condition)
+		conditions.Set(virtualMachineCtx.VSphereVM, metav1.Condition{
+			Type:   infrav1.VSphereVMVirtualMachineResizedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: infrav1.VSphereVMVirtualMachineResizedReason,
+		})
+	}
+	return true, nil
+}
+
+// getResizeConfigSpec returns a config spec with the changes to CPU, memory and resource allocation
+// which can be applied to the VM in its current power state, or nil if there are none.
+// It also returns whether there are changes which can only be applied once the VM is powered off.
+func getResizeConfigSpec(vsphereVM *infrav1.VSphereVM, vm mo.VirtualMachine) (*types.VirtualMachineConfigSpec, bool) {
+	if vm.Config == nil {
+		return nil, false
+	}
+
+	poweredOn := vm.Runtime.PowerState == types.VirtualMachinePowerStatePoweredOn
+	hardware := vm.Config.Hardware
+	spec := vsphereVM.Spec
+
+	configSpec := types.VirtualMachineConfigSpec{}
+	changed, requiresPowerOff := false, false
+
+	if spec.NumCPUs != 0 && spec.NumCPUs != hardware.NumCPU {
+		hotPluggable := (spec.NumCPUs > hardware.NumCPU && ptr.Deref(vm.Config.CpuHotAddEnabled, false)) ||
+			(spec.NumCPUs < hardware.NumCPU && ptr.Deref(vm.Config.CpuHotRemoveEnabled, false))
+		if !poweredOn || hotPluggable {
+			configSpec.NumCPUs = spec.NumCPUs
+			changed = true
+		} else {
+			requiresPowerOff = true
+		}
+	}
+
+	// A numCoresPerSocket of 0 lets vSphere assign the value at power on, so it
+	// can't be compared to the current value.
+	if spec.NumCoresPerSocket != nil && *spec.NumCoresPerSocket != 0 && *spec.NumCoresPerSocket != ptr.Deref(hardware.NumCoresPerSocket, 0) {
+		if !poweredOn {
+			configSpec.NumCoresPerSocket = spec.NumCoresPerSocket
+			changed = true
+		} else {
+			requiresPowerOff = true
+		}
+	}
+
+	if spec.MemoryMiB != 0 && spec.MemoryMiB != int64(hardware.MemoryMB) {
+		hotPluggable := spec.MemoryMiB > int64(hardware.MemoryMB) && ptr.Deref(vm.Config.MemoryHotAddEnabled, false)
+		if !poweredOn || hotPluggable {
+			configSpec.MemoryMB = spec.MemoryMiB
+			changed = true
+		} else {
+			requiresPowerOff = true
+		}
+	}
+
+	// Reservations, limits and shares can always be changed on a running VM.
+	if cpuAllocation := vcenter.CPUAllocation(spec.Resources); !resourceAllocationMatches(cpuAllocation, vm.Config.CpuAllocation) {
+		configSpec.CpuAllocation = cpuAllocation
+		changed = true
+	}
+	if memoryAllocation := vcenter.MemoryAllocation(spec.Resources); !resourceAllocationMatches(memoryAllocation, vm.Config.MemoryAllocation) {
+		configSpec.MemoryAllocation = memoryAllocation
+		changed = true
+	}
+
+	if !changed {
+		return nil, requiresPowerOff
+	}
+	return &configSpec, requiresPowerOff
+}
+
+// resourceAllocationMatches returns true if all the values set in desired match
+// the current allocation of the VM.
+func resourceAllocationMatches(desired, current *types.ResourceAllocationInfo) bool {
+	if desired == nil {
+		return true
+	}
+	if current == nil {
+		return false
+	}
+	if desired.Reservation != nil && ptr.Deref(current.Reservation, 0) != *desired.Reservation {
+		return false
+	}
+	if desired.Limit != nil && ptr.Deref(current.Limit, -1) != *desired.Limit {
+		return false
+	}
+	if desired.Shares != nil && (current.Shares == nil ||
+		current.Shares.Level != desired.Shares.Level ||
+		current.Shares.Shares != desired.Shares.Shares) {
+		return false
+	}
+	return true
+}
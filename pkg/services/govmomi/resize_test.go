@@ -0,0 +1,267 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func Test_reconcileHardwareResources(t *testing.T) {
+	var vmCtx *virtualMachineContext
+	var g *WithT
+	var vms *VMService
+
+	before := func() {
+		vmCtx = emptyVirtualMachineContext()
+		vmCtx.Client = fake.NewClientBuilder().Build()
+
+		vms = &VMService{}
+	}
+
+	newVSphereVM := func(cloneSpec infrav1.VirtualMachineCloneSpec) *infrav1.VSphereVM {
+		return &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vsphereVM1",
+				Namespace: "my-namespace",
+			},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: cloneSpec,
+			},
+		}
+	}
+
+	waitForTask := func(ctx context.Context, c *vim25.Client) {
+		g.Expect(vmCtx.VSphereVM.Status.TaskRef).ToNot(BeEmpty())
+		task := object.NewTask(c, types.ManagedObjectReference{Type: "Task", Value: vmCtx.VSphereVM.Status.TaskRef})
+		g.Expect(task.Wait(ctx)).To(Succeed())
+		vmCtx.VSphereVM.Status.TaskRef = ""
+	}
+
+	getVM := func(ctx context.Context, vm *object.VirtualMachine) mo.VirtualMachine {
+		var o mo.VirtualMachine
+		g.Expect(vm.Properties(ctx, vm.Reference(), []string{"config.hardware", "config.cpuAllocation", "runtime.powerState"}, &o)).To(Succeed())
+		return o
+	}
+
+	t.Run("does nothing when CPU and memory are not set", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{})
+
+			ok, err := vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			g.Expect(conditions.Has(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineResizedCondition)).To(BeFalse())
+			return nil
+		})
+	})
+
+	t.Run("reconfigures a powered off VM", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := getPoweredoffVM(ctx, c)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{
+				NumCPUs:           4,
+				NumCoresPerSocket: ptr.To[int32](2),
+				MemoryMiB:         1024,
+				Resources: infrav1.VirtualMachineResources{
+					Shares: infrav1.VirtualMachineResourceShares{CPU: 2000},
+				},
+			})
+
+			ok, err := vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			g.Expect(conditions.GetReason(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineResizedCondition)).To(Equal(infrav1.VSphereVMVirtualMachineResizingReason))
+			waitForTask(ctx, c)
+
+			o := getVM(ctx, vm)
+			g.Expect(o.Config.Hardware.NumCPU).To(Equal(int32(4)))
+			g.Expect(o.Config.Hardware.NumCoresPerSocket).To(Equal(ptr.To[int32](2)))
+			g.Expect(o.Config.Hardware.MemoryMB).To(Equal(int32(1024)))
+			g.Expect(o.Config.CpuAllocation.Shares.Shares).To(Equal(int32(2000)))
+
+			ok, err = vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(conditions.IsTrue(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineResizedCondition)).To(BeTrue())
+			return nil
+		})
+	})
+
+	t.Run("does not power off a running VM without hot add when resizePolicy is HotAdd", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{
+				NumCPUs:      4,
+				ResizePolicy: infrav1.VirtualMachineResizePolicyHotAdd,
+			})
+
+			ok, err := vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			g.Expect(conditions.GetReason(vmCtx.VSphereVM, infrav1.VSphereVMVirtualMachineResizedCondition)).To(Equal(infrav1.VSphereVMVirtualMachineResizePowerCycleRequiredReason))
+
+			o := getVM(ctx, vm)
+			g.Expect(o.Runtime.PowerState).To(Equal(types.VirtualMachinePowerStatePoweredOn))
+			g.Expect(o.Config.Hardware.NumCPU).ToNot(Equal(int32(4)))
+			return nil
+		})
+	})
+
+	t.Run("powers off a running VM without hot add when resizePolicy is PowerCycle", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{
+				NumCPUs:      4,
+				ResizePolicy: infrav1.VirtualMachineResizePolicyPowerCycle,
+			})
+
+			ok, err := vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, c)
+			g.Expect(getVM(ctx, vm).Runtime.PowerState).To(Equal(types.VirtualMachinePowerStatePoweredOff))
+
+			ok, err = vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, c)
+			g.Expect(getVM(ctx, vm).Config.Hardware.NumCPU).To(Equal(int32(4)))
+			return nil
+		})
+	})
+
+	t.Run("hot adds CPU and memory to a running VM", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := getPoweredoffVM(ctx, c)
+			g.Expect(err).ToNot(HaveOccurred())
+			task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+				CpuHotAddEnabled:    ptr.To(true),
+				MemoryHotAddEnabled: ptr.To(true),
+			})
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(task.Wait(ctx)).To(Succeed())
+			task, err = vm.PowerOn(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(task.Wait(ctx)).To(Succeed())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = newVSphereVM(infrav1.VirtualMachineCloneSpec{
+				NumCPUs:   4,
+				MemoryMiB: 4096,
+			})
+
+			ok, err := vms.reconcileHardwareResources(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			waitForTask(ctx, c)
+
+			o := getVM(ctx, vm)
+			g.Expect(o.Runtime.PowerState).To(Equal(types.VirtualMachinePowerStatePoweredOn))
+			g.Expect(o.Config.Hardware.NumCPU).To(Equal(int32(4)))
+			g.Expect(o.Config.Hardware.MemoryMB).To(Equal(int32(4096)))
+			return nil
+		})
+	})
+}
+
+func Test_resourceAllocationMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired *types.ResourceAllocationInfo
+		current *types.ResourceAllocationInfo
+		want    bool
+	}{
+		{
+			name: "nothing desired",
+			want: true,
+		},
+		{
+			name:    "no current allocation",
+			desired: &types.ResourceAllocationInfo{Reservation: ptr.To[int64](100)},
+			want:    false,
+		},
+		{
+			name:    "reservation matches and limit is not desired",
+			desired: &types.ResourceAllocationInfo{Reservation: ptr.To[int64](100)},
+			current: &types.ResourceAllocationInfo{Reservation: ptr.To[int64](100), Limit: ptr.To[int64](-1)},
+			want:    true,
+		},
+		{
+			name:    "limit differs",
+			desired: &types.ResourceAllocationInfo{Limit: ptr.To[int64](200)},
+			current: &types.ResourceAllocationInfo{Limit: ptr.To[int64](-1)},
+			want:    false,
+		},
+		{
+			name:    "shares level differs",
+			desired: &types.ResourceAllocationInfo{Shares: &types.SharesInfo{Shares: 2000, Level: types.SharesLevelCustom}},
+			current: &types.ResourceAllocationInfo{Shares: &types.SharesInfo{Shares: 2000, Level: types.SharesLevelNormal}},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(resourceAllocationMatches(tt.desired, tt.current)).To(Equal(tt.want))
+		})
+	}
+}
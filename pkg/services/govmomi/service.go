@@ -20,7 +20,9 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"time"
 
 	pkgerrors "github.com/pkg/errors"
@@ -158,10 +160,30 @@ func (vms *VMService) ReconcileVM(ctx context.Context, vmCtx *capvcontext.VMCont
 
 	vms.reconcileUUID(ctx, virtualMachineCtx)
 
+	if ok, err := vms.reconcileContentLibraryDeployment(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
 	if ok, err := vms.reconcileHardwareVersion(ctx, virtualMachineCtx); err != nil || !ok {
 		return vm, err
 	}
 
+	if ok, err := vms.reconcileHardwareResources(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
+	if ok, err := vms.reconcileDisks(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
+	if ok, err := vms.reconcileCustomVMXKeys(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
+	if ok, err := vms.reconcileDrift(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
 	if err := vms.reconcilePCIDevices(ctx, virtualMachineCtx); err != nil {
 		return vm, err
 	}
@@ -178,6 +200,10 @@ func (vms *VMService) ReconcileVM(ctx context.Context, vmCtx *capvcontext.VMCont
 		return vm, err
 	}
 
+	if ok, err := vms.reconcileGuestCustomization(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
 	if err := vms.reconcileStoragePolicy(ctx, virtualMachineCtx); err != nil {
 		return vm, err
 	}
@@ -190,10 +216,22 @@ func (vms *VMService) ReconcileVM(ctx context.Context, vmCtx *capvcontext.VMCont
 		return vm, err
 	}
 
+	if ok, err := vms.reconcilePlacementRuleMembership(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
 	if ok, err := vms.reconcilePowerState(ctx, virtualMachineCtx); err != nil || !ok {
 		return vm, err
 	}
 
+	if ok, err := vms.reconcileBootstrapISO(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
+	if ok, err := vms.reconcileBootstrapDataScrub(ctx, virtualMachineCtx); err != nil || !ok {
+		return vm, err
+	}
+
 	if err := vms.reconcileHostInfo(ctx, virtualMachineCtx); err != nil {
 		return vm, err
 	}
@@ -240,6 +278,10 @@ func (vms *VMService) DestroyVM(ctx context.Context, vmCtx *capvcontext.VMContex
 		// If the VM's MoRef could not be found then the VM no longer exists. This
 		// is the desired state.
 		if isNotFound(err) || isFolderNotFound(err) {
+			// The seed ISO is not deleted together with the VM.
+			if err := deleteBootstrapISO(ctx, vmCtx); err != nil {
+				return reconcile.Result{}, vm, err
+			}
 			vm.State = services.VirtualMachineStateNotFound
 			return reconcile.Result{}, vm, nil
 		}
@@ -314,6 +356,10 @@ func (vms *VMService) DestroyVM(ctx context.Context, vmCtx *capvcontext.VMContex
 		vmCtx.VSphereVM.Status.ModuleUUID = nil
 	}
 
+	if err := vms.removeFromPlacementRule(ctx, virtualMachineCtx); err != nil {
+		return reconcile.Result{}, vm, err
+	}
+
 	// At this point the VM is not powered on and can be destroyed. Store the
 	// destroy task's reference and return a requeue error.
 	log.Info("Destroying vm")
@@ -361,12 +407,17 @@ func (vms *VMService) reconcileIPAddresses(ctx context.Context, virtualMachineCt
 func (vms *VMService) reconcileMetadata(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	existingMetadata, err := vms.getMetadata(ctx, virtualMachineCtx)
+	newMetadata, err := util.GetMachineMetadata(virtualMachineCtx.VSphereVM.Name, *virtualMachineCtx.VSphereVM, virtualMachineCtx.IPAMState, virtualMachineCtx.State.Network...)
 	if err != nil {
 		return false, err
 	}
 
-	newMetadata, err := util.GetMachineMetadata(virtualMachineCtx.VSphereVM.Name, *virtualMachineCtx.VSphereVM, virtualMachineCtx.IPAMState, virtualMachineCtx.State.Network...)
+	// The metadata is delivered with the bootstrap data in the seed ISO.
+	if virtualMachineCtx.VSphereVM.Spec.BootstrapDataDelivery == infrav1.BootstrapDataDeliveryNoCloudISO {
+		return vms.reconcileBootstrapISOMetadata(ctx, virtualMachineCtx, newMetadata)
+	}
+
+	existingMetadata, err := vms.getMetadata(ctx, virtualMachineCtx, guestInfoKeyMetadata)
 	if err != nil {
 		return false, err
 	}
@@ -387,6 +438,57 @@ func (vms *VMService) reconcileMetadata(ctx context.Context, virtualMachineCtx *
 	return false, nil
 }
 
+// reconcileCustomVMXKeys sets the custom VMX keys of an existing VM if they have been
+// added or changed. Keys which have been removed from the spec are not removed from the VM.
+func (vms *VMService) reconcileCustomVMXKeys(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if len(virtualMachineCtx.VSphereVM.Spec.CustomVMXKeys) == 0 {
+		return true, nil
+	}
+
+	var obj mo.VirtualMachine
+	if err := virtualMachineCtx.Obj.Properties(ctx, virtualMachineCtx.Obj.Reference(), []string{"config.extraConfig"}, &obj); err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to fetch extraConfig for vm %s", virtualMachineCtx)
+	}
+
+	current := map[string]string{}
+	if obj.Config != nil {
+		for _, ec := range obj.Config.ExtraConfig {
+			if optVal := ec.GetOptionValue(); optVal != nil {
+				if v, ok := optVal.Value.(string); ok {
+					current[optVal.Key] = v
+				}
+			}
+		}
+	}
+
+	changedKeys := map[string]string{}
+	for k, v := range virtualMachineCtx.VSphereVM.Spec.CustomVMXKeys {
+		if value, ok := current[k]; !ok || value != v {
+			changedKeys[k] = v
+		}
+	}
+	if len(changedKeys) == 0 {
+		return true, nil
+	}
+
+	log.Info("Updating custom VMX keys of VM", "keys", slices.Sorted(maps.Keys(changedKeys)))
+	var extraConfig extra.Config
+	if err := extraConfig.SetCustomVMXKeys(changedKeys); err != nil {
+		return false, err
+	}
+	task, err := virtualMachineCtx.Obj.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+		ExtraConfig: extraConfig,
+	})
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to set custom VMX keys on vm %s", virtualMachineCtx)
+	}
+
+	virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+	return false, nil
+}
+
 func (vms *VMService) reconcilePowerState(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -605,7 +707,28 @@ func (vms *VMService) reconcilePCIDevices(ctx context.Context, virtualMachineCtx
 	return nil
 }
 
-func (vms *VMService) getMetadata(ctx context.Context, virtualMachineCtx *virtualMachineContext) (string, error) {
+// getMetadata returns the decoded metadata at the extra config key of the VM.
+func (vms *VMService) getMetadata(ctx context.Context, virtualMachineCtx *virtualMachineContext, key string) (string, error) {
+	metadataBase64, err := vms.getExtraConfigValue(ctx, virtualMachineCtx, key)
+	if err != nil {
+		return "", err
+	}
+
+	if metadataBase64 == "" {
+		return "", nil
+	}
+
+	metadataBuf, err := base64.StdEncoding.DecodeString(metadataBase64)
+	if err != nil {
+		return "", pkgerrors.Wrapf(err, "unable to decode metadata for %s", virtualMachineCtx)
+	}
+
+	return string(metadataBuf), nil
+}
+
+// getExtraConfigValue returns the value at the extra config key of the VM, or an empty string
+// if the key is not set.
+func (vms *VMService) getExtraConfigValue(ctx context.Context, virtualMachineCtx *virtualMachineContext, key string) (string, error) {
 	var (
 		obj mo.VirtualMachine
 
@@ -620,25 +743,15 @@ func (vms *VMService) getMetadata(ctx context.Context, virtualMachineCtx *virtua
 		return "", nil
 	}
 
-	var metadataBase64 string
+	var value string
 	for _, ec := range obj.Config.ExtraConfig {
-		if optVal := ec.GetOptionValue(); optVal != nil && optVal.Key == guestInfoKeyMetadata {
+		if optVal := ec.GetOptionValue(); optVal != nil && optVal.Key == key {
 			if v, ok := optVal.Value.(string); ok {
-				metadataBase64 = v
+				value = v
 			}
 		}
 	}
-
-	if metadataBase64 == "" {
-		return "", nil
-	}
-
-	metadataBuf, err := base64.StdEncoding.DecodeString(metadataBase64)
-	if err != nil {
-		return "", pkgerrors.Wrapf(err, "unable to decode metadata for %s", virtualMachineCtx)
-	}
-
-	return string(metadataBuf), nil
+	return value, nil
 }
 
 func (vms *VMService) reconcileHostInfo(ctx context.Context, virtualMachineCtx *virtualMachineContext) error {
@@ -27,8 +27,10 @@ import (
 	pbmsimulator "github.com/vmware/govmomi/pbm/simulator"
 	"github.com/vmware/govmomi/simulator"
 	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
 	"github.com/vmware/govmomi/vim25/types"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -105,6 +107,60 @@ func Test_reconcilePCIDevices(t *testing.T) {
 	})
 }
 
+func Test_reconcileCustomVMXKeys(t *testing.T) {
+	var vmCtx *virtualMachineContext
+	var g *WithT
+	var vms *VMService
+
+	before := func() {
+		vmCtx = emptyVirtualMachineContext()
+		vmCtx.Client = fake.NewClientBuilder().Build()
+
+		vms = &VMService{}
+	}
+
+	t.Run("sets added and changed custom VMX keys on an existing VM", func(t *testing.T) {
+		g = NewWithT(t)
+		before()
+
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			vmCtx.Obj = vm
+			vmCtx.VSphereVM = &infrav1.VSphereVM{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vsphereVM1",
+					Namespace: "my-namespace",
+				},
+				Spec: infrav1.VSphereVMSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						CustomVMXKeys: map[string]string{"foo": "bar"},
+					},
+				},
+			}
+
+			ok, err := vms.reconcileCustomVMXKeys(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeFalse())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).ToNot(BeEmpty())
+			task := object.NewTask(c, types.ManagedObjectReference{Type: "Task", Value: vmCtx.VSphereVM.Status.TaskRef})
+			g.Expect(task.Wait(ctx)).To(Succeed())
+			vmCtx.VSphereVM.Status.TaskRef = ""
+
+			var o mo.VirtualMachine
+			g.Expect(vm.Properties(ctx, vm.Reference(), []string{"config.extraConfig"}, &o)).To(Succeed())
+			g.Expect(o.Config.ExtraConfig).To(ContainElement(&types.OptionValue{Key: "foo", Value: "bar"}))
+
+			ok, err = vms.reconcileCustomVMXKeys(ctx, vmCtx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(ok).To(BeTrue())
+			g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+			return nil
+		})
+	})
+}
+
 func Test_ReconcileStoragePolicy(t *testing.T) {
 	var vmCtx *virtualMachineContext
 	var g *WithT
@@ -230,3 +286,40 @@ func storagePolicyModel() (*simulator.Model, error) {
 	model.Host = 1
 	return model, nil
 }
+
+func Test_reconcileContentLibraryDeployment(t *testing.T) {
+	g := NewWithT(t)
+	vms := &VMService{}
+
+	simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+		vm, err := find.NewFinder(c).VirtualMachine(ctx, "DC0_H0_VM0")
+		g.Expect(err).ToNot(HaveOccurred())
+		var o mo.VirtualMachine
+		g.Expect(vm.Properties(ctx, vm.Reference(), []string{"config.instanceUuid"}, &o)).To(Succeed())
+
+		vmCtx := emptyVirtualMachineContext()
+		vmCtx.Obj = vm
+		vmCtx.Ref = vm.Reference()
+		vmCtx.VSphereVM = &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vsphereVM1",
+				Namespace: "my-namespace",
+				UID:       apitypes.UID(o.Config.InstanceUuid),
+			},
+		}
+
+		// The VM was not deployed from a content library.
+		ok, err := vms.reconcileContentLibraryDeployment(ctx, vmCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+
+		// The deployed VM got the instance UUID of the VSphereVM, so it is configured.
+		vmCtx.VSphereVM.Status.ContentLibraryItemID = "item-id"
+		ok, err = vms.reconcileContentLibraryDeployment(ctx, vmCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+		g.Expect(vmCtx.VSphereVM.Status.ContentLibraryItemID).To(BeEmpty())
+		g.Expect(vmCtx.VSphereVM.Status.TaskRef).To(BeEmpty())
+		return nil
+	})
+}
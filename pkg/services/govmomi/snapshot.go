@@ -0,0 +1,176 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"time"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+)
+
+// ErrPCIPassthroughDevices is returned when a snapshot of a VM with PCI passthrough devices is
+// requested, as vSphere does not support snapshots of such VMs.
+var ErrPCIPassthroughDevices = pkgerrors.New("snapshots of VMs with PCI passthrough devices are not supported")
+
+// VMSnapshot is a snapshot of a VM.
+type VMSnapshot struct {
+	// Ref is the value of the managed object reference of the snapshot.
+	Ref string
+	// CreateTime is the time at which the snapshot was created.
+	CreateTime time.Time
+}
+
+// CreateVMSnapshot creates a snapshot with the given name of the VM of the VSphereVM. If the VM
+// already has a snapshot with the name, it is returned instead, so that a snapshot whose creation
+// was not recorded is not created twice.
+func CreateVMSnapshot(ctx context.Context, vmCtx *capvcontext.VMContext, name string, spec infrav1.VSphereVMSnapshotSpec) (VMSnapshot, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vm, vmMo, err := getVMForSnapshot(ctx, vmCtx)
+	if err != nil {
+		return VMSnapshot{}, err
+	}
+	if snapshot := findSnapshotTree(vmMo.Snapshot, func(tree types.VirtualMachineSnapshotTree) bool { return tree.Name == name }); snapshot != nil {
+		log.Info("Found existing snapshot of VM", "snapshotName", name, "snapshotRef", snapshot.Snapshot.Value)
+		return VMSnapshot{Ref: snapshot.Snapshot.Value, CreateTime: snapshot.CreateTime}, nil
+	}
+
+	if vmMo.Config != nil && len(object.VirtualDeviceList(vmMo.Config.Hardware.Device).SelectByType((*types.VirtualPCIPassthrough)(nil))) > 0 {
+		return VMSnapshot{}, ErrPCIPassthroughDevices
+	}
+
+	memory := ptr.Deref(spec.Memory, false)
+	task, err := vm.CreateSnapshot(ctx, name, spec.Description, memory, !memory && ptr.Deref(spec.Quiesce, false))
+	if err != nil {
+		return VMSnapshot{}, pkgerrors.Wrapf(err, "failed to trigger creation of snapshot %s for %s", name, vmCtx)
+	}
+	taskInfo, err := task.WaitForResult(ctx)
+	if err != nil {
+		return VMSnapshot{}, pkgerrors.Wrapf(err, "failed to create snapshot %s for %s", name, vmCtx)
+	}
+	ref, ok := taskInfo.Result.(types.ManagedObjectReference)
+	if !ok {
+		return VMSnapshot{}, pkgerrors.Errorf("unexpected result of the creation of snapshot %s for %s: %T", name, vmCtx, taskInfo.Result)
+	}
+	log.Info("Created snapshot of VM", "snapshotName", name, "snapshotRef", ref.Value)
+
+	createTime := time.Now()
+	if taskInfo.CompleteTime != nil {
+		createTime = *taskInfo.CompleteTime
+	}
+	return VMSnapshot{Ref: ref.Value, CreateTime: createTime}, nil
+}
+
+// GetVMSnapshot returns the snapshot of the VM of the VSphereVM with the given reference, or nil
+// if the VM or the snapshot does not exist.
+func GetVMSnapshot(ctx context.Context, vmCtx *capvcontext.VMContext, snapshotRef string) (*VMSnapshot, error) {
+	_, vmMo, err := getVMForSnapshot(ctx, vmCtx)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	snapshot := findSnapshotTree(vmMo.Snapshot, func(tree types.VirtualMachineSnapshotTree) bool { return tree.Snapshot.Value == snapshotRef })
+	if snapshot == nil {
+		return nil, nil
+	}
+	return &VMSnapshot{Ref: snapshot.Snapshot.Value, CreateTime: snapshot.CreateTime}, nil
+}
+
+// RemoveVMSnapshot removes the snapshot with the given reference from the VM of the VSphereVM.
+// The children of the snapshot are kept. Snapshots and VMs which don't exist are ignored.
+func RemoveVMSnapshot(ctx context.Context, vmCtx *capvcontext.VMContext, snapshotRef string) error {
+	snapshot, err := GetVMSnapshot(ctx, vmCtx, snapshotRef)
+	if err != nil || snapshot == nil {
+		return err
+	}
+
+	res, err := methods.RemoveSnapshot_Task(ctx, vmCtx.Session.Client.Client, &types.RemoveSnapshot_Task{
+		This:           snapshotMoRef(snapshotRef),
+		RemoveChildren: false,
+		Consolidate:    ptr.To(true),
+	})
+	if err != nil {
+		return pkgerrors.Wrapf(err, "failed to trigger removal of snapshot %s of %s", snapshotRef, vmCtx)
+	}
+	if err := object.NewTask(vmCtx.Session.Client.Client, res.Returnval).Wait(ctx); err != nil {
+		return pkgerrors.Wrapf(err, "failed to remove snapshot %s of %s", snapshotRef, vmCtx)
+	}
+	ctrl.LoggerFrom(ctx).Info("Removed snapshot of VM", "snapshotRef", snapshotRef)
+	return nil
+}
+
+// RevertVMSnapshot reverts the VM of the VSphereVM to the snapshot with the given reference.
+func RevertVMSnapshot(ctx context.Context, vmCtx *capvcontext.VMContext, snapshotRef string) error {
+	res, err := methods.RevertToSnapshot_Task(ctx, vmCtx.Session.Client.Client, &types.RevertToSnapshot_Task{
+		This: snapshotMoRef(snapshotRef),
+	})
+	if err != nil {
+		return pkgerrors.Wrapf(err, "failed to trigger revert of %s to snapshot %s", vmCtx, snapshotRef)
+	}
+	if err := object.NewTask(vmCtx.Session.Client.Client, res.Returnval).Wait(ctx); err != nil {
+		return pkgerrors.Wrapf(err, "failed to revert %s to snapshot %s", vmCtx, snapshotRef)
+	}
+	ctrl.LoggerFrom(ctx).Info("Reverted VM to snapshot", "snapshotRef", snapshotRef)
+	return nil
+}
+
+func getVMForSnapshot(ctx context.Context, vmCtx *capvcontext.VMContext) (*object.VirtualMachine, mo.VirtualMachine, error) {
+	vmRef, err := findVM(ctx, vmCtx)
+	if err != nil {
+		return nil, mo.VirtualMachine{}, err
+	}
+
+	vm := object.NewVirtualMachine(vmCtx.Session.Client.Client, vmRef)
+	var vmMo mo.VirtualMachine
+	if err := vm.Properties(ctx, vmRef, []string{"snapshot", "config.hardware.device"}, &vmMo); err != nil {
+		return nil, mo.VirtualMachine{}, pkgerrors.Wrapf(err, "failed to get snapshots of %s", vmCtx)
+	}
+	return vm, vmMo, nil
+}
+
+// findSnapshotTree returns the first snapshot of the snapshot tree which matches, or nil if none does.
+func findSnapshotTree(info *types.VirtualMachineSnapshotInfo, match func(types.VirtualMachineSnapshotTree) bool) *types.VirtualMachineSnapshotTree {
+	if info == nil {
+		return nil
+	}
+	trees := info.RootSnapshotList
+	for len(trees) > 0 {
+		tree := trees[0]
+		trees = trees[1:]
+		if match(tree) {
+			return &tree
+		}
+		trees = append(trees, tree.ChildSnapshotList...)
+	}
+	return nil
+}
+
+func snapshotMoRef(snapshotRef string) types.ManagedObjectReference {
+	return types.ManagedObjectReference{Type: "VirtualMachineSnapshot", Value: snapshotRef}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+func Test_VMSnapshot(t *testing.T) {
+	// setup returns a context for the DC0_H0_VM0 VM.
+	setup := func(ctx context.Context, g *WithT, c *vim25.Client) (*capvcontext.VMContext, *object.VirtualMachine) {
+		finder := find.NewFinder(c)
+		vm, err := finder.VirtualMachine(ctx, "DC0_H0_VM0")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		vmCtx := &capvcontext.VMContext{
+			ControllerManagerContext: &capvcontext.ControllerManagerContext{},
+			Session:                  &session.Session{Client: &govmomi.Client{Client: c}, Finder: finder},
+			VSphereVM: &infrav1.VSphereVM{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vsphereVM1",
+					Namespace: "my-namespace",
+				},
+				Spec: infrav1.VSphereVMSpec{BiosUUID: vm.UUID(ctx)},
+			},
+		}
+		return vmCtx, vm
+	}
+
+	t.Run("create, revert and remove a snapshot", func(t *testing.T) {
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			g := NewWithT(t)
+			vmCtx, vm := setup(ctx, g, c)
+
+			spec := infrav1.VSphereVMSnapshotSpec{VMName: "vsphereVM1", Description: "before upgrade", Quiesce: ptr.To(true)}
+			snapshot, err := CreateVMSnapshot(ctx, vmCtx, "snapshot-1", spec)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(snapshot.Ref).ToNot(BeEmpty())
+			g.Expect(snapshot.CreateTime.IsZero()).To(BeFalse())
+
+			// The snapshot is not created twice.
+			again, err := CreateVMSnapshot(ctx, vmCtx, "snapshot-1", spec)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(again.Ref).To(Equal(snapshot.Ref))
+
+			found, err := GetVMSnapshot(ctx, vmCtx, snapshot.Ref)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(found).ToNot(BeNil())
+
+			g.Expect(RevertVMSnapshot(ctx, vmCtx, snapshot.Ref)).To(Succeed())
+
+			g.Expect(RemoveVMSnapshot(ctx, vmCtx, snapshot.Ref)).To(Succeed())
+			var vmMo mo.VirtualMachine
+			g.Expect(vm.Properties(ctx, vm.Reference(), []string{"snapshot"}, &vmMo)).To(Succeed())
+			g.Expect(vmMo.Snapshot).To(BeNil())
+
+			// Snapshots which don't exist are ignored.
+			g.Expect(RemoveVMSnapshot(ctx, vmCtx, snapshot.Ref)).To(Succeed())
+			found, err = GetVMSnapshot(ctx, vmCtx, snapshot.Ref)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(found).To(BeNil())
+			return nil
+		})
+	})
+
+	t.Run("VM with PCI passthrough devices", func(t *testing.T) {
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			g := NewWithT(t)
+			vmCtx, vm := setup(ctx, g, c)
+
+			task, err := vm.PowerOff(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(task.Wait(ctx)).To(Succeed())
+			g.Expect(vm.AddDevice(ctx, &types.VirtualPCIPassthrough{
+				VirtualDevice: types.VirtualDevice{
+					Backing: &types.VirtualPCIPassthroughDynamicBackingInfo{
+						AllowedDevice: []types.VirtualPCIPassthroughAllowedDevice{{VendorId: 5678, DeviceId: 1234}},
+					},
+				},
+			})).To(Succeed())
+
+			_, err = CreateVMSnapshot(ctx, vmCtx, "snapshot-1", infrav1.VSphereVMSnapshotSpec{VMName: "vsphereVM1"})
+			g.Expect(err).To(MatchError(ErrPCIPassthroughDevices))
+			return nil
+		})
+	})
+
+	t.Run("VM which does not exist", func(t *testing.T) {
+		simulator.Run(func(ctx context.Context, c *vim25.Client) error {
+			g := NewWithT(t)
+			vmCtx, _ := setup(ctx, g, c)
+			vmCtx.VSphereVM.Spec.BiosUUID = "265104de-1472-547c-b873-6dc7883fb6cb"
+
+			found, err := GetVMSnapshot(ctx, vmCtx, "snapshot-1")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(found).To(BeNil())
+			g.Expect(RemoveVMSnapshot(ctx, vmCtx, "snapshot-1")).To(Succeed())
+			return nil
+		})
+	})
+}
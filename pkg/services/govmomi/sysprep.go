@@ -0,0 +1,252 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"net"
+	"slices"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	corev1 "k8s.io/api/core/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+const (
+	// defaultSysprepFullName, defaultSysprepOrganizationName, defaultSysprepTimeZone and
+	// defaultSysprepWorkgroup are the values used when the corresponding fields are not set
+	// in the sysprep spec.
+	defaultSysprepFullName         = "Administrator"
+	defaultSysprepOrganizationName = "Kubernetes"
+	defaultSysprepTimeZone         = int32(85)
+	defaultSysprepWorkgroup        = "WORKGROUP"
+
+	// sysprepAdminPasswordKey is the key of the password in the admin password Secret.
+	sysprepAdminPasswordKey = "password"
+)
+
+// reconcileGuestCustomization customizes the guest of a Windows VM with sysprep once the
+// addresses of its network devices are known, before the VM is powered on for the first time.
+// The guest is customized only once, the VM is marked as pending customization when it is created.
+func (vms *VMService) reconcileGuestCustomization(ctx context.Context, virtualMachineCtx *virtualMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if virtualMachineCtx.VSphereVM.Spec.Sysprep == nil {
+		return true, nil
+	}
+
+	var obj mo.VirtualMachine
+	if err := virtualMachineCtx.Obj.Properties(ctx, virtualMachineCtx.Obj.Reference(), []string{"config.extraConfig"}, &obj); err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to fetch extraConfig for vm %s", virtualMachineCtx)
+	}
+	if obj.Config == nil || !isGuestCustomizationPending(obj.Config.ExtraConfig) {
+		return true, nil
+	}
+
+	adminPassword, err := vms.getSysprepAdminPassword(ctx, virtualMachineCtx)
+	if err != nil {
+		return false, err
+	}
+	spec, err := getSysprepCustomizationSpec(virtualMachineCtx.VSphereVM.Name, *virtualMachineCtx.VSphereVM, virtualMachineCtx.IPAMState, virtualMachineCtx.State.Network, adminPassword)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("Customizing guest with sysprep")
+	task, err := virtualMachineCtx.Obj.Customize(ctx, *spec)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "error triggering customize op for vm %s", virtualMachineCtx)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to customize vm %s", virtualMachineCtx)
+	}
+
+	// If the VM can't be marked as customized, it is customized again on the next reconcile,
+	// which replaces the pending customization as the VM has not been powered on yet.
+	var extraConfig extra.Config
+	extraConfig.SetGuestCustomizationPending(false)
+	task, err = virtualMachineCtx.Obj.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+		ExtraConfig: extraConfig,
+	})
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "unable to mark vm %s as customized", virtualMachineCtx)
+	}
+
+	virtualMachineCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+	log.Info("Wait for VM to be marked as customized")
+	return false, nil
+}
+
+// isGuestCustomizationPending returns true if the extra config marks the guest customization as pending.
+func isGuestCustomizationPending(extraConfig []types.BaseOptionValue) bool {
+	for _, ec := range extraConfig {
+		if optVal := ec.GetOptionValue(); optVal != nil && optVal.Key == extra.GuestCustomizationKey {
+			return optVal.Value == extra.GuestCustomizationPending
+		}
+	}
+	return false
+}
+
+// getSysprepAdminPassword returns the password of the Administrator account from the Secret
+// referenced by the sysprep spec, or an empty string if no Secret is referenced.
+func (vms *VMService) getSysprepAdminPassword(ctx context.Context, virtualMachineCtx *virtualMachineContext) (string, error) {
+	secretName := virtualMachineCtx.VSphereVM.Spec.Sysprep.AdminPasswordSecretName
+	if secretName == "" {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := apitypes.NamespacedName{
+		Namespace: virtualMachineCtx.VSphereVM.Namespace,
+		Name:      secretName,
+	}
+	if err := virtualMachineCtx.Client.Get(ctx, secretKey, secret); err != nil {
+		return "", pkgerrors.Wrapf(err, "failed to get sysprep admin password secret for %s", virtualMachineCtx)
+	}
+	password, ok := secret.Data[sysprepAdminPasswordKey]
+	if !ok {
+		return "", pkgerrors.Errorf("sysprep admin password secret %s is missing the %s key", secretKey, sysprepAdminPasswordKey)
+	}
+	return string(password), nil
+}
+
+// getSysprepCustomizationSpec returns the sysprep customization spec of a Windows VM. The addresses
+// of the network devices are the static ones from the spec and the ones claimed from IP pools.
+// Only the first IPv4 address of a network device can be set by sysprep.
+func getSysprepCustomizationSpec(hostname string, vsphereVM infrav1.VSphereVM, ipamState map[string]infrav1.NetworkDeviceSpec, networkStatuses []infrav1.NetworkStatus, adminPassword string) (*types.CustomizationSpec, error) {
+	sysprep := ptr.Deref(vsphereVM.Spec.Sysprep, infrav1.SysprepSpec{})
+
+	identity := &types.CustomizationSysprep{
+		GuiUnattended: types.CustomizationGuiUnattended{
+			TimeZone:       ptr.Deref(sysprep.TimeZone, defaultSysprepTimeZone),
+			AutoLogon:      false,
+			AutoLogonCount: 1,
+		},
+		UserData: types.CustomizationUserData{
+			FullName:     valueOrDefault(sysprep.FullName, defaultSysprepFullName),
+			OrgName:      valueOrDefault(sysprep.OrganizationName, defaultSysprepOrganizationName),
+			ComputerName: &types.CustomizationFixedName{Name: hostname},
+		},
+		Identification: types.CustomizationIdentification{
+			JoinWorkgroup: valueOrDefault(sysprep.Workgroup, defaultSysprepWorkgroup),
+		},
+	}
+	if adminPassword != "" {
+		identity.GuiUnattended.Password = &types.CustomizationPassword{
+			Value:     adminPassword,
+			PlainText: true,
+		}
+	}
+
+	spec := &types.CustomizationSpec{
+		Identity: identity,
+	}
+
+	// Every network device of the VM requires an adapter mapping, in the order of the devices.
+	devices := vsphereVM.Spec.Network.Devices
+	for i := range max(len(devices), len(networkStatuses)) {
+		var device infrav1.NetworkDeviceSpec
+		if i < len(devices) {
+			devices[i].DeepCopyInto(&device)
+		}
+		if i < len(networkStatuses) {
+			device.MACAddr = networkStatuses[i].MACAddr
+		}
+		if state, ok := ipamState[device.MACAddr]; ok {
+			device.IPAddrs = append(device.IPAddrs, state.IPAddrs...)
+			device.Gateway4 = state.Gateway4
+			device.Gateway6 = state.Gateway6
+		}
+
+		adapter, err := getSysprepAdapter(device)
+		if err != nil {
+			return nil, err
+		}
+		spec.NicSettingMap = append(spec.NicSettingMap, types.CustomizationAdapterMapping{
+			MacAddress: device.MACAddr,
+			Adapter:    *adapter,
+		})
+
+		for _, searchDomain := range device.SearchDomains {
+			if !slices.Contains(spec.GlobalIPSettings.DnsSuffixList, searchDomain) {
+				spec.GlobalIPSettings.DnsSuffixList = append(spec.GlobalIPSettings.DnsSuffixList, searchDomain)
+			}
+		}
+	}
+	return spec, nil
+}
+
+// getSysprepAdapter returns the IP settings of a network device of a Windows VM.
+func getSysprepAdapter(device infrav1.NetworkDeviceSpec) (*types.CustomizationIPSettings, error) {
+	adapter := &types.CustomizationIPSettings{
+		DnsServerList: device.Nameservers,
+	}
+
+	var ipv6Addresses []types.BaseCustomizationIpV6Generator
+	for _, ipAddr := range device.IPAddrs {
+		ip, ipNet, err := net.ParseCIDR(ipAddr)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "invalid IP address %q of network device %s", ipAddr, device.MACAddr)
+		}
+		if ip.To4() != nil {
+			if adapter.Ip == nil {
+				adapter.Ip = &types.CustomizationFixedIp{IpAddress: ip.String()}
+				adapter.SubnetMask = net.IP(ipNet.Mask).String()
+			}
+			continue
+		}
+		prefix, _ := ipNet.Mask.Size()
+		ipv6Addresses = append(ipv6Addresses, &types.CustomizationFixedIpV6{
+			IpAddress:  ip.String(),
+			SubnetMask: int32(prefix), //nolint:gosec // The prefix of an IPv6 address is at most 128.
+		})
+	}
+
+	// Devices without a static IPv4 address get their address from DHCP.
+	if adapter.Ip == nil {
+		adapter.Ip = &types.CustomizationDhcpIpGenerator{}
+	} else if device.Gateway4 != "" {
+		adapter.Gateway = []string{device.Gateway4}
+	}
+
+	switch {
+	case len(ipv6Addresses) > 0:
+		adapter.IpV6Spec = &types.CustomizationIPSettingsIpV6AddressSpec{Ip: ipv6Addresses}
+		if device.Gateway6 != "" {
+			adapter.IpV6Spec.Gateway = []string{device.Gateway6}
+		}
+	case ptr.Deref(device.DHCP6, false):
+		adapter.IpV6Spec = &types.CustomizationIPSettingsIpV6AddressSpec{
+			Ip: []types.BaseCustomizationIpV6Generator{&types.CustomizationDhcpIpV6Generator{}},
+		}
+	}
+	return adapter, nil
+}
+
+func valueOrDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+func Test_isGuestCustomizationPending(t *testing.T) {
+	tests := []struct {
+		name        string
+		extraConfig []types.BaseOptionValue
+		want        bool
+	}{
+		{
+			name: "no marker",
+			extraConfig: []types.BaseOptionValue{
+				&types.OptionValue{Key: "guestinfo.metadata", Value: "foo"},
+			},
+			want: false,
+		},
+		{
+			name: "pending",
+			extraConfig: []types.BaseOptionValue{
+				&types.OptionValue{Key: extra.GuestCustomizationKey, Value: extra.GuestCustomizationPending},
+			},
+			want: true,
+		},
+		{
+			name: "customized",
+			extraConfig: []types.BaseOptionValue{
+				&types.OptionValue{Key: extra.GuestCustomizationKey, Value: ""},
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isGuestCustomizationPending(tt.extraConfig)).To(Equal(tt.want))
+		})
+	}
+}
+
+func Test_getSysprepCustomizationSpec(t *testing.T) {
+	t.Run("uses defaults", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereVM := infrav1.VSphereVM{
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					OS:      infrav1.Windows,
+					Sysprep: &infrav1.SysprepSpec{},
+					Network: infrav1.NetworkSpec{
+						Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "network1", DHCP4: ptr.To(true)}},
+					},
+				},
+			},
+		}
+		networkStatuses := []infrav1.NetworkStatus{{MACAddr: "00:50:56:00:00:01"}}
+
+		spec, err := getSysprepCustomizationSpec("win-1", vsphereVM, nil, networkStatuses, "")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		identity, ok := spec.Identity.(*types.CustomizationSysprep)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(identity.GuiUnattended.TimeZone).To(Equal(defaultSysprepTimeZone))
+		g.Expect(identity.GuiUnattended.Password).To(BeNil())
+		g.Expect(identity.UserData.FullName).To(Equal(defaultSysprepFullName))
+		g.Expect(identity.UserData.OrgName).To(Equal(defaultSysprepOrganizationName))
+		g.Expect(identity.UserData.ComputerName).To(Equal(&types.CustomizationFixedName{Name: "win-1"}))
+		g.Expect(identity.Identification.JoinWorkgroup).To(Equal(defaultSysprepWorkgroup))
+
+		g.Expect(spec.NicSettingMap).To(HaveLen(1))
+		g.Expect(spec.NicSettingMap[0].MacAddress).To(Equal("00:50:56:00:00:01"))
+		g.Expect(spec.NicSettingMap[0].Adapter.Ip).To(Equal(&types.CustomizationDhcpIpGenerator{}))
+		g.Expect(spec.NicSettingMap[0].Adapter.IpV6Spec).To(BeNil())
+	})
+
+	t.Run("uses static and claimed addresses", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereVM := infrav1.VSphereVM{
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					OS: infrav1.Windows,
+					Sysprep: &infrav1.SysprepSpec{
+						FullName:         "Operator",
+						OrganizationName: "Example",
+						TimeZone:         ptr.To[int32](4),
+						Workgroup:        "CLUSTER",
+					},
+					Network: infrav1.NetworkSpec{
+						Devices: []infrav1.NetworkDeviceSpec{
+							{
+								NetworkName:   "network1",
+								IPAddrs:       []string{"192.168.1.10/24", "192.168.1.11/24", "fd00::10/64"},
+								Gateway4:      "192.168.1.1",
+								Gateway6:      "fd00::1",
+								Nameservers:   []string{"192.168.1.2"},
+								SearchDomains: []string{"example.com"},
+							},
+							{
+								NetworkName:   "network2",
+								DHCP6:         ptr.To(true),
+								SearchDomains: []string{"example.com", "example.org"},
+							},
+						},
+					},
+				},
+			},
+		}
+		networkStatuses := []infrav1.NetworkStatus{{MACAddr: "00:50:56:00:00:01"}, {MACAddr: "00:50:56:00:00:02"}}
+		ipamState := map[string]infrav1.NetworkDeviceSpec{
+			"00:50:56:00:00:02": {IPAddrs: []string{"10.0.0.10/16"}, Gateway4: "10.0.0.1"},
+		}
+
+		spec, err := getSysprepCustomizationSpec("win-1", vsphereVM, ipamState, networkStatuses, "secret")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		identity, ok := spec.Identity.(*types.CustomizationSysprep)
+		g.Expect(ok).To(BeTrue())
+		g.Expect(identity.GuiUnattended.TimeZone).To(Equal(int32(4)))
+		g.Expect(identity.GuiUnattended.Password).To(Equal(&types.CustomizationPassword{Value: "secret", PlainText: true}))
+		g.Expect(identity.UserData.FullName).To(Equal("Operator"))
+		g.Expect(identity.UserData.OrgName).To(Equal("Example"))
+		g.Expect(identity.Identification.JoinWorkgroup).To(Equal("CLUSTER"))
+
+		g.Expect(spec.GlobalIPSettings.DnsSuffixList).To(Equal([]string{"example.com", "example.org"}))
+		g.Expect(spec.NicSettingMap).To(HaveLen(2))
+
+		adapter := spec.NicSettingMap[0].Adapter
+		g.Expect(spec.NicSettingMap[0].MacAddress).To(Equal("00:50:56:00:00:01"))
+		g.Expect(adapter.Ip).To(Equal(&types.CustomizationFixedIp{IpAddress: "192.168.1.10"}))
+		g.Expect(adapter.SubnetMask).To(Equal("255.255.255.0"))
+		g.Expect(adapter.Gateway).To(Equal([]string{"192.168.1.1"}))
+		g.Expect(adapter.DnsServerList).To(Equal([]string{"192.168.1.2"}))
+		g.Expect(adapter.IpV6Spec).To(Equal(&types.CustomizationIPSettingsIpV6AddressSpec{
+			Ip:      []types.BaseCustomizationIpV6Generator{&types.CustomizationFixedIpV6{IpAddress: "fd00::10", SubnetMask: 64}},
+			Gateway: []string{"fd00::1"},
+		}))
+
+		adapter = spec.NicSettingMap[1].Adapter
+		g.Expect(spec.NicSettingMap[1].MacAddress).To(Equal("00:50:56:00:00:02"))
+		g.Expect(adapter.Ip).To(Equal(&types.CustomizationFixedIp{IpAddress: "10.0.0.10"}))
+		g.Expect(adapter.SubnetMask).To(Equal("255.255.0.0"))
+		g.Expect(adapter.Gateway).To(Equal([]string{"10.0.0.1"}))
+		g.Expect(adapter.IpV6Spec).To(Equal(&types.CustomizationIPSettingsIpV6AddressSpec{
+			Ip: []types.BaseCustomizationIpV6Generator{&types.CustomizationDhcpIpV6Generator{}},
+		}))
+	})
+
+	t.Run("fails on an invalid address", func(t *testing.T) {
+		g := NewWithT(t)
+
+		vsphereVM := infrav1.VSphereVM{
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					OS:      infrav1.Windows,
+					Sysprep: &infrav1.SysprepSpec{},
+					Network: infrav1.NetworkSpec{
+						Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "network1", IPAddrs: []string{"192.168.1.10"}}},
+					},
+				},
+			},
+		}
+
+		_, err := getSysprepCustomizationSpec("win-1", vsphereVM, nil, nil, "")
+		g.Expect(err).To(HaveOccurred())
+	})
+}
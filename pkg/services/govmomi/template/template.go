@@ -14,17 +14,26 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package template has tools for finding VM templates.
+// Package template has tools for finding VM templates and content library items.
 package template
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	pkgerrors "github.com/pkg/errors"
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
 )
 
@@ -67,6 +76,177 @@ func findTemplateByName(ctx context.Context, session *session.Session, templateI
 	return tpl, nil
 }
 
+// FindTemplateBySelector finds the most recently created template in the datacenter of the
+// session which has all the tags of the selector attached. Only virtual machines marked as
+// templates are considered, tagged virtual machines like the nodes of a cluster are ignored.
+// It returns the template and its instance UUID.
+func FindTemplateBySelector(ctx context.Context, session *session.Session, selector infrav1.VirtualMachineTemplateSelector) (*object.VirtualMachine, string, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if session.TagManager == nil {
+		return nil, "", pkgerrors.New("unable to find template by selector: tag manager is not initialized")
+	}
+
+	candidates, err := findTaggedObjects(ctx, session.TagManager, selector, "VirtualMachine")
+	if err != nil {
+		return nil, "", err
+	}
+	if len(candidates) == 0 {
+		return nil, "", pkgerrors.Errorf("unable to find template with tags %s", selectorString(selector))
+	}
+
+	var vms []mo.VirtualMachine
+	if err := property.DefaultCollector(session.Client.Client).Retrieve(ctx, candidates, []string{"name", "config.template", "config.createDate", "config.instanceUuid"}, &vms); err != nil {
+		return nil, "", pkgerrors.Wrapf(err, "error getting properties of templates with tags %s", selectorString(selector))
+	}
+
+	// Prefer the most recently created template.
+	slices.SortStableFunc(vms, func(a, b mo.VirtualMachine) int {
+		if c := createDate(b).Compare(createDate(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	for _, vm := range vms {
+		if vm.Config == nil || !vm.Config.Template || vm.Config.InstanceUuid == "" {
+			continue
+		}
+		// Templates in other datacenters can't be found by their instance UUID.
+		tpl, err := findTemplateByInstanceUUID(ctx, session, vm.Config.InstanceUuid)
+		if err != nil {
+			return nil, "", err
+		}
+		if tpl != nil && tpl.Reference() == vm.Self {
+			log.V(5).Info("Found template by selector", "name", vm.Name, "instanceUUID", vm.Config.InstanceUuid)
+			return tpl, vm.Config.InstanceUuid, nil
+		}
+	}
+	return nil, "", pkgerrors.Errorf("unable to find template with tags %s in the datacenter", selectorString(selector))
+}
+
+// FindContentLibraryItemBySelector finds the most recently created item of the content library of
+// the selector which has all the tags of the selector attached.
+func FindContentLibraryItemBySelector(ctx context.Context, session *session.Session, selector infrav1.VirtualMachineTemplateSelector) (*library.Item, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if session.TagManager == nil {
+		return nil, pkgerrors.New("unable to find content library item by selector: tag manager is not initialized")
+	}
+
+	manager := library.NewManager(session.TagManager.Client)
+	libraryIDs, err := manager.FindLibrary(ctx, library.Find{Name: selector.ContentLibrary})
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to find content library %q", selector.ContentLibrary)
+	}
+	if len(libraryIDs) != 1 {
+		return nil, pkgerrors.Errorf("expected exactly one content library with name %q, found %d", selector.ContentLibrary, len(libraryIDs))
+	}
+
+	candidates, err := findTaggedObjects(ctx, session.TagManager, selector, contentLibraryItemType)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]library.Item, 0, len(candidates))
+	for _, candidate := range candidates {
+		item, err := manager.GetLibraryItem(ctx, candidate.Value)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to get content library item %q", candidate.Value)
+		}
+		if item.LibraryID == libraryIDs[0] {
+			items = append(items, *item)
+		}
+	}
+	if len(items) == 0 {
+		return nil, pkgerrors.Errorf("unable to find item with tags %s in content library %q", selectorString(selector), selector.ContentLibrary)
+	}
+
+	// Prefer the most recently created item.
+	slices.SortStableFunc(items, func(a, b library.Item) int {
+		if c := creationTime(b).Compare(creationTime(a)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	log.V(5).Info("Found content library item by selector", "name", items[0].Name, "libraryItemID", items[0].ID)
+	return &items[0], nil
+}
+
+// contentLibraryItemType is the type of the content library items tags are attached to.
+const contentLibraryItemType = "com.vmware.content.library.Item"
+
+// findTaggedObjects returns the objects of the given type which have all the tags of the selector attached.
+func findTaggedObjects(ctx context.Context, tagManager *tags.Manager, selector infrav1.VirtualMachineTemplateSelector, objectType string) ([]types.ManagedObjectReference, error) {
+	var candidates []types.ManagedObjectReference
+	for i, matchTag := range selector.MatchTags {
+		tag, err := tagManager.GetTagForCategory(ctx, matchTag.Name, matchTag.Category)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to find tag %q in category %q", matchTag.Name, matchTag.Category)
+		}
+		objs, err := tagManager.ListAttachedObjects(ctx, tag.ID)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to list objects with tag %q in category %q", matchTag.Name, matchTag.Category)
+		}
+
+		var refs []types.ManagedObjectReference
+		for _, obj := range objs {
+			if ref := obj.Reference(); ref.Type == objectType && (i == 0 || slices.Contains(candidates, ref)) {
+				refs = append(refs, ref)
+			}
+		}
+		candidates = refs
+	}
+	return candidates, nil
+}
+
+func creationTime(item library.Item) time.Time {
+	if item.CreationTime == nil {
+		return time.Time{}
+	}
+	return *item.CreationTime
+}
+
+func createDate(vm mo.VirtualMachine) time.Time {
+	if vm.Config == nil || vm.Config.CreateDate == nil {
+		return time.Time{}
+	}
+	return *vm.Config.CreateDate
+}
+
+func selectorString(selector infrav1.VirtualMachineTemplateSelector) string {
+	tags := make([]string, 0, len(selector.MatchTags))
+	for _, tag := range selector.MatchTags {
+		tags = append(tags, tag.Category+":"+tag.Name)
+	}
+	return strings.Join(tags, ", ")
+}
+
+// Info is the hardware and guest information of a template.
+type Info struct {
+	// GuestID is the guest operating system identifier of the template, e.g. ubuntu64Guest.
+	GuestID string
+
+	// DiskGiB is the size of the template's primary disk, in GiB.
+	DiskGiB int32
+}
+
+// GetInfo returns the hardware and guest information of the given template.
+func GetInfo(ctx context.Context, tpl *object.VirtualMachine) (*Info, error) {
+	var vm mo.VirtualMachine
+	if err := tpl.Properties(ctx, tpl.Reference(), []string{"config.guestId", "config.hardware.device"}, &vm); err != nil {
+		return nil, pkgerrors.Wrapf(err, "error getting properties for template %s", tpl.Reference().Value)
+	}
+	if vm.Config == nil {
+		return nil, pkgerrors.Errorf("template %s has no config", tpl.Reference().Value)
+	}
+
+	info := &Info{GuestID: vm.Config.GuestId}
+	disks := object.VirtualDeviceList(vm.Config.Hardware.Device).SelectByType((*types.VirtualDisk)(nil))
+	if len(disks) > 0 {
+		info.DiskGiB = int32(disks[0].(*types.VirtualDisk).CapacityInKB / (1024 * 1024))
+	}
+	return info, nil
+}
+
 func isValidUUID(str string) bool {
 	_, err := uuid.Parse(str)
 	return err == nil
@@ -0,0 +1,230 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"context"
+	"crypto/tls"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vapi/library"
+	_ "github.com/vmware/govmomi/vapi/simulator" // run init func to register the tagging API endpoints.
+	"github.com/vmware/govmomi/vapi/tags"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+func TestFindTemplateBySelector(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	model := simulator.VPX()
+	model.Host = 0
+	model.Machine = 3
+	g.Expect(model.Create()).To(Succeed())
+	t.Cleanup(model.Remove)
+	model.Service.TLS = new(tls.Config)
+	model.Service.RegisterEndpoints = true
+
+	server := model.Service.NewServer()
+	t.Cleanup(server.Close)
+	pass, _ := server.URL.User.Password()
+
+	s, err := session.GetOrCreate(ctx,
+		session.NewParams().
+			WithServer(server.URL.Host).
+			WithUserInfo(server.URL.User.Username(), pass).
+			WithDatacenter("*").
+			WithInsecure(true))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	createTag := func(category, name string) string {
+		categoryID, err := s.TagManager.CreateCategory(ctx, &tags.Category{Name: category, Cardinality: "MULTIPLE"})
+		if err != nil {
+			cat, getErr := s.TagManager.GetCategory(ctx, category)
+			g.Expect(getErr).ToNot(HaveOccurred())
+			categoryID = cat.ID
+		}
+		tagID, err := s.TagManager.CreateTag(ctx, &tags.Tag{Name: name, CategoryID: categoryID})
+		g.Expect(err).ToNot(HaveOccurred())
+		return tagID
+	}
+	k8sVersionTag := createTag("k8s-version", "v1.34.1")
+	osTag := createTag("os", "ubuntu-2404")
+	createTag("k8s-version", "v1.33.0")
+	nodeTag := createTag("role", "node")
+
+	vms := make([]*object.VirtualMachine, 0, 3)
+	for _, name := range []string{"DC0_C0_RP0_VM0", "DC0_C0_RP0_VM1", "DC0_C0_RP0_VM2"} {
+		vm, err := s.Finder.VirtualMachine(ctx, name)
+		g.Expect(err).ToNot(HaveOccurred())
+		vms = append(vms, vm)
+	}
+
+	// VM0 and VM1 match the Kubernetes version, only VM1 and VM2 match the OS.
+	g.Expect(s.TagManager.AttachTag(ctx, k8sVersionTag, vms[0])).To(Succeed())
+	g.Expect(s.TagManager.AttachTag(ctx, k8sVersionTag, vms[1])).To(Succeed())
+	g.Expect(s.TagManager.AttachTag(ctx, osTag, vms[1])).To(Succeed())
+	g.Expect(s.TagManager.AttachTag(ctx, osTag, vms[2])).To(Succeed())
+	g.Expect(s.TagManager.AttachTag(ctx, nodeTag, vms[2])).To(Succeed())
+
+	// VM0 and VM1 are templates, VM2 is a tagged node VM.
+	for _, vm := range vms[:2] {
+		model.Map().Get(vm.Reference()).(*simulator.VirtualMachine).Config.Template = true
+	}
+
+	// Make VM1 the most recently created template and VM2 the most recently created VM.
+	simVM1 := model.Map().Get(vms[1].Reference()).(*simulator.VirtualMachine)
+	simVM1.Config.CreateDate = ptr.To(time.Now().Add(time.Hour))
+	simVM2 := model.Map().Get(vms[2].Reference()).(*simulator.VirtualMachine)
+	simVM2.Config.CreateDate = ptr.To(time.Now().Add(2 * time.Hour))
+
+	instanceUUID := func(vm *object.VirtualMachine) string {
+		var o mo.VirtualMachine
+		g.Expect(vm.Properties(ctx, vm.Reference(), []string{"config.instanceUuid"}, &o)).To(Succeed())
+		return o.Config.InstanceUuid
+	}
+
+	tests := []struct {
+		name     string
+		selector infrav1.VirtualMachineTemplateSelector
+		want     *object.VirtualMachine
+		wantErr  bool
+	}{
+		{
+			name:     "most recently created template is preferred",
+			selector: infrav1.VirtualMachineTemplateSelector{MatchTags: []infrav1.VirtualMachineTemplateTag{{Category: "k8s-version", Name: "v1.34.1"}}},
+			want:     vms[1],
+		},
+		{
+			name: "all tags must match",
+			selector: infrav1.VirtualMachineTemplateSelector{MatchTags: []infrav1.VirtualMachineTemplateTag{
+				{Category: "k8s-version", Name: "v1.34.1"},
+				{Category: "os", Name: "ubuntu-2404"},
+			}},
+			want: vms[1],
+		},
+		{
+			name:     "template matching a single tag",
+			selector: infrav1.VirtualMachineTemplateSelector{MatchTags: []infrav1.VirtualMachineTemplateTag{{Category: "os", Name: "ubuntu-2404"}}},
+			want:     vms[1],
+		},
+		{
+			name:     "virtual machines which are not templates are ignored",
+			selector: infrav1.VirtualMachineTemplateSelector{MatchTags: []infrav1.VirtualMachineTemplateTag{{Category: "role", Name: "node"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "no template with the tag",
+			selector: infrav1.VirtualMachineTemplateSelector{MatchTags: []infrav1.VirtualMachineTemplateTag{{Category: "k8s-version", Name: "v1.33.0"}}},
+			wantErr:  true,
+		},
+		{
+			name:     "tag does not exist",
+			selector: infrav1.VirtualMachineTemplateSelector{MatchTags: []infrav1.VirtualMachineTemplateTag{{Category: "k8s-version", Name: "v1.32.0"}}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			tpl, uuid, err := FindTemplateBySelector(ctx, s, tt.selector)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(tpl.Reference()).To(Equal(tt.want.Reference()))
+			g.Expect(uuid).To(Equal(instanceUUID(tt.want)))
+		})
+	}
+}
+
+func TestFindContentLibraryItemBySelector(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	model := simulator.VPX()
+	model.Host = 0
+	g.Expect(model.Create()).To(Succeed())
+	t.Cleanup(model.Remove)
+	model.Service.TLS = new(tls.Config)
+	model.Service.RegisterEndpoints = true
+
+	server := model.Service.NewServer()
+	t.Cleanup(server.Close)
+	pass, _ := server.URL.User.Password()
+
+	s, err := session.GetOrCreate(ctx,
+		session.NewParams().
+			WithServer(server.URL.Host).
+			WithUserInfo(server.URL.User.Username(), pass).
+			WithDatacenter("*").
+			WithInsecure(true))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ds, err := s.Finder.DefaultDatastore(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	manager := library.NewManager(s.TagManager.Client)
+	createLibrary := func(name string) string {
+		libraryID, err := manager.CreateLibrary(ctx, library.Library{
+			Name:    name,
+			Type:    "LOCAL",
+			Storage: []library.StorageBacking{{DatastoreID: ds.Reference().Value, Type: "DATASTORE"}},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		return libraryID
+	}
+	libraryID := createLibrary("capv")
+	otherLibraryID := createLibrary("other")
+
+	categoryID, err := s.TagManager.CreateCategory(ctx, &tags.Category{Name: "k8s-version", Cardinality: "MULTIPLE"})
+	g.Expect(err).ToNot(HaveOccurred())
+	tagID, err := s.TagManager.CreateTag(ctx, &tags.Tag{Name: "v1.34.1", CategoryID: categoryID})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	createItem := func(libraryID, name string) string {
+		itemID, err := manager.CreateLibraryItem(ctx, library.Item{Name: name, Type: library.ItemTypeOVF, LibraryID: libraryID})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(s.TagManager.AttachTag(ctx, tagID, types.ManagedObjectReference{Type: contentLibraryItemType, Value: itemID})).To(Succeed())
+		return itemID
+	}
+	createItem(libraryID, "ubuntu-2404-kube-v1.34.1-a")
+	newestItemID := createItem(libraryID, "ubuntu-2404-kube-v1.34.1-b")
+	createItem(otherLibraryID, "ubuntu-2404-kube-v1.34.1-c")
+
+	selector := infrav1.VirtualMachineTemplateSelector{
+		MatchTags:      []infrav1.VirtualMachineTemplateTag{{Category: "k8s-version", Name: "v1.34.1"}},
+		ContentLibrary: "capv",
+	}
+	item, err := FindContentLibraryItemBySelector(ctx, s, selector)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(item.ID).To(Equal(newestItemID))
+
+	selector.ContentLibrary = "missing"
+	_, err = FindContentLibraryItemBySelector(ctx, s, selector)
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+)
+
+// ErrTemplateNotImported is returned by ResolveTemplateRef if the template of the VSphereTemplate
+// is not imported yet.
+var ErrTemplateNotImported = pkgerrors.New("template is not imported yet")
+
+// ResolveTemplateRef returns the instance UUID of the template of the VSphereTemplate with the
+// reference in the namespace.
+func ResolveTemplateRef(ctx context.Context, c client.Client, namespace string, ref infrav1.VSphereTemplateReference) (string, error) {
+	vsphereTemplate := &infrav1.VSphereTemplate{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: ref.Name}, vsphereTemplate); err != nil {
+		return "", pkgerrors.Wrapf(err, "failed to get VSphereTemplate %s/%s", namespace, ref.Name)
+	}
+	if vsphereTemplate.Status.InstanceUUID == "" {
+		return "", pkgerrors.Wrapf(ErrTemplateNotImported, "VSphereTemplate %s/%s", namespace, ref.Name)
+	}
+	return vsphereTemplate.Status.InstanceUUID, nil
+}
+
+// resolveTemplateRef records the instance UUID of the template of the VSphereTemplate the VSphereVM
+// references in the status of the VSphereVM, so the VM is cloned from it. The template is resolved
+// only once, so the VM is not affected if the VSphereTemplate is recreated.
+func resolveTemplateRef(ctx context.Context, vmCtx *capvcontext.VMContext) error {
+	ref := vmCtx.VSphereVM.Spec.TemplateRef
+	if ref == nil || vmCtx.VSphereVM.Status.TemplateUUID != "" {
+		return nil
+	}
+	templateUUID, err := ResolveTemplateRef(ctx, vmCtx.Client, vmCtx.VSphereVM.Namespace, *ref)
+	if err != nil {
+		return err
+	}
+	vmCtx.VSphereVM.Status.TemplateUUID = templateUUID
+	return nil
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
+)
+
+func Test_resolveTemplateRef(t *testing.T) {
+	imported := &infrav1.VSphereTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "imported", Namespace: "test"},
+		Status:     infrav1.VSphereTemplateStatus{InstanceUUID: "5016e1a9-2d3b-4d5e-9b6a-6f0e1f2a3b4c"},
+	}
+	importing := &infrav1.VSphereTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "importing", Namespace: "test"},
+	}
+	controllerManagerCtx := fake.NewControllerManagerContext(imported, importing)
+
+	newVMContext := func(templateRef string) *capvcontext.VMContext {
+		return &capvcontext.VMContext{
+			ControllerManagerContext: controllerManagerCtx,
+			VSphereVM: &infrav1.VSphereVM{
+				ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "test"},
+				Spec: infrav1.VSphereVMSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						TemplateRef: &infrav1.VSphereTemplateReference{Name: templateRef},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("imported template", func(t *testing.T) {
+		g := NewWithT(t)
+		vmCtx := newVMContext("imported")
+		g.Expect(resolveTemplateRef(context.Background(), vmCtx)).To(Succeed())
+		g.Expect(vmCtx.VSphereVM.Status.TemplateUUID).To(Equal(imported.Status.InstanceUUID))
+	})
+
+	t.Run("template which is not imported yet", func(t *testing.T) {
+		g := NewWithT(t)
+		vmCtx := newVMContext("importing")
+		err := resolveTemplateRef(context.Background(), vmCtx)
+		g.Expect(errors.Is(err, ErrTemplateNotImported)).To(BeTrue())
+		g.Expect(vmCtx.VSphereVM.Status.TemplateUUID).To(BeEmpty())
+	})
+
+	t.Run("template which is already resolved", func(t *testing.T) {
+		g := NewWithT(t)
+		vmCtx := newVMContext("missing")
+		vmCtx.VSphereVM.Status.TemplateUUID = "5016e1a9-0000-0000-0000-000000000000"
+		g.Expect(resolveTemplateRef(context.Background(), vmCtx)).To(Succeed())
+		g.Expect(vmCtx.VSphereVM.Status.TemplateUUID).To(Equal("5016e1a9-0000-0000-0000-000000000000"))
+	})
+}
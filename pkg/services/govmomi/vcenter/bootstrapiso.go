@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"bytes"
+	"context"
+	"path"
+
+	"github.com/kdomanski/iso9660"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	"sigs.k8s.io/yaml"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+const (
+	// bootstrapISODirectory is the directory on the datastore in which the seed ISOs are uploaded.
+	bootstrapISODirectory = "capv-cidata"
+
+	// bootstrapISOVolumeID is the volume identifier which cloud-init looks for to find a NoCloud seed.
+	bootstrapISOVolumeID = "cidata"
+)
+
+// NewBootstrapISO renders a NoCloud seed ISO with the bootstrap data as user-data. The network
+// of the metadata is written to network-config and the rest of the metadata to meta-data.
+func NewBootstrapISO(bootstrapData, metadata []byte) ([]byte, error) {
+	meta := map[string]interface{}{}
+	if err := yaml.Unmarshal(metadata, &meta); err != nil {
+		return nil, pkgerrors.Wrap(err, "unable to parse metadata for the seed ISO")
+	}
+
+	files := map[string][]byte{
+		"user-data": bootstrapData,
+	}
+	if network, ok := meta["network"]; ok {
+		delete(meta, "network")
+		networkConfig, err := yaml.Marshal(network)
+		if err != nil {
+			return nil, pkgerrors.Wrap(err, "unable to render network-config for the seed ISO")
+		}
+		files["network-config"] = networkConfig
+	}
+	metaData, err := yaml.Marshal(meta)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "unable to render meta-data for the seed ISO")
+	}
+	files["meta-data"] = metaData
+
+	w, err := iso9660.NewWriter()
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "unable to create seed ISO")
+	}
+	defer func() {
+		_ = w.Cleanup()
+	}()
+	for name, data := range files {
+		if err := w.AddFile(bytes.NewReader(data), name); err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to add %s to the seed ISO", name)
+		}
+	}
+
+	var iso bytes.Buffer
+	if err := w.WriteTo(&iso, bootstrapISOVolumeID); err != nil {
+		return nil, pkgerrors.Wrap(err, "unable to write seed ISO")
+	}
+	return iso.Bytes(), nil
+}
+
+// BootstrapISOPath returns the datastore path of the seed ISO of the VSphereVM on the datastore.
+func BootstrapISOPath(datastore string, vsphereVM *infrav1.VSphereVM) string {
+	return (&object.DatastorePath{
+		Datastore: datastore,
+		Path:      path.Join(bootstrapISODirectory, vsphereVM.Namespace, vsphereVM.Name+".iso"),
+	}).String()
+}
+
+// UploadBootstrapISO uploads the seed ISO to the datastore path, replacing the existing ISO.
+func UploadBootstrapISO(ctx context.Context, s *session.Session, datastorePath string, iso []byte) error {
+	var p object.DatastorePath
+	if !p.FromString(datastorePath) {
+		return pkgerrors.Errorf("invalid datastore path %q of seed ISO", datastorePath)
+	}
+	ds, err := s.Finder.Datastore(ctx, p.Datastore)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to find datastore %s for seed ISO", p.Datastore)
+	}
+	dc, err := s.Finder.Datacenter(ctx, ds.DatacenterPath)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to find datacenter of datastore %s for seed ISO", p.Datastore)
+	}
+
+	dir := ds.Path(path.Dir(p.Path))
+	if err := object.NewFileManager(s.Client.Client).MakeDirectory(ctx, dir, dc, true); err != nil && !fault.Is(err, &types.FileAlreadyExists{}) {
+		return pkgerrors.Wrapf(err, "unable to create directory %s for seed ISO", dir)
+	}
+
+	upload := soap.DefaultUpload
+	upload.ContentLength = int64(len(iso))
+	if err := ds.Upload(ctx, bytes.NewReader(iso), p.Path, &upload); err != nil {
+		return pkgerrors.Wrapf(err, "unable to upload seed ISO %s", datastorePath)
+	}
+	return nil
+}
+
+// DeleteBootstrapISO deletes the seed ISO at the datastore path. Deleting an ISO which does not
+// exist is a no-op.
+func DeleteBootstrapISO(ctx context.Context, s *session.Session, datastorePath string) error {
+	var p object.DatastorePath
+	if !p.FromString(datastorePath) {
+		return pkgerrors.Errorf("invalid datastore path %q of seed ISO", datastorePath)
+	}
+	ds, err := s.Finder.Datastore(ctx, p.Datastore)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to find datastore %s for seed ISO", p.Datastore)
+	}
+	dc, err := s.Finder.Datacenter(ctx, ds.DatacenterPath)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to find datacenter of datastore %s for seed ISO", p.Datastore)
+	}
+
+	task, err := object.NewFileManager(s.Client.Client).DeleteDatastoreFile(ctx, datastorePath, dc)
+	if err == nil {
+		err = task.Wait(ctx)
+	}
+	if err != nil && !fault.Is(err, &types.FileNotFound{}) {
+		return pkgerrors.Wrapf(err, "unable to delete seed ISO %s", datastorePath)
+	}
+	return nil
+}
+
+// FindBootstrapISOCdrom returns the CD-ROM of the devices in which the seed ISO at the datastore
+// path is inserted, or nil if the ISO is not inserted.
+func FindBootstrapISOCdrom(devices object.VirtualDeviceList, datastorePath string) *types.VirtualCdrom {
+	for _, device := range devices.SelectByType((*types.VirtualCdrom)(nil)) {
+		cdrom := device.(*types.VirtualCdrom)
+		if backing, ok := cdrom.Backing.(*types.VirtualCdromIsoBackingInfo); ok && backing.FileName == datastorePath {
+			return cdrom
+		}
+	}
+	return nil
+}
+
+// getBootstrapISODeviceSpec returns the device spec which inserts the seed ISO into the first
+// CD-ROM of the devices of the template, or adds a CD-ROM with the ISO if the template has none.
+func getBootstrapISODeviceSpec(devices object.VirtualDeviceList, datastorePath string) (types.BaseVirtualDeviceConfigSpec, error) {
+	operation := types.VirtualDeviceConfigSpecOperationEdit
+	cdrom, err := devices.FindCdrom("")
+	if err != nil {
+		var controller types.BaseVirtualController
+		if ide, err := devices.FindIDEController(""); err == nil {
+			controller = ide
+		} else if sata, err := devices.FindSATAController(""); err == nil {
+			controller = sata
+		} else {
+			return nil, pkgerrors.New("unable to find an IDE or SATA controller for the CD-ROM of the seed ISO")
+		}
+		if cdrom, err = devices.CreateCdrom(controller); err != nil {
+			return nil, pkgerrors.Wrap(err, "unable to create CD-ROM for the seed ISO")
+		}
+		operation = types.VirtualDeviceConfigSpecOperationAdd
+	}
+
+	cdrom = devices.InsertIso(cdrom, datastorePath)
+	cdrom.Connectable = &types.VirtualDeviceConnectInfo{
+		AllowGuestControl: true,
+		Connected:         true,
+		StartConnected:    true,
+	}
+	return &types.VirtualDeviceConfigSpec{
+		Operation: operation,
+		Device:    cdrom,
+	}, nil
+}
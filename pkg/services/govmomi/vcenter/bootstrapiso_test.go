@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"bytes"
+	ctx "context"
+	"io"
+	"testing"
+
+	"github.com/kdomanski/iso9660"
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+// readISO returns the label and the files in the root directory of an ISO.
+func readISO(g *gomega.WithT, iso []byte) (string, map[string]string) {
+	image, err := iso9660.OpenImage(bytes.NewReader(iso))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	label, err := image.Label()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	root, err := image.RootDir()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	children, err := root.GetChildren()
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	files := map[string]string{}
+	for _, child := range children {
+		data, err := io.ReadAll(child.Reader())
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		files[child.Name()] = string(data)
+	}
+	return label, files
+}
+
+func TestNewBootstrapISO(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	iso, err := NewBootstrapISO([]byte("#cloud-config\nruncmd: []\n"), []byte(`
+instance-id: "test-vm"
+local-hostname: "test-vm"
+network:
+  version: 2
+  ethernets:
+    id0:
+      match:
+        macaddress: "00:50:56:a0:00:01"
+      dhcp4: true
+`))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	label, files := readISO(g, iso)
+	g.Expect(label).To(gomega.Equal("cidata"))
+	g.Expect(files).To(gomega.HaveLen(3))
+	g.Expect(files).To(gomega.HaveKeyWithValue("user-data", "#cloud-config\nruncmd: []\n"))
+	g.Expect(files).To(gomega.HaveKeyWithValue("meta-data", "instance-id: test-vm\nlocal-hostname: test-vm\n"))
+	g.Expect(files).To(gomega.HaveKeyWithValue("network-config", `ethernets:
+  id0:
+    dhcp4: true
+    match:
+      macaddress: 00:50:56:a0:00:01
+version: 2
+`))
+}
+
+func TestCloneWithBootstrapISO(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	vmCtx := &capvcontext.VMContext{
+		Session: session,
+		VSphereVM: &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test", Name: "seed-iso", UID: apitypes.UID("seed-iso")},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					Template:              "DC0_C0_RP0_VM0",
+					Datastore:             "LocalDS_0",
+					BootstrapDataDelivery: infrav1.BootstrapDataDeliveryNoCloudISO,
+					Network: infrav1.NetworkSpec{
+						Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "VM Network", DHCP4: ptr.To(true)}},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := getBootstrapISO(vmCtx, []byte("{}"), bootstrapv1.Ignition, &extra.Config{})
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("ignition bootstrap data can not be delivered")))
+
+	bootstrapData := []byte("#cloud-config\n")
+	extraConfig, err := getExtraConfig(ctx.TODO(), vmCtx, bootstrapData, bootstrapv1.CloudConfig)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	bootstrapISO, err := getBootstrapISO(vmCtx, bootstrapData, bootstrapv1.CloudConfig, &extraConfig)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	task, err := cloneVM(ctx.TODO(), vmCtx, extraConfig, bootstrapISO)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+
+	isoPath := "[LocalDS_0] capv-cidata/test/seed-iso.iso"
+	g.Expect(vmCtx.VSphereVM.Status.BootstrapISO).To(gomega.Equal(isoPath))
+
+	// The ISO is attached to the VM instead of setting the bootstrap data in the guestinfo.
+	vm, err := session.Finder.VirtualMachine(ctx.TODO(), "seed-iso")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	devices, err := vm.Device(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	cdrom := FindBootstrapISOCdrom(devices, isoPath)
+	g.Expect(cdrom).ToNot(gomega.BeNil())
+	g.Expect(cdrom.Connectable.StartConnected).To(gomega.BeTrue())
+	var vmMo mo.VirtualMachine
+	g.Expect(vm.Properties(ctx.TODO(), vm.Reference(), []string{"config.extraConfig"}, &vmMo)).To(gomega.Succeed())
+	g.Expect(extraConfigValue(vmMo.Config.ExtraConfig, "guestinfo.userdata")).To(gomega.BeEmpty())
+	g.Expect(extraConfigValue(vmMo.Config.ExtraConfig, extra.BootstrapISOMetadataKey)).ToNot(gomega.BeEmpty())
+
+	ds, err := session.Finder.Datastore(ctx.TODO(), "LocalDS_0")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	_, files := readISO(g, downloadISO(g, ds, "capv-cidata/test/seed-iso.iso"))
+	g.Expect(files).To(gomega.HaveKeyWithValue("user-data", "#cloud-config\n"))
+	g.Expect(files).To(gomega.HaveKey("network-config"))
+
+	// The ISO is replaced when it is uploaded again.
+	iso, err := NewBootstrapISO([]byte("#cloud-config\nruncmd: []\n"), nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(UploadBootstrapISO(ctx.TODO(), session, isoPath, iso)).To(gomega.Succeed())
+	_, files = readISO(g, downloadISO(g, ds, "capv-cidata/test/seed-iso.iso"))
+	g.Expect(files).To(gomega.HaveKeyWithValue("user-data", "#cloud-config\nruncmd: []\n"))
+
+	g.Expect(DeleteBootstrapISO(ctx.TODO(), session, isoPath)).To(gomega.Succeed())
+	_, err = ds.Stat(ctx.TODO(), "capv-cidata/test/seed-iso.iso")
+	g.Expect(err).To(gomega.HaveOccurred())
+	// Deleting an ISO which does not exist is a no-op.
+	g.Expect(DeleteBootstrapISO(ctx.TODO(), session, isoPath)).To(gomega.Succeed())
+}
+
+func TestGetBootstrapISODeviceSpec(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	ide := &types.VirtualIDEController{VirtualController: types.VirtualController{VirtualDevice: types.VirtualDevice{Key: 200}}}
+	cdrom := &types.VirtualCdrom{VirtualDevice: types.VirtualDevice{Key: 3000, ControllerKey: 200, UnitNumber: ptr.To[int32](0)}}
+
+	// The ISO is inserted into the existing CD-ROM.
+	spec, err := getBootstrapISODeviceSpec(object.VirtualDeviceList{ide, cdrom}, "[ds] iso")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	deviceSpec := spec.GetVirtualDeviceConfigSpec()
+	g.Expect(deviceSpec.Operation).To(gomega.Equal(types.VirtualDeviceConfigSpecOperationEdit))
+	g.Expect(deviceSpec.Device.GetVirtualDevice().Key).To(gomega.Equal(int32(3000)))
+	g.Expect(deviceSpec.Device.GetVirtualDevice().Backing).To(gomega.Equal(&types.VirtualCdromIsoBackingInfo{
+		VirtualDeviceFileBackingInfo: types.VirtualDeviceFileBackingInfo{FileName: "[ds] iso"},
+	}))
+
+	// A CD-ROM is added if there is none.
+	spec, err = getBootstrapISODeviceSpec(object.VirtualDeviceList{ide}, "[ds] iso")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	deviceSpec = spec.GetVirtualDeviceConfigSpec()
+	g.Expect(deviceSpec.Operation).To(gomega.Equal(types.VirtualDeviceConfigSpecOperationAdd))
+	g.Expect(deviceSpec.Device.GetVirtualDevice().ControllerKey).To(gomega.Equal(int32(200)))
+
+	_, err = getBootstrapISODeviceSpec(object.VirtualDeviceList{}, "[ds] iso")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func downloadISO(g *gomega.WithT, ds *object.Datastore, path string) []byte {
+	r, _, err := ds.Download(ctx.TODO(), path, &soap.DefaultDownload)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer r.Close()
+	iso, err := io.ReadAll(r)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return iso
+}
@@ -41,6 +41,7 @@ import (
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/template"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/util"
 )
 
 const (
@@ -55,7 +56,8 @@ const (
 
 // Clone kicks off a clone operation on vCenter to create a new virtual machine. This function does not wait for
 // the virtual machine to be created on the vCenter, which can be resolved by waiting on the task reference stored
-// in VMContext.VSphereVM.Status.TaskRef.
+// in VMContext.VSphereVM.Status.TaskRef. If the VSphereVM references a content library item, the virtual machine
+// is deployed from the item instead.
 func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []byte, format bootstrapv1.Format) error {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -67,8 +69,42 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 	}
 	log.Info("Starting clone process")
 
+	extraConfig, err := getExtraConfig(ctx, vmCtx, bootstrapData, format)
+	if err != nil {
+		return err
+	}
+
+	var bootstrapISO []byte
+	if vmCtx.VSphereVM.Spec.BootstrapDataDelivery == infrav1.BootstrapDataDeliveryNoCloudISO {
+		bootstrapISO, err = getBootstrapISO(vmCtx, bootstrapData, format, &extraConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	task, err := cloneVM(ctx, vmCtx, extraConfig, bootstrapISO)
+	if err != nil {
+		return err
+	}
+
+	vmCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+
+	// patch the vsphereVM early to ensure that the task is
+	// reflected in the status right away, this avoids situations
+	// of concurrent clones
+	if err := vmCtx.Patch(ctx); err != nil {
+		log.Error(err, "Failed to patch VSphereVM (best-effort)")
+	}
+	return nil
+}
+
+// getExtraConfig returns the extra config of a new VM with the bootstrap data and the custom VMX keys.
+// The bootstrap data is not set if it is delivered with a seed ISO.
+func getExtraConfig(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []byte, format bootstrapv1.Format) (extra.Config, error) {
+	log := ctrl.LoggerFrom(ctx)
+
 	var extraConfig extra.Config
-	if len(bootstrapData) > 0 {
+	if len(bootstrapData) > 0 && vmCtx.VSphereVM.Spec.BootstrapDataDelivery != infrav1.BootstrapDataDeliveryNoCloudISO {
 		log.Info("Applied bootstrap data to VM clone spec")
 		switch format {
 		case bootstrapv1.CloudConfig:
@@ -77,22 +113,88 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 			extraConfig.SetIgnitionUserData(bootstrapData)
 		}
 	}
+	if vmCtx.VSphereVM.Spec.Sysprep != nil {
+		// The guest is customized once the addresses of the network devices are known,
+		// before the VM is powered on for the first time.
+		extraConfig.SetGuestCustomizationPending(true)
+	}
 	if vmCtx.VSphereVM.Spec.CustomVMXKeys != nil {
 		log.Info("Applied custom VMX keys to VM clone spec")
 		if err := extraConfig.SetCustomVMXKeys(vmCtx.VSphereVM.Spec.CustomVMXKeys); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	tpl, err := template.FindTemplate(ctx, vmCtx.GetSession(), vmCtx.VSphereVM.Spec.Template)
+	return extraConfig, nil
+}
+
+// getBootstrapISO renders the seed ISO of a new VM with the bootstrap data and the metadata
+// of the VM, and records the metadata in the extra config. The MAC addresses of the network
+// devices are not known yet, so the ISO is rendered again once they are.
+func getBootstrapISO(vmCtx *capvcontext.VMContext, bootstrapData []byte, format bootstrapv1.Format, extraConfig *extra.Config) ([]byte, error) {
+	if format == bootstrapv1.Ignition {
+		return nil, pkgerrors.Errorf("ignition bootstrap data can not be delivered with %s for %q", infrav1.BootstrapDataDeliveryNoCloudISO, vmCtx)
+	}
+	metadata, err := util.GetMachineMetadata(vmCtx.VSphereVM.Name, *vmCtx.VSphereVM, nil)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	iso, err := NewBootstrapISO(bootstrapData, metadata)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to render seed ISO for %q", vmCtx)
+	}
+	extraConfig.SetBootstrapISOMetadata(metadata)
+	return iso, nil
+}
+
+// attachBootstrapISO uploads the seed ISO of a new VM to the datastore, records its path in the
+// status and returns the device spec which attaches it to the VM as a CD-ROM.
+func attachBootstrapISO(ctx context.Context, vmCtx *capvcontext.VMContext, datastoreRef types.ManagedObjectReference, devices object.VirtualDeviceList, bootstrapISO []byte) (types.BaseVirtualDeviceConfigSpec, error) {
+	datastore, err := object.NewDatastore(vmCtx.Session.Client.Client, datastoreRef).ObjectName(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get name of datastore %s for %q", datastoreRef.Value, vmCtx)
+	}
+	isoPath := BootstrapISOPath(datastore, vmCtx.VSphereVM)
+	if err := UploadBootstrapISO(ctx, vmCtx.Session, isoPath, bootstrapISO); err != nil {
+		return nil, err
+	}
+	ctrl.LoggerFrom(ctx).Info("Uploaded seed ISO", "path", isoPath)
+	vmCtx.VSphereVM.Status.BootstrapISO = isoPath
+	return getBootstrapISODeviceSpec(devices, isoPath)
+}
+
+// cloneVM triggers the clone of a new VM, or its deployment from a content library item,
+// and returns the task of the operation. If a seed ISO is given, it is uploaded and attached
+// to the new VM.
+func cloneVM(ctx context.Context, vmCtx *capvcontext.VMContext, extraConfig extra.Config, bootstrapISO []byte) (*object.Task, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if vmCtx.VSphereVM.Spec.ContentLibraryItem != nil || selectsContentLibraryItem(vmCtx.VSphereVM.Spec.TemplateSelector) {
+		return deployFromContentLibrary(ctx, vmCtx, extraConfig)
+	}
+
+	// If an instant clone is requested, the VM is forked from the parent VM if it is
+	// available. Otherwise a linked clone is created from the template.
+	if vmCtx.VSphereVM.Spec.CloneMode == infrav1.InstantClone {
+		log.Info("Instant clone requested")
+		parent, devices, err := findInstantCloneParent(ctx, vmCtx)
+		if err != nil {
+			return nil, err
+		}
+		if parent != nil {
+			return instantCloneVM(ctx, vmCtx, parent, devices, extraConfig)
+		}
+	}
+
+	tpl, err := findTemplate(ctx, vmCtx)
+	if err != nil {
+		return nil, err
 	}
 
 	// If a linked clone is requested then a MoRef for a snapshot must be
 	// found with which to perform the linked clone.
 	var snapshotRef *types.ManagedObjectReference
 
-	if vmCtx.VSphereVM.Spec.CloneMode == "" || vmCtx.VSphereVM.Spec.CloneMode == infrav1.LinkedClone {
+	if vmCtx.VSphereVM.Spec.CloneMode == "" || vmCtx.VSphereVM.Spec.CloneMode == infrav1.LinkedClone || vmCtx.VSphereVM.Spec.CloneMode == infrav1.InstantClone {
 		log.Info("Linked clone requested")
 		// If the name of a snapshot was not provided then find the template's
 		// current snapshot.
@@ -100,7 +202,7 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 			log.Info("Searching for current snapshot")
 			var vm mo.VirtualMachine
 			if err := tpl.Properties(ctx, tpl.Reference(), []string{"snapshot"}, &vm); err != nil {
-				return pkgerrors.Wrapf(err, "error getting snapshot information for template %s", vmCtx.VSphereVM.Spec.Template)
+				return nil, pkgerrors.Wrapf(err, "error getting snapshot information for template %s", tpl.Reference().Value)
 			}
 			if vm.Snapshot != nil {
 				snapshotRef = vm.Snapshot.CurrentSnapshot
@@ -113,6 +215,20 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 				log.Info("Failed to find snapshot", "snapshotName", snapshotName)
 			}
 		}
+
+		// Create the snapshot if the template has none, instead of
+		// falling back to a full clone.
+		if snapshotRef == nil && vmCtx.VSphereVM.Spec.SnapshotPolicy == infrav1.LinkedCloneSnapshotPolicyCreateIfMissing {
+			snapshotName := vmCtx.VSphereVM.Spec.Snapshot
+			if snapshotName == "" {
+				snapshotName = infrav1.LinkedCloneSnapshotName
+			}
+			var err error
+			snapshotRef, err = createLinkedCloneSnapshot(ctx, tpl, snapshotName)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// The type of clone operation depends on whether there is a snapshot
@@ -129,77 +245,26 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 
 	folder, err := vmCtx.Session.Finder.FolderOrDefault(ctx, vmCtx.VSphereVM.Spec.Folder)
 	if err != nil {
-		return pkgerrors.Wrapf(err, "unable to get folder for %q", vmCtx)
+		return nil, pkgerrors.Wrapf(err, "unable to get folder for %q", vmCtx)
 	}
 
 	pool, err := vmCtx.Session.Finder.ResourcePoolOrDefault(ctx, vmCtx.VSphereVM.Spec.ResourcePool)
 	if err != nil {
-		return pkgerrors.Wrapf(err, "unable to get resource pool for %q", vmCtx)
+		return nil, pkgerrors.Wrapf(err, "unable to get resource pool for %q", vmCtx)
 	}
 
 	devices, err := tpl.Device(ctx)
 	if err != nil {
-		return pkgerrors.Wrapf(err, "error getting devices for %q", vmCtx)
-	}
-
-	// Create a new list of device specs for cloning the VM.
-	var deviceSpecs []types.BaseVirtualDeviceConfigSpec
-
-	// Only non-linked clones may expand the size of the template's disk.
-	if snapshotRef == nil {
-		diskSpecs, err := getDiskSpec(vmCtx, devices)
-		if err != nil {
-			return pkgerrors.Wrapf(err, "error getting disk spec for %q", vmCtx)
-		}
-		deviceSpecs = append(deviceSpecs, diskSpecs...)
-	}
-
-	// Process all DataDisks definitions to dynamically create and add disks to the VM
-	if len(vmCtx.VSphereVM.Spec.DataDisks) > 0 {
-		dataDisks, err := createDataDisks(ctx, vmCtx.VSphereVM.Spec.DataDisks, devices)
-		if err != nil {
-			return pkgerrors.Wrapf(err, "error getting data disks")
-		}
-		log.V(4).Info("Adding the following data disks", "disks", dataDisks)
-		deviceSpecs = append(deviceSpecs, dataDisks...)
+		return nil, pkgerrors.Wrapf(err, "error getting devices for %q", vmCtx)
 	}
 
-	networkSpecs, err := getNetworkSpecs(ctx, vmCtx, devices)
+	configSpec, err := getConfigSpec(ctx, vmCtx, devices, extraConfig, snapshotRef != nil)
 	if err != nil {
-		return pkgerrors.Wrapf(err, "error getting network specs for %q", vmCtx)
-	}
-
-	deviceSpecs = append(deviceSpecs, networkSpecs...)
-
-	numCPUs := vmCtx.VSphereVM.Spec.NumCPUs
-	if numCPUs < 2 {
-		numCPUs = 2
-	}
-	numCoresPerSocket := vmCtx.VSphereVM.Spec.NumCoresPerSocket
-
-	memMiB := vmCtx.VSphereVM.Spec.MemoryMiB
-	if memMiB == 0 {
-		memMiB = 2048
+		return nil, err
 	}
 
-	// Disable the vAppConfig during VM creation to ensure Cloud-Init inside of the guest does not
-	// activate and prefer the OVF datasource over the VMware datasource.
-	vappConfigRemoved := true
-
 	spec := types.VirtualMachineCloneSpec{
-		Config: &types.VirtualMachineConfigSpec{
-			// Assign the clone's InstanceUUID the value of the Kubernetes Machine
-			// object's UID. This allows lookup of the cloned VM prior to knowing
-			// the VM's UUID.
-			InstanceUuid:      string(vmCtx.VSphereVM.UID),
-			Flags:             newVMFlagInfo(),
-			DeviceChange:      deviceSpecs,
-			ExtraConfig:       extraConfig,
-			NumCPUs:           numCPUs,
-			NumCoresPerSocket: numCoresPerSocket,
-			MemoryMB:          memMiB,
-			VAppConfigRemoved: &vappConfigRemoved,
-		},
+		Config: configSpec,
 		Location: types.VirtualMachineRelocateSpec{
 			DiskMoveType: string(diskMoveType),
 			Folder:       types.NewReference(folder.Reference()),
@@ -213,75 +278,72 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 		Snapshot: snapshotRef,
 	}
 
-	// Set CPU reservations, limits and shares if specified
-	if !vmCtx.VSphereVM.Spec.Resources.Requests.CPU.IsZero() || !vmCtx.VSphereVM.Spec.Resources.Limits.CPU.IsZero() || vmCtx.VSphereVM.Spec.Resources.Shares.CPU > 0 {
-		cpuAllocation := types.ResourceAllocationInfo{}
-		if !vmCtx.VSphereVM.Spec.Resources.Requests.CPU.IsZero() {
-			cpuReservationMhz := convertQuantityToMhz(vmCtx.VSphereVM.Spec.Resources.Requests.CPU)
-			cpuAllocation.Reservation = ptr.To(cpuReservationMhz)
-		}
-		if !vmCtx.VSphereVM.Spec.Resources.Limits.CPU.IsZero() {
-			cpuLimitMhz := convertQuantityToMhz(vmCtx.VSphereVM.Spec.Resources.Limits.CPU)
-			cpuAllocation.Limit = ptr.To(cpuLimitMhz)
-		}
-		if vmCtx.VSphereVM.Spec.Resources.Shares.CPU > 0 {
-			cpuShares := types.SharesInfo{
-				Shares: vmCtx.VSphereVM.Spec.Resources.Shares.CPU,
-				Level:  types.SharesLevelCustom,
-			}
-			cpuAllocation.Shares = ptr.To(cpuShares)
-		}
-		spec.Config.CpuAllocation = ptr.To(cpuAllocation)
+	datastoreRef, _, err := getDatastore(ctx, vmCtx, pool, types.StoragePlacementSpec{
+		Type:      string(types.StoragePlacementSpecPlacementTypeClone),
+		Vm:        types.NewReference(tpl.Reference()),
+		CloneName: vmCtx.VSphereVM.Name,
+		CloneSpec: &spec,
+		Folder:    spec.Location.Folder,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Set memory reservations, limits and shares if specified
-	if !vmCtx.VSphereVM.Spec.Resources.Requests.Memory.IsZero() || !vmCtx.VSphereVM.Spec.Resources.Limits.Memory.IsZero() || vmCtx.VSphereVM.Spec.Resources.Shares.Memory > 0 {
-		memoryAllocation := types.ResourceAllocationInfo{}
-		if !vmCtx.VSphereVM.Spec.Resources.Requests.Memory.IsZero() {
-			memoryReservationMiB := convertQuantityToMiB(vmCtx.VSphereVM.Spec.Resources.Requests.Memory)
-			memoryAllocation.Reservation = ptr.To(memoryReservationMiB)
-		}
-		if !vmCtx.VSphereVM.Spec.Resources.Limits.Memory.IsZero() {
-			memoryLimitMiB := convertQuantityToMiB(vmCtx.VSphereVM.Spec.Resources.Limits.Memory)
-			memoryAllocation.Limit = ptr.To(memoryLimitMiB)
-		}
-		if vmCtx.VSphereVM.Spec.Resources.Shares.Memory > 0 {
-			memoryShares := types.SharesInfo{
-				Shares: vmCtx.VSphereVM.Spec.Resources.Shares.Memory,
-				Level:  types.SharesLevelCustom,
-			}
-			memoryAllocation.Shares = ptr.To(memoryShares)
+	disks := devices.SelectByType((*types.VirtualDisk)(nil))
+	isLinkedClone := snapshotRef != nil
+	spec.Location.Disk = getDiskLocators(disks, *datastoreRef, isLinkedClone)
+	spec.Location.Datastore = datastoreRef
+
+	if bootstrapISO != nil {
+		deviceSpec, err := attachBootstrapISO(ctx, vmCtx, *datastoreRef, devices, bootstrapISO)
+		if err != nil {
+			return nil, err
 		}
-		spec.Config.MemoryAllocation = ptr.To(memoryAllocation)
+		spec.Config.DeviceChange = append(spec.Config.DeviceChange, deviceSpec)
 	}
 
-	// For PCI devices, the memory for the VM needs to be reserved
-	// We can replace this once we have another way of reserving memory option
-	// exposed via the API types.
-	if len(vmCtx.VSphereVM.Spec.PciDevices) > 0 {
-		spec.Config.MemoryReservationLockedToMax = ptr.To(true)
+	log.Info(fmt.Sprintf("Cloning Machine with clone mode %s", vmCtx.VSphereVM.Status.CloneMode))
+	task, err := tpl.Clone(ctx, folder, vmCtx.VSphereVM.Name, spec)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error trigging clone op for machine %s", vmCtx)
 	}
+	return task, nil
+}
+
+// getDatastore returns the datastore on which a new VM is placed and the ID of the
+// storage policy, if one is set. The datastore is either the one from the spec, the one
+// recommended by Storage DRS in the datastore cluster from the spec, one of the datastores
+// compatible with the storage policy or the default datastore. The placement spec describes
+// the new VM to Storage DRS.
+func getDatastore(ctx context.Context, vmCtx *capvcontext.VMContext, pool *object.ResourcePool, placementSpec types.StoragePlacementSpec) (*types.ManagedObjectReference, string, error) {
+	log := ctrl.LoggerFrom(ctx)
 
 	var datastoreRef *types.ManagedObjectReference
 	if vmCtx.VSphereVM.Spec.Datastore != "" {
 		datastore, err := vmCtx.Session.Finder.Datastore(ctx, vmCtx.VSphereVM.Spec.Datastore)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to get datastore %s for %q", vmCtx.VSphereVM.Spec.Datastore, vmCtx)
+			return nil, "", pkgerrors.Wrapf(err, "unable to get datastore %s for %q", vmCtx.VSphereVM.Spec.Datastore, vmCtx)
 		}
 		datastoreRef = types.NewReference(datastore.Reference())
-		spec.Location.Datastore = datastoreRef
+	}
+	if vmCtx.VSphereVM.Spec.DatastoreCluster != "" {
+		var err error
+		datastoreRef, err = recommendDatastore(ctx, vmCtx, pool, placementSpec)
+		if err != nil {
+			return nil, "", err
+		}
 	}
 
 	var storageProfileID string
 	if vmCtx.VSphereVM.Spec.StoragePolicyName != "" {
 		pbmClient, err := pbm.NewClient(ctx, vmCtx.Session.Client.Client)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to create pbm client for %q", vmCtx)
+			return nil, "", pkgerrors.Wrapf(err, "unable to create pbm client for %q", vmCtx)
 		}
 
 		storageProfileID, err = pbmClient.ProfileIDByName(ctx, vmCtx.VSphereVM.Spec.StoragePolicyName)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to get storageProfileID from name %s for %q", vmCtx.VSphereVM.Spec.StoragePolicyName, vmCtx)
+			return nil, "", pkgerrors.Wrapf(err, "unable to get storageProfileID from name %s for %q", vmCtx.VSphereVM.Spec.StoragePolicyName, vmCtx)
 		}
 
 		var hubs []pbmTypes.PbmPlacementHub
@@ -296,12 +358,12 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 			// Otherwise we should get just the Datastores connected to our pool
 			cluster, err := pool.Owner(ctx)
 			if err != nil {
-				return pkgerrors.Wrapf(err, "failed to get owning cluster of resourcepool %q to calculate datastore based on storage policy", pool)
+				return nil, "", pkgerrors.Wrapf(err, "failed to get owning cluster of resourcepool %q to calculate datastore based on storage policy", pool)
 			}
 
 			dsList, err := object.NewComputeResource(vmCtx.Session.Client.Client, cluster.Reference()).Datastores(ctx)
 			if err != nil {
-				return pkgerrors.Wrapf(err, "unable to list datastores from owning cluster of requested resourcepool")
+				return nil, "", pkgerrors.Wrapf(err, "unable to list datastores from owning cluster of requested resourcepool")
 			}
 
 			var refs []types.ManagedObjectReference
@@ -311,7 +373,7 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 
 			var datastores []mo.Datastore
 			if err := property.DefaultCollector(vmCtx.Session.Client.Client).Retrieve(ctx, refs, []string{"summary"}, &datastores); err != nil {
-				return pkgerrors.Wrapf(err, "unable to collect datastore properties to validate maintenance mode")
+				return nil, "", pkgerrors.Wrapf(err, "unable to collect datastore properties to validate maintenance mode")
 			}
 
 			for _, ds := range datastores {
@@ -331,11 +393,11 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 		constraints = append(constraints, &pbmTypes.PbmPlacementCapabilityProfileRequirement{ProfileId: pbmTypes.PbmProfileId{UniqueId: storageProfileID}})
 		result, err := pbmClient.CheckRequirements(ctx, hubs, nil, constraints)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to check requirements for storage policy")
+			return nil, "", pkgerrors.Wrapf(err, "unable to check requirements for storage policy")
 		}
 
 		if len(result.CompatibleDatastores()) == 0 {
-			return fmt.Errorf("no compatible datastores found for storage policy: %s", vmCtx.VSphereVM.Spec.StoragePolicyName)
+			return nil, "", fmt.Errorf("no compatible datastores found for storage policy: %s", vmCtx.VSphereVM.Spec.StoragePolicyName)
 		}
 
 		// If datastoreRef is nil here it means that the user didn't specify a Datastore. So we should
@@ -354,70 +416,222 @@ func Clone(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []by
 		// if no datastore defined through VM spec or storage policy, use default
 		datastore, err := vmCtx.Session.Finder.DefaultDatastore(ctx)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to get default datastore for %q", vmCtx)
+			return nil, "", pkgerrors.Wrapf(err, "unable to get default datastore for %q", vmCtx)
 		}
 		datastoreRef = types.NewReference(datastore.Reference())
 	}
 
-	disks := devices.SelectByType((*types.VirtualDisk)(nil))
-	isLinkedClone := snapshotRef != nil
-	spec.Location.Disk = getDiskLocators(disks, *datastoreRef, isLinkedClone)
-	spec.Location.Datastore = datastoreRef
+	return datastoreRef, storageProfileID, nil
+}
+
+// recommendDatastore returns the datastore which Storage DRS recommends for the new VM in the
+// datastore cluster from the spec, and records its name in the status of the VSphereVM.
+func recommendDatastore(ctx context.Context, vmCtx *capvcontext.VMContext, pool *object.ResourcePool, placementSpec types.StoragePlacementSpec) (*types.ManagedObjectReference, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	datastoreCluster, err := vmCtx.Session.Finder.DatastoreCluster(ctx, vmCtx.VSphereVM.Spec.DatastoreCluster)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get datastore cluster %s for %q", vmCtx.VSphereVM.Spec.DatastoreCluster, vmCtx)
+	}
+
+	podRef := datastoreCluster.Reference()
+	placementSpec.ResourcePool = types.NewReference(pool.Reference())
+	placementSpec.PodSelectionSpec = types.StorageDrsPodSelectionSpec{
+		StoragePod:      &podRef,
+		InitialVmConfig: []types.VmPodConfigForPlacement{{StoragePod: podRef}},
+	}
+	result, err := object.NewStorageResourceManager(vmCtx.Session.Client.Client).RecommendDatastores(ctx, placementSpec)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get datastore recommendations from datastore cluster %s for %q", vmCtx.VSphereVM.Spec.DatastoreCluster, vmCtx)
+	}
+
+	for _, recommendation := range result.Recommendations {
+		for _, action := range recommendation.Action {
+			placementAction, ok := action.(*types.StoragePlacementAction)
+			if !ok {
+				continue
+			}
+			datastoreRef, ok := diskPlacementDatastore(placementAction)
+			if !ok {
+				continue
+			}
+			name, err := object.NewDatastore(vmCtx.Session.Client.Client, datastoreRef).ObjectName(ctx)
+			if err != nil {
+				return nil, pkgerrors.Wrapf(err, "unable to get name of datastore %s for %q", datastoreRef.Value, vmCtx)
+			}
+			log.Info("Storage DRS recommended datastore", "datastoreCluster", vmCtx.VSphereVM.Spec.DatastoreCluster, "datastore", name)
+			vmCtx.VSphereVM.Status.Datastore = name
+			return &datastoreRef, nil
+		}
+	}
+	return nil, pkgerrors.Errorf("no datastore recommended by Storage DRS for the disks of %q in datastore cluster %s", vmCtx, vmCtx.VSphereVM.Spec.DatastoreCluster)
+}
+
+// diskPlacementDatastore returns the datastore a Storage DRS placement action places the disks of
+// the new VM on. It returns false if the action places another VM, or if it spreads the disks
+// across datastores, as all disks of the new VM are placed on the same datastore.
+func diskPlacementDatastore(action *types.StoragePlacementAction) (types.ManagedObjectReference, bool) {
+	if action.Vm != nil {
+		return types.ManagedObjectReference{}, false
+	}
+
+	// Without disk locators, the disks are placed with the files of the VM.
+	datastoreRef := action.Destination
+	if action.RelocateSpec.Datastore != nil {
+		datastoreRef = *action.RelocateSpec.Datastore
+	}
+	if len(action.RelocateSpec.Disk) > 0 {
+		datastoreRef = action.RelocateSpec.Disk[0].Datastore
+	}
+	for _, disk := range action.RelocateSpec.Disk {
+		if disk.Datastore != datastoreRef {
+			return types.ManagedObjectReference{}, false
+		}
+	}
+	return datastoreRef, datastoreRef.Value != ""
+}
+
+// getConfigSpec returns the config spec which is applied to a new VM, based on the devices
+// of the template it is created from.
+func getConfigSpec(ctx context.Context, vmCtx *capvcontext.VMContext, devices object.VirtualDeviceList, extraConfig extra.Config, isLinkedClone bool) (*types.VirtualMachineConfigSpec, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Create a new list of device specs for the VM.
+	var deviceSpecs []types.BaseVirtualDeviceConfigSpec
+
+	// Only non-linked clones may expand the size of the template's disk.
+	if !isLinkedClone {
+		diskSpecs, err := getDiskSpec(vmCtx, devices)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error getting disk spec for %q", vmCtx)
+		}
+		deviceSpecs = append(deviceSpecs, diskSpecs...)
+	}
+
+	// Process all DataDisks definitions to dynamically create and add disks to the VM
+	if len(vmCtx.VSphereVM.Spec.DataDisks) > 0 {
+		dataDisks, err := createDataDisks(ctx, vmCtx.VSphereVM.Spec.DataDisks, devices)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "error getting data disks")
+		}
+		log.V(4).Info("Adding the following data disks", "disks", dataDisks)
+		deviceSpecs = append(deviceSpecs, dataDisks...)
+	}
+
+	networkSpecs, err := getNetworkSpecs(ctx, vmCtx, devices)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error getting network specs for %q", vmCtx)
+	}
+
+	deviceSpecs = append(deviceSpecs, networkSpecs...)
+
+	numCPUs := vmCtx.VSphereVM.Spec.NumCPUs
+	if numCPUs < 2 {
+		numCPUs = 2
+	}
+	numCoresPerSocket := vmCtx.VSphereVM.Spec.NumCoresPerSocket
+
+	memMiB := vmCtx.VSphereVM.Spec.MemoryMiB
+	if memMiB == 0 {
+		memMiB = 2048
+	}
+
+	// Disable the vAppConfig during VM creation to ensure Cloud-Init inside of the guest does not
+	// activate and prefer the OVF datasource over the VMware datasource.
+	vappConfigRemoved := true
+
+	configSpec := &types.VirtualMachineConfigSpec{
+		// Assign the VM's InstanceUUID the value of the Kubernetes Machine
+		// object's UID. This allows lookup of the VM prior to knowing
+		// the VM's UUID.
+		InstanceUuid:      string(vmCtx.VSphereVM.UID),
+		Flags:             newVMFlagInfo(),
+		DeviceChange:      deviceSpecs,
+		ExtraConfig:       extraConfig,
+		NumCPUs:           numCPUs,
+		NumCoresPerSocket: numCoresPerSocket,
+		MemoryMB:          memMiB,
+		VAppConfigRemoved: &vappConfigRemoved,
+	}
+
+	// Set CPU and memory reservations, limits and shares if specified
+	configSpec.CpuAllocation = CPUAllocation(vmCtx.VSphereVM.Spec.Resources)
+	configSpec.MemoryAllocation = MemoryAllocation(vmCtx.VSphereVM.Spec.Resources)
 
-	spec.Config.NestedHVEnabled = vmCtx.VSphereVM.Spec.NestedHV
+	// For PCI devices, the memory for the VM needs to be reserved
+	// We can replace this once we have another way of reserving memory option
+	// exposed via the API types.
+	if len(vmCtx.VSphereVM.Spec.PciDevices) > 0 {
+		configSpec.MemoryReservationLockedToMax = ptr.To(true)
+	}
+
+	configSpec.NestedHVEnabled = vmCtx.VSphereVM.Spec.NestedHV
 	if vmCtx.VSphereVM.Spec.FtEncryptionMode == infrav1.FtEncryptionDisabled || vmCtx.VSphereVM.Spec.FtEncryptionMode == infrav1.FtEncryptionOpportunistic || vmCtx.VSphereVM.Spec.FtEncryptionMode == infrav1.FtEncryptionRequired {
-		spec.Config.FtEncryptionMode = string(vmCtx.VSphereVM.Spec.FtEncryptionMode)
+		configSpec.FtEncryptionMode = string(vmCtx.VSphereVM.Spec.FtEncryptionMode)
 	}
 	if vmCtx.VSphereVM.Spec.MigrateEncryption == infrav1.DisabledMigrateEncryption || vmCtx.VSphereVM.Spec.MigrateEncryption == infrav1.OpportunisticMigrateEncryption || vmCtx.VSphereVM.Spec.MigrateEncryption == infrav1.RequiredMigrateEncryption {
-		spec.Config.MigrateEncryption = string(vmCtx.VSphereVM.Spec.MigrateEncryption)
+		configSpec.MigrateEncryption = string(vmCtx.VSphereVM.Spec.MigrateEncryption)
 	}
 	if vmCtx.VSphereVM.Spec.CryptoProfile != "" {
 		pbmClient, err := pbm.NewClient(ctx, vmCtx.Session.Client.Client)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to create pbm client for %q", vmCtx)
+			return nil, pkgerrors.Wrapf(err, "unable to create pbm client for %q", vmCtx)
 		}
 
 		spbmStoragePolicyID, err := pbmClient.ProfileIDByName(ctx, vmCtx.VSphereVM.Spec.CryptoProfile)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to get storageProfileID from name %s for %q", vmCtx.VSphereVM.Spec.CryptoProfile, vmCtx)
+			return nil, pkgerrors.Wrapf(err, "unable to get storageProfileID from name %s for %q", vmCtx.VSphereVM.Spec.CryptoProfile, vmCtx)
 		}
 		profileSpec := types.VirtualMachineDefinedProfileSpec{
 			ProfileId: spbmStoragePolicyID,
 		}
-		spec.Config.VmProfile = append(spec.Config.VmProfile, &profileSpec)
+		configSpec.VmProfile = append(configSpec.VmProfile, &profileSpec)
 	}
 	if vmCtx.VSphereVM.Spec.CryptoKeyID != "" {
 		kmip, err := crypto.GetManagerKmip(vmCtx.Session.Client.Client)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to create kmip client for %q", vmCtx)
+			return nil, pkgerrors.Wrapf(err, "unable to create kmip client for %q", vmCtx)
 		}
 		keyID, err := kmip.GenerateKey(ctx, vmCtx.VSphereVM.Spec.CryptoKeyID)
 		if err != nil {
-			return pkgerrors.Wrapf(err, "unable to generate a key for %q", vmCtx)
+			return nil, pkgerrors.Wrapf(err, "unable to generate a key for %q", vmCtx)
 		}
 		cryptoSpec := types.CryptoSpecEncrypt{
 			CryptoKeyId: types.CryptoKeyId{
 				KeyId: keyID,
 			},
 		}
-		spec.Config.Crypto = &cryptoSpec
+		configSpec.Crypto = &cryptoSpec
 	}
 
-	log.Info(fmt.Sprintf("Cloning Machine with clone mode %s", vmCtx.VSphereVM.Status.CloneMode))
-	task, err := tpl.Clone(ctx, folder, vmCtx.VSphereVM.Name, spec)
-	if err != nil {
-		return pkgerrors.Wrapf(err, "error trigging clone op for machine %s", vmCtx)
-	}
+	return configSpec, nil
+}
 
-	vmCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+// findTemplate finds the template from which the VM is cloned. If the template is
+// selected by the templateSelector, the instance UUID of the selected template is
+// recorded in the status, so the same template is used if the clone is retried.
+// The template of the templateRef is found by the instance UUID in the status.
+func findTemplate(ctx context.Context, vmCtx *capvcontext.VMContext) (*object.VirtualMachine, error) {
+	selector := vmCtx.VSphereVM.Spec.TemplateSelector
+	templateRef := vmCtx.VSphereVM.Spec.TemplateRef
+	if selector == nil && templateRef == nil {
+		return template.FindTemplate(ctx, vmCtx.GetSession(), vmCtx.VSphereVM.Spec.Template)
+	}
+	if templateUUID := vmCtx.VSphereVM.Status.TemplateUUID; templateUUID != "" {
+		return template.FindTemplate(ctx, vmCtx.GetSession(), templateUUID)
+	}
+	// The template of a VSphereTemplate is resolved before the VM is cloned.
+	if templateRef != nil {
+		return nil, pkgerrors.Errorf("template of VSphereTemplate %s is not resolved", templateRef.Name)
+	}
 
-	// patch the vsphereVM early to ensure that the task is
-	// reflected in the status right away, this avoids situations
-	// of concurrent clones
-	if err := vmCtx.Patch(ctx); err != nil {
-		log.Error(err, "Failed to patch VSphereVM (best-effort)")
+	tpl, templateUUID, err := template.FindTemplateBySelector(ctx, vmCtx.GetSession(), *selector)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	ctrl.LoggerFrom(ctx).Info("Selected template", "templateUUID", templateUUID)
+	vmCtx.VSphereVM.Status.TemplateUUID = templateUUID
+	return tpl, nil
 }
 
 func newVMFlagInfo() *types.VirtualMachineFlagInfo {
@@ -585,6 +799,50 @@ func createDataDisks(ctx context.Context, dataDiskDefs []infrav1.VSphereDisk, de
 	return additionalDisks, nil
 }
 
+// CPUAllocation returns the CPU reservation, limit and shares of the given resources
+// or nil if none of them is specified.
+func CPUAllocation(resources infrav1.VirtualMachineResources) *types.ResourceAllocationInfo {
+	if resources.Requests.CPU.IsZero() && resources.Limits.CPU.IsZero() && resources.Shares.CPU <= 0 {
+		return nil
+	}
+	cpuAllocation := types.ResourceAllocationInfo{}
+	if !resources.Requests.CPU.IsZero() {
+		cpuAllocation.Reservation = ptr.To(convertQuantityToMhz(resources.Requests.CPU))
+	}
+	if !resources.Limits.CPU.IsZero() {
+		cpuAllocation.Limit = ptr.To(convertQuantityToMhz(resources.Limits.CPU))
+	}
+	if resources.Shares.CPU > 0 {
+		cpuAllocation.Shares = &types.SharesInfo{
+			Shares: resources.Shares.CPU,
+			Level:  types.SharesLevelCustom,
+		}
+	}
+	return &cpuAllocation
+}
+
+// MemoryAllocation returns the memory reservation, limit and shares of the given resources
+// or nil if none of them is specified.
+func MemoryAllocation(resources infrav1.VirtualMachineResources) *types.ResourceAllocationInfo {
+	if resources.Requests.Memory.IsZero() && resources.Limits.Memory.IsZero() && resources.Shares.Memory <= 0 {
+		return nil
+	}
+	memoryAllocation := types.ResourceAllocationInfo{}
+	if !resources.Requests.Memory.IsZero() {
+		memoryAllocation.Reservation = ptr.To(convertQuantityToMiB(resources.Requests.Memory))
+	}
+	if !resources.Limits.Memory.IsZero() {
+		memoryAllocation.Limit = ptr.To(convertQuantityToMiB(resources.Limits.Memory))
+	}
+	if resources.Shares.Memory > 0 {
+		memoryAllocation.Shares = &types.SharesInfo{
+			Shares: resources.Shares.Memory,
+			Level:  types.SharesLevelCustom,
+		}
+	}
+	return &memoryAllocation
+}
+
 // convertQuantityToMhz converts a quantity to MHz, rounding up to the nearest MHz.
 func convertQuantityToMhz(quantity resource.Quantity) int64 {
 	return int64(math.Ceil(float64(quantity.Value()) / float64(1000000)))
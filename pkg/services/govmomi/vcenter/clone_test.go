@@ -26,7 +26,9 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/simulator"
 	_ "github.com/vmware/govmomi/vapi/simulator" // run init func to register the tagging API endpoints.
+	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
 	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
@@ -153,6 +155,168 @@ func TestGetDiskSpec(t *testing.T) {
 	}
 }
 
+func TestFindTemplate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	tpl, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM0")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	task, err := tpl.PowerOff(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+	g.Expect(tpl.MarkAsTemplate(ctx.TODO())).To(gomega.Succeed())
+	categoryID, err := session.TagManager.CreateCategory(ctx.TODO(), &tags.Category{Name: "k8s-version", Cardinality: "MULTIPLE"})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	tagID, err := session.TagManager.CreateTag(ctx.TODO(), &tags.Tag{Name: "v1.34.1", CategoryID: categoryID})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(session.TagManager.AttachTag(ctx.TODO(), tagID, tpl)).To(gomega.Succeed())
+
+	vmCtx := &capvcontext.VMContext{
+		Session: session,
+		VSphereVM: &infrav1.VSphereVM{
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					TemplateSelector: &infrav1.VirtualMachineTemplateSelector{
+						MatchTags: []infrav1.VirtualMachineTemplateTag{{Category: "k8s-version", Name: "v1.34.1"}},
+					},
+				},
+			},
+		},
+	}
+
+	found, err := findTemplate(ctx.TODO(), vmCtx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(found.Reference()).To(gomega.Equal(tpl.Reference()))
+	g.Expect(vmCtx.VSphereVM.Status.TemplateUUID).ToNot(gomega.BeEmpty())
+
+	// The recorded template is used even if it doesn't match the selector anymore.
+	g.Expect(session.TagManager.DetachTag(ctx.TODO(), tagID, tpl)).To(gomega.Succeed())
+	found, err = findTemplate(ctx.TODO(), vmCtx)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(found.Reference()).To(gomega.Equal(tpl.Reference()))
+}
+
+func TestGetDatastoreFromDatastoreCluster(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	dc, err := session.Finder.DefaultDatacenter(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	folders, err := dc.Folders(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	pod, err := folders.DatastoreFolder.CreateStoragePod(ctx.TODO(), "DC0_POD0")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	ds, err := session.Finder.DefaultDatastore(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	task, err := pod.MoveInto(ctx.TODO(), []types.ManagedObjectReference{ds.Reference()})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+
+	pool, err := session.Finder.DefaultResourcePool(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	vmCtx := &capvcontext.VMContext{
+		Session: session,
+		VSphereVM: &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{Name: "vm"},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+					DatastoreCluster: "DC0_POD0",
+				},
+			},
+		},
+	}
+
+	datastoreRef, _, err := getDatastore(ctx.TODO(), vmCtx, pool, types.StoragePlacementSpec{
+		Type:       string(types.StoragePlacementSpecPlacementTypeCreate),
+		ConfigSpec: &types.VirtualMachineConfigSpec{Name: "vm"},
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(*datastoreRef).To(gomega.Equal(ds.Reference()))
+	g.Expect(vmCtx.VSphereVM.Status.Datastore).To(gomega.Equal(ds.Name()))
+
+	vmCtx.VSphereVM.Spec.DatastoreCluster = "does-not-exist"
+	_, _, err = getDatastore(ctx.TODO(), vmCtx, pool, types.StoragePlacementSpec{
+		Type:       string(types.StoragePlacementSpecPlacementTypeCreate),
+		ConfigSpec: &types.VirtualMachineConfigSpec{Name: "vm"},
+	})
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestDiskPlacementDatastore(t *testing.T) {
+	ds1 := types.ManagedObjectReference{Type: "Datastore", Value: "ds-1"}
+	ds2 := types.ManagedObjectReference{Type: "Datastore", Value: "ds-2"}
+
+	tests := []struct {
+		name   string
+		action types.StoragePlacementAction
+		want   types.ManagedObjectReference
+		wantOK bool
+	}{
+		{
+			name: "disks are placed with the files of the VM",
+			action: types.StoragePlacementAction{
+				RelocateSpec: types.VirtualMachineRelocateSpec{Datastore: &ds1},
+				Destination:  ds1,
+			},
+			want:   ds1,
+			wantOK: true,
+		},
+		{
+			name: "disks are placed on another datastore than the files of the VM",
+			action: types.StoragePlacementAction{
+				RelocateSpec: types.VirtualMachineRelocateSpec{
+					Datastore: &ds1,
+					Disk: []types.VirtualMachineRelocateSpecDiskLocator{
+						{DiskId: 2000, Datastore: ds2},
+						{DiskId: 2001, Datastore: ds2},
+					},
+				},
+				Destination: ds1,
+			},
+			want:   ds2,
+			wantOK: true,
+		},
+		{
+			name: "disks are spread across datastores",
+			action: types.StoragePlacementAction{
+				RelocateSpec: types.VirtualMachineRelocateSpec{
+					Datastore: &ds1,
+					Disk: []types.VirtualMachineRelocateSpecDiskLocator{
+						{DiskId: 2000, Datastore: ds1},
+						{DiskId: 2001, Datastore: ds2},
+					},
+				},
+				Destination: ds1,
+			},
+			wantOK: false,
+		},
+		{
+			name: "another VM is placed",
+			action: types.StoragePlacementAction{
+				Vm:           &types.ManagedObjectReference{Type: "VirtualMachine", Value: "vm-1"},
+				RelocateSpec: types.VirtualMachineRelocateSpec{Datastore: &ds1},
+				Destination:  ds1,
+			},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			got, ok := diskPlacementDatastore(&tt.action)
+			g.Expect(ok).To(gomega.Equal(tt.wantOK))
+			if tt.wantOK {
+				g.Expect(got).To(gomega.Equal(tt.want))
+			}
+		})
+	}
+}
+
 func TestCreateDataDisks(t *testing.T) {
 	model, session, server := initSimulator(t)
 	t.Cleanup(model.Remove)
@@ -394,7 +558,8 @@ func initSimulator(t *testing.T) (*simulator.Model, *session.Session, *simulator
 		session.NewParams().
 			WithServer(server.URL.Host).
 			WithUserInfo(server.URL.User.Username(), pass).
-			WithDatacenter("*"))
+			WithDatacenter("*").
+			WithInsecure(true))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/library"
+	vapivcenter "github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/types"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/template"
+)
+
+// deployFromContentLibrary deploys a new virtual machine from the content library item of the
+// VSphereVM, or the item selected by its templateSelector, and kicks off the reconfiguration of the virtual machine with the VSphereVM spec.
+// The deployment itself is synchronous, the returned task is the one of the reconfiguration.
+// Since the deployment can't be tracked by a task, the ID of the item is recorded in the status
+// of the VSphereVM before it starts, so a virtual machine which was deployed but not configured
+// is configured by ConfigureDeployedVM on the next reconcile.
+func deployFromContentLibrary(ctx context.Context, vmCtx *capvcontext.VMContext, extraConfig extra.Config) (*object.Task, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if vmCtx.Session.TagManager == nil {
+		return nil, pkgerrors.Errorf("unable to deploy content library item for %q: rest client is not initialized", vmCtx)
+	}
+	item, err := getContentLibraryItem(ctx, vmCtx)
+	if err != nil {
+		return nil, err
+	}
+	var provisioningMode infrav1.ProvisioningMode
+	if itemRef := vmCtx.VSphereVM.Spec.ContentLibraryItem; itemRef != nil {
+		provisioningMode = itemRef.ProvisioningMode
+	}
+	if item.Type == library.ItemTypeVMTX && provisioningMode != "" {
+		return nil, pkgerrors.Errorf("unable to deploy content library item %q for %q: provisioningMode is not supported for VM templates", item.ID, vmCtx)
+	}
+
+	folder, err := vmCtx.Session.Finder.FolderOrDefault(ctx, vmCtx.VSphereVM.Spec.Folder)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get folder for %q", vmCtx)
+	}
+
+	pool, err := vmCtx.Session.Finder.ResourcePoolOrDefault(ctx, vmCtx.VSphereVM.Spec.ResourcePool)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get resource pool for %q", vmCtx)
+	}
+
+	datastoreRef, storageProfileID, err := getDatastore(ctx, vmCtx, pool, types.StoragePlacementSpec{
+		Type:       string(types.StoragePlacementSpecPlacementTypeCreate),
+		ConfigSpec: &types.VirtualMachineConfigSpec{Name: vmCtx.VSphereVM.Name},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	vmCtx.VSphereVM.Status.ContentLibraryItemID = item.ID
+	if err := vmCtx.Patch(ctx); err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to record deployment of content library item %q for %q", item.ID, vmCtx)
+	}
+
+	// The virtual machine is always deployed powered off, so it can be
+	// reconfigured before it boots for the first time.
+	manager := vapivcenter.NewManager(vmCtx.Session.TagManager.Client)
+	var vmRef *types.ManagedObjectReference
+	switch item.Type {
+	case library.ItemTypeOVF:
+		log.Info("Deploying OVF template from content library", "libraryItemID", item.ID)
+		vmRef, err = manager.DeployLibraryItem(ctx, item.ID, vapivcenter.Deploy{
+			DeploymentSpec: vapivcenter.DeploymentSpec{
+				Name:                vmCtx.VSphereVM.Name,
+				AcceptAllEULA:       true,
+				StorageProvisioning: storageProvisioning(provisioningMode),
+				StorageProfileID:    storageProfileID,
+				DefaultDatastoreID:  datastoreRef.Value,
+			},
+			Target: vapivcenter.Target{
+				ResourcePoolID: pool.Reference().Value,
+				FolderID:       folder.Reference().Value,
+			},
+		})
+	case library.ItemTypeVMTX:
+		log.Info("Deploying VM template from content library", "libraryItemID", item.ID)
+		storage := &vapivcenter.DiskStorage{Datastore: datastoreRef.Value}
+		if storageProfileID != "" {
+			storage.StoragePolicy = &vapivcenter.StoragePolicy{Policy: storageProfileID, Type: "USE_SPECIFIED_POLICY"}
+		}
+		vmRef, err = manager.DeployTemplateLibraryItem(ctx, item.ID, vapivcenter.DeployTemplate{
+			Name: vmCtx.VSphereVM.Name,
+			Placement: &vapivcenter.Placement{
+				ResourcePool: pool.Reference().Value,
+				Folder:       folder.Reference().Value,
+			},
+			VMHomeStorage: storage,
+			DiskStorage:   storage,
+			PoweredOn:     false,
+		})
+	default:
+		return nil, pkgerrors.Errorf("unable to deploy content library item %q for %q: unsupported item type %q", item.ID, vmCtx, item.Type)
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error deploying content library item %q for %q", item.ID, vmCtx)
+	}
+
+	// If the reconfiguration can't be triggered, the deployed virtual machine is
+	// found by its inventory path and configured on the next reconcile.
+	vm := object.NewVirtualMachine(vmCtx.Session.Client.Client, *vmRef)
+	task, err := reconfigureDeployedVM(ctx, vmCtx, vm, extraConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	vmCtx.VSphereVM.Status.CloneMode = infrav1.FullClone
+	return task, nil
+}
+
+// ConfigureDeployedVM kicks off the reconfiguration of a virtual machine which was deployed from
+// the content library item of the VSphereVM but did not get the config of the VSphereVM, e.g.
+// because the controller restarted during the deployment. This function does not wait for the
+// reconfiguration, which can be resolved by waiting on the task reference stored in
+// VMContext.VSphereVM.Status.TaskRef.
+func ConfigureDeployedVM(ctx context.Context, vmCtx *capvcontext.VMContext, vm *object.VirtualMachine, bootstrapData []byte, format bootstrapv1.Format) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	extraConfig, err := getExtraConfig(ctx, vmCtx, bootstrapData, format)
+	if err != nil {
+		return err
+	}
+	if vmCtx.VSphereVM.Spec.BootstrapDataDelivery == infrav1.BootstrapDataDeliveryNoCloudISO {
+		if _, err := getBootstrapISO(vmCtx, bootstrapData, format, &extraConfig); err != nil {
+			return err
+		}
+	}
+
+	log.Info("Configuring VM deployed from content library", "libraryItemID", vmCtx.VSphereVM.Status.ContentLibraryItemID)
+	task, err := reconfigureDeployedVM(ctx, vmCtx, vm, extraConfig)
+	if err != nil {
+		return err
+	}
+
+	vmCtx.VSphereVM.Status.CloneMode = infrav1.FullClone
+	vmCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+	return nil
+}
+
+// reconfigureDeployedVM applies the config of the VSphereVM to a virtual machine deployed from
+// a content library item, the same way it is applied to a cloned virtual machine.
+func reconfigureDeployedVM(ctx context.Context, vmCtx *capvcontext.VMContext, vm *object.VirtualMachine, extraConfig extra.Config) (*object.Task, error) {
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error getting devices for %q", vmCtx)
+	}
+
+	configSpec, err := getConfigSpec(ctx, vmCtx, devices, extraConfig, false)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := vm.Reconfigure(ctx, *configSpec)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error triggering reconfigure op for deployed VM %s", vmCtx)
+	}
+	return task, nil
+}
+
+// getContentLibraryItem returns the content library item the VM is deployed from. If the item is
+// selected by the templateSelector, its ID is recorded in the status, so the same item is used if
+// the deployment is retried.
+func getContentLibraryItem(ctx context.Context, vmCtx *capvcontext.VMContext) (*library.Item, error) {
+	manager := library.NewManager(vmCtx.Session.TagManager.Client)
+	if itemRef := vmCtx.VSphereVM.Spec.ContentLibraryItem; itemRef != nil {
+		item, err := findContentLibraryItem(ctx, manager, *itemRef)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to find content library item for %q", vmCtx)
+		}
+		return item, nil
+	}
+	if itemID := vmCtx.VSphereVM.Status.TemplateLibraryItemID; itemID != "" {
+		item, err := manager.GetLibraryItem(ctx, itemID)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to get content library item %q for %q", itemID, vmCtx)
+		}
+		return item, nil
+	}
+
+	item, err := template.FindContentLibraryItemBySelector(ctx, vmCtx.GetSession(), *vmCtx.VSphereVM.Spec.TemplateSelector)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to select content library item for %q", vmCtx)
+	}
+	ctrl.LoggerFrom(ctx).Info("Selected content library item", "libraryItemID", item.ID)
+	vmCtx.VSphereVM.Status.TemplateLibraryItemID = item.ID
+	return item, nil
+}
+
+// findContentLibraryItem finds the content library item by its name in the given library or
+// by its ID.
+func findContentLibraryItem(ctx context.Context, manager *library.Manager, itemRef infrav1.ContentLibraryItemReference) (*library.Item, error) {
+	var libraryID string
+	if itemRef.Library != "" {
+		libraryIDs, err := manager.FindLibrary(ctx, library.Find{Name: itemRef.Library})
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to find content library %q", itemRef.Library)
+		}
+		if len(libraryIDs) != 1 {
+			return nil, pkgerrors.Errorf("expected exactly one content library with name %q, found %d", itemRef.Library, len(libraryIDs))
+		}
+		libraryID = libraryIDs[0]
+
+		itemIDs, err := manager.FindLibraryItems(ctx, library.FindItem{LibraryID: libraryID, Name: itemRef.Item})
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to find item %q in content library %q", itemRef.Item, itemRef.Library)
+		}
+		switch len(itemIDs) {
+		case 0:
+			// The item may be referenced by its ID.
+		case 1:
+			return manager.GetLibraryItem(ctx, itemIDs[0])
+		default:
+			return nil, pkgerrors.Errorf("expected exactly one item with name %q in content library %q, found %d", itemRef.Item, itemRef.Library, len(itemIDs))
+		}
+	}
+
+	item, err := manager.GetLibraryItem(ctx, itemRef.Item)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get content library item %q", itemRef.Item)
+	}
+	if libraryID != "" && item.LibraryID != libraryID {
+		return nil, pkgerrors.Errorf("content library item %q is not in content library %q", itemRef.Item, itemRef.Library)
+	}
+	return item, nil
+}
+
+// selectsContentLibraryItem returns true if the template selector selects a content library item
+// instead of a template.
+func selectsContentLibraryItem(selector *infrav1.VirtualMachineTemplateSelector) bool {
+	return selector != nil && selector.ContentLibrary != ""
+}
+
+// storageProvisioning returns the OVF storage provisioning type for the given provisioning mode.
+func storageProvisioning(mode infrav1.ProvisioningMode) string {
+	switch mode {
+	case infrav1.ThinProvisioningMode:
+		return "thin"
+	case infrav1.ThickProvisioningMode:
+		return "thick"
+	case infrav1.EagerlyZeroedProvisioningMode:
+		return "eagerZeroedThick"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,257 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	ctx "context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vapi/tags"
+	vapivcenter "github.com/vmware/govmomi/vapi/vcenter"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+func TestDeployFromContentLibrary(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	libraryID, itemID := createContentLibraryTemplate(t, session)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+	vsphereVM := &infrav1.VSphereVM{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployed-vm",
+			Namespace: metav1.NamespaceDefault,
+			UID:       "f5e8d7e6-1c2b-4a5d-9e8f-7a6b5c4d3e2f",
+		},
+		Spec: infrav1.VSphereVMSpec{
+			VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+				ContentLibraryItem: &infrav1.ContentLibraryItemReference{
+					Library: "capv",
+					Item:    "ubuntu-2404",
+				},
+				NumCPUs:   4,
+				MemoryMiB: 4096,
+				Network: infrav1.NetworkSpec{
+					Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "VM Network"}},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vsphereVM).WithStatusSubresource(vsphereVM).Build()
+	patchHelper, err := patch.NewHelper(vsphereVM, c)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	vmCtx := &capvcontext.VMContext{
+		Session:     session,
+		VSphereVM:   vsphereVM,
+		PatchHelper: patchHelper,
+	}
+	g.Expect(Clone(ctx.TODO(), vmCtx, []byte("#cloud-config"), "")).To(gomega.Succeed())
+	g.Expect(vsphereVM.Status.CloneMode).To(gomega.Equal(infrav1.FullClone))
+	g.Expect(vsphereVM.Status.TaskRef).ToNot(gomega.BeEmpty())
+	g.Expect(vsphereVM.Status.ContentLibraryItemID).To(gomega.Equal(itemID))
+
+	// The simulator deploys VM templates as templates, which can't be reconfigured,
+	// so only the deployment itself is verified here.
+	vm, err := session.Finder.VirtualMachine(ctx.TODO(), "deployed-vm")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	task := object.NewTask(session.Client.Client, types.ManagedObjectReference{Type: "Task", Value: vsphereVM.Status.TaskRef})
+	var taskObj mo.Task
+	g.Expect(task.Properties(ctx.TODO(), task.Reference(), []string{"info"}, &taskObj)).To(gomega.Succeed())
+	g.Expect(taskObj.Info.Entity.Reference()).To(gomega.Equal(vm.Reference()))
+	g.Expect(taskObj.Info.DescriptionId).To(gomega.Equal("VirtualMachine.reconfigVm"))
+
+	t.Run("deployed VM is reconfigured", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		vm, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM1")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		task, err := reconfigureDeployedVM(ctx.TODO(), vmCtx, vm, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+
+		ref, err := session.FindByInstanceUUID(ctx.TODO(), string(vsphereVM.UID))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ref).ToNot(gomega.BeNil())
+		g.Expect(ref.Reference()).To(gomega.Equal(vm.Reference()))
+		var o mo.VirtualMachine
+		g.Expect(vm.Properties(ctx.TODO(), vm.Reference(), []string{"config.hardware"}, &o)).To(gomega.Succeed())
+		g.Expect(o.Config.Hardware.NumCPU).To(gomega.Equal(int32(4)))
+		g.Expect(o.Config.Hardware.MemoryMB).To(gomega.Equal(int32(4096)))
+	})
+
+	t.Run("deployed VM which was not configured is configured", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		deployedVM := vsphereVM.DeepCopy()
+		deployedVM.UID = "0b7d4c1e-6f2a-4e3b-8d9c-5a4b3c2d1e0f"
+		deployedVM.Status = infrav1.VSphereVMStatus{ContentLibraryItemID: itemID}
+		deployedCtx := &capvcontext.VMContext{
+			Session:   session,
+			VSphereVM: deployedVM,
+		}
+
+		vm, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM0")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ConfigureDeployedVM(ctx.TODO(), deployedCtx, vm, []byte("#cloud-config"), "")).To(gomega.Succeed())
+		g.Expect(deployedVM.Status.CloneMode).To(gomega.Equal(infrav1.FullClone))
+		g.Expect(deployedVM.Status.TaskRef).ToNot(gomega.BeEmpty())
+
+		task := object.NewTask(session.Client.Client, types.ManagedObjectReference{Type: "Task", Value: deployedVM.Status.TaskRef})
+		g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+		ref, err := session.FindByInstanceUUID(ctx.TODO(), string(deployedVM.UID))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ref).ToNot(gomega.BeNil())
+		g.Expect(ref.Reference()).To(gomega.Equal(vm.Reference()))
+	})
+
+	t.Run("provisioning mode is rejected for VM templates", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		rejectedVM := vsphereVM.DeepCopy()
+		rejectedVM.Name = "rejected-vm"
+		rejectedVM.Status = infrav1.VSphereVMStatus{}
+		rejectedVM.Spec.ContentLibraryItem.ProvisioningMode = infrav1.ThinProvisioningMode
+		rejectedCtx := &capvcontext.VMContext{
+			Session:   session,
+			VSphereVM: rejectedVM,
+		}
+
+		err := Clone(ctx.TODO(), rejectedCtx, []byte("#cloud-config"), "")
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("provisioningMode is not supported for VM templates")))
+		g.Expect(rejectedVM.Status.ContentLibraryItemID).To(gomega.BeEmpty())
+		_, err = session.Finder.VirtualMachine(ctx.TODO(), "rejected-vm")
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("item is selected by tags", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		categoryID, err := session.TagManager.CreateCategory(ctx.TODO(), &tags.Category{Name: "k8s-version", Cardinality: "MULTIPLE"})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		tagID, err := session.TagManager.CreateTag(ctx.TODO(), &tags.Tag{Name: "v1.34.1", CategoryID: categoryID})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(session.TagManager.AttachTag(ctx.TODO(), tagID, types.ManagedObjectReference{Type: "com.vmware.content.library.Item", Value: itemID})).To(gomega.Succeed())
+
+		selectedVM := vsphereVM.DeepCopy()
+		selectedVM.Status = infrav1.VSphereVMStatus{}
+		selectedVM.Spec.ContentLibraryItem = nil
+		selectedVM.Spec.TemplateSelector = &infrav1.VirtualMachineTemplateSelector{
+			MatchTags:      []infrav1.VirtualMachineTemplateTag{{Category: "k8s-version", Name: "v1.34.1"}},
+			ContentLibrary: "capv",
+		}
+		selectedCtx := &capvcontext.VMContext{
+			Session:   session,
+			VSphereVM: selectedVM,
+		}
+
+		item, err := getContentLibraryItem(ctx.TODO(), selectedCtx)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(item.ID).To(gomega.Equal(itemID))
+		g.Expect(selectedVM.Status.TemplateLibraryItemID).To(gomega.Equal(itemID))
+
+		// The recorded item is used if the deployment is retried, even if it no longer matches.
+		g.Expect(session.TagManager.DetachTag(ctx.TODO(), tagID, types.ManagedObjectReference{Type: "com.vmware.content.library.Item", Value: itemID})).To(gomega.Succeed())
+		item, err = getContentLibraryItem(ctx.TODO(), selectedCtx)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(item.ID).To(gomega.Equal(itemID))
+	})
+
+	t.Run("item is found by ID", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		item, err := findContentLibraryItem(ctx.TODO(), library.NewManager(session.TagManager.Client), infrav1.ContentLibraryItemReference{Item: itemID})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(item.LibraryID).To(gomega.Equal(libraryID))
+		g.Expect(item.Type).To(gomega.Equal(library.ItemTypeVMTX))
+	})
+
+	t.Run("item is not found in another library", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		manager := library.NewManager(session.TagManager.Client)
+		_, err := manager.CreateLibrary(ctx.TODO(), library.Library{Name: "other", Type: "LOCAL", Storage: contentLibraryStorage(t, session)})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		_, err = findContentLibraryItem(ctx.TODO(), manager, infrav1.ContentLibraryItemReference{Library: "other", Item: itemID})
+		g.Expect(err).To(gomega.HaveOccurred())
+		_, err = findContentLibraryItem(ctx.TODO(), manager, infrav1.ContentLibraryItemReference{Library: "other", Item: "ubuntu-2404"})
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
+
+func TestStorageProvisioning(t *testing.T) {
+	g := gomega.NewWithT(t)
+
+	g.Expect(storageProvisioning("")).To(gomega.BeEmpty())
+	g.Expect(storageProvisioning(infrav1.ThinProvisioningMode)).To(gomega.Equal("thin"))
+	g.Expect(storageProvisioning(infrav1.ThickProvisioningMode)).To(gomega.Equal("thick"))
+	g.Expect(storageProvisioning(infrav1.EagerlyZeroedProvisioningMode)).To(gomega.Equal("eagerZeroedThick"))
+}
+
+// createContentLibraryTemplate creates the content library "capv" with the VM template
+// "ubuntu-2404" and returns the IDs of the library and the item.
+func createContentLibraryTemplate(t *testing.T, session *session.Session) (string, string) {
+	t.Helper()
+	g := gomega.NewWithT(t)
+
+	libraryID, err := library.NewManager(session.TagManager.Client).CreateLibrary(ctx.TODO(), library.Library{
+		Name:    "capv",
+		Type:    "LOCAL",
+		Storage: contentLibraryStorage(t, session),
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	vm, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM0")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	pool, err := session.Finder.DefaultResourcePool(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	folder, err := session.Finder.DefaultFolder(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	itemID, err := vapivcenter.NewManager(session.TagManager.Client).CreateTemplate(ctx.TODO(), vapivcenter.Template{
+		Name:      "ubuntu-2404",
+		Library:   libraryID,
+		SourceVM:  vm.Reference().Value,
+		Placement: &vapivcenter.Placement{ResourcePool: pool.Reference().Value, Folder: folder.Reference().Value},
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return libraryID, itemID
+}
+
+func contentLibraryStorage(t *testing.T, session *session.Session) []library.StorageBacking {
+	t.Helper()
+
+	ds, err := session.Finder.DefaultDatastore(ctx.TODO())
+	gomega.NewWithT(t).Expect(err).ToNot(gomega.HaveOccurred())
+	return []library.StorageBacking{{DatastoreID: ds.Reference().Value, Type: "DATASTORE"}}
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"context"
+	"fmt"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/util"
+)
+
+// findInstantCloneParent returns the parent VM from which the VM is instant cloned and the
+// devices of the parent VM. It returns nil if the parent VM is not available or the VM can't
+// be instant cloned, in which case the VM is linked cloned from its template instead.
+func findInstantCloneParent(ctx context.Context, vmCtx *capvcontext.VMContext) (*object.VirtualMachine, object.VirtualDeviceList, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if reason := instantCloneUnsupportedReason(vmCtx.VSphereVM.Spec); reason != "" {
+		log.Info("Instant clone is not supported, falling back to linked clone", "reason", reason)
+		return nil, nil, nil
+	}
+
+	parentName := vmCtx.VSphereVM.Spec.InstantCloneParent
+	parent, err := vmCtx.Session.Finder.VirtualMachine(ctx, parentName)
+	if err != nil {
+		if _, ok := err.(*find.NotFoundError); ok {
+			log.Info("Instant clone parent VM not found, falling back to linked clone", "instantCloneParent", parentName)
+			return nil, nil, nil
+		}
+		return nil, nil, pkgerrors.Wrapf(err, "unable to find instant clone parent VM %q for %q", parentName, vmCtx)
+	}
+
+	var parentMo mo.VirtualMachine
+	if err := parent.Properties(ctx, parent.Reference(), []string{"config.hardware.device", "runtime"}, &parentMo); err != nil {
+		return nil, nil, pkgerrors.Wrapf(err, "unable to get properties of instant clone parent VM %q for %q", parentName, vmCtx)
+	}
+	if parentMo.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOn || !ptr.Deref(parentMo.Runtime.InstantCloneFrozen, false) {
+		log.Info("Instant clone parent VM is not running and frozen, falling back to linked clone",
+			"instantCloneParent", parentName, "powerState", parentMo.Runtime.PowerState, "instantCloneFrozen", ptr.Deref(parentMo.Runtime.InstantCloneFrozen, false))
+		return nil, nil, nil
+	}
+
+	var devices object.VirtualDeviceList
+	if parentMo.Config != nil {
+		devices = parentMo.Config.Hardware.Device
+	}
+	// Network devices can't be added to or removed from an instant clone, only edited.
+	if nics := devices.SelectByType((*types.VirtualEthernetCard)(nil)); len(nics) != len(vmCtx.VSphereVM.Spec.Network.Devices) {
+		log.Info("Instant clone parent VM has a different number of network devices, falling back to linked clone",
+			"instantCloneParent", parentName, "parentNetworkDevices", len(nics), "networkDevices", len(vmCtx.VSphereVM.Spec.Network.Devices))
+		return nil, nil, nil
+	}
+	return parent, devices, nil
+}
+
+// instantCloneUnsupportedReason returns why a VM with the given spec can't be instant cloned,
+// or an empty string if it can. The disks, devices and storage of an instant clone are the ones
+// of its parent VM.
+func instantCloneUnsupportedReason(spec infrav1.VSphereVMSpec) string {
+	switch {
+	case len(spec.DataDisks) > 0 || len(spec.AdditionalDisksGiB) > 0:
+		return "additional disks can't be added to an instant clone"
+	case len(spec.PciDevices) > 0:
+		return "PCI devices can't be added to an instant clone"
+	case spec.Sysprep != nil:
+		return "the guest of an instant clone can't be customized with sysprep"
+	case spec.StoragePolicyName != "":
+		return "a storage policy can't be applied to an instant clone"
+	case spec.CryptoKeyID != "" || spec.CryptoProfile != "":
+		return "an instant clone can't be encrypted"
+	}
+	return ""
+}
+
+// instantCloneVM triggers the instant clone of a new VM from the running, frozen parent VM and
+// returns the task of the operation. The new VM is running once the task is completed.
+func instantCloneVM(ctx context.Context, vmCtx *capvcontext.VMContext, parent *object.VirtualMachine, devices object.VirtualDeviceList, extraConfig extra.Config) (*object.Task, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	spec, err := getInstantCloneSpec(ctx, vmCtx, parent, devices, extraConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	vmCtx.VSphereVM.Status.CloneMode = infrav1.InstantClone
+	vmCtx.VSphereVM.Status.Snapshot = ""
+
+	log.Info(fmt.Sprintf("Cloning Machine with clone mode %s", vmCtx.VSphereVM.Status.CloneMode), "instantCloneParent", parent.Reference().Value)
+	task, err := parent.InstantClone(ctx, spec)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error trigging instant clone op for machine %s", vmCtx)
+	}
+
+	// The instance UUID can't be set in the instant clone spec, so the forked VM is reconfigured
+	// with the UID of the VSphereVM, by which it is found, like a VM claimed from a warm pool.
+	// The fork completes within seconds, as the VM shares the memory and disks of its parent.
+	taskInfo, err := task.WaitForResult(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to instant clone machine %s", vmCtx)
+	}
+	vmRef, ok := taskInfo.Result.(types.ManagedObjectReference)
+	if !ok {
+		return nil, pkgerrors.Errorf("instant clone of machine %s returned no VM", vmCtx)
+	}
+	return setInstanceUUID(ctx, vmCtx, object.NewVirtualMachine(vmCtx.Session.Client.Client, vmRef))
+}
+
+// setInstanceUUID reconfigures the instance UUID of the given VM to the UID of the VSphereVM.
+func setInstanceUUID(ctx context.Context, vmCtx *capvcontext.VMContext, vm *object.VirtualMachine) (*object.Task, error) {
+	task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+		InstanceUuid: string(vmCtx.VSphereVM.UID),
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error triggering reconfigure op for instance UUID of machine %s", vmCtx)
+	}
+	return task, nil
+}
+
+// getInstantCloneSpec returns the spec of the instant clone of the VM. The network devices of the
+// parent VM are connected to the networks of the VM and get new MAC addresses, and the bootstrap
+// data and the cloud-init metadata of the VM are injected into the guest through the extra config.
+func getInstantCloneSpec(ctx context.Context, vmCtx *capvcontext.VMContext, parent *object.VirtualMachine, devices object.VirtualDeviceList, extraConfig extra.Config) (types.VirtualMachineInstantCloneSpec, error) {
+	folder, err := vmCtx.Session.Finder.FolderOrDefault(ctx, vmCtx.VSphereVM.Spec.Folder)
+	if err != nil {
+		return types.VirtualMachineInstantCloneSpec{}, pkgerrors.Wrapf(err, "unable to get folder for %q", vmCtx)
+	}
+
+	pool, err := vmCtx.Session.Finder.ResourcePoolOrDefault(ctx, vmCtx.VSphereVM.Spec.ResourcePool)
+	if err != nil {
+		return types.VirtualMachineInstantCloneSpec{}, pkgerrors.Wrapf(err, "unable to get resource pool for %q", vmCtx)
+	}
+
+	networkSpecs, err := getInstantCloneNetworkSpecs(ctx, vmCtx, devices)
+	if err != nil {
+		return types.VirtualMachineInstantCloneSpec{}, pkgerrors.Wrapf(err, "error getting network specs for %q", vmCtx)
+	}
+
+	metadata, err := getInstantCloneMetadata(vmCtx)
+	if err != nil {
+		return types.VirtualMachineInstantCloneSpec{}, err
+	}
+	extraConfig.SetCloudInitMetadata(metadata)
+
+	spec := types.VirtualMachineInstantCloneSpec{
+		Name: vmCtx.VSphereVM.Name,
+		Location: types.VirtualMachineRelocateSpec{
+			Folder:       types.NewReference(folder.Reference()),
+			Pool:         types.NewReference(pool.Reference()),
+			DeviceChange: networkSpecs,
+		},
+		Config: extraConfig,
+	}
+
+	datastoreRef, _, err := getDatastore(ctx, vmCtx, pool, types.StoragePlacementSpec{
+		Type:      string(types.StoragePlacementSpecPlacementTypeClone),
+		Vm:        types.NewReference(parent.Reference()),
+		CloneName: vmCtx.VSphereVM.Name,
+		CloneSpec: &types.VirtualMachineCloneSpec{Location: spec.Location},
+		Folder:    spec.Location.Folder,
+	})
+	if err != nil {
+		return types.VirtualMachineInstantCloneSpec{}, err
+	}
+	spec.Location.Datastore = datastoreRef
+
+	return spec, nil
+}
+
+// getInstantCloneNetworkSpecs returns the edits of the network devices of the parent VM, which
+// connect them to the networks of the VM in order and assign them the MAC addresses of the VM, or
+// new generated ones.
+func getInstantCloneNetworkSpecs(ctx context.Context, vmCtx *capvcontext.VMContext, devices object.VirtualDeviceList) ([]types.BaseVirtualDeviceConfigSpec, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	nics := devices.SelectByType((*types.VirtualEthernetCard)(nil))
+	deviceSpecs := []types.BaseVirtualDeviceConfigSpec{}
+	for i := range vmCtx.VSphereVM.Spec.Network.Devices {
+		netSpec := &vmCtx.VSphereVM.Spec.Network.Devices[i]
+		if i >= len(nics) {
+			return nil, pkgerrors.Errorf("instant clone parent VM has no network device for network %q", netSpec.NetworkName)
+		}
+		ref, err := vmCtx.Session.Finder.Network(ctx, netSpec.NetworkName)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to find network %q", netSpec.NetworkName)
+		}
+		backing, err := ref.EthernetCardBackingInfo(ctx)
+		if err != nil {
+			return nil, pkgerrors.Wrapf(err, "unable to create new ethernet card backing info for network %q on %q", netSpec.NetworkName, vmCtx)
+		}
+
+		nic := nics[i].(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		nic.Backing = backing
+		nic.MacAddress = ""
+		nic.AddressType = string(types.VirtualEthernetCardMacTypeGenerated)
+		if netSpec.MACAddr != "" {
+			nic.MacAddress = netSpec.MACAddr
+			nic.AddressType = string(types.VirtualEthernetCardMacTypeManual)
+			log.V(4).Info("Configured manual MAC address", "macAddress", nic.MacAddress)
+		}
+
+		deviceSpecs = append(deviceSpecs, &types.VirtualDeviceConfigSpec{
+			Device:    nics[i],
+			Operation: types.VirtualDeviceConfigSpecOperationEdit,
+		})
+	}
+	return deviceSpecs, nil
+}
+
+// getInstantCloneMetadata returns the cloud-init metadata injected into the guest of an instant
+// clone. The metadata can only be rendered before the VM exists if the MAC addresses of all the
+// network devices are set and none of them gets its addresses from IP pools. Otherwise it is empty,
+// so that the metadata of the parent VM is removed, and the metadata is set as soon as the MAC
+// addresses and the IP addresses are known, like for the other clone modes.
+func getInstantCloneMetadata(vmCtx *capvcontext.VMContext) ([]byte, error) {
+	for _, device := range vmCtx.VSphereVM.Spec.Network.Devices {
+		if device.MACAddr == "" || len(device.AddressesFromPools) > 0 {
+			return nil, nil
+		}
+	}
+	metadata, err := util.GetMachineMetadata(vmCtx.VSphereVM.Name, *vmCtx.VSphereVM, nil)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get metadata for %q", vmCtx)
+	}
+	return metadata, nil
+}
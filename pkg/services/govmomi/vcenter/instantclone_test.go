@@ -0,0 +1,199 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	ctx "context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+func TestInstantClone(t *testing.T) {
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	parent, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tpl, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	task, err := tpl.CreateSnapshot(ctx.TODO(), "base", "", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.Wait(ctx.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	setFrozen := func(frozen bool) {
+		model.Map().Get(parent.Reference()).(*simulator.VirtualMachine).Runtime.InstantCloneFrozen = ptr.To(frozen)
+	}
+
+	newVMContext := func(name string) *capvcontext.VMContext {
+		return &capvcontext.VMContext{
+			Session: session,
+			VSphereVM: &infrav1.VSphereVM{
+				ObjectMeta: metav1.ObjectMeta{Name: name, UID: apitypes.UID(name)},
+				Spec: infrav1.VSphereVMSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						Template:           "DC0_C0_RP0_VM1",
+						CloneMode:          infrav1.InstantClone,
+						InstantCloneParent: "DC0_C0_RP0_VM0",
+						Network: infrav1.NetworkSpec{
+							Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "VM Network", DHCP4: ptr.To(true)}},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("parent VM is running and frozen", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		setFrozen(true)
+		t.Cleanup(func() { setFrozen(false) })
+
+		vmCtx := newVMContext("instant-clone")
+		found, devices, err := findInstantCloneParent(ctx.TODO(), vmCtx)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(found).ToNot(gomega.BeNil())
+		g.Expect(found.Reference()).To(gomega.Equal(parent.Reference()))
+
+		// The network device of the parent VM gets a new MAC address.
+		var extraConfig extra.Config
+		extraConfig.SetCloudInitUserData([]byte("#cloud-config"))
+		spec, err := getInstantCloneSpec(ctx.TODO(), vmCtx, found, devices, extraConfig)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(spec.Name).To(gomega.Equal("instant-clone"))
+		g.Expect(spec.Location.Datastore).ToNot(gomega.BeNil())
+		g.Expect(spec.Location.DeviceChange).To(gomega.HaveLen(1))
+		deviceSpec := spec.Location.DeviceChange[0].GetVirtualDeviceConfigSpec()
+		g.Expect(deviceSpec.Operation).To(gomega.Equal(types.VirtualDeviceConfigSpecOperationEdit))
+		nic := deviceSpec.Device.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		g.Expect(nic.AddressType).To(gomega.Equal(string(types.VirtualEthernetCardMacTypeGenerated)))
+		g.Expect(nic.MacAddress).To(gomega.BeEmpty())
+		// The metadata of the parent VM is removed until the MAC address is known.
+		g.Expect(optionValue(spec.Config, "guestinfo.userdata")).ToNot(gomega.BeEmpty())
+		g.Expect(spec.Config).To(gomega.ContainElement(gomega.BeEquivalentTo(&types.OptionValue{Key: "guestinfo.metadata", Value: ""})))
+
+		// The metadata is injected if the MAC address is set.
+		vmCtx.VSphereVM.Spec.Network.Devices[0].MACAddr = "00:50:56:00:00:01"
+		spec, err = getInstantCloneSpec(ctx.TODO(), vmCtx, found, devices, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		nic = spec.Location.DeviceChange[0].GetVirtualDeviceConfigSpec().Device.(types.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+		g.Expect(nic.AddressType).To(gomega.Equal(string(types.VirtualEthernetCardMacTypeManual)))
+		g.Expect(nic.MacAddress).To(gomega.Equal("00:50:56:00:00:01"))
+		g.Expect(optionValue(spec.Config, "guestinfo.metadata")).ToNot(gomega.BeEmpty())
+	})
+
+	t.Run("instant clone gets the UID of the VSphereVM as instance UUID", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		// The simulator does not fork VMs, so the reconfigure of the forked VM is run on an existing one.
+		vm, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM1")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		vmCtx := newVMContext("instant-clone-uuid")
+		vmCtx.VSphereVM.UID = "5c0d1e2f-3a4b-4c5d-8e6f-7a8b9c0d1e2f"
+
+		task, err := setInstanceUUID(ctx.TODO(), vmCtx, vm)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+
+		ref, err := session.FindByInstanceUUID(ctx.TODO(), string(vmCtx.VSphereVM.UID))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ref).ToNot(gomega.BeNil())
+		g.Expect(ref.Reference()).To(gomega.Equal(vm.Reference()))
+	})
+
+	tests := []struct {
+		name   string
+		frozen bool
+		modify func(*infrav1.VSphereVM)
+	}{
+		{
+			name:   "parent VM does not exist",
+			frozen: true,
+			modify: func(vm *infrav1.VSphereVM) { vm.Spec.InstantCloneParent = "does-not-exist" },
+		},
+		{
+			name:   "parent VM is not frozen",
+			frozen: false,
+		},
+		{
+			name:   "parent VM has a different number of network devices",
+			frozen: true,
+			modify: func(vm *infrav1.VSphereVM) {
+				vm.Spec.Network.Devices = append(vm.Spec.Network.Devices, infrav1.NetworkDeviceSpec{NetworkName: "VM Network"})
+			},
+		},
+		{
+			name:   "VM has data disks",
+			frozen: true,
+			modify: func(vm *infrav1.VSphereVM) { vm.Spec.DataDisks = []infrav1.VSphereDisk{{Name: "data", SizeGiB: 10}} },
+		},
+	}
+	for _, tc := range tests {
+		t.Run("falls back to linked clone if the "+tc.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			setFrozen(tc.frozen)
+			t.Cleanup(func() { setFrozen(false) })
+
+			vmCtx := newVMContext("linked-clone")
+			if tc.modify != nil {
+				tc.modify(vmCtx.VSphereVM)
+			}
+			found, _, err := findInstantCloneParent(ctx.TODO(), vmCtx)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(found).To(gomega.BeNil())
+
+			task, err := cloneVM(ctx.TODO(), vmCtx, nil, nil)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+			// The template has a snapshot, so the VM is a linked clone.
+			g.Expect(vmCtx.VSphereVM.Status.CloneMode).To(gomega.Equal(infrav1.LinkedClone))
+			g.Expect(vmCtx.VSphereVM.Status.Snapshot).ToNot(gomega.BeEmpty())
+
+			vm, err := session.Finder.VirtualMachine(ctx.TODO(), "linked-clone")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			task, err = vm.Destroy(ctx.TODO())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+		})
+	}
+}
+
+func optionValue(config []types.BaseOptionValue, key string) any {
+	for _, option := range config {
+		if option.GetOptionValue().Key == key {
+			return option.GetOptionValue().Value
+		}
+	}
+	return nil
+}
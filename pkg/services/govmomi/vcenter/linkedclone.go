@@ -0,0 +1,75 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"context"
+	"sync"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// linkedCloneSnapshotDescription is the description of the snapshots created for linked clones.
+const linkedCloneSnapshotDescription = "Snapshot for linked clones, created by cluster-api-provider-vsphere"
+
+// linkedCloneSnapshotMutex serializes the creation of snapshots for linked clones, so the
+// snapshot of a template is not created twice for VMs which are cloned at the same time.
+var linkedCloneSnapshotMutex sync.Mutex
+
+// createLinkedCloneSnapshot returns the snapshot with the given name of the VM, and creates it if
+// it does not exist. Snapshots of VMs which are marked as templates can't be created, and the
+// templates of users are never marked as VMs to create one, as they are shared with other users.
+// The snapshots of the replicas of templates are created before the replicas are marked as templates.
+func createLinkedCloneSnapshot(ctx context.Context, tpl *object.VirtualMachine, name string) (*types.ManagedObjectReference, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	linkedCloneSnapshotMutex.Lock()
+	defer linkedCloneSnapshotMutex.Unlock()
+
+	// The snapshot may have been created since it was looked up.
+	if snapshotRef, err := tpl.FindSnapshot(ctx, name); err == nil {
+		return snapshotRef, nil
+	}
+
+	var tplMo mo.VirtualMachine
+	if err := tpl.Properties(ctx, tpl.Reference(), []string{"config.template"}, &tplMo); err != nil {
+		return nil, pkgerrors.Wrapf(err, "error getting properties of template %s", tpl.Reference().Value)
+	}
+	if tplMo.Config != nil && tplMo.Config.Template {
+		return nil, pkgerrors.Errorf("template %s has no snapshot %s for linked clones and snapshots of VM templates can't be created: "+
+			"create the snapshot, or use template replicas of the failure domains", tpl.Reference().Value, name)
+	}
+
+	task, err := tpl.CreateSnapshot(ctx, name, linkedCloneSnapshotDescription, false, false)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error triggering creation of snapshot %s of template %s", name, tpl.Reference().Value)
+	}
+	taskInfo, err := task.WaitForResult(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to create snapshot %s of template %s", name, tpl.Reference().Value)
+	}
+	snapshotRef, ok := taskInfo.Result.(types.ManagedObjectReference)
+	if !ok {
+		return nil, pkgerrors.Errorf("unexpected result of the creation of snapshot %s of template %s: %T", name, tpl.Reference().Value, taskInfo.Result)
+	}
+	log.Info("Created snapshot of template for linked clones", "snapshotName", name, "snapshotRef", snapshotRef.Value)
+	return &snapshotRef, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	ctx "context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/vim25/mo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+)
+
+func TestLinkedCloneSnapshotPolicy(t *testing.T) {
+	tests := []struct {
+		name              string
+		snapshotPolicy    infrav1.LinkedCloneSnapshotPolicy
+		snapshot          string
+		markAsTemplate    bool
+		wantErr           bool
+		expectedCloneMode infrav1.CloneMode
+		expectedSnapshot  string
+	}{
+		{
+			name:              "falls back to a full clone if the template has no snapshot",
+			expectedCloneMode: infrav1.FullClone,
+		},
+		{
+			name:              "creates the default snapshot if the template has no snapshot",
+			snapshotPolicy:    infrav1.LinkedCloneSnapshotPolicyCreateIfMissing,
+			expectedCloneMode: infrav1.LinkedClone,
+			expectedSnapshot:  infrav1.LinkedCloneSnapshotName,
+		},
+		{
+			name:              "creates the named snapshot if the template does not have it",
+			snapshotPolicy:    infrav1.LinkedCloneSnapshotPolicyCreateIfMissing,
+			snapshot:          "golden",
+			expectedCloneMode: infrav1.LinkedClone,
+			expectedSnapshot:  "golden",
+		},
+		{
+			name:           "fails instead of changing a vSphere template without snapshot",
+			snapshotPolicy: infrav1.LinkedCloneSnapshotPolicyCreateIfMissing,
+			markAsTemplate: true,
+			wantErr:        true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			model, session, server := initSimulator(t)
+			t.Cleanup(model.Remove)
+			t.Cleanup(server.Close)
+
+			tpl, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM0")
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			task, err := tpl.PowerOff(ctx.TODO())
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+			if tc.markAsTemplate {
+				g.Expect(tpl.MarkAsTemplate(ctx.TODO())).To(gomega.Succeed())
+			}
+
+			vmCtx := &capvcontext.VMContext{
+				Session: session,
+				VSphereVM: &infrav1.VSphereVM{
+					ObjectMeta: metav1.ObjectMeta{Name: "linked-clone", UID: apitypes.UID("linked-clone")},
+					Spec: infrav1.VSphereVMSpec{
+						VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+							Template:       "DC0_C0_RP0_VM0",
+							Snapshot:       tc.snapshot,
+							SnapshotPolicy: tc.snapshotPolicy,
+							Network: infrav1.NetworkSpec{
+								Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "VM Network", DHCP4: ptr.To(true)}},
+							},
+						},
+					},
+				},
+			}
+			task, err = cloneVM(ctx.TODO(), vmCtx, nil, nil)
+			var tplMo mo.VirtualMachine
+			if tc.wantErr {
+				g.Expect(err).To(gomega.HaveOccurred())
+				// The vSphere template is left untouched.
+				g.Expect(tpl.Properties(ctx.TODO(), tpl.Reference(), []string{"snapshot", "config.template"}, &tplMo)).To(gomega.Succeed())
+				g.Expect(tplMo.Config.Template).To(gomega.BeTrue())
+				g.Expect(tplMo.Snapshot).To(gomega.BeNil())
+				return
+			}
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+			g.Expect(vmCtx.VSphereVM.Status.CloneMode).To(gomega.Equal(tc.expectedCloneMode))
+
+			g.Expect(tpl.Properties(ctx.TODO(), tpl.Reference(), []string{"snapshot", "config.template"}, &tplMo)).To(gomega.Succeed())
+			g.Expect(tplMo.Config.Template).To(gomega.Equal(tc.markAsTemplate))
+			if tc.expectedSnapshot == "" {
+				g.Expect(tplMo.Snapshot).To(gomega.BeNil())
+				return
+			}
+			snapshotRef, err := tpl.FindSnapshot(ctx.TODO(), tc.expectedSnapshot)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(vmCtx.VSphereVM.Status.Snapshot).To(gomega.Equal(snapshotRef.Value))
+
+			// The snapshot is reused by the next clones.
+			vmCtx.VSphereVM.Name = "linked-clone-2"
+			task, err = cloneVM(ctx.TODO(), vmCtx, nil, nil)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+			g.Expect(vmCtx.VSphereVM.Status.Snapshot).To(gomega.Equal(snapshotRef.Value))
+			g.Expect(tpl.Properties(ctx.TODO(), tpl.Reference(), []string{"snapshot"}, &tplMo)).To(gomega.Succeed())
+			g.Expect(tplMo.Snapshot.RootSnapshotList).To(gomega.HaveLen(1))
+		})
+	}
+}
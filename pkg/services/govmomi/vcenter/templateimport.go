@@ -0,0 +1,494 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf/importer"
+	"github.com/vmware/govmomi/vapi/library"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+// ErrChecksumMismatch is returned by ImportTemplate if the checksum of the downloaded OVA or OVF
+// does not match the expected checksum.
+var ErrChecksumMismatch = pkgerrors.New("checksum mismatch")
+
+// TemplateImport is the import of an OVA or OVF from a URL as a template.
+type TemplateImport struct {
+	// Name is the name of the template.
+	Name string
+
+	// Source is the OVA or OVF which is imported.
+	Source infrav1.VSphereTemplateSource
+
+	// Datacenter is the name or inventory path of the datacenter of the template.
+	// Defaults to the default datacenter.
+	Datacenter string
+
+	// Datastore is the name or inventory path of the datastore of the template.
+	Datastore string
+
+	// Folder is the name or inventory path of the folder of the template.
+	// Defaults to the default folder of the datacenter.
+	Folder string
+
+	// ResourcePool is the name or inventory path of the resource pool in which the template is imported.
+	// Defaults to the default resource pool of the datacenter.
+	ResourcePool string
+
+	// Network is the name or inventory path of the network to which the networks of the OVF are mapped.
+	// The networks are not mapped if it is not set.
+	Network string
+
+	// HTTPClient is the client used to download the OVA or OVF.
+	// Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// ImportTemplate imports the OVA or OVF at the URL of the source as a template, unless a template
+// with the same name which was imported from the same source already exists in the folder. The OVA
+// or the OVF descriptor is downloaded to a temporary file first and imported only if its checksum
+// matches. The files referenced by an OVF are verified against the manifest of the OVF while they
+// are imported. The imported VM is removed if its source can't be set, so it is imported again
+// instead of blocking the name. It returns the instance UUID and the inventory path of the template.
+func ImportTemplate(ctx context.Context, s *session.Session, imp TemplateImport) (string, string, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("template", imp.Name, "url", imp.Source.URL)
+
+	finder := find.NewFinder(s.Client.Client)
+	datacenter, err := finder.DatacenterOrDefault(ctx, imp.Datacenter)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get datacenter %s", imp.Datacenter)
+	}
+	finder.SetDatacenter(datacenter)
+
+	folder, err := finder.FolderOrDefault(ctx, imp.Folder)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get folder %s", imp.Folder)
+	}
+	source := templateSource(imp.Source)
+
+	// A template which was imported from the same source is adopted, e.g. after a restart of the
+	// controller while it was marked as a template.
+	existing, err := finder.VirtualMachine(ctx, path.Join(folder.InventoryPath, imp.Name))
+	if err != nil {
+		if _, ok := err.(*find.NotFoundError); !ok {
+			return "", "", pkgerrors.Wrapf(err, "unable to get VM %s", imp.Name)
+		}
+	}
+	if existing != nil {
+		var vm mo.VirtualMachine
+		if err := existing.Properties(ctx, existing.Reference(), []string{"config.template", "config.extraConfig"}, &vm); err != nil {
+			return "", "", pkgerrors.Wrapf(err, "unable to get properties of VM %s", imp.Name)
+		}
+		if vm.Config == nil || extraConfigValue(vm.Config.ExtraConfig, extra.TemplateSourceKey) != source {
+			return "", "", pkgerrors.Errorf("VM %s already exists and was not imported from %s", existing.InventoryPath, imp.Source.URL)
+		}
+		log.V(4).Info("Adopting imported template")
+		return markAsImportedTemplate(ctx, s, existing, vm.Config.Template)
+	}
+
+	datastore, err := finder.Datastore(ctx, imp.Datastore)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get datastore %s", imp.Datastore)
+	}
+	pool, err := finder.ResourcePoolOrDefault(ctx, imp.ResourcePool)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get resource pool %s", imp.ResourcePool)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "capv-template-")
+	if err != nil {
+		return "", "", pkgerrors.Wrap(err, "unable to create temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	archive, err := newTemplateArchive(ctx, imp.Source.URL, tmpDir, imp.HTTPClient)
+	if err != nil {
+		return "", "", err
+	}
+	log.Info("Downloading OVA or OVF")
+	if err := archive.download(ctx, imp.Source.Checksum); err != nil {
+		return "", "", err
+	}
+
+	ovfImporter := &importer.Importer{
+		Log: func(msg string) (int, error) {
+			log.V(5).Info(strings.TrimSpace(msg))
+			return len(msg), nil
+		},
+		Name:         imp.Name,
+		Client:       s.Client.Client,
+		Finder:       finder,
+		Datacenter:   datacenter,
+		Datastore:    datastore,
+		ResourcePool: pool,
+		Folder:       folder,
+		Archive:      archive,
+	}
+	opts := importer.Options{
+		Name:             &imp.Name,
+		DiskProvisioning: string(types.OvfCreateImportSpecParamsDiskProvisioningTypeThin),
+	}
+	if imp.Network != "" {
+		networks, err := archive.networks()
+		if err != nil {
+			return "", "", err
+		}
+		for _, network := range networks {
+			opts.NetworkMapping = append(opts.NetworkMapping, importer.Network{Name: network, Network: imp.Network})
+		}
+	}
+
+	log.Info("Importing OVA or OVF")
+	ref, err := ovfImporter.Import(ctx, archive.descriptor(), opts)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "failed to import %s", imp.Source.URL)
+	}
+	vm := object.NewVirtualMachine(s.Client.Client, *ref)
+
+	if err := setTemplateSource(ctx, vm, source); err != nil {
+		// The imported VM can't be adopted without its source, so it is removed to import it again.
+		if task, destroyErr := vm.Destroy(ctx); destroyErr != nil {
+			log.Error(destroyErr, "Failed to remove imported VM without template source")
+		} else if destroyErr := task.Wait(ctx); destroyErr != nil {
+			log.Error(destroyErr, "Failed to remove imported VM without template source")
+		}
+		return "", "", pkgerrors.Wrapf(err, "unable to set source of template %s", imp.Name)
+	}
+	return markAsImportedTemplate(ctx, s, vm, false)
+}
+
+// setTemplateSource sets the source of the imported VM, which is used to adopt it.
+func setTemplateSource(ctx context.Context, vm *object.VirtualMachine, source string) error {
+	var extraConfig extra.Config
+	extraConfig.SetTemplateSource(source)
+	task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{ExtraConfig: extraConfig})
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
+
+// markAsImportedTemplate marks the imported VM as a template, unless it is one already, and returns
+// its instance UUID and inventory path.
+func markAsImportedTemplate(ctx context.Context, s *session.Session, vm *object.VirtualMachine, isTemplate bool) (string, string, error) {
+	if !isTemplate {
+		if err := vm.MarkAsTemplate(ctx); err != nil {
+			return "", "", pkgerrors.Wrapf(err, "unable to mark VM %s as template", vm.Reference().Value)
+		}
+	}
+
+	var vmMo mo.VirtualMachine
+	if err := vm.Properties(ctx, vm.Reference(), []string{"config.instanceUuid"}, &vmMo); err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get instance UUID of template %s", vm.Reference().Value)
+	}
+	if vmMo.Config == nil || vmMo.Config.InstanceUuid == "" {
+		return "", "", pkgerrors.Errorf("template %s has no instance UUID", vm.Reference().Value)
+	}
+	inventoryPath, _, err := inventoryPath(ctx, s, vm.Reference())
+	if err != nil {
+		return "", "", err
+	}
+	return vmMo.Config.InstanceUuid, inventoryPath, nil
+}
+
+// templateSource is the value of the extra config key extra.TemplateSourceKey of a template
+// which is imported from the source.
+func templateSource(source infrav1.VSphereTemplateSource) string {
+	return string(source.Checksum.Algorithm) + ":" + strings.ToLower(source.Checksum.Value)
+}
+
+func extraConfigValue(extraConfig []types.BaseOptionValue, key string) string {
+	for _, option := range extraConfig {
+		if o := option.GetOptionValue(); o.Key == key {
+			if value, ok := o.Value.(string); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// templateArchive is an importer.Archive for an OVA or OVF at a URL. The OVA or the OVF descriptor
+// is downloaded to a local file, so its checksum is verified before it is imported and it is not
+// modified while it is imported. The files referenced by an OVF are downloaded relative to its URL
+// while they are imported and verified against the manifest of the OVF.
+type templateArchive struct {
+	// ctx is the context of the import, which is used to download the files referenced by an OVF,
+	// as importer.Archive opens files without a context.
+	ctx context.Context //nolint:containedctx
+
+	url    *url.URL
+	file   string
+	ova    bool
+	client *http.Client
+
+	// manifest are the checksums of the files referenced by an OVF, by file name.
+	manifest map[string]*library.Checksum
+}
+
+func newTemplateArchive(ctx context.Context, rawURL, dir string, client *http.Client) (*templateArchive, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "invalid URL %s", rawURL)
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	name := path.Base(u.Path)
+	if name == "/" || name == "." {
+		name = "template.ova"
+	}
+	return &templateArchive{
+		ctx:    ctx,
+		url:    u,
+		file:   filepath.Join(dir, name),
+		ova:    !strings.EqualFold(path.Ext(name), ".ovf"),
+		client: client,
+	}, nil
+}
+
+// descriptor returns the path of the OVF descriptor in the archive.
+func (a *templateArchive) descriptor() string {
+	if a.ova {
+		return "*.ovf"
+	}
+	return filepath.Base(a.file)
+}
+
+// download downloads the OVA or the OVF descriptor and verifies its checksum. The manifest of an
+// OVF is downloaded as well and has to contain the checksum of the descriptor.
+func (a *templateArchive) download(ctx context.Context, checksum infrav1.VSphereTemplateChecksum) error {
+	h, err := newTemplateHash(string(checksum.Algorithm))
+	if err != nil {
+		return err
+	}
+
+	body, _, err := a.get(ctx, a.url)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	f, err := os.Create(a.file)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to create %s", a.file)
+	}
+	defer f.Close()
+	if _, err := io.Copy(io.MultiWriter(f, h), body); err != nil {
+		return pkgerrors.Wrapf(err, "failed to download %s", a.url)
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != strings.ToLower(checksum.Value) {
+		return pkgerrors.Wrapf(ErrChecksumMismatch, "%s checksum of %s is %s, expected %s", checksum.Algorithm, a.url, actual, strings.ToLower(checksum.Value))
+	}
+
+	if a.ova {
+		return nil
+	}
+	return a.downloadManifest(ctx)
+}
+
+// downloadManifest downloads the manifest of the OVF, which is the OVF's name with the extension
+// .mf, and verifies that it contains the checksum of the downloaded descriptor. OVFs without a
+// manifest are rejected, as the files referenced by the descriptor could not be verified.
+func (a *templateArchive) downloadManifest(ctx context.Context) error {
+	name := strings.TrimSuffix(filepath.Base(a.file), filepath.Ext(a.file)) + ".mf"
+	ref, err := url.Parse(name)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "invalid manifest name %s", name)
+	}
+	body, _, err := a.get(ctx, a.url.ResolveReference(ref))
+	if err != nil {
+		return pkgerrors.Wrapf(err, "OVF %s requires a manifest", a.url)
+	}
+	defer body.Close()
+	a.manifest, err = library.ReadManifest(body)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to read manifest of %s", a.url)
+	}
+
+	descriptor, err := os.Open(a.file)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to open %s", a.file)
+	}
+	defer descriptor.Close()
+	return a.verify(filepath.Base(a.file), descriptor)
+}
+
+// verify verifies the content of the file against the checksum in the manifest of the OVF.
+func (a *templateArchive) verify(name string, r io.Reader) error {
+	h, expected, err := a.manifestHash(name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, r); err != nil {
+		return pkgerrors.Wrapf(err, "failed to read %s", name)
+	}
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return pkgerrors.Wrapf(ErrChecksumMismatch, "checksum of %s is %s, expected %s from the manifest of %s", name, actual, expected, a.url)
+	}
+	return nil
+}
+
+// manifestHash returns the hash to compute the checksum of the file and the checksum of the
+// file in the manifest of the OVF.
+func (a *templateArchive) manifestHash(name string) (hash.Hash, string, error) {
+	checksum, ok := a.manifest[name]
+	if !ok {
+		return nil, "", pkgerrors.Errorf("manifest of %s has no checksum for %s", a.url, name)
+	}
+	h, err := newTemplateHash(checksum.Algorithm)
+	if err != nil {
+		return nil, "", pkgerrors.Wrapf(err, "invalid checksum of %s in the manifest of %s", name, a.url)
+	}
+	return h, strings.ToLower(checksum.Checksum), nil
+}
+
+// newTemplateHash returns the hash of the checksum algorithm, e.g. SHA256 or sha256.
+func newTemplateHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToLower(algorithm) {
+	case strings.ToLower(string(infrav1.VSphereTemplateChecksumAlgorithmSHA256)):
+		return sha256.New(), nil
+	case strings.ToLower(string(infrav1.VSphereTemplateChecksumAlgorithmSHA512)):
+		return sha512.New(), nil
+	default:
+		return nil, pkgerrors.Errorf("unsupported checksum algorithm %q", algorithm)
+	}
+}
+
+// networks returns the names of the networks of the OVF.
+func (a *templateArchive) networks() ([]string, error) {
+	data, err := importer.ReadOvf(a.descriptor(), a)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to read OVF of %s", a.url)
+	}
+	envelope, err := importer.ReadEnvelope(data)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to parse OVF of %s", a.url)
+	}
+
+	var networks []string
+	if envelope.Network != nil {
+		for _, network := range envelope.Network.Networks {
+			networks = append(networks, network.Name)
+		}
+	}
+	return networks, nil
+}
+
+// Open implements importer.Archive. The files referenced by an OVF fail to be read to the end if
+// their checksum doesn't match the manifest.
+func (a *templateArchive) Open(name string) (io.ReadCloser, int64, error) {
+	if a.ova {
+		ova := &importer.TapeArchive{Path: a.file}
+		return ova.Open(name)
+	}
+	if name == filepath.Base(a.file) {
+		return importer.Opener{}.OpenLocal(a.file)
+	}
+	h, expected, err := a.manifestHash(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	ref, err := url.Parse(name)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrapf(err, "invalid file name %s", name)
+	}
+	body, size, err := a.get(a.ctx, a.url.ResolveReference(ref))
+	if err != nil {
+		return nil, 0, err
+	}
+	return &verifyingReader{ReadCloser: body, name: name, hash: h, expected: expected}, size, nil
+}
+
+func (a *templateArchive) get(ctx context.Context, u *url.URL) (io.ReadCloser, int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrapf(err, "invalid request for %s", u)
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, 0, pkgerrors.Wrapf(err, "failed to download %s", u)
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, 0, pkgerrors.Errorf("failed to download %s: %s", u, resp.Status)
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// verifyingReader computes the checksum of a file while it is read and returns an error instead of
+// io.EOF if the checksum doesn't match.
+type verifyingReader struct {
+	io.ReadCloser
+	name     string
+	hash     hash.Hash
+	expected string
+}
+
+// Read implements io.Reader.
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			return n, pkgerrors.Wrapf(ErrChecksumMismatch, "checksum of %s is %s, expected %s from the manifest", r.name, actual, r.expected)
+		}
+	}
+	return n, err
+}
+
+// DeleteTemplate removes the template with the instance UUID from vCenter, if it exists.
+func DeleteTemplate(ctx context.Context, s *session.Session, instanceUUID string) error {
+	ref, err := s.FindByInstanceUUID(ctx, instanceUUID)
+	if err != nil {
+		return err
+	}
+	if ref == nil {
+		return nil
+	}
+	task, err := object.NewVirtualMachine(s.Client.Client, ref.Reference()).Destroy(ctx)
+	if err != nil {
+		return pkgerrors.Wrapf(err, "unable to delete template %s", instanceUUID)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return pkgerrors.Wrapf(err, "unable to delete template %s", instanceUUID)
+	}
+	return nil
+}
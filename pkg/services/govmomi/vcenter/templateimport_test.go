@@ -0,0 +1,228 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"archive/tar"
+	"bytes"
+	ctx "context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/vim25/mo"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+)
+
+const testOVF = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:rasd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_ResourceAllocationSettingData" xmlns:vssd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData">
+  <References/>
+  <NetworkSection>
+    <Info>The list of logical networks</Info>
+    <Network ovf:name="nat">
+      <Description>The nat network</Description>
+    </Network>
+  </NetworkSection>
+  <VirtualSystem ovf:id="node">
+    <Info>A virtual machine</Info>
+    <VirtualHardwareSection>
+      <Info>Virtual hardware requirements</Info>
+      <System>
+        <vssd:ElementName>Virtual Hardware Family</vssd:ElementName>
+        <vssd:InstanceID>0</vssd:InstanceID>
+        <vssd:VirtualSystemType>vmx-13</vssd:VirtualSystemType>
+      </System>
+      <Item>
+        <rasd:AllocationUnits>hertz * 10^6</rasd:AllocationUnits>
+        <rasd:ElementName>2 virtual CPU(s)</rasd:ElementName>
+        <rasd:InstanceID>1</rasd:InstanceID>
+        <rasd:ResourceType>3</rasd:ResourceType>
+        <rasd:VirtualQuantity>2</rasd:VirtualQuantity>
+      </Item>
+      <Item>
+        <rasd:AllocationUnits>byte * 2^20</rasd:AllocationUnits>
+        <rasd:ElementName>2048MB of memory</rasd:ElementName>
+        <rasd:InstanceID>2</rasd:InstanceID>
+        <rasd:ResourceType>4</rasd:ResourceType>
+        <rasd:VirtualQuantity>2048</rasd:VirtualQuantity>
+      </Item>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+func TestImportTemplate(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	var ova bytes.Buffer
+	tw := tar.NewWriter(&ova)
+	g.Expect(tw.WriteHeader(&tar.Header{Name: "node.ovf", Mode: 0o600, Size: int64(len(testOVF))})).To(gomega.Succeed())
+	_, err := tw.Write([]byte(testOVF))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(tw.Close()).To(gomega.Succeed())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/images/node.ova", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(ova.Bytes())
+	})
+	mux.HandleFunc("/images/node.ovf", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testOVF))
+	})
+	mux.HandleFunc("/images/node.mf", func(w http.ResponseWriter, _ *http.Request) {
+		ovfSum := sha256.Sum256([]byte(testOVF))
+		diskSum := sha256.Sum256([]byte("disk"))
+		_, _ = fmt.Fprintf(w, "SHA256(node.ovf)= %x\nSHA256(disk.vmdk)= %x\n", ovfSum, diskSum)
+	})
+	mux.HandleFunc("/images/disk.vmdk", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("tampered disk"))
+	})
+	mux.HandleFunc("/images/without-manifest/node.ovf", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(testOVF))
+	})
+	imageServer := httptest.NewServer(mux)
+	t.Cleanup(imageServer.Close)
+
+	checksum := func(data []byte) infrav1.VSphereTemplateChecksum {
+		sum := sha256.Sum256(data)
+		return infrav1.VSphereTemplateChecksum{
+			Algorithm: infrav1.VSphereTemplateChecksumAlgorithmSHA256,
+			Value:     hex.EncodeToString(sum[:]),
+		}
+	}
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		_, _, err := ImportTemplate(ctx.TODO(), session, TemplateImport{
+			Name: "node-mismatch",
+			Source: infrav1.VSphereTemplateSource{
+				URL:      imageServer.URL + "/images/node.ova",
+				Checksum: checksum([]byte("something else")),
+			},
+			Datastore: "LocalDS_0",
+		})
+		g.Expect(err).To(gomega.HaveOccurred())
+		g.Expect(errors.Is(err, ErrChecksumMismatch)).To(gomega.BeTrue())
+
+		_, err = session.Finder.VirtualMachine(ctx.TODO(), "node-mismatch")
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+
+	t.Run("OVA is imported as a template", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		imp := TemplateImport{
+			Name: "node-ova",
+			Source: infrav1.VSphereTemplateSource{
+				URL:      imageServer.URL + "/images/node.ova",
+				Checksum: checksum(ova.Bytes()),
+			},
+			Datastore: "LocalDS_0",
+			Network:   "VM Network",
+		}
+		instanceUUID, inventoryPath, err := ImportTemplate(ctx.TODO(), session, imp)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(instanceUUID).ToNot(gomega.BeEmpty())
+		g.Expect(inventoryPath).To(gomega.Equal("/DC0/vm/node-ova"))
+
+		tpl, err := session.Finder.VirtualMachine(ctx.TODO(), inventoryPath)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		var tplMo mo.VirtualMachine
+		g.Expect(tpl.Properties(ctx.TODO(), tpl.Reference(), []string{"config.template", "config.instanceUuid", "config.extraConfig"}, &tplMo)).To(gomega.Succeed())
+		g.Expect(tplMo.Config.Template).To(gomega.BeTrue())
+		g.Expect(tplMo.Config.InstanceUuid).To(gomega.Equal(instanceUUID))
+		g.Expect(extraConfigValue(tplMo.Config.ExtraConfig, extra.TemplateSourceKey)).To(gomega.Equal("SHA256:" + imp.Source.Checksum.Value))
+
+		// The template is adopted instead of being imported again.
+		adoptedUUID, adoptedPath, err := ImportTemplate(ctx.TODO(), session, imp)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(adoptedUUID).To(gomega.Equal(instanceUUID))
+		g.Expect(adoptedPath).To(gomega.Equal(inventoryPath))
+
+		g.Expect(DeleteTemplate(ctx.TODO(), session, instanceUUID)).To(gomega.Succeed())
+		_, err = session.Finder.VirtualMachine(ctx.TODO(), inventoryPath)
+		g.Expect(err).To(gomega.HaveOccurred())
+		// Deleting a template which does not exist is a no-op.
+		g.Expect(DeleteTemplate(ctx.TODO(), session, instanceUUID)).To(gomega.Succeed())
+	})
+
+	t.Run("OVF is imported as a template", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		instanceUUID, inventoryPath, err := ImportTemplate(ctx.TODO(), session, TemplateImport{
+			Name: "node-ovf",
+			Source: infrav1.VSphereTemplateSource{
+				URL:      imageServer.URL + "/images/node.ovf",
+				Checksum: checksum([]byte(testOVF)),
+			},
+			Datastore: "LocalDS_0",
+		})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(instanceUUID).ToNot(gomega.BeEmpty())
+		g.Expect(inventoryPath).To(gomega.Equal("/DC0/vm/node-ovf"))
+	})
+
+	t.Run("OVF without a manifest is rejected", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		_, _, err := ImportTemplate(ctx.TODO(), session, TemplateImport{
+			Name: "node-without-manifest",
+			Source: infrav1.VSphereTemplateSource{
+				URL:      imageServer.URL + "/images/without-manifest/node.ovf",
+				Checksum: checksum([]byte(testOVF)),
+			},
+			Datastore: "LocalDS_0",
+		})
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("requires a manifest")))
+	})
+
+	t.Run("files of an OVF are verified against its manifest", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		archive, err := newTemplateArchive(ctx.TODO(), imageServer.URL+"/images/node.ovf", t.TempDir(), nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(archive.download(ctx.TODO(), checksum([]byte(testOVF)))).To(gomega.Succeed())
+
+		disk, _, err := archive.Open("disk.vmdk")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		defer disk.Close()
+		_, err = io.ReadAll(disk)
+		g.Expect(errors.Is(err, ErrChecksumMismatch)).To(gomega.BeTrue())
+
+		_, _, err = archive.Open("other.vmdk")
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("has no checksum for other.vmdk")))
+	})
+
+	t.Run("VM with the same name which was not imported from the source", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		_, _, err := ImportTemplate(ctx.TODO(), session, TemplateImport{
+			Name: "DC0_C0_RP0_VM0",
+			Source: infrav1.VSphereTemplateSource{
+				URL:      imageServer.URL + "/images/node.ova",
+				Checksum: checksum(ova.Bytes()),
+			},
+			Datastore: "LocalDS_0",
+		})
+		g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("already exists")))
+	})
+}
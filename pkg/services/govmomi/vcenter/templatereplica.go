@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/template"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+// TemplateReplica is the replica of a template on a datastore, from which the VMs on the datastore
+// are linked cloned instead of the template.
+type TemplateReplica struct {
+	// Template is the name, inventory path, managed object reference or managed object ID of the template.
+	Template string
+
+	// Datastore is the name or inventory path of the datastore of the replica.
+	Datastore string
+
+	// ResourcePool is the name or inventory path of the resource pool in which the replica is cloned.
+	// Defaults to the default resource pool.
+	ResourcePool string
+}
+
+// ReconcileTemplateReplica ensures that the template has a replica on the datastore. The replica is
+// a full clone of the template in the folder of the template, with a snapshot for linked clones,
+// and is marked as a template. If the template is already on the datastore, it is its own replica.
+// It returns the inventory path of the replica once it is ready, and the reference of the clone
+// task of the replica while it is running, which has to be passed to the next call to not clone
+// the replica twice.
+func ReconcileTemplateReplica(ctx context.Context, s *session.Session, replica TemplateReplica, pendingTaskRef string) (string, string, error) {
+	log := ctrl.LoggerFrom(ctx).WithValues("template", replica.Template, "datastore", replica.Datastore)
+
+	if pendingTaskRef != "" {
+		var task mo.Task
+		err := s.Client.RetrieveOne(ctx, types.ManagedObjectReference{Type: "Task", Value: pendingTaskRef}, []string{"info.state", "info.error"}, &task)
+		if err != nil && !fault.Is(err, &types.ManagedObjectNotFound{}) {
+			return "", pendingTaskRef, pkgerrors.Wrapf(err, "unable to get clone task %s of replica of template %s", pendingTaskRef, replica.Template)
+		}
+		if err == nil {
+			switch task.Info.State {
+			case types.TaskInfoStateQueued, types.TaskInfoStateRunning:
+				return "", pendingTaskRef, nil
+			case types.TaskInfoStateError:
+				// The replica is cloned by another reconciler, e.g. of a failure domain on the same
+				// datastore, and is found once its clone task is completed.
+				if task.Info.Error != nil {
+					if _, ok := task.Info.Error.Fault.(*types.DuplicateName); ok {
+						return "", "", nil
+					}
+				}
+				errorMessage := "unknown error"
+				if task.Info.Error != nil {
+					errorMessage = task.Info.Error.LocalizedMessage
+				}
+				return "", "", pkgerrors.Errorf("failed to clone replica of template %s: %s", replica.Template, errorMessage)
+			}
+		}
+	}
+
+	tpl, err := template.FindTemplate(ctx, s, replica.Template)
+	if err != nil {
+		return "", "", err
+	}
+	var tplMo mo.VirtualMachine
+	if err := tpl.Properties(ctx, tpl.Reference(), []string{"name", "parent", "datastore", "config.instanceUuid"}, &tplMo); err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get properties of template %s", replica.Template)
+	}
+	if tplMo.Parent == nil || tplMo.Config == nil {
+		return "", "", pkgerrors.Errorf("unable to get folder of template %s", replica.Template)
+	}
+	datastore, err := s.Finder.Datastore(ctx, replica.Datastore)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get datastore %s", replica.Datastore)
+	}
+
+	// A template which is already on the datastore is not replicated.
+	if slices.Contains(tplMo.Datastore, datastore.Reference()) {
+		return inventoryPath(ctx, s, tpl.Reference())
+	}
+
+	folder := object.NewFolder(s.Client.Client, *tplMo.Parent)
+	name := templateReplicaName(tplMo.Name, datastore.Reference())
+	replicaMo, err := findTemplateReplica(ctx, s, folder, name, tplMo.Config.InstanceUuid, datastore.Reference())
+	if err != nil {
+		return "", "", err
+	}
+
+	if replicaMo == nil {
+		pool, err := s.Finder.ResourcePoolOrDefault(ctx, replica.ResourcePool)
+		if err != nil {
+			return "", "", pkgerrors.Wrapf(err, "unable to get resource pool %s for replica of template %s", replica.ResourcePool, replica.Template)
+		}
+
+		var extraConfig extra.Config
+		extraConfig.SetTemplateReplicaSource(tplMo.Config.InstanceUuid)
+		log.Info("Cloning replica of template", "replicaName", name)
+		task, err := tpl.Clone(ctx, folder, name, types.VirtualMachineCloneSpec{
+			Config: &types.VirtualMachineConfigSpec{ExtraConfig: extraConfig},
+			Location: types.VirtualMachineRelocateSpec{
+				DiskMoveType: string(fullCloneDiskMoveType),
+				Datastore:    types.NewReference(datastore.Reference()),
+				Pool:         types.NewReference(pool.Reference()),
+			},
+			PowerOn: false,
+		})
+		if err != nil {
+			return "", "", pkgerrors.Wrapf(err, "error triggering clone op for replica of template %s", replica.Template)
+		}
+		return "", task.Reference().Value, nil
+	}
+
+	// The replica is marked as a template once it has the snapshot for linked clones.
+	replicaVM := object.NewVirtualMachine(s.Client.Client, replicaMo.Reference())
+	if replicaMo.Snapshot == nil {
+		if _, err := createLinkedCloneSnapshot(ctx, replicaVM, infrav1.LinkedCloneSnapshotName); err != nil {
+			return "", "", err
+		}
+	}
+	if !replicaMo.Config.Template {
+		if err := replicaVM.MarkAsTemplate(ctx); err != nil {
+			return "", "", pkgerrors.Wrapf(err, "unable to mark replica %s of template %s as template", replicaMo.Name, replica.Template)
+		}
+		log.Info("Replica of template is ready", "replicaName", replicaMo.Name)
+	}
+	return inventoryPath(ctx, s, replicaMo.Reference())
+}
+
+// templateReplicaName returns the name of the replica of the template on the datastore. The name
+// is unique per template and datastore, so vCenter rejects a second replica on the same datastore
+// cloned concurrently, e.g. for two failure domains.
+func templateReplicaName(templateName string, datastoreRef types.ManagedObjectReference) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write([]byte(datastoreRef.Value))
+	suffix := fmt.Sprintf("-%08x", hasher.Sum32())
+	// The names of VMs are limited to 80 characters.
+	if len(templateName) > 80-len(suffix) {
+		templateName = templateName[:80-len(suffix)]
+	}
+	return templateName + suffix
+}
+
+// findTemplateReplica returns the replica of the template with the instance UUID on the datastore
+// in the folder, or nil if there is none. An error is returned if another VM has the name of the
+// replica, as the replica can't be cloned then.
+func findTemplateReplica(ctx context.Context, s *session.Session, folder *object.Folder, name, templateUUID string, datastoreRef types.ManagedObjectReference) (*mo.VirtualMachine, error) {
+	children, err := folder.Children(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to list VMs of folder %s", folder.Reference().Value)
+	}
+
+	var refs []types.ManagedObjectReference
+	for _, child := range children {
+		if child.Reference().Type == "VirtualMachine" {
+			refs = append(refs, child.Reference())
+		}
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	var vms []mo.VirtualMachine
+	if err := s.Client.Retrieve(ctx, refs, []string{"name", "datastore", "snapshot", "config.template", "config.extraConfig"}, &vms); err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get properties of VMs of folder %s", folder.Reference().Value)
+	}
+	var conflict bool
+	for i := range vms {
+		vm := &vms[i]
+		if vm.Config == nil {
+			continue
+		}
+		isReplica := false
+		for _, option := range vm.Config.ExtraConfig {
+			if o := option.GetOptionValue(); o.Key == extra.TemplateReplicaSourceKey && o.Value == templateUUID {
+				isReplica = true
+			}
+		}
+		if isReplica && slices.Contains(vm.Datastore, datastoreRef) {
+			return vm, nil
+		}
+		if vm.Name == name {
+			conflict = true
+		}
+	}
+	if conflict {
+		return nil, pkgerrors.Errorf("VM %s in folder %s is not a replica of template %s", name, folder.Reference().Value, templateUUID)
+	}
+	return nil, nil
+}
+
+func inventoryPath(ctx context.Context, s *session.Session, ref types.ManagedObjectReference) (string, string, error) {
+	path, err := find.InventoryPath(ctx, s.Client.Client, ref)
+	if err != nil {
+		return "", "", pkgerrors.Wrapf(err, "unable to get inventory path of %s", ref.Value)
+	}
+	return path, "", nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	ctx "context"
+	"path"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func TestReconcileTemplateReplica(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	tpl, err := session.Finder.VirtualMachine(ctx.TODO(), "DC0_C0_RP0_VM0")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	task, err := tpl.PowerOff(ctx.TODO())
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+	g.Expect(tpl.MarkAsTemplate(ctx.TODO())).To(gomega.Succeed())
+
+	// Add a second datastore to the hosts of the cluster.
+	hosts, err := session.Finder.HostSystemList(ctx.TODO(), "DC0_C0/*")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	for _, host := range hosts {
+		datastoreSystem, err := host.ConfigManager().DatastoreSystem(ctx.TODO())
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		_, err = datastoreSystem.CreateLocalDatastore(ctx.TODO(), "LocalDS_1", t.TempDir())
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+	}
+
+	t.Run("template is already on the datastore", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		replicaPath, taskRef, err := ReconcileTemplateReplica(ctx.TODO(), session, TemplateReplica{Template: "DC0_C0_RP0_VM0", Datastore: "LocalDS_0"}, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(taskRef).To(gomega.BeEmpty())
+		g.Expect(replicaPath).To(gomega.Equal(tpl.InventoryPath))
+	})
+
+	t.Run("template is replicated to the datastore", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		replica := TemplateReplica{Template: "DC0_C0_RP0_VM0", Datastore: "LocalDS_1"}
+		replicaPath, taskRef, err := ReconcileTemplateReplica(ctx.TODO(), session, replica, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(replicaPath).To(gomega.BeEmpty())
+		g.Expect(taskRef).ToNot(gomega.BeEmpty())
+
+		// The replica is ready once its clone task is completed.
+		g.Eventually(func(g gomega.Gomega) {
+			replicaPath, taskRef, err = ReconcileTemplateReplica(ctx.TODO(), session, replica, taskRef)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
+			g.Expect(taskRef).To(gomega.BeEmpty())
+			g.Expect(replicaPath).ToNot(gomega.BeEmpty())
+		}).Should(gomega.Succeed())
+
+		replicaVM, err := session.Finder.VirtualMachine(ctx.TODO(), replicaPath)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(replicaVM.Reference()).ToNot(gomega.Equal(tpl.Reference()))
+		replicas, err := session.Finder.VirtualMachineList(ctx.TODO(), tpl.Name()+"-*")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(replicas).To(gomega.HaveLen(1))
+		var replicaMo mo.VirtualMachine
+		g.Expect(replicaVM.Properties(ctx.TODO(), replicaVM.Reference(), []string{"datastore", "config.template"}, &replicaMo)).To(gomega.Succeed())
+		g.Expect(replicaMo.Config.Template).To(gomega.BeTrue())
+		datastore, err := session.Finder.Datastore(ctx.TODO(), "LocalDS_1")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(replicaMo.Datastore).To(gomega.ConsistOf(datastore.Reference()))
+		_, err = replicaVM.FindSnapshot(ctx.TODO(), infrav1.LinkedCloneSnapshotName)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+
+		// A replica cloned concurrently on the same datastore, e.g. for another failure domain,
+		// is rejected by vCenter, as it has the same name, and the existing replica is used.
+		folder, err := session.Finder.Folder(ctx.TODO(), path.Dir(replicaPath))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		pool, err := session.Finder.DefaultResourcePool(ctx.TODO())
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		duplicateTask, err := tpl.Clone(ctx.TODO(), folder, path.Base(replicaPath), types.VirtualMachineCloneSpec{
+			Location: types.VirtualMachineRelocateSpec{Pool: types.NewReference(pool.Reference())},
+		})
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(duplicateTask.Wait(ctx.TODO())).ToNot(gomega.Succeed())
+		_, taskRef, err = ReconcileTemplateReplica(ctx.TODO(), session, replica, duplicateTask.Reference().Value)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(taskRef).To(gomega.BeEmpty())
+
+		// The existing replica is reused.
+		existingPath, taskRef, err := ReconcileTemplateReplica(ctx.TODO(), session, replica, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(taskRef).To(gomega.BeEmpty())
+		g.Expect(existingPath).To(gomega.Equal(replicaPath))
+	})
+
+	t.Run("template does not exist", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+		_, _, err := ReconcileTemplateReplica(ctx.TODO(), session, TemplateReplica{Template: "does-not-exist", Datastore: "LocalDS_1"}, "")
+		g.Expect(err).To(gomega.HaveOccurred())
+	})
+}
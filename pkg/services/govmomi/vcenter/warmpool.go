@@ -0,0 +1,408 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/google/uuid"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apitypes "k8s.io/apimachinery/pkg/types"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/extra"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+// WarmPool is a pool of powered-off VMs which are cloned from the spec of a VSphereMachineTemplate
+// in advance and adopted by new VSphereVMs instead of cloning them.
+type WarmPool struct {
+	// Namespace and Name are the namespace and name of the VSphereMachineTemplate.
+	Namespace string
+	Name      string
+
+	// Folder is the name or inventory path of the folder of the VMs of the pool.
+	Folder string
+
+	// Placement is the hash of the datacenter, compute and storage placement of the VMs of the
+	// pool. The VMs of a VSphereVM placed in a failure domain are in the pool of its placement.
+	Placement string
+}
+
+// NewWarmPool returns the warm pool of the VSphereMachineTemplate for the placement of the given
+// spec. The VMs of the pool are placed in the folder of the template spec, unless the warm pool
+// has its own folder.
+func NewWarmPool(template *infrav1.VSphereMachineTemplate, spec infrav1.VirtualMachineCloneSpec) WarmPool {
+	folder := template.Spec.WarmPool.Folder
+	if folder == "" {
+		folder = template.Spec.Template.Spec.Folder
+	}
+	return WarmPool{
+		Namespace: template.Namespace,
+		Name:      template.Name,
+		Folder:    folder,
+		Placement: warmPoolPlacement(spec),
+	}
+}
+
+// warmPoolPlacement returns the hash of the fields of the spec which determine where the VMs
+// are placed.
+func warmPoolPlacement(spec infrav1.VirtualMachineCloneSpec) string {
+	data, _ := json.Marshal([]string{spec.Datacenter, spec.ResourcePool, spec.Datastore, spec.DatastoreCluster, spec.StoragePolicyName})
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(data)
+	return fmt.Sprintf("%08x", hasher.Sum32())
+}
+
+// Owner returns the value of the extra config key which identifies the VMs of the pool.
+func (p WarmPool) Owner() string {
+	return fmt.Sprintf("%s/%s/%s", p.Namespace, p.Name, p.Placement)
+}
+
+// warmPoolMutexes contains a mutex per warm pool which serializes picking the VMs of the pool
+// which are adopted or removed.
+var warmPoolMutexes sync.Map
+
+// claimedWarmPoolVMs contains the references of the VMs of warm pools which are being adopted or
+// removed, so a VM is neither adopted twice nor removed while it is adopted.
+var claimedWarmPoolVMs sync.Map
+
+// claimWarmPoolVMs claims the VMs which are picked from the VMs of the warm pool which are not
+// claimed yet. It returns the claimed VMs, which have to be released with releaseWarmPoolVMs once
+// they are reconfigured or destroyed, and the number of VMs of the pool which are left. The lock
+// of the pool is only held while the VMs are picked, so the tasks of the claimed VMs are not
+// serialized.
+func claimWarmPoolVMs(ctx context.Context, s *session.Session, pool WarmPool, pick func(vms []mo.VirtualMachine) []mo.VirtualMachine) ([]mo.VirtualMachine, int32, error) {
+	mu, _ := warmPoolMutexes.LoadOrStore(s.Client.URL().Host+"/"+pool.Owner(), &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	vms, err := ListWarmPoolVMs(ctx, s, pool)
+	if err != nil {
+		return nil, 0, err
+	}
+	unclaimed := make([]mo.VirtualMachine, 0, len(vms))
+	for _, vm := range vms {
+		if _, ok := claimedWarmPoolVMs.Load(warmPoolVMKey(s, vm)); !ok {
+			unclaimed = append(unclaimed, vm)
+		}
+	}
+	claimed := pick(unclaimed)
+	for _, vm := range claimed {
+		claimedWarmPoolVMs.Store(warmPoolVMKey(s, vm), struct{}{})
+	}
+	return claimed, int32(len(unclaimed) - len(claimed)), nil
+}
+
+// releaseWarmPoolVMs releases VMs claimed with claimWarmPoolVMs.
+func releaseWarmPoolVMs(s *session.Session, vms ...mo.VirtualMachine) {
+	for _, vm := range vms {
+		claimedWarmPoolVMs.Delete(warmPoolVMKey(s, vm))
+	}
+}
+
+func warmPoolVMKey(s *session.Session, vm mo.VirtualMachine) string {
+	return s.Client.URL().Host + "/" + vm.Reference().Value
+}
+
+// ListWarmPoolVMs returns the powered-off VMs of the warm pool, sorted by their name.
+func ListWarmPoolVMs(ctx context.Context, s *session.Session, pool WarmPool) ([]mo.VirtualMachine, error) {
+	folder, err := s.Finder.FolderOrDefault(ctx, pool.Folder)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get folder %q of warm pool %s", pool.Folder, pool.Owner())
+	}
+	children, err := folder.Children(ctx)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to list VMs of warm pool %s", pool.Owner())
+	}
+
+	var refs []types.ManagedObjectReference
+	for _, child := range children {
+		if child.Reference().Type == "VirtualMachine" {
+			refs = append(refs, child.Reference())
+		}
+	}
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	var vms []mo.VirtualMachine
+	if err := s.Client.Retrieve(ctx, refs, []string{"name", "config.extraConfig", "runtime.powerState"}, &vms); err != nil {
+		return nil, pkgerrors.Wrapf(err, "unable to get properties of VMs of warm pool %s", pool.Owner())
+	}
+
+	owner := pool.Owner()
+	poolVMs := make([]mo.VirtualMachine, 0, len(vms))
+	for _, vm := range vms {
+		if vm.Config == nil || vm.Runtime.PowerState != types.VirtualMachinePowerStatePoweredOff {
+			continue
+		}
+		for _, option := range vm.Config.ExtraConfig {
+			if o := option.GetOptionValue(); o.Key == extra.WarmPoolOwnerKey && o.Value == owner {
+				poolVMs = append(poolVMs, vm)
+				break
+			}
+		}
+	}
+	sort.Slice(poolVMs, func(i, j int) bool {
+		return poolVMs[i].Name < poolVMs[j].Name
+	})
+	return poolVMs, nil
+}
+
+// ReconcileWarmPool removes surplus VMs from the warm pool and triggers the clone of missing ones.
+// It returns the number of VMs in the pool and the references of the clone tasks which are still
+// running, which have to be passed to the next call to not clone the same VMs twice.
+func ReconcileWarmPool(ctx context.Context, s *session.Session, pool WarmPool, spec infrav1.VirtualMachineCloneSpec, size int32, pendingTaskRefs []string) (int32, []string, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	pending := make([]string, 0, len(pendingTaskRefs))
+	for _, taskRef := range pendingTaskRefs {
+		var task mo.Task
+		if err := s.Client.RetrieveOne(ctx, types.ManagedObjectReference{Type: "Task", Value: taskRef}, []string{"info.state", "info.error"}, &task); err != nil {
+			if fault.Is(err, &types.ManagedObjectNotFound{}) {
+				continue
+			}
+			return 0, pendingTaskRefs, pkgerrors.Wrapf(err, "unable to get clone task %s of warm pool %s", taskRef, pool.Owner())
+		}
+		switch task.Info.State {
+		case types.TaskInfoStateQueued, types.TaskInfoStateRunning:
+			pending = append(pending, taskRef)
+		case types.TaskInfoStateError:
+			var errorMessage string
+			if task.Info.Error != nil {
+				errorMessage = task.Info.Error.LocalizedMessage
+			}
+			log.Info("Failed to clone VM of warm pool", "taskRef", taskRef, "error", errorMessage)
+		}
+	}
+
+	replicas, err := removeSurplusWarmPoolVMs(ctx, s, pool, size)
+	if err != nil {
+		return 0, pending, err
+	}
+
+	for missing := size - replicas - int32(len(pending)); missing > 0; missing-- {
+		name := fmt.Sprintf("%s-warm-%s", pool.Name, utilrand.String(5))
+		log.Info("Cloning VM for warm pool", "vmName", name)
+		task, err := cloneWarmPoolVM(ctx, s, pool, name, spec)
+		if err != nil {
+			return replicas, pending, err
+		}
+		pending = append(pending, task.Reference().Value)
+	}
+	return replicas, pending, nil
+}
+
+// removeSurplusWarmPoolVMs destroys the VMs of the warm pool which exceed its size and returns
+// the number of remaining VMs.
+func removeSurplusWarmPoolVMs(ctx context.Context, s *session.Session, pool WarmPool, size int32) (int32, error) {
+	surplus, replicas, err := claimWarmPoolVMs(ctx, s, pool, func(vms []mo.VirtualMachine) []mo.VirtualMachine {
+		if len(vms) <= int(size) {
+			return nil
+		}
+		return vms[size:]
+	})
+	if err != nil {
+		return 0, err
+	}
+	defer releaseWarmPoolVMs(s, surplus...)
+
+	for i, vm := range surplus {
+		ctrl.LoggerFrom(ctx).Info("Removing VM from warm pool", "vmName", vm.Name)
+		task, err := object.NewVirtualMachine(s.Client.Client, vm.Reference()).Destroy(ctx)
+		if err != nil {
+			return replicas + int32(len(surplus)-i), pkgerrors.Wrapf(err, "unable to destroy VM %s of warm pool %s", vm.Name, pool.Owner())
+		}
+		if err := task.Wait(ctx); err != nil {
+			return replicas + int32(len(surplus)-i), pkgerrors.Wrapf(err, "unable to destroy VM %s of warm pool %s", vm.Name, pool.Owner())
+		}
+	}
+	return replicas, nil
+}
+
+// cloneWarmPoolVM triggers the clone of a VM for the warm pool. The VM is cloned the same way as
+// the VM of a VSphereVM with the given spec, except that it is placed in the folder of the pool
+// and has no bootstrap data.
+func cloneWarmPoolVM(ctx context.Context, s *session.Session, pool WarmPool, name string, spec infrav1.VirtualMachineCloneSpec) (*object.Task, error) {
+	vmCtx := &capvcontext.VMContext{
+		Session: s,
+		VSphereVM: &infrav1.VSphereVM{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: pool.Namespace,
+				Name:      name,
+				UID:       apitypes.UID(uuid.New().String()),
+			},
+			Spec: infrav1.VSphereVMSpec{
+				VirtualMachineCloneSpec: *spec.DeepCopy(),
+			},
+		},
+	}
+	if pool.Folder != "" {
+		vmCtx.VSphereVM.Spec.Folder = pool.Folder
+	}
+	// The VMs of the pool are powered off until they are adopted, so they are linked
+	// clones instead of instant clones, which are running once they are created.
+	if vmCtx.VSphereVM.Spec.CloneMode == infrav1.InstantClone {
+		vmCtx.VSphereVM.Spec.CloneMode = infrav1.LinkedClone
+	}
+
+	var extraConfig extra.Config
+	extraConfig.SetWarmPoolOwner(pool.Owner())
+	return cloneVM(ctx, vmCtx, extraConfig, nil)
+}
+
+// AdoptWarmPoolVM adopts a VM of the warm pool for the VSphereVM instead of cloning one. The VM is
+// reconfigured with the bootstrap data, the custom VMX keys, the instance UUID and the network
+// devices of the VSphereVM, renamed and moved into the folder and resource pool of the VSphereVM.
+// Returns false if the pool has no VM left. The move can be resolved by waiting on the task
+// reference stored in VMContext.VSphereVM.Status.TaskRef.
+func AdoptWarmPoolVM(ctx context.Context, vmCtx *capvcontext.VMContext, pool WarmPool, bootstrapData []byte, format bootstrapv1.Format) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	vm, devices, err := claimWarmPoolVM(ctx, vmCtx, pool, bootstrapData, format)
+	if err != nil || vm == nil {
+		return false, err
+	}
+	log = log.WithValues("vmRef", vm.Reference().Value)
+	log.Info("Adopted VM of warm pool")
+
+	// From here on the VM is found by its instance UUID. If renaming or moving it fails,
+	// it keeps the name and the location of the warm pool.
+	task, err := vm.Rename(ctx, vmCtx.VSphereVM.Name)
+	if err != nil {
+		return true, pkgerrors.Wrapf(err, "error triggering rename op for adopted VM %s", vmCtx)
+	}
+	if err := task.Wait(ctx); err != nil {
+		return true, pkgerrors.Wrapf(err, "unable to rename adopted VM %s", vmCtx)
+	}
+
+	folder, err := vmCtx.Session.Finder.FolderOrDefault(ctx, vmCtx.VSphereVM.Spec.Folder)
+	if err != nil {
+		return true, pkgerrors.Wrapf(err, "unable to get folder for %q", vmCtx)
+	}
+	resourcePool, err := vmCtx.Session.Finder.ResourcePoolOrDefault(ctx, vmCtx.VSphereVM.Spec.ResourcePool)
+	if err != nil {
+		return true, pkgerrors.Wrapf(err, "unable to get resource pool for %q", vmCtx)
+	}
+	task, err = vm.Relocate(ctx, types.VirtualMachineRelocateSpec{
+		Folder: types.NewReference(folder.Reference()),
+		Pool:   types.NewReference(resourcePool.Reference()),
+	}, types.VirtualMachineMovePriorityDefaultPriority)
+	if err != nil {
+		return true, pkgerrors.Wrapf(err, "error triggering relocate op for adopted VM %s", vmCtx)
+	}
+
+	vmCtx.VSphereVM.Status.CloneMode = infrav1.FullClone
+	if isLinkedClone(devices) {
+		vmCtx.VSphereVM.Status.CloneMode = infrav1.LinkedClone
+	}
+	vmCtx.VSphereVM.Status.TaskRef = task.Reference().Value
+
+	// patch the vsphereVM early to ensure that the task is
+	// reflected in the status right away.
+	if err := vmCtx.Patch(ctx); err != nil {
+		log.Error(err, "Failed to patch VSphereVM (best-effort)")
+	}
+	return true, nil
+}
+
+// claimWarmPoolVM reconfigures the first VM of the warm pool for the VSphereVM, which removes it
+// from the pool. Returns nil if the pool has no VM left.
+func claimWarmPoolVM(ctx context.Context, vmCtx *capvcontext.VMContext, pool WarmPool, bootstrapData []byte, format bootstrapv1.Format) (*object.VirtualMachine, object.VirtualDeviceList, error) {
+	vms, _, err := claimWarmPoolVMs(ctx, vmCtx.Session, pool, func(vms []mo.VirtualMachine) []mo.VirtualMachine {
+		return vms[:min(1, len(vms))]
+	})
+	if err != nil || len(vms) == 0 {
+		return nil, nil, err
+	}
+	// Once the VM is reconfigured it is no longer part of the pool.
+	defer releaseWarmPoolVMs(vmCtx.Session, vms...)
+	vm := object.NewVirtualMachine(vmCtx.Session.Client.Client, vms[0].Reference())
+
+	extraConfig, err := getExtraConfig(ctx, vmCtx, bootstrapData, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	extraConfig.SetWarmPoolOwner("")
+
+	devices, err := vm.Device(ctx)
+	if err != nil {
+		return nil, nil, pkgerrors.Wrapf(err, "error getting devices of VM %s of warm pool %s", vms[0].Name, pool.Owner())
+	}
+	deviceSpecs, err := getNetworkSpecs(ctx, vmCtx, devices)
+	if err != nil {
+		return nil, nil, pkgerrors.Wrapf(err, "error getting network specs for %q", vmCtx)
+	}
+
+	// The seed ISO is uploaded to the datastore of the VM.
+	if vmCtx.VSphereVM.Spec.BootstrapDataDelivery == infrav1.BootstrapDataDeliveryNoCloudISO {
+		bootstrapISO, err := getBootstrapISO(vmCtx, bootstrapData, format, &extraConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		var vmMo mo.VirtualMachine
+		if err := vm.Properties(ctx, vm.Reference(), []string{"datastore"}, &vmMo); err != nil {
+			return nil, nil, pkgerrors.Wrapf(err, "error getting datastores of VM %s of warm pool %s", vms[0].Name, pool.Owner())
+		}
+		if len(vmMo.Datastore) == 0 {
+			return nil, nil, pkgerrors.Errorf("VM %s of warm pool %s has no datastore", vms[0].Name, pool.Owner())
+		}
+		deviceSpec, err := attachBootstrapISO(ctx, vmCtx, vmMo.Datastore[0], devices, bootstrapISO)
+		if err != nil {
+			return nil, nil, err
+		}
+		deviceSpecs = append(deviceSpecs, deviceSpec)
+	}
+
+	task, err := vm.Reconfigure(ctx, types.VirtualMachineConfigSpec{
+		InstanceUuid: string(vmCtx.VSphereVM.UID),
+		ExtraConfig:  extraConfig,
+		DeviceChange: deviceSpecs,
+	})
+	if err != nil {
+		return nil, nil, pkgerrors.Wrapf(err, "error triggering reconfigure op for VM %s of warm pool %s", vms[0].Name, pool.Owner())
+	}
+	if err := task.Wait(ctx); err != nil {
+		return nil, nil, pkgerrors.Wrapf(err, "unable to reconfigure VM %s of warm pool %s", vms[0].Name, pool.Owner())
+	}
+	return vm, devices, nil
+}
+
+// isLinkedClone returns true if the first disk of the VM is backed by a child disk.
+func isLinkedClone(devices object.VirtualDeviceList) bool {
+	disks := devices.SelectByType((*types.VirtualDisk)(nil))
+	if len(disks) == 0 {
+		return false
+	}
+	backing, ok := disks[0].(*types.VirtualDisk).Backing.(*types.VirtualDiskFlatVer2BackingInfo)
+	return ok && backing.Parent != nil
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vcenter
+
+import (
+	ctx "context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	"github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/session"
+)
+
+func TestWarmPool(t *testing.T) {
+	g := gomega.NewWithT(t)
+	model, session, server := initSimulator(t)
+	t.Cleanup(model.Remove)
+	t.Cleanup(server.Close)
+
+	vmFolder, err := session.Finder.Folder(ctx.TODO(), "/DC0/vm")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	_, err = vmFolder.CreateFolder(ctx.TODO(), "warm-pool")
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+
+	spec := infrav1.VirtualMachineCloneSpec{
+		Template:  "DC0_C0_RP0_VM0",
+		CloneMode: infrav1.FullClone,
+		NumCPUs:   2,
+		MemoryMiB: 2048,
+		Network: infrav1.NetworkSpec{
+			Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "VM Network"}},
+		},
+	}
+
+	template := &infrav1.VSphereMachineTemplate{ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "md-0"}}
+	template.Spec.WarmPool.Folder = "/DC0/vm/warm-pool"
+	pool := NewWarmPool(template, spec)
+
+	waitForTasks := func(g *gomega.WithT, taskRefs []string) {
+		for _, taskRef := range taskRefs {
+			task := object.NewTask(session.Client.Client, types.ManagedObjectReference{Type: "Task", Value: taskRef})
+			g.Expect(task.Wait(ctx.TODO())).To(gomega.Succeed())
+		}
+	}
+
+	// The missing VMs are cloned.
+	replicas, pending, err := ReconcileWarmPool(ctx.TODO(), session, pool, spec, 2, nil)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(replicas).To(gomega.BeZero())
+	g.Expect(pending).To(gomega.HaveLen(2))
+	waitForTasks(g, pending)
+
+	replicas, pending, err = ReconcileWarmPool(ctx.TODO(), session, pool, spec, 2, pending)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(replicas).To(gomega.Equal(int32(2)))
+	g.Expect(pending).To(gomega.BeEmpty())
+
+	// VMs of other pools are ignored.
+	otherVMs, err := ListWarmPoolVMs(ctx.TODO(), session, WarmPool{Namespace: metav1.NamespaceDefault, Name: "md-1", Folder: pool.Folder, Placement: pool.Placement})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(otherVMs).To(gomega.BeEmpty())
+
+	// VMs with another placement, e.g. of another failure domain, are ignored.
+	otherSpec := *spec.DeepCopy()
+	otherSpec.ResourcePool = "/DC0/host/DC0_C0/Resources/zone-b"
+	otherVMs, err = ListWarmPoolVMs(ctx.TODO(), session, NewWarmPool(template, otherSpec))
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	g.Expect(otherVMs).To(gomega.BeEmpty())
+
+	t.Run("claimed VMs are neither claimed again nor removed", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		first := func(vms []mo.VirtualMachine) []mo.VirtualMachine { return vms[:min(1, len(vms))] }
+		claimed, left, err := claimWarmPoolVMs(ctx.TODO(), session, pool, first)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(claimed).To(gomega.HaveLen(1))
+		g.Expect(left).To(gomega.Equal(int32(1)))
+
+		otherClaimed, left, err := claimWarmPoolVMs(ctx.TODO(), session, pool, first)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(otherClaimed).To(gomega.HaveLen(1))
+		g.Expect(otherClaimed[0].Reference()).ToNot(gomega.Equal(claimed[0].Reference()))
+		g.Expect(left).To(gomega.BeZero())
+		releaseWarmPoolVMs(session, otherClaimed...)
+
+		// The claimed VM is not counted and not removed.
+		replicas, _, err := ReconcileWarmPool(ctx.TODO(), session, pool, spec, 1, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(replicas).To(gomega.Equal(int32(1)))
+		releaseWarmPoolVMs(session, claimed...)
+
+		poolVMs, err := ListWarmPoolVMs(ctx.TODO(), session, pool)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(poolVMs).To(gomega.HaveLen(2))
+	})
+
+	t.Run("VM of the pool is adopted", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		poolVMs, err := ListWarmPoolVMs(ctx.TODO(), session, pool)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(poolVMs).To(gomega.HaveLen(2))
+
+		vmCtx := newWarmPoolVMContext(g, session, "adopted-vm", spec)
+		adopted, err := AdoptWarmPoolVM(ctx.TODO(), vmCtx, pool, []byte("#cloud-config"), bootstrapv1.CloudConfig)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(adopted).To(gomega.BeTrue())
+		g.Expect(vmCtx.VSphereVM.Status.CloneMode).To(gomega.Equal(infrav1.FullClone))
+		waitForTasks(g, []string{vmCtx.VSphereVM.Status.TaskRef})
+
+		ref, err := session.FindByInstanceUUID(ctx.TODO(), string(vmCtx.VSphereVM.UID))
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(ref).ToNot(gomega.BeNil())
+		g.Expect(ref.Reference()).To(gomega.Equal(poolVMs[0].Reference()))
+
+		var vm mo.VirtualMachine
+		g.Expect(session.Client.RetrieveOne(ctx.TODO(), ref.Reference(), []string{"name", "parent", "config.extraConfig"}, &vm)).To(gomega.Succeed())
+		g.Expect(vm.Name).To(gomega.Equal("adopted-vm"))
+		g.Expect(*vm.Parent).To(gomega.Equal(vmFolder.Reference()))
+		var keys []string
+		for _, option := range vm.Config.ExtraConfig {
+			keys = append(keys, option.GetOptionValue().Key)
+		}
+		g.Expect(keys).To(gomega.ContainElement("guestinfo.userdata"))
+		g.Expect(keys).ToNot(gomega.ContainElement("capv.warmpool.owner"))
+
+		poolVMs, err = ListWarmPoolVMs(ctx.TODO(), session, pool)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(poolVMs).To(gomega.HaveLen(1))
+	})
+
+	t.Run("surplus VMs are removed", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		replicas, pending, err := ReconcileWarmPool(ctx.TODO(), session, pool, spec, 0, nil)
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(replicas).To(gomega.BeZero())
+		g.Expect(pending).To(gomega.BeEmpty())
+	})
+
+	t.Run("nothing is adopted from an empty pool", func(t *testing.T) {
+		g := gomega.NewWithT(t)
+
+		vmCtx := newWarmPoolVMContext(g, session, "cloned-vm", spec)
+		adopted, err := AdoptWarmPoolVM(ctx.TODO(), vmCtx, pool, nil, "")
+		g.Expect(err).ToNot(gomega.HaveOccurred())
+		g.Expect(adopted).To(gomega.BeFalse())
+	})
+}
+
+func newWarmPoolVMContext(g *gomega.WithT, session *session.Session, name string, spec infrav1.VirtualMachineCloneSpec) *capvcontext.VMContext {
+	scheme := runtime.NewScheme()
+	g.Expect(infrav1.AddToScheme(scheme)).To(gomega.Succeed())
+	vsphereVM := &infrav1.VSphereVM{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			UID:       "a3c1f0d2-6b7e-4c8d-9f1a-2b3c4d5e6f70",
+		},
+		Spec: infrav1.VSphereVMSpec{
+			VirtualMachineCloneSpec: spec,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(vsphereVM).WithStatusSubresource(vsphereVM).Build()
+	patchHelper, err := patch.NewHelper(vsphereVM, c)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	return &capvcontext.VMContext{
+		Session:     session,
+		VSphereVM:   vsphereVM,
+		PatchHelper: patchHelper,
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	bootstrapv1 "sigs.k8s.io/cluster-api/api/bootstrap/kubeadm/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
+	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/services/govmomi/vcenter"
+)
+
+// adoptWarmPoolVM adopts a VM of the warm pool of the VSphereMachineTemplate the VSphereVM was
+// cloned from. Returns false if the template has no warm pool, the pool has no VM left or the
+// VMs of the pool do not match the spec of the VSphereVM, in which case the VM has to be cloned.
+func adoptWarmPoolVM(ctx context.Context, vmCtx *capvcontext.VMContext, bootstrapData []byte, format bootstrapv1.Format) (bool, error) {
+	templateName, ok := vmCtx.VSphereVM.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok || vmCtx.ControllerManagerContext == nil {
+		return false, nil
+	}
+	groupKind := schema.ParseGroupKind(vmCtx.VSphereVM.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation])
+	if groupKind != infrav1.GroupVersion.WithKind("VSphereMachineTemplate").GroupKind() {
+		return false, nil
+	}
+
+	template := &infrav1.VSphereMachineTemplate{}
+	if err := vmCtx.Client.Get(ctx, client.ObjectKey{Namespace: vmCtx.VSphereVM.Namespace, Name: templateName}, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, pkgerrors.Wrapf(err, "failed to get VSphereMachineTemplate %s/%s", vmCtx.VSphereVM.Namespace, templateName)
+	}
+	if template.Spec.WarmPool.Size == nil || *template.Spec.WarmPool.Size == 0 {
+		return false, nil
+	}
+	if !isWarmPoolCompatible(template.Spec.Template.Spec.VirtualMachineCloneSpec, vmCtx.VSphereVM.Spec.VirtualMachineCloneSpec) {
+		return false, nil
+	}
+
+	return vcenter.AdoptWarmPoolVM(ctx, vmCtx, vcenter.NewWarmPool(template, vmCtx.VSphereVM.Spec.VirtualMachineCloneSpec), bootstrapData, format)
+}
+
+// isWarmPoolCompatible returns true if a VM cloned for the warm pool with the given template
+// spec can be adopted for a VSphereVM with the given spec. The fields which are applied when
+// the VM is adopted or when it is reconciled afterwards are ignored. The placement of the
+// VSphereVM must match the placement of the template: failure domains are not supported, as
+// the pool is only filled for the placement of the template, so the VSphereVMs of a failure
+// domain which overrides it are always cloned.
+func isWarmPoolCompatible(templateSpec, vmSpec infrav1.VirtualMachineCloneSpec) bool {
+	return equality.Semantic.DeepEqual(warmPoolCloneSpec(templateSpec), warmPoolCloneSpec(vmSpec))
+}
+
+func warmPoolCloneSpec(spec infrav1.VirtualMachineCloneSpec) *infrav1.VirtualMachineCloneSpec {
+	s := spec.DeepCopy()
+	s.Server = ""
+	s.Thumbprint = ""
+	s.Folder = ""
+	s.Network = infrav1.NetworkSpec{}
+	s.CustomVMXKeys = nil
+	s.TagIDs = nil
+	s.ResizePolicy = ""
+	s.DiskGrowHint = ""
+	s.DriftRemediation = nil
+	return s
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package govmomi
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+)
+
+func Test_isWarmPoolCompatible(t *testing.T) {
+	templateSpec := infrav1.VirtualMachineCloneSpec{
+		Template:   "ubuntu-2404",
+		Datacenter: "DC0",
+		Folder:     "templates",
+		NumCPUs:    2,
+		MemoryMiB:  4096,
+		DiskGiB:    20,
+		Network: infrav1.NetworkSpec{
+			Devices: []infrav1.NetworkDeviceSpec{{NetworkName: "VM Network"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(spec *infrav1.VirtualMachineCloneSpec)
+		want   bool
+	}{
+		{
+			name:   "same spec",
+			mutate: func(*infrav1.VirtualMachineCloneSpec) {},
+			want:   true,
+		},
+		{
+			name: "folder, network and custom VMX keys are applied on adoption",
+			mutate: func(spec *infrav1.VirtualMachineCloneSpec) {
+				spec.Server = "vcenter.example.com"
+				spec.Folder = "cluster"
+				spec.Network.Devices[0].IPAddrs = []string{"192.168.1.10/24"}
+				spec.CustomVMXKeys = map[string]string{"key": "value"}
+			},
+			want: true,
+		},
+		{
+			name: "different hardware",
+			mutate: func(spec *infrav1.VirtualMachineCloneSpec) {
+				spec.NumCPUs = 4
+			},
+			want: false,
+		},
+		{
+			name: "different datastore",
+			mutate: func(spec *infrav1.VirtualMachineCloneSpec) {
+				spec.Datastore = "ds-1"
+			},
+			want: false,
+		},
+		{
+			name: "different resource pool, e.g. of a failure domain",
+			mutate: func(spec *infrav1.VirtualMachineCloneSpec) {
+				spec.ResourcePool = "zone-b-rp"
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			vmSpec := *templateSpec.DeepCopy()
+			tt.mutate(&vmSpec)
+			g.Expect(isWarmPoolCompatible(templateSpec, vmSpec)).To(Equal(tt.want))
+		})
+	}
+}
@@ -20,11 +20,13 @@ package services
 import (
 	"context"
 	"encoding/json"
+	"strconv"
 	"strings"
 
 	pkgerrors "github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
@@ -164,6 +166,23 @@ func (v *VimMachineService) ReconcileNormal(ctx context.Context, machineCtx capv
 		return false, err
 	}
 
+	// Place the machine in a failure domain before its VSphereVM is created.
+	if vsphereVM == nil {
+		if ok, err := v.reconcileFailureDomain(ctx, vimMachineCtx); !ok {
+			if err != nil {
+				return false, err
+			}
+			deprecatedv1beta1conditions.MarkFalse(vimMachineCtx.VSphereMachine, infrav1.VMProvisionedV1Beta1Condition, infrav1.WaitingForFailureDomainCapacityV1Beta1Reason, clusterv1.ConditionSeverityInfo, "")
+			conditions.Set(vimMachineCtx.VSphereMachine, metav1.Condition{
+				Type:    infrav1.VSphereMachineVirtualMachineProvisionedCondition,
+				Status:  metav1.ConditionFalse,
+				Reason:  infrav1.VSphereMachineVirtualMachineWaitingForFailureDomainCapacityReason,
+				Message: "Waiting for a failure domain with enough free capacity",
+			})
+			return true, nil
+		}
+	}
+
 	log = log.WithValues("VSphereVM", klog.KObj(vsphereVM))
 	ctx = ctrl.LoggerInto(ctx, log)
 	vm, err := v.createOrPatchVSphereVM(ctx, vimMachineCtx, vsphereVM)
@@ -373,6 +392,23 @@ func (v *VimMachineService) createOrPatchVSphereVM(ctx context.Context, vimMachi
 			vm.Labels[clusterv1.MachineControlPlaneLabel] = val
 		}
 
+		// Add the name of the MachineDeployment, so the VSphereVMs of a MachineDeployment
+		// can be found when placing them relative to each other with DRS rules.
+		if val, ok := vimMachineCtx.Machine.Labels[clusterv1.MachineDeploymentNameLabel]; ok {
+			vm.Labels[clusterv1.MachineDeploymentNameLabel] = val
+		}
+
+		// Propagate the template the VSphereMachine was cloned from, so a VM
+		// of the template's warm pool can be adopted for the VSphereVM.
+		for _, annotation := range []string{clusterv1.TemplateClonedFromNameAnnotation, clusterv1.TemplateClonedFromGroupKindAnnotation} {
+			if val, ok := vimMachineCtx.VSphereMachine.Annotations[annotation]; ok {
+				if vm.Annotations == nil {
+					vm.Annotations = map[string]string{}
+				}
+				vm.Annotations[annotation] = val
+			}
+		}
+
 		// Copy the VSphereMachine's VM clone spec into the VSphereVM's
 		// clone spec.
 		vimMachineCtx.VSphereMachine.Spec.VirtualMachineCloneSpec.DeepCopyInto(&vm.Spec.VirtualMachineCloneSpec)
@@ -396,6 +432,9 @@ func (v *VimMachineService) createOrPatchVSphereVM(ctx context.Context, vimMachi
 		}
 		if vsphereVM != nil {
 			vm.Spec.BiosUUID = vsphereVM.Spec.BiosUUID
+			// The template is immutable, and may have been overridden by the replica
+			// of the template on the datastore of the failure domain.
+			vm.Spec.Template = vsphereVM.Spec.Template
 		}
 		vm.Spec.PowerOffMode = vimMachineCtx.VSphereMachine.Spec.PowerOffMode
 		vm.Spec.GuestSoftPowerOffTimeoutSeconds = vimMachineCtx.VSphereMachine.Spec.GuestSoftPowerOffTimeoutSeconds
@@ -452,11 +491,131 @@ func GenerateVSphereVMName(machineName string, namingStrategy infrav1.VSphereVMN
 	return name, nil
 }
 
+// reconcileFailureDomain places a worker machine without a failure domain in the failure domain
+// of the cluster with the most free capacity, if the VSphereCluster uses the MostFreeCapacity
+// failure domain placement strategy. It returns false if no failure domain has enough capacity.
+func (v *VimMachineService) reconcileFailureDomain(ctx context.Context, vimMachineCtx *capvcontext.VIMMachineContext) (bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+	if vimMachineCtx.Machine.Spec.FailureDomain != "" || vimMachineCtx.VSphereMachine.Status.FailureDomain != "" {
+		return true, nil
+	}
+
+	placement := vimMachineCtx.VSphereCluster.Spec.Placement.FailureDomains
+	if placement.Strategy != infrav1.MostFreeCapacityFailureDomainPlacementStrategy ||
+		clusterutilv1.IsControlPlaneMachine(vimMachineCtx.Machine) ||
+		len(vimMachineCtx.VSphereCluster.Status.FailureDomains) == 0 {
+		return true, nil
+	}
+
+	// The capacity of the failure domains is only refreshed periodically, so the memory of the
+	// machines which were placed but are not provisioned yet is reserved.
+	// NOTE: Machines without memory in their spec get the memory of the VSphereMachineTemplate
+	// they were cloned from, as it is reported in its capacity.
+	machines, err := v.GetMachinesInCluster(ctx, vimMachineCtx.VSphereMachine.Namespace, vimMachineCtx.Machine.Spec.ClusterName)
+	if err != nil {
+		return false, pkgerrors.Wrapf(err, "failed to list VSphereMachines of cluster %s", vimMachineCtx.Machine.Spec.ClusterName)
+	}
+	reservedMemoryMiB := map[string]int64{}
+	templateMemoryMiB := map[string]int64{}
+	for _, machine := range machines {
+		vsphereMachine, ok := machine.(*infrav1.VSphereMachine)
+		if !ok || vsphereMachine.Status.FailureDomain == "" || ptr.Deref(vsphereMachine.Status.Initialization.Provisioned, false) {
+			continue
+		}
+		memoryMiB := vsphereMachine.Spec.MemoryMiB
+		if memoryMiB == 0 {
+			templateName := vsphereMachine.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+			if _, ok := templateMemoryMiB[templateName]; !ok {
+				templateMemoryMiB[templateName], err = v.getTemplateMemoryMiB(ctx, vsphereMachine)
+				if err != nil {
+					return false, err
+				}
+			}
+			memoryMiB = templateMemoryMiB[templateName]
+		}
+		reservedMemoryMiB[vsphereMachine.Status.FailureDomain] += memoryMiB
+	}
+
+	failureDomain, ok := selectFailureDomainByCapacity(vimMachineCtx.VSphereCluster.Status.FailureDomains, placement.MinDatastoreFreeGiB, reservedMemoryMiB)
+	if !ok {
+		log.Info("Waiting for a failure domain with enough free capacity")
+		return false, nil
+	}
+	log.Info("Placing machine in the failure domain with the most free capacity", "failureDomain", failureDomain)
+	vimMachineCtx.VSphereMachine.Status.FailureDomain = failureDomain
+	return true, nil
+}
+
+// getTemplateMemoryMiB returns the memory of the VSphereMachineTemplate the VSphereMachine was cloned
+// from, according to its capacity. It returns 0 if the template or its capacity is not known.
+func (v *VimMachineService) getTemplateMemoryMiB(ctx context.Context, vsphereMachine *infrav1.VSphereMachine) (int64, error) {
+	name, ok := vsphereMachine.Annotations[clusterv1.TemplateClonedFromNameAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	groupKind := schema.ParseGroupKind(vsphereMachine.Annotations[clusterv1.TemplateClonedFromGroupKindAnnotation])
+	if groupKind != infrav1.GroupVersion.WithKind("VSphereMachineTemplate").GroupKind() {
+		return 0, nil
+	}
+
+	vsphereMachineTemplate := &infrav1.VSphereMachineTemplate{}
+	if err := v.Client.Get(ctx, client.ObjectKey{Namespace: vsphereMachine.Namespace, Name: name}, vsphereMachineTemplate); err != nil {
+		if apierrors.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, pkgerrors.Wrapf(err, "failed to get VSphereMachineTemplate %s", klog.KRef(vsphereMachine.Namespace, name))
+	}
+	memory, ok := vsphereMachineTemplate.Status.Capacity[infrav1.VSphereResourceMemory]
+	if !ok {
+		return 0, nil
+	}
+	return memory.Value() / (1024 * 1024), nil
+}
+
+// selectFailureDomainByCapacity returns the failure domain with the most free memory, and then the most free
+// CPU, according to the attributes of the failure domains. Failure domains without capacity attributes, or whose
+// datastore has less free space than minDatastoreFreeGiB, are ignored.
+func selectFailureDomainByCapacity(failureDomains []clusterv1.FailureDomain, minDatastoreFreeGiB *int64, reservedMemoryMiB map[string]int64) (string, bool) {
+	var selected string
+	var selectedMemoryMiB, selectedCPUMHz int64
+	for _, failureDomain := range failureDomains {
+		memoryMiB, err := strconv.ParseInt(failureDomain.Attributes[infrav1.FailureDomainMemoryFreeMiBAttribute], 10, 64)
+		if err != nil {
+			continue
+		}
+		memoryMiB -= reservedMemoryMiB[failureDomain.Name]
+		cpuMHz, err := strconv.ParseInt(failureDomain.Attributes[infrav1.FailureDomainCPUFreeMHzAttribute], 10, 64)
+		if err != nil {
+			continue
+		}
+		if minDatastoreFreeGiB != nil {
+			datastoreGiB, err := strconv.ParseInt(failureDomain.Attributes[infrav1.FailureDomainDatastoreFreeGiBAttribute], 10, 64)
+			if err != nil || datastoreGiB < *minDatastoreFreeGiB {
+				continue
+			}
+		}
+
+		if selected == "" || memoryMiB > selectedMemoryMiB || (memoryMiB == selectedMemoryMiB && cpuMHz > selectedCPUMHz) {
+			selected, selectedMemoryMiB, selectedCPUMHz = failureDomain.Name, memoryMiB, cpuMHz
+		}
+	}
+	return selected, selected != ""
+}
+
+// getFailureDomainName returns the failure domain of the machine: the failure domain of the owner
+// CAPI machine, or the failure domain in which CAPV placed the machine.
+func getFailureDomainName(vimMachineCtx *capvcontext.VIMMachineContext) string {
+	if vimMachineCtx.Machine.Spec.FailureDomain != "" {
+		return vimMachineCtx.Machine.Spec.FailureDomain
+	}
+	return vimMachineCtx.VSphereMachine.Status.FailureDomain
+}
+
 // generateOverrideFunc returns a function which can override the values in the VSphereVM Spec
-// with the values from the FailureDomain (if any) set on the owner CAPI machine.
+// with the values from the FailureDomain (if any) set on the owner CAPI machine, or chosen by CAPV.
 func (v *VimMachineService) generateOverrideFunc(ctx context.Context, vimMachineCtx *capvcontext.VIMMachineContext) (func(vm *infrav1.VSphereVM), bool) {
 	log := ctrl.LoggerFrom(ctx)
-	failureDomainName := vimMachineCtx.Machine.Spec.FailureDomain
+	failureDomainName := getFailureDomainName(vimMachineCtx)
 	if failureDomainName == "" {
 		return nil, false
 	}
@@ -485,6 +644,8 @@ func (v *VimMachineService) generateOverrideFunc(ctx context.Context, vimMachine
 		}
 		if vsphereFailureDomain.Spec.Topology.Datastore != "" {
 			vm.Spec.Datastore = vsphereFailureDomain.Spec.Topology.Datastore
+			// The datastore of the failure domain takes precedence over a datastore cluster.
+			vm.Spec.DatastoreCluster = ""
 		}
 		if len(vsphereFailureDomain.Spec.Topology.Networks) > 0 {
 			vm.Spec.Network.Devices = overrideNetworkDeviceSpecs(vm.Spec.Network.Devices, vsphereFailureDomain.Spec.Topology.Networks, mergeFailureDomainNetworkName)
@@ -492,6 +653,13 @@ func (v *VimMachineService) generateOverrideFunc(ctx context.Context, vimMachine
 		if len(vsphereFailureDomain.Spec.Topology.NetworkConfigurations) > 0 {
 			vm.Spec.Network.Devices = overrideNetworkDeviceSpecs(vm.Spec.Network.Devices, vsphereFailureDomain.Spec.Topology.NetworkConfigurations, mergeNetworkConfigurationInNetworkDeviceSpec)
 		}
+		// Clone from the replica of the template on the datastore of the failure domain, once it is ready.
+		for _, replica := range vsphereDeploymentZone.Status.TemplateReplicas {
+			if replica.Template == vm.Spec.Template && replica.Replica != "" {
+				vm.Spec.Template = replica.Replica
+				break
+			}
+		}
 	}
 	return overrideWithFailureDomainFunc, true
 }
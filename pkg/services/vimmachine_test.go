@@ -22,6 +22,8 @@ import (
 
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	clusterv1 "sigs.k8s.io/cluster-api/api/core/v1beta2"
@@ -30,6 +32,7 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
+	capvcontext "sigs.k8s.io/cluster-api-provider-vsphere/pkg/context"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/context/fake"
 	"sigs.k8s.io/cluster-api-provider-vsphere/pkg/util"
 )
@@ -128,6 +131,32 @@ func Test_VimMachineService_GenerateOverrideFunc(t *testing.T) {
 		g.Expect(vm.Spec.Datacenter).To(Equal("dc-one"))
 	})
 
+	t.Run("uses the replica of the template on the datastore of the failure domain once it is ready", func(t *testing.T) {
+		g := NewWithT(t)
+		zone := deplZone("one")
+		zone.Spec.TemplateReplicas = []string{"ubuntu", "photon"}
+		zone.Status.TemplateReplicas = []infrav1.VSphereDeploymentZoneTemplateReplica{
+			{Template: "ubuntu", Replica: "/dc-one/vm/ubuntu-abcde"},
+			{Template: "photon", TaskRef: "task-1"},
+		}
+		controllerManagerContext := fake.NewControllerManagerContext(zone, failureDomain("one"))
+		machineCtx := fake.NewMachineContext(ctx, fake.NewClusterContext(ctx, controllerManagerContext), controllerManagerContext)
+		machineCtx.Machine.Spec.FailureDomain = "zone-one"
+		vimMachineService := &VimMachineService{controllerManagerContext.Client}
+
+		overrideFunc, ok := vimMachineService.generateOverrideFunc(ctx, machineCtx)
+		g.Expect(ok).To(BeTrue())
+
+		vm := &infrav1.VSphereVM{Spec: infrav1.VSphereVMSpec{VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{Template: "ubuntu"}}}
+		overrideFunc(vm)
+		g.Expect(vm.Spec.Template).To(Equal("/dc-one/vm/ubuntu-abcde"))
+
+		// The template is used until its replica is ready.
+		vm = &infrav1.VSphereVM{Spec: infrav1.VSphereVMSpec{VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{Template: "photon"}}}
+		overrideFunc(vm)
+		g.Expect(vm.Spec.Template).To(Equal("photon"))
+	})
+
 	t.Run("fails to generate an override function for non-existent failure domain value", func(t *testing.T) {
 		g := NewWithT(t)
 		controllerManagerContext := fake.NewControllerManagerContext(deplZone("one"), deplZone("two"), failureDomain("one"), failureDomain("two"))
@@ -314,6 +343,223 @@ func Test_VimMachineService_GenerateOverrideFunc(t *testing.T) {
 	})
 }
 
+func Test_VimMachineService_reconcileFailureDomain(t *testing.T) {
+	failureDomains := []clusterv1.FailureDomain{
+		{
+			Name: "zone-one",
+			Attributes: map[string]string{
+				infrav1.FailureDomainCPUFreeMHzAttribute:       "8000",
+				infrav1.FailureDomainMemoryFreeMiBAttribute:    "8192",
+				infrav1.FailureDomainDatastoreFreeGiBAttribute: "500",
+			},
+		},
+		{
+			Name: "zone-two",
+			Attributes: map[string]string{
+				infrav1.FailureDomainCPUFreeMHzAttribute:       "4000",
+				infrav1.FailureDomainMemoryFreeMiBAttribute:    "4096",
+				infrav1.FailureDomainDatastoreFreeGiBAttribute: "50",
+			},
+		},
+	}
+
+	newMachineCtx := func(objs ...ctrlclient.Object) *capvcontext.VIMMachineContext {
+		controllerManagerContext := fake.NewControllerManagerContext(objs...)
+		machineCtx := fake.NewMachineContext(ctx, fake.NewClusterContext(ctx, controllerManagerContext), controllerManagerContext)
+		machineCtx.Machine.Spec.ClusterName = "cluster"
+		machineCtx.VSphereCluster.Spec.Placement.FailureDomains.Strategy = infrav1.MostFreeCapacityFailureDomainPlacementStrategy
+		machineCtx.VSphereCluster.Status.FailureDomains = failureDomains
+		return machineCtx
+	}
+
+	t.Run("places the machine in the failure domain with the most free memory", func(t *testing.T) {
+		g := NewWithT(t)
+		machineCtx := newMachineCtx()
+		vimMachineService := &VimMachineService{machineCtx.ControllerManagerContext.Client}
+
+		ok, err := vimMachineService.reconcileFailureDomain(ctx, machineCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+		g.Expect(machineCtx.VSphereMachine.Status.FailureDomain).To(Equal("zone-one"))
+
+		overrideFunc, ok := vimMachineService.generateOverrideFunc(ctx, machineCtx)
+		g.Expect(ok).To(BeFalse())
+		g.Expect(overrideFunc).To(BeNil())
+	})
+
+	t.Run("reserves the memory of the machines which are not provisioned yet", func(t *testing.T) {
+		g := NewWithT(t)
+		pendingMachine := &infrav1.VSphereMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: fake.Namespace,
+				Name:      "pending",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+			},
+			Spec: infrav1.VSphereMachineSpec{
+				VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{MemoryMiB: 6144},
+			},
+			Status: infrav1.VSphereMachineStatus{FailureDomain: "zone-one"},
+		}
+		machineCtx := newMachineCtx(pendingMachine)
+		vimMachineService := &VimMachineService{machineCtx.ControllerManagerContext.Client}
+
+		ok, err := vimMachineService.reconcileFailureDomain(ctx, machineCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+		g.Expect(machineCtx.VSphereMachine.Status.FailureDomain).To(Equal("zone-two"))
+	})
+
+	t.Run("reserves the memory of the template of machines without memory in their spec", func(t *testing.T) {
+		g := NewWithT(t)
+		vsphereMachineTemplate := &infrav1.VSphereMachineTemplate{
+			ObjectMeta: metav1.ObjectMeta{Namespace: fake.Namespace, Name: "md-0"},
+			Status: infrav1.VSphereMachineTemplateStatus{
+				Capacity: corev1.ResourceList{
+					infrav1.VSphereResourceMemory: resource.MustParse("6Gi"),
+				},
+			},
+		}
+		pendingMachine := &infrav1.VSphereMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: fake.Namespace,
+				Name:      "pending",
+				Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster"},
+				Annotations: map[string]string{
+					clusterv1.TemplateClonedFromNameAnnotation:      "md-0",
+					clusterv1.TemplateClonedFromGroupKindAnnotation: "VSphereMachineTemplate.infrastructure.cluster.x-k8s.io",
+				},
+			},
+			Status: infrav1.VSphereMachineStatus{FailureDomain: "zone-one"},
+		}
+		machineCtx := newMachineCtx(vsphereMachineTemplate, pendingMachine)
+		vimMachineService := &VimMachineService{machineCtx.ControllerManagerContext.Client}
+
+		ok, err := vimMachineService.reconcileFailureDomain(ctx, machineCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+		g.Expect(machineCtx.VSphereMachine.Status.FailureDomain).To(Equal("zone-two"))
+	})
+
+	t.Run("waits when no failure domain has enough datastore free space", func(t *testing.T) {
+		g := NewWithT(t)
+		machineCtx := newMachineCtx()
+		machineCtx.VSphereCluster.Spec.Placement.FailureDomains.MinDatastoreFreeGiB = ptr.To[int64](1000)
+		vimMachineService := &VimMachineService{machineCtx.ControllerManagerContext.Client}
+
+		ok, err := vimMachineService.reconcileFailureDomain(ctx, machineCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeFalse())
+		g.Expect(machineCtx.VSphereMachine.Status.FailureDomain).To(BeEmpty())
+	})
+
+	t.Run("does not place machines which have a failure domain", func(t *testing.T) {
+		g := NewWithT(t)
+		machineCtx := newMachineCtx()
+		machineCtx.Machine.Spec.FailureDomain = "zone-two"
+		vimMachineService := &VimMachineService{machineCtx.ControllerManagerContext.Client}
+
+		ok, err := vimMachineService.reconcileFailureDomain(ctx, machineCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+		g.Expect(machineCtx.VSphereMachine.Status.FailureDomain).To(BeEmpty())
+	})
+
+	t.Run("does not place control plane machines", func(t *testing.T) {
+		g := NewWithT(t)
+		machineCtx := newMachineCtx()
+		machineCtx.Machine.Labels = map[string]string{clusterv1.MachineControlPlaneLabel: ""}
+		vimMachineService := &VimMachineService{machineCtx.ControllerManagerContext.Client}
+
+		ok, err := vimMachineService.reconcileFailureDomain(ctx, machineCtx)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+		g.Expect(machineCtx.VSphereMachine.Status.FailureDomain).To(BeEmpty())
+	})
+}
+
+func Test_selectFailureDomainByCapacity(t *testing.T) {
+	failureDomain := func(name, cpuFreeMHz, memoryFreeMiB, datastoreFreeGiB string) clusterv1.FailureDomain {
+		attributes := map[string]string{}
+		for attribute, value := range map[string]string{
+			infrav1.FailureDomainCPUFreeMHzAttribute:       cpuFreeMHz,
+			infrav1.FailureDomainMemoryFreeMiBAttribute:    memoryFreeMiB,
+			infrav1.FailureDomainDatastoreFreeGiBAttribute: datastoreFreeGiB,
+		} {
+			if value != "" {
+				attributes[attribute] = value
+			}
+		}
+		return clusterv1.FailureDomain{Name: name, Attributes: attributes}
+	}
+
+	tests := []struct {
+		name                string
+		failureDomains      []clusterv1.FailureDomain
+		minDatastoreFreeGiB *int64
+		reservedMemoryMiB   map[string]int64
+		want                string
+	}{
+		{
+			name:           "no failure domains",
+			failureDomains: nil,
+			want:           "",
+		},
+		{
+			name: "failure domains without capacity are ignored",
+			failureDomains: []clusterv1.FailureDomain{
+				failureDomain("a", "", "", ""),
+				failureDomain("b", "1000", "1024", ""),
+			},
+			want: "b",
+		},
+		{
+			name: "the failure domain with the most free memory is selected",
+			failureDomains: []clusterv1.FailureDomain{
+				failureDomain("a", "8000", "1024", "100"),
+				failureDomain("b", "1000", "2048", "100"),
+			},
+			want: "b",
+		},
+		{
+			name: "the failure domain with the most free CPU is selected if free memory is equal",
+			failureDomains: []clusterv1.FailureDomain{
+				failureDomain("a", "1000", "2048", "100"),
+				failureDomain("b", "2000", "2048", "100"),
+			},
+			want: "b",
+		},
+		{
+			name: "reserved memory is subtracted from the free memory",
+			failureDomains: []clusterv1.FailureDomain{
+				failureDomain("a", "1000", "4096", "100"),
+				failureDomain("b", "1000", "2048", "100"),
+			},
+			reservedMemoryMiB: map[string]int64{"a": 4096},
+			want:              "b",
+		},
+		{
+			name: "failure domains below the datastore threshold are ignored",
+			failureDomains: []clusterv1.FailureDomain{
+				failureDomain("a", "1000", "4096", "10"),
+				failureDomain("b", "1000", "2048", "100"),
+				failureDomain("c", "1000", "8192", ""),
+			},
+			minDatastoreFreeGiB: ptr.To[int64](50),
+			want:                "b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, ok := selectFailureDomainByCapacity(tt.failureDomains, tt.minDatastoreFreeGiB, tt.reservedMemoryMiB)
+			g.Expect(ok).To(Equal(tt.want != ""))
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
 func Test_mergeNetworkConfigurationToNetworkDeviceSpec(t *testing.T) {
 	t.Run("all fields from NetworkConfiguration are overridden", func(t *testing.T) {
 		g := NewWithT(t)
@@ -495,6 +741,23 @@ func Test_VimMachineService_createOrPatchVSphereVM(t *testing.T) {
 		g.Expect(err).NotTo(HaveOccurred())
 		g.Expect(vmName).To(Equal(fakeLongClusterName))
 	})
+
+	t.Run("propagates the template the VSphereMachine was cloned from", func(t *testing.T) {
+		g := NewWithT(t)
+		controllerManagerContext := fake.NewControllerManagerContext(getVSphereVM(hostAddr, metav1.ConditionTrue), deplZone("one"), deplZone("two"), failureDomain("one"), failureDomain("two"))
+		machineCtx := fake.NewMachineContext(ctx, fake.NewClusterContext(ctx, controllerManagerContext), controllerManagerContext)
+		machineCtx.VSphereMachine.SetAnnotations(map[string]string{
+			clusterv1.TemplateClonedFromNameAnnotation:      "md-0",
+			clusterv1.TemplateClonedFromGroupKindAnnotation: "VSphereMachineTemplate.infrastructure.cluster.x-k8s.io",
+		})
+		machineCtx.Machine.SetName(fakeLongClusterName)
+		vimMachineService := &VimMachineService{controllerManagerContext.Client}
+
+		vm, err := vimMachineService.createOrPatchVSphereVM(ctx, machineCtx, getVSphereVM(hostAddr, metav1.ConditionTrue))
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(vm.Annotations).To(HaveKeyWithValue(clusterv1.TemplateClonedFromNameAnnotation, "md-0"))
+		g.Expect(vm.Annotations).To(HaveKeyWithValue(clusterv1.TemplateClonedFromGroupKindAnnotation, "VSphereMachineTemplate.infrastructure.cluster.x-k8s.io"))
+	})
 }
 
 func Test_VimMachineService_reconcileProviderID(t *testing.T) {
@@ -0,0 +1,74 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsNamespace = "capv"
+	metricsSubsystem = "vcenter"
+
+	apiSOAP = "soap"
+	apiREST = "rest"
+)
+
+var (
+	sessionsActive = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "sessions_active",
+		Help:      "Number of cached vCenter sessions.",
+	}, []string{"server"})
+
+	logins = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "logins_total",
+		Help:      "Total number of successful vCenter logins.",
+	}, []string{"server", "api"})
+
+	loginFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "login_failures_total",
+		Help:      "Total number of failed vCenter logins.",
+	}, []string{"server", "api"})
+
+	callDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Subsystem: metricsSubsystem,
+		Name:      "call_duration_seconds",
+		Help:      "Latency of vCenter API calls, excluding the time spent waiting for a free call slot.",
+		Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"server", "api"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(sessionsActive, logins, loginFailures, callDuration)
+}
+
+// observeLogin records the result of a login to a vCenter.
+func observeLogin(server, api string, err error) {
+	if err != nil {
+		loginFailures.WithLabelValues(server, api).Inc()
+		return
+	}
+	logins.WithLabelValues(server, api).Inc()
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+	"github.com/vmware/govmomi/vim25/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// restSessionPath is the path of the vCenter REST session resource.
+const restSessionPath = "/com/vmware/cis/session"
+
+// keepAliveInterval is the idle time after which a request is sent to keep a session alive.
+// vCenter expires idle sessions after 30 minutes by default.
+const keepAliveInterval = 5 * time.Minute
+
+var (
+	// global pool map against servers in map[server]*pool.
+	pools sync.Map
+
+	// maxConcurrentCallsPerServer is the maximum number of concurrent SOAP and REST calls per vCenter.
+	// There is no limit if it is 0.
+	maxConcurrentCallsPerServer int
+)
+
+// SetMaxConcurrentCallsPerServer sets the maximum number of concurrent SOAP and REST calls per vCenter,
+// across all sessions of the vCenter. Long polls and long running vAPI calls are not limited, as they
+// would block the other calls for their whole duration. There is no limit if n is 0.
+// It must be called before the first session is created.
+func SetMaxConcurrentCallsPerServer(n int) {
+	maxConcurrentCallsPerServer = n
+}
+
+// pool contains the cached sessions of a single vCenter.
+type pool struct {
+	server string
+
+	// mutex to control access to the sessions of the vCenter, to avoid duplicate
+	// session creations on startup without blocking the sessions of other vCenters.
+	mu       sync.Mutex
+	sessions map[string]*Session
+
+	// calls is a semaphore which limits the number of concurrent calls to the vCenter.
+	// It is nil if the number of concurrent calls is not limited.
+	calls chan struct{}
+}
+
+// getPool returns the pool of a vCenter, creating it if it does not exist yet.
+func getPool(server string) *pool {
+	if p, ok := pools.Load(server); ok {
+		return p.(*pool)
+	}
+
+	p := &pool{
+		server:   server,
+		sessions: map[string]*Session{},
+	}
+	if maxConcurrentCallsPerServer > 0 {
+		p.calls = make(chan struct{}, maxConcurrentCallsPerServer)
+	}
+	actual, _ := pools.LoadOrStore(server, p)
+	return actual.(*pool)
+}
+
+// add adds a session to the pool. The pool must be locked.
+func (p *pool) add(key string, s *Session) {
+	p.sessions[key] = s
+	sessionsActive.WithLabelValues(p.server).Set(float64(len(p.sessions)))
+}
+
+// remove logs out a session and removes it from the pool. The pool must be locked.
+func (p *pool) remove(ctx context.Context, key string) {
+	log := ctrl.LoggerFrom(ctx)
+
+	s, ok := p.sessions[key]
+	if !ok {
+		return
+	}
+
+	if err := s.TagManager.Logout(ctx); err != nil {
+		log.Error(err, "Failed to logout REST session")
+	} else {
+		log.Info("Logout REST session succeed")
+	}
+	if err := s.Client.Logout(ctx); err != nil {
+		log.Error(err, "Failed to logout session")
+	} else {
+		log.Info("Logout session succeed")
+	}
+
+	delete(p.sessions, key)
+	sessionsActive.WithLabelValues(p.server).Set(float64(len(p.sessions)))
+}
+
+// acquire blocks until a call to the vCenter is allowed.
+// The returned func must be called once the call is done.
+func (p *pool) acquire(ctx context.Context) (func(), error) {
+	if p.calls == nil {
+		return func() {}, nil
+	}
+	select {
+	case p.calls <- struct{}{}:
+		return func() { <-p.calls }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// health tracks whether the SOAP and REST sessions of a Session are still authenticated.
+// It is updated by the keep-alive and by calls failing because the session is not authenticated,
+// so cached sessions can be handed out without checking them against vCenter every time.
+type health struct {
+	soapExpired atomic.Bool
+	restExpired atomic.Bool
+}
+
+func (h *health) isActive() bool {
+	return !h.soapExpired.Load() && !h.restExpired.Load()
+}
+
+// soapRoundTripper limits the concurrent SOAP calls to a vCenter, records their latency and
+// tracks whether the session is still authenticated.
+type soapRoundTripper struct {
+	roundTripper soap.RoundTripper
+	pool         *pool
+	health       *health
+}
+
+// RoundTrip implements soap.RoundTripper.
+func (rt *soapRoundTripper) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	err := rt.roundTrip(ctx, req, res)
+
+	switch {
+	case err != nil && fault.Is(err, &types.NotAuthenticated{}):
+		rt.health.soapExpired.Store(true)
+	case err == nil && isSOAPLogout(req):
+		rt.health.soapExpired.Store(true)
+	}
+	return err
+}
+
+// roundTrip sends the request, limiting the number of concurrent calls and recording their latency.
+func (rt *soapRoundTripper) roundTrip(ctx context.Context, req, res soap.HasFault) error {
+	if isSOAPLongPoll(req) {
+		// Long polls, e.g. waiting for a task, would block the calls of other sessions and skew the latency.
+		return rt.roundTripper.RoundTrip(ctx, req, res)
+	}
+
+	release, err := rt.pool.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	start := time.Now()
+	err = rt.roundTripper.RoundTrip(ctx, req, res)
+	callDuration.WithLabelValues(rt.pool.server, apiSOAP).Observe(time.Since(start).Seconds())
+	return err
+}
+
+// restRoundTripper limits the concurrent REST calls to a vCenter, records their latency and
+// tracks whether the session is still authenticated.
+type restRoundTripper struct {
+	roundTripper http.RoundTripper
+	pool         *pool
+	health       *health
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *restRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.roundTrip(req)
+
+	switch {
+	case err == nil && resp.StatusCode == http.StatusUnauthorized:
+		rt.health.restExpired.Store(true)
+	case err == nil && req.Method == http.MethodDelete && strings.HasSuffix(req.URL.Path, restSessionPath):
+		// The session has been logged out.
+		rt.health.restExpired.Store(true)
+	}
+	return resp, err
+}
+
+// roundTrip sends the request, limiting the number of concurrent calls and recording their latency.
+func (rt *restRoundTripper) roundTrip(req *http.Request) (*http.Response, error) {
+	if isLongRunningRESTCall(req) {
+		// Long running calls, e.g. deploying a content library item, would block the calls of other
+		// sessions and skew the latency.
+		return rt.roundTripper.RoundTrip(req)
+	}
+
+	release, err := rt.pool.acquire(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	start := time.Now()
+	resp, err := rt.roundTripper.RoundTrip(req)
+	callDuration.WithLabelValues(rt.pool.server, apiREST).Observe(time.Since(start).Seconds())
+	return resp, err
+}
+
+// isSOAPLongPoll returns true if the call waits for updates of the property collector,
+// which blocks until an object changes or the wait times out.
+func isSOAPLongPoll(req soap.HasFault) bool {
+	switch req.(type) {
+	case *methods.WaitForUpdatesExBody, *methods.WaitForUpdatesBody:
+		return true
+	}
+	return false
+}
+
+// isLongRunningRESTCall returns true if the call is a vAPI action which only returns once it
+// is completed, e.g. deploying a VM from a content library item.
+func isLongRunningRESTCall(req *http.Request) bool {
+	query := req.URL.Query()
+	for _, action := range []string{query.Get("action"), query.Get("~action")} {
+		switch action {
+		case "deploy", "instant-clone", "sync":
+			return true
+		}
+	}
+	return false
+}
+
+func isSOAPLogout(req soap.HasFault) bool {
+	_, ok := req.(*methods.LogoutBody)
+	return ok
+}
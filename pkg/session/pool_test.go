@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package session
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/vmware/govmomi"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/methods"
+	"github.com/vmware/govmomi/vim25/soap"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api-provider-vsphere/internal/test/helpers/vcsim"
+)
+
+func TestPoolAcquire(t *testing.T) {
+	g := NewWithT(t)
+
+	SetMaxConcurrentCallsPerServer(2)
+	defer SetMaxConcurrentCallsPerServer(0)
+	p := &pool{server: "limited", calls: make(chan struct{}, maxConcurrentCallsPerServer)}
+
+	release1, err := p.acquire(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	release2, err := p.acquire(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// A third call blocks until one of the calls is done.
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = p.acquire(ctx)
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+
+	release1()
+	release3, err := p.acquire(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	release2()
+	release3()
+
+	// Calls are not limited by default.
+	unlimited := &pool{server: "unlimited"}
+	for range 10 {
+		_, err := unlimited.acquire(context.Background())
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+}
+
+func TestSessionHealth(t *testing.T) {
+	g := NewWithT(t)
+	ctrl.SetLogger(klog.Background())
+
+	simr, err := vcsim.NewBuilder().
+		WithModel(simulator.VPX()).Build()
+	if err != nil {
+		t.Fatalf("failed to create VC simulator")
+	}
+	defer simr.Destroy()
+
+	params := NewParams().
+		WithServer(simr.ServerURL().Host).
+		WithUserInfo(simr.Username(), simr.Password()).
+		WithInsecure(true)
+	server := params.server
+
+	ctx := context.Background()
+	s, err := GetOrCreate(ctx, params)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s.health.isActive()).To(BeTrue())
+	g.Expect(testutil.ToFloat64(sessionsActive.WithLabelValues(server))).To(Equal(float64(1)))
+	g.Expect(testutil.ToFloat64(logins.WithLabelValues(server, apiSOAP))).To(BeNumerically(">=", 1))
+	g.Expect(testutil.ToFloat64(logins.WithLabelValues(server, apiREST))).To(BeNumerically(">=", 1))
+
+	// Calls are observed.
+	_, err = methods.GetCurrentTime(ctx, s.Client)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(testutil.CollectAndCount(callDuration)).To(BeNumerically(">=", 1))
+
+	// A SOAP session which has been terminated on the vCenter is marked as expired by the next call.
+	userSession, err := s.SessionManager.UserSession(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	admin, err := govmomi.NewClient(ctx, simr.ServerURL(), true)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(admin.SessionManager.TerminateSession(ctx, []string{userSession.Key})).To(Succeed())
+	_, err = methods.GetCurrentTime(ctx, s.Client)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(s.health.isActive()).To(BeFalse())
+
+	// The expired session is replaced.
+	s2, err := GetOrCreate(ctx, params)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s2).ToNot(BeIdenticalTo(s))
+	g.Expect(s2.health.isActive()).To(BeTrue())
+	g.Expect(testutil.ToFloat64(sessionsActive.WithLabelValues(server))).To(Equal(float64(1)))
+
+	// A session which has been terminated on the vCenter since the last call is not returned.
+	userSession, err = s2.SessionManager.UserSession(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(admin.SessionManager.TerminateSession(ctx, []string{userSession.Key})).To(Succeed())
+	g.Expect(s2.health.isActive()).To(BeTrue())
+	s3, err := GetOrCreate(ctx, params)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s3).ToNot(BeIdenticalTo(s2))
+
+	Clear()
+	g.Expect(testutil.ToFloat64(sessionsActive.WithLabelValues(server))).To(Equal(float64(0)))
+}
+
+func TestLongRunningCalls(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(isSOAPLongPoll(&methods.WaitForUpdatesExBody{})).To(BeTrue())
+	g.Expect(isSOAPLongPoll(&methods.WaitForUpdatesBody{})).To(BeTrue())
+	g.Expect(isSOAPLongPoll(&methods.CurrentTimeBody{})).To(BeFalse())
+
+	for url, want := range map[string]bool{
+		"https://vcenter/rest/com/vmware/vcenter/ovf/library-item/id:item?~action=deploy": true,
+		"https://vcenter/api/vcenter/vm-template/library-items/item?action=deploy":        true,
+		"https://vcenter/api/content/library/item/item":                                   false,
+		"https://vcenter/rest/com/vmware/cis/session":                                     false,
+	} {
+		req, err := http.NewRequest(http.MethodPost, url, http.NoBody)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(isLongRunningRESTCall(req)).To(Equal(want), url)
+	}
+
+	// Long polls are not limited.
+	p := &pool{server: "long-poll", calls: make(chan struct{}, 1)}
+	p.calls <- struct{}{}
+	rt := &soapRoundTripper{roundTripper: soapRoundTripperFunc(func(context.Context, soap.HasFault, soap.HasFault) error {
+		return nil
+	}), pool: p, health: &health{}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	g.Expect(rt.RoundTrip(ctx, &methods.WaitForUpdatesExBody{}, &methods.WaitForUpdatesExBody{})).To(Succeed())
+	g.Expect(rt.RoundTrip(ctx, &methods.CurrentTimeBody{}, &methods.CurrentTimeBody{})).To(MatchError(context.DeadlineExceeded))
+}
+
+type soapRoundTripperFunc func(ctx context.Context, req, res soap.HasFault) error
+
+func (f soapRoundTripperFunc) RoundTrip(ctx context.Context, req, res soap.HasFault) error {
+	return f(ctx, req, res)
+}
@@ -20,10 +20,11 @@ package session
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"fmt"
 	"net/netip"
 	"net/url"
-	"sync"
+	"strings"
 
 	"github.com/blang/semver"
 	pkgerrors "github.com/pkg/errors"
@@ -31,30 +32,24 @@ import (
 	"github.com/vmware/govmomi/find"
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/session"
+	"github.com/vmware/govmomi/session/keepalive"
 	"github.com/vmware/govmomi/vapi/rest"
 	"github.com/vmware/govmomi/vapi/tags"
 	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/methods"
 	"github.com/vmware/govmomi/vim25/soap"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	infrav1 "sigs.k8s.io/cluster-api-provider-vsphere/api/govmomi/v1beta2"
 )
 
-var (
-	// global Session map against sessionKeys in map[sessionKey]Session.
-	sessionCache sync.Map
-
-	// mutex to control access to the GetOrCreate function to avoid duplicate
-	// session creations on startup.
-	sessionMU sync.Mutex
-)
-
 // Session is a vSphere session with a configured Finder.
 type Session struct {
 	*govmomi.Client
 	Finder     *find.Finder
 	datacenter *object.Datacenter
 	TagManager *tags.Manager
+	health     *health
 }
 
 // Feature is a set of Features of the session.
@@ -71,6 +66,8 @@ type Params struct {
 	datacenter string
 	userinfo   *url.Userinfo
 	thumbprint string
+	caBundle   []byte
+	insecure   bool
 	feature    Feature
 }
 
@@ -105,6 +102,20 @@ func (p *Params) WithThumbprint(thumbprint string) *Params {
 	return p
 }
 
+// WithCABundle adds PEM encoded CA certificates to parameters, which are trusted
+// to verify the vCenter server certificate instead of the system trust store.
+func (p *Params) WithCABundle(caBundle []byte) *Params {
+	p.caBundle = caBundle
+	return p
+}
+
+// WithInsecure allows to skip the verification of the vCenter server certificate
+// if neither a thumbprint nor a CA bundle is set.
+func (p *Params) WithInsecure(insecure bool) *Params {
+	p.insecure = insecure
+	return p
+}
+
 // WithFeatures adds features to parameters.
 func (p *Params) WithFeatures(feature Feature) *Params {
 	p.feature = feature
@@ -113,6 +124,9 @@ func (p *Params) WithFeatures(feature Feature) *Params {
 
 // GetOrCreate gets a cached session or creates a new one if one does not
 // already exist.
+// Cached sessions are kept alive in the background. They are checked against
+// vCenter before they are returned, unless they are already known to be expired,
+// as vCenter may have terminated them since the last call.
 func GetOrCreate(ctx context.Context, params *Params) (*Session, error) {
 	log := ctrl.LoggerFrom(ctx).WithValues(
 		"server", params.server,
@@ -120,80 +134,39 @@ func GetOrCreate(ctx context.Context, params *Params) (*Session, error) {
 		"username", params.userinfo.Username())
 	ctx = ctrl.LoggerInto(ctx, log)
 
-	sessionMU.Lock()
-	defer sessionMU.Unlock()
-
-	userPassword, _ := params.userinfo.Password()
-	h := sha256.New()
-	h.Write([]byte(userPassword))
-	hashedUserPassword := h.Sum(nil)
-	sessionKey := fmt.Sprintf("%s#%s#%s#%x", params.server, params.datacenter, params.userinfo.Username(),
-		hashedUserPassword)
-	if cachedSession, ok := sessionCache.Load(sessionKey); ok {
-		s := cachedSession.(*Session)
-
-		// Retrieve the current session from Managed Object.
-		// The userSession is active when the value is not nil.
-		userSession, err := s.SessionManager.UserSession(ctx)
-		if err != nil {
-			log.Error(err, "Failed to check if vim session is active")
-		}
+	p := getPool(params.server)
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-		tagManagerSession, err := s.TagManager.Session(ctx)
-		if err != nil {
-			log.Error(err, "Failed to check if REST session is active")
-		}
-
-		if userSession != nil && tagManagerSession != nil {
+	sessionKey := getSessionKey(params)
+	if s, ok := p.sessions[sessionKey]; ok {
+		if s.health.isActive() && s.isActive(ctx) {
 			log.Info("Found active cached vSphere client session")
 			return s, nil
 		}
 
-		log.Info("Logout the REST session because it is inactive")
-		if err := s.TagManager.Logout(ctx); err != nil {
-			log.Error(err, "Failed to logout REST session")
-		} else {
-			log.Info("Logout REST session succeed")
-		}
-
 		log.Info("Logout the session because it is inactive")
-		if err := s.Client.Logout(ctx); err != nil {
-			log.Error(err, "Failed to logout session")
-		} else {
-			log.Info("Logout session succeed")
-		}
-	}
-
-	// soap.ParseURL expects a valid URL. In the case of a bare, unbracketed
-	// IPv6 address (e.g fd00::1) ParseURL will fail. Surround unbracketed IPv6
-	// addresses with brackets.
-	urlSafeServer := params.server
-	ip, err := netip.ParseAddr(urlSafeServer)
-	if err == nil && ip.Is6() {
-		urlSafeServer = fmt.Sprintf("[%s]", urlSafeServer)
+		p.remove(ctx, sessionKey)
 	}
 
-	soapURL, err := soap.ParseURL(urlSafeServer)
+	soapURL, err := parseURL(params)
 	if err != nil {
-		return nil, pkgerrors.Wrapf(err, "failed to create vCenter session: error parsing vSphere URL %q", params.server)
-	}
-	if soapURL == nil {
-		return nil, pkgerrors.Errorf("failed to create vCenter session: error parsing vSphere URL %q: URL is nil", params.server)
+		return nil, pkgerrors.Wrapf(err, "failed to create vCenter session")
 	}
 
-	soapURL.User = params.userinfo
-	client, err := newClient(ctx, soapURL, params.thumbprint, params.feature)
+	h := &health{}
+	client, err := newClient(ctx, soapURL, params, p, h)
 	if err != nil {
 		return nil, pkgerrors.Wrapf(err, "failed to create vCenter session")
 	}
 
-	session := Session{Client: client}
+	session := Session{Client: client, health: h}
 	session.UserAgent = infrav1.GroupVersion.String()
 
 	// Assign the finder to the session.
 	session.Finder = find.NewFinder(session.Client.Client, false)
 	// Assign tag manager to the session.
-	manager, err := newManager(ctx, client.Client, soapURL.User, params.feature)
+	manager, err := newManager(ctx, client.Client, soapURL.User, params.feature, p, h)
 	if err != nil {
 		log.Error(err, "Failed to create tags manager, will logout")
 		// Logout of previously logged session to not leak
@@ -222,18 +195,155 @@ func GetOrCreate(ctx context.Context, params *Params) (*Session, error) {
 		session.Finder.SetDatacenter(dc)
 	}
 	// Cache the session.
-	sessionCache.Store(sessionKey, &session)
+	p.add(sessionKey, &session)
 
 	log.Info("Created and cached vSphere client session")
 
 	return &session, nil
 }
 
-func newClient(ctx context.Context, url *url.URL, thumbprint string, _ Feature) (*govmomi.Client, error) {
-	insecure := thumbprint == ""
+// isActive checks whether the SOAP and REST sessions are still authenticated.
+// The sessions are active when vCenter returns them.
+func (s *Session) isActive(ctx context.Context) bool {
+	log := ctrl.LoggerFrom(ctx)
+
+	userSession, err := s.SessionManager.UserSession(ctx)
+	if err != nil {
+		log.Error(err, "Failed to check if vim session is active")
+	}
+
+	tagManagerSession, err := s.TagManager.Session(ctx)
+	if err != nil {
+		log.Error(err, "Failed to check if REST session is active")
+	}
+
+	return userSession != nil && tagManagerSession != nil
+}
+
+// Validate verifies that a session can be created with the given parameters, without caching it.
+// The sessions created for the validation are logged out before returning.
+func Validate(ctx context.Context, params *Params) error {
+	soapURL, err := parseURL(params)
+	if err != nil {
+		return err
+	}
+
+	p := getPool(params.server)
+	h := &health{}
+	client, err := newClient(ctx, soapURL, params, p, h)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = client.Logout(ctx)
+	}()
+
+	manager, err := newManager(ctx, client.Client, soapURL.User, params.feature, p, h)
+	if err != nil {
+		return err
+	}
+	return manager.Logout(ctx)
+}
+
+// Evict logs out and removes all cached sessions of the user which have been created with another
+// password than the given one, e.g. because the credentials have been rotated. All sessions of the
+// user are removed if the password is empty.
+// It returns the number of evicted sessions.
+func Evict(ctx context.Context, username, password string) int {
+	log := ctrl.LoggerFrom(ctx).WithValues("username", username)
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	passwordHash := fmt.Sprintf("%x", hashPassword(password))
+	evicted := 0
+	pools.Range(func(_, value any) bool {
+		p := value.(*pool)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		for key := range p.sessions {
+			// The session key ends with the username and the hash of the password.
+			fields := strings.Split(key, "#")
+			if len(fields) < 2 || fields[len(fields)-2] != username {
+				continue
+			}
+			if password == "" || fields[len(fields)-1] != passwordHash {
+				p.remove(ctx, key)
+				evicted++
+			}
+		}
+		return true
+	})
+
+	if evicted > 0 {
+		log.Info(fmt.Sprintf("Evicted %d cached vSphere client sessions", evicted))
+	}
+	return evicted
+}
+
+func getSessionKey(params *Params) string {
+	userPassword, _ := params.userinfo.Password()
+	return fmt.Sprintf("%s#%s#%s#%s#%x", params.server, params.datacenter, getTLSKey(params), params.userinfo.Username(),
+		hashPassword(userPassword))
+}
+
+// getTLSKey returns a key for the settings used to verify the vCenter server certificate,
+// so sessions are not shared between parameters with different settings.
+func getTLSKey(params *Params) string {
+	switch {
+	case params.thumbprint != "":
+		return params.thumbprint
+	case len(params.caBundle) > 0:
+		return fmt.Sprintf("%x", sha256.Sum256(params.caBundle))
+	case params.insecure:
+		return "insecure"
+	default:
+		return ""
+	}
+}
+
+func hashPassword(password string) []byte {
+	h := sha256.New()
+	h.Write([]byte(password))
+	return h.Sum(nil)
+}
+
+// parseURL returns the URL of the vCenter including the user info.
+func parseURL(params *Params) (*url.URL, error) {
+	// soap.ParseURL expects a valid URL. In the case of a bare, unbracketed
+	// IPv6 address (e.g fd00::1) ParseURL will fail. Surround unbracketed IPv6
+	// addresses with brackets.
+	urlSafeServer := params.server
+	ip, err := netip.ParseAddr(urlSafeServer)
+	if err == nil && ip.Is6() {
+		urlSafeServer = fmt.Sprintf("[%s]", urlSafeServer)
+	}
+
+	soapURL, err := soap.ParseURL(urlSafeServer)
+	if err != nil {
+		return nil, pkgerrors.Wrapf(err, "error parsing vSphere URL %q", params.server)
+	}
+	if soapURL == nil {
+		return nil, pkgerrors.Errorf("error parsing vSphere URL %q: URL is nil", params.server)
+	}
+
+	soapURL.User = params.userinfo
+	return soapURL, nil
+}
+
+func newClient(ctx context.Context, url *url.URL, params *Params, p *pool, h *health) (*govmomi.Client, error) {
+	// The vCenter server certificate is verified using the thumbprint, the CA bundle or the
+	// system trust store, in this order. Verification is only skipped if explicitly requested.
+	insecure := params.thumbprint == "" && len(params.caBundle) == 0 && params.insecure
 	soapClient := soap.NewClient(url, insecure)
-	if !insecure {
-		soapClient.SetThumbprint(url.Host, thumbprint)
+	switch {
+	case params.thumbprint != "":
+		soapClient.SetThumbprint(url.Host, params.thumbprint)
+	case len(params.caBundle) > 0:
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(params.caBundle) {
+			return nil, pkgerrors.New("failed to create client: CA bundle does not contain any PEM encoded certificate")
+		}
+		soapClient.DefaultTransport().TLSClientConfig.RootCAs = rootCAs
 	}
 
 	vimClient, err := vim25.NewClient(ctx, soapClient)
@@ -242,12 +352,27 @@ func newClient(ctx context.Context, url *url.URL, thumbprint string, _ Feature)
 	}
 	vimClient.UserAgent = "k8s-capv-useragent"
 
+	// Limit and instrument all calls of the session and keep the session alive in the background.
+	vimClient.RoundTripper = keepalive.NewHandlerSOAP(&soapRoundTripper{
+		roundTripper: vimClient.RoundTripper,
+		pool:         p,
+		health:       h,
+	}, keepAliveInterval, func() error {
+		_, err := methods.GetCurrentTime(context.Background(), vimClient.RoundTripper)
+		if err != nil {
+			h.soapExpired.Store(true)
+		}
+		return err
+	})
+
 	c := &govmomi.Client{
 		Client:         vimClient,
 		SessionManager: session.NewManager(vimClient),
 	}
 
-	if err := c.Login(ctx, url.User); err != nil {
+	err = c.Login(ctx, url.User)
+	observeLogin(p.server, apiSOAP, err)
+	if err != nil {
 		return nil, pkgerrors.Wrapf(err, "failed to create client: failed to login")
 	}
 
@@ -255,9 +380,29 @@ func newClient(ctx context.Context, url *url.URL, thumbprint string, _ Feature)
 }
 
 // newManager creates a Manager that encompasses the REST Client for the VSphere tagging API.
-func newManager(ctx context.Context, client *vim25.Client, user *url.Userinfo, _ Feature) (*tags.Manager, error) {
+func newManager(ctx context.Context, client *vim25.Client, user *url.Userinfo, _ Feature, p *pool, h *health) (*tags.Manager, error) {
 	rc := rest.NewClient(client)
-	if err := rc.Login(ctx, user); err != nil {
+
+	// Limit and instrument all calls of the session and keep the session alive in the background.
+	rc.Transport = &restRoundTripper{
+		roundTripper: rc.Transport,
+		pool:         p,
+		health:       h,
+	}
+	rc.Transport = keepalive.NewHandlerREST(rc, keepAliveInterval, func() error {
+		s, err := rc.Session(context.Background())
+		if err == nil && s == nil {
+			err = pkgerrors.New("REST session is not authenticated")
+		}
+		if err != nil {
+			h.restExpired.Store(true)
+		}
+		return err
+	})
+
+	err := rc.Login(ctx, user)
+	observeLogin(p.server, apiREST, err)
+	if err != nil {
 		return nil, pkgerrors.Wrapf(err, "failed to create tags manager: failed to login REST client")
 	}
 	return tags.NewManager(rc), nil
@@ -279,9 +424,14 @@ func (s *Session) GetVersion() (infrav1.VCenterVersion, error) {
 
 // Clear is meant to destroy all the cached sessions.
 func Clear() {
-	sessionCache.Range(func(_, s any) bool {
-		cachedSession := s.(*Session)
-		_ = cachedSession.Logout(context.Background())
+	pools.Range(func(_, value any) bool {
+		p := value.(*pool)
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		for key := range p.sessions {
+			p.remove(context.Background(), key)
+		}
 		return true
 	})
 }
@@ -50,7 +50,8 @@ func TestGetSession(t *testing.T) {
 
 	params := NewParams().
 		WithServer(simr.ServerURL().Host).
-		WithUserInfo(simr.Username(), simr.Password()).WithDatacenter("*")
+		WithUserInfo(simr.Username(), simr.Password()).WithDatacenter("*").
+		WithInsecure(true)
 
 	// Get first session
 	ctx := context.Background()
@@ -84,6 +85,139 @@ func TestGetSession(t *testing.T) {
 	assertSessionCountEqualTo(g, simr, 1)
 }
 
+func TestEvict(t *testing.T) {
+	g := NewWithT(t)
+	ctrl.SetLogger(klog.Background())
+
+	simr, err := vcsim.NewBuilder().
+		WithModel(simulator.VPX()).Build()
+	if err != nil {
+		t.Fatalf("failed to create VC simulator")
+	}
+	defer simr.Destroy()
+
+	params := NewParams().
+		WithServer(simr.ServerURL().Host).
+		WithUserInfo(simr.Username(), simr.Password()).
+		WithInsecure(true)
+
+	ctx := context.Background()
+	s, err := GetOrCreate(ctx, params)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s).ToNot(BeNil())
+	assertSessionCountEqualTo(g, simr, 1)
+
+	// Sessions of other users and sessions with the current password are not evicted.
+	g.Expect(Evict(ctx, "other-user", "")).To(Equal(0))
+	g.Expect(Evict(ctx, simr.Username(), simr.Password())).To(Equal(0))
+	assertSessionCountEqualTo(g, simr, 1)
+
+	// Sessions created with another password are logged out and evicted.
+	g.Expect(Evict(ctx, simr.Username(), "rotated-password")).To(Equal(1))
+	assertSessionCountEqualTo(g, simr, 0)
+	_, ok := getPool(params.server).sessions[getSessionKey(params)]
+	g.Expect(ok).To(BeFalse())
+
+	// A new session is created afterwards.
+	s2, err := GetOrCreate(ctx, params)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s2).ToNot(BeIdenticalTo(s))
+	assertSessionCountEqualTo(g, simr, 1)
+
+	// All sessions of the user are evicted if no password is given, e.g. because the username changed.
+	g.Expect(Evict(ctx, simr.Username(), "")).To(Equal(1))
+	assertSessionCountEqualTo(g, simr, 0)
+}
+
+func TestValidate(t *testing.T) {
+	g := NewWithT(t)
+	ctrl.SetLogger(klog.Background())
+
+	simr, err := vcsim.NewBuilder().
+		WithModel(simulator.VPX()).Build()
+	if err != nil {
+		t.Fatalf("failed to create VC simulator")
+	}
+	defer simr.Destroy()
+
+	ctx := context.Background()
+	params := NewParams().
+		WithServer(simr.ServerURL().Host).
+		WithUserInfo(simr.Username(), simr.Password()).
+		WithInsecure(true)
+	g.Expect(Validate(ctx, params)).To(Succeed())
+
+	// Validation does not cache the session.
+	_, ok := getPool(params.server).sessions[getSessionKey(params)]
+	g.Expect(ok).To(BeFalse())
+
+	params = NewParams().
+		WithServer(simr.ServerURL().Host).
+		WithUserInfo(simr.Username(), "").
+		WithInsecure(true)
+	g.Expect(Validate(ctx, params)).ToNot(Succeed())
+}
+
+func TestTLSVerification(t *testing.T) {
+	ctrl.SetLogger(klog.Background())
+
+	simr, err := vcsim.NewBuilder().
+		WithModel(simulator.VPX()).Build()
+	if err != nil {
+		t.Fatalf("failed to create VC simulator")
+	}
+	defer simr.Destroy()
+
+	tests := []struct {
+		name    string
+		params  func(*Params) *Params
+		wantErr bool
+	}{
+		{
+			name:    "fails without thumbprint, CA bundle or insecure if the certificate is not trusted by the system",
+			params:  func(p *Params) *Params { return p },
+			wantErr: true,
+		},
+		{
+			name:   "succeeds with the thumbprint of the certificate",
+			params: func(p *Params) *Params { return p.WithThumbprint(simr.Thumbprint()) },
+		},
+		{
+			name:   "succeeds with a CA bundle containing the certificate",
+			params: func(p *Params) *Params { return p.WithCABundle(simr.CABundle()) },
+		},
+		{
+			name:    "fails with an invalid CA bundle",
+			params:  func(p *Params) *Params { return p.WithCABundle([]byte("invalid")) },
+			wantErr: true,
+		},
+		{
+			name:   "succeeds with insecure",
+			params: func(p *Params) *Params { return p.WithInsecure(true) },
+		},
+		{
+			name:    "verifies the certificate with the CA bundle even if insecure is set",
+			params:  func(p *Params) *Params { return p.WithCABundle([]byte("invalid")).WithInsecure(true) },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			params := tt.params(NewParams().
+				WithServer(simr.ServerURL().Host).
+				WithUserInfo(simr.Username(), simr.Password()))
+			err := Validate(context.Background(), params)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 func sessionCount(stdout io.Reader) (int, error) {
 	buf := make([]byte, 1024)
 	count := 0
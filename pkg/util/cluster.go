@@ -87,3 +87,32 @@ func GetVSphereClusterFromVSphereMachine(ctx context.Context, c client.Client, m
 	err := c.Get(ctx, vsphereClusterKey, vsphereCluster)
 	return vsphereCluster, err
 }
+
+// GetVSphereClusterFromVSphereMachinePool gets the infrastructure.cluster.x-k8s.io.VSphereCluster resource for the given VSphereMachinePool.
+func GetVSphereClusterFromVSphereMachinePool(ctx context.Context, c client.Client, machinePool *infrav1.VSphereMachinePool) (*infrav1.VSphereCluster, error) {
+	clusterName := machinePool.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		return nil, pkgerrors.Errorf("error getting VSphereCluster name from VSphereMachinePool %s/%s",
+			machinePool.Namespace, machinePool.Name)
+	}
+	namespacedName := apitypes.NamespacedName{
+		Namespace: machinePool.Namespace,
+		Name:      clusterName,
+	}
+	cluster := &clusterv1.Cluster{}
+	if err := c.Get(ctx, namespacedName, cluster); err != nil {
+		return nil, err
+	}
+
+	if !cluster.Spec.InfrastructureRef.IsDefined() {
+		return nil, pkgerrors.Errorf("error getting VSphereCluster name from VSphereMachinePool %s/%s: Cluster.spec.infrastructureRef not yet set",
+			machinePool.Namespace, machinePool.Name)
+	}
+	vsphereClusterKey := apitypes.NamespacedName{
+		Namespace: machinePool.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	vsphereCluster := &infrav1.VSphereCluster{}
+	err := c.Get(ctx, vsphereClusterKey, vsphereCluster)
+	return vsphereCluster, err
+}
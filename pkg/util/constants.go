@@ -139,3 +139,63 @@ network:
       {{- end }}
     {{- end }}
 `
+
+// windowsMetadataFormat is the metadata of Windows VMs, which is read by the VMware
+// guestinfo metadata service of Cloudbase-Init. It only contains the keys of the network
+// configuration v2 which are supported by Cloudbase-Init, and the network adapters keep
+// their names unless a device name is set.
+const windowsMetadataFormat = `
+instance-id: "{{ .Hostname }}"
+local-hostname: "{{ .Hostname }}"
+network:
+  version: 2
+  ethernets:
+    {{- range $i, $net := .Devices }}
+    id{{ $i }}:
+      match:
+        macaddress: "{{ $net.MACAddr }}"
+      {{- if $net.DeviceName }}
+      set-name: "{{ $net.DeviceName }}"
+      {{- end }}
+      dhcp4: {{ if $net.DHCP4 }}{{ $net.DHCP4 }}{{ else }}false{{ end }}
+      dhcp6: {{ if $net.DHCP6 }}{{ $net.DHCP6 }}{{ else }}false{{ end }}
+      {{- if $net.IPAddrs }}
+      addresses:
+      {{- range $net.IPAddrs }}
+      - "{{ . }}"
+      {{- end }}
+      {{- end }}
+      {{- if $net.Gateway4 }}
+      gateway4: "{{ $net.Gateway4 }}"
+      {{- end }}
+      {{- if $net.Gateway6 }}
+      gateway6: "{{ $net.Gateway6 }}"
+      {{- end }}
+      {{- if .MTU }}
+      mtu: {{ .MTU }}
+      {{- end }}
+      {{- if .Routes }}
+      routes:
+      {{- range .Routes }}
+      - to: "{{ .To }}"
+        via: "{{ .Via }}"
+        metric: {{ .Metric }}
+      {{- end }}
+      {{- end }}
+      {{- if nameservers $net }}
+      nameservers:
+        {{- if $net.Nameservers }}
+        addresses:
+        {{- range $net.Nameservers }}
+        - "{{ . }}"
+        {{- end }}
+        {{- end }}
+        {{- if $net.SearchDomains }}
+        search:
+        {{- range $net.SearchDomains }}
+        - "{{ . }}"
+        {{- end }}
+        {{- end }}
+      {{- end }}
+    {{- end }}
+`
@@ -76,7 +76,7 @@ func IsControlPlaneMachine(machine metav1.Object) bool {
 }
 
 // GetMachineMetadata the cloud-init metadata as a base-64 encoded
-// string for a given VSphereMachine.
+// string for a given VSphereMachine, or the Cloudbase-Init metadata for Windows VMs.
 // IPAM state includes IP and Gateways that should be added to each device.
 func GetMachineMetadata(hostname string, vsphereVM infrav1.VSphereVM, ipamState map[string]infrav1.NetworkDeviceSpec, networkStatuses ...infrav1.NetworkStatus) ([]byte, error) {
 	// Create a copy of the devices and add their MAC addresses from a network status.
@@ -140,13 +140,19 @@ func GetMachineMetadata(hostname string, vsphereVM infrav1.VSphereVM, ipamState
 		devices[i].MACAddr = status.MACAddr
 	}
 
+	// Windows VMs are configured by Cloudbase-Init instead of cloud-init.
+	format := metadataFormat
+	if vsphereVM.Spec.OS == infrav1.Windows {
+		format = windowsMetadataFormat
+	}
+
 	buf := &bytes.Buffer{}
 	tpl := template.Must(template.New("t").Funcs(
 		template.FuncMap{
 			"nameservers": func(spec infrav1.NetworkDeviceSpec) bool {
 				return len(spec.Nameservers) > 0 || len(spec.SearchDomains) > 0
 			},
-		}).Parse(metadataFormat))
+		}).Parse(format))
 	if err := tpl.Execute(buf, struct {
 		Hostname    string
 		Devices     []infrav1.NetworkDeviceSpec
@@ -180,6 +186,25 @@ func GetOwnerVSphereMachine(ctx context.Context, c client.Client, obj metav1.Obj
 	return nil, nil
 }
 
+// GetOwnerVSphereMachinePool returns the VSphereMachinePool owner for the passed object.
+func GetOwnerVSphereMachinePool(ctx context.Context, c client.Client, obj metav1.ObjectMeta) (*infrav1.VSphereMachinePool, error) {
+	for _, ref := range obj.OwnerReferences {
+		gv, err := schema.ParseGroupVersion(ref.APIVersion)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Kind == "VSphereMachinePool" && gv.Group == infrav1.GroupVersion.Group {
+			m := &infrav1.VSphereMachinePool{}
+			key := client.ObjectKey{Name: ref.Name, Namespace: obj.Namespace}
+			if err := c.Get(ctx, key, m); err != nil {
+				return nil, err
+			}
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
 func getVSphereMachineByName(ctx context.Context, c client.Client, namespace, name string) (*infrav1.VSphereMachine, error) {
 	m := &infrav1.VSphereMachine{}
 	key := client.ObjectKey{Name: name, Namespace: namespace}
@@ -904,6 +904,60 @@ network:
       - to: "0.0.0.0/0"
         via: "192.168.4.1"
         metric: 222
+`,
+		},
+		{
+			name: "windows",
+			machine: &infrav1.VSphereVM{
+				Spec: infrav1.VSphereVMSpec{
+					VirtualMachineCloneSpec: infrav1.VirtualMachineCloneSpec{
+						OS: infrav1.Windows,
+						Network: infrav1.NetworkSpec{
+							Devices: []infrav1.NetworkDeviceSpec{
+								{
+									NetworkName:   "network1",
+									MACAddr:       "00:00:00:00:ab",
+									IPAddrs:       []string{"192.168.4.21/24"},
+									Gateway4:      "192.168.4.1",
+									Nameservers:   []string{"8.8.8.8"},
+									SearchDomains: []string{"example.com"},
+								},
+								{
+									NetworkName: "network12",
+									MACAddr:     "00:00:00:00:cd",
+									DHCP4:       ptr.To(true),
+									DeviceName:  "Ethernet1",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: `
+instance-id: "test-vm"
+local-hostname: "test-vm"
+network:
+  version: 2
+  ethernets:
+    id0:
+      match:
+        macaddress: "00:00:00:00:ab"
+      dhcp4: false
+      dhcp6: false
+      addresses:
+      - "192.168.4.21/24"
+      gateway4: "192.168.4.1"
+      nameservers:
+        addresses:
+        - "8.8.8.8"
+        search:
+        - "example.com"
+    id1:
+      match:
+        macaddress: "00:00:00:00:cd"
+      set-name: "Ethernet1"
+      dhcp4: true
+      dhcp6: false
 `,
 		},
 	}
@@ -67,7 +67,15 @@ type VSphereMachine struct{}
 
 // SetupWebhookWithManager sets up VSphereMachine webhooks.
 func (webhook *VSphereMachine) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return (&webhooks.VSphereMachine{}).SetupWebhookWithManager(mgr)
+	return (&webhooks.VSphereMachine{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr)
+}
+
+// VSphereMachinePool implements a validation webhook for VSphereMachinePool.
+type VSphereMachinePool struct{}
+
+// SetupWebhookWithManager sets up VSphereMachinePool webhooks.
+func (webhook *VSphereMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return (&webhooks.VSphereMachinePool{}).SetupWebhookWithManager(mgr)
 }
 
 // VSphereMachineTemplate implements a validation webhook for VSphereMachineTemplate.